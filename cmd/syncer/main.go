@@ -50,6 +50,7 @@ import (
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/manager"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/k8scloudoperator"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/k8soperator"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/sharding"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/storagepool"
 )
 
@@ -87,6 +88,10 @@ var (
 	periodicSyncIntervalInMin = flag.Duration("storagequota-sync-interval", 30*time.Minute,
 		"Periodic sync interval in Minutes")
 	enableProfileServer = flag.Bool("enable-profile-server", false, "Enable profiling endpoint for the syncer.")
+	enableSharding      = flag.Bool("sharding", false, "Enable sharding of the full sync and metadata sync "+
+		"between the syncer replicas. Requires leader election. Only supported in Vanilla clusters.")
+	shardingVolumeRanges = flag.Int("sharding-volume-ranges", 4, "Number of ranges the volume IDs of each "+
+		"vCenter are split in when sharding is enabled. Defaults to 4.")
 )
 
 // main for vsphere syncer.
@@ -181,6 +186,7 @@ func main() {
 				}
 			}()
 			prometheus.SyncerInfo.WithLabelValues(syncer.Version).Set(1)
+			common.RegisterHealthEndpoints(http.DefaultServeMux)
 			for {
 				log.Info("Starting the http server to expose Prometheus metrics..")
 				http.Handle("/metrics", promhttp.Handler())
@@ -192,9 +198,21 @@ func main() {
 			}
 		}()
 
-		// Initialize syncer components that are dependant on the outcome of
-		// leader election, if enabled.
-		run = initSyncerComponents(ctx, clusterFlavor, &syncer.COInitParams)
+		if *enableSharding && (!*enableLeaderElection || clusterFlavor != cnstypes.CnsClusterFlavorVanilla) {
+			log.Warnf("Sharding requires leader election and is only supported in Vanilla clusters. " +
+				"Disabling sharding.")
+			*enableSharding = false
+		}
+		if *enableSharding {
+			// Start the metadata syncer for the shards owned by this instance,
+			// and initialize the other syncer components that are dependant on
+			// the outcome of leader election.
+			run = initShardedSyncerComponents(ctx, clusterFlavor, &syncer.COInitParams)
+		} else {
+			// Initialize syncer components that are dependant on the outcome of
+			// leader election, if enabled.
+			run = initSyncerComponents(ctx, clusterFlavor, &syncer.COInitParams)
+		}
 
 		if !*enableLeaderElection {
 			run(ctx)
@@ -269,157 +287,230 @@ func initSyncerComponents(ctx context.Context, clusterFlavor cnstypes.CnsCluster
 				cleanupSessions(ctx, r)
 			}
 		}()
-		if err := manager.InitCommonModules(ctx, clusterFlavor, coInitParams); err != nil {
-			log.Errorf("Error initializing common modules for all flavors. Error: %+v", err)
+		configInfo := initSyncerConfig(ctx, clusterFlavor, coInitParams)
+		startSyncerOperators(ctx, clusterFlavor, configInfo, coInitParams)
+		runMetadataSyncer(ctx, clusterFlavor, configInfo)
+	}
+}
+
+// initShardedSyncerComponents joins the syncer shard members and starts the
+// metadata syncer, which syncs the shards owned by this instance of
+// vsphere-syncer. It returns the function initializing the other syncer
+// components, which is only called by the leader instance.
+func initShardedSyncerComponents(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavor,
+	coInitParams *interface{}) func(ctx context.Context) {
+	log := logger.GetLogger(ctx)
+	k8sClient, err := k8s.NewClient(ctx)
+	if err != nil {
+		log.Fatalf("Creating Kubernetes client failed. Err: %v", err)
+	}
+	id, err := defaultLeaderElectionIdentity()
+	if err != nil {
+		log.Fatalf("error getting the default shard identity. Err: %v", err)
+	}
+	namespace := *leaderElectionNamespace
+	if namespace == "" {
+		namespace = inClusterNamespace()
+	}
+	sharder := sharding.New(k8sClient, sharding.Config{
+		Namespace:     namespace,
+		Identity:      sanitizeName(id),
+		LeaseDuration: *leaderElectionLeaseDuration,
+		RenewPeriod:   *leaderElectionRetryPeriod,
+		VolumeRanges:  *shardingVolumeRanges,
+	})
+	if err := sharder.Start(ctx); err != nil {
+		log.Fatalf("Failed to start sharding. Err: %v", err)
+	}
+	syncer.Sharder = sharder
+
+	configInfo := initSyncerConfig(ctx, clusterFlavor, coInitParams)
+	go func() {
+		defer func() {
+			log.Info("Cleaning up vc sessions metadata syncer")
+			if r := recover(); r != nil {
+				cleanupSessions(ctx, r)
+			}
+		}()
+		runMetadataSyncer(ctx, clusterFlavor, configInfo)
+	}()
+	return func(_ context.Context) {
+		defer func() {
+			log.Info("Cleaning up vc sessions syncer components")
+			if r := recover(); r != nil {
+				cleanupSessions(ctx, r)
+			}
+		}()
+		startSyncerOperators(ctx, clusterFlavor, configInfo, coInitParams)
+	}
+}
+
+// initSyncerConfig initializes the common modules and returns the
+// configuration of the syncer.
+func initSyncerConfig(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavor,
+	coInitParams *interface{}) *config.ConfigurationInfo {
+	log := logger.GetLogger(ctx)
+	if err := manager.InitCommonModules(ctx, clusterFlavor, coInitParams); err != nil {
+		log.Errorf("Error initializing common modules for all flavors. Error: %+v", err)
+		os.Exit(1)
+	}
+	var configInfo *config.ConfigurationInfo
+	var err error
+	if clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.CSIInternalGeneratedClusterID) {
+		configInfo, err = syncer.SyncerInitConfigInfo(ctx)
+		if err != nil {
+			log.Errorf("failed to initialize the configInfo. Err: %+v", err)
 			os.Exit(1)
 		}
-		var configInfo *config.ConfigurationInfo
-		var err error
-		if clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
-			commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.CSIInternalGeneratedClusterID) {
-			configInfo, err = syncer.SyncerInitConfigInfo(ctx)
+	} else {
+		configInfo, err = config.InitConfigInfo(ctx)
+		if err != nil {
+			log.Errorf("failed to initialize the configInfo. Err: %+v", err)
+			os.Exit(1)
+		}
+	}
+
+	if clusterFlavor == cnstypes.CnsClusterFlavorVanilla {
+		// Initialize node manager so that syncer components can
+		// retrieve NodeVM using the NodeID.
+		nodeMgr := &node.Nodes{}
+		err = nodeMgr.Initialize(ctx)
+		if err != nil {
+			log.Errorf("failed to initialize nodeManager. Error: %+v", err)
+			os.Exit(1)
+		}
+		if configInfo.Cfg.Global.ClusterDistribution == "" {
+			config, err := rest.InClusterConfig()
 			if err != nil {
-				log.Errorf("failed to initialize the configInfo. Err: %+v", err)
+				log.Errorf("failed to get InClusterConfig: %v", err)
 				os.Exit(1)
 			}
-		} else {
-			configInfo, err = config.InitConfigInfo(ctx)
+			clientset, err := kubernetes.NewForConfig(config)
 			if err != nil {
-				log.Errorf("failed to initialize the configInfo. Err: %+v", err)
+				log.Errorf("failed to create kubernetes client with err: %v", err)
 				os.Exit(1)
 			}
-		}
-
-		// Initialize CNS Operator for Supervisor clusters.
-		if clusterFlavor == cnstypes.CnsClusterFlavorWorkload {
-			go func() {
-				defer func() {
-					log.Info("Cleaning up vc sessions storage pool service")
-					if r := recover(); r != nil {
-						cleanupSessions(ctx, r)
-					}
-				}()
-				if err := storagepool.InitStoragePoolService(ctx, configInfo, coInitParams); err != nil {
-					log.Errorf("Error initializing StoragePool Service. Error: %+v", err)
-					utils.LogoutAllvCenterSessions(ctx)
-					os.Exit(0)
-				}
-			}()
-		}
 
-		if clusterFlavor == cnstypes.CnsClusterFlavorVanilla {
-			// Initialize node manager so that syncer components can
-			// retrieve NodeVM using the NodeID.
-			nodeMgr := &node.Nodes{}
-			err = nodeMgr.Initialize(ctx)
+			// Get the version info for the Kubernetes API server
+			versionInfo, err := clientset.Discovery().ServerVersion()
 			if err != nil {
-				log.Errorf("failed to initialize nodeManager. Error: %+v", err)
+				log.Errorf("failed to fetch versionInfo with err: %v", err)
 				os.Exit(1)
 			}
-			if configInfo.Cfg.Global.ClusterDistribution == "" {
-				config, err := rest.InClusterConfig()
-				if err != nil {
-					log.Errorf("failed to get InClusterConfig: %v", err)
-					os.Exit(1)
-				}
-				clientset, err := kubernetes.NewForConfig(config)
-				if err != nil {
-					log.Errorf("failed to create kubernetes client with err: %v", err)
-					os.Exit(1)
-				}
 
-				// Get the version info for the Kubernetes API server
-				versionInfo, err := clientset.Discovery().ServerVersion()
-				if err != nil {
-					log.Errorf("failed to fetch versionInfo with err: %v", err)
-					os.Exit(1)
-				}
-
-				// Extract the version string from the version info
-				version := versionInfo.GitVersion
-				var ClusterDistNameToServerVersion = map[string]string{
-					"gke":       "Anthos",
-					"racher":    "Rancher",
-					"rke":       "Rancher",
-					"docker":    "DockerEE",
-					"dockeree":  "DockerEE",
-					"openshift": "Openshift",
-					"wcp":       "Supervisor",
-					"vmware":    "TanzuKubernetesCluster",
-					"eks":       "EKS",
-					"aks":       "AKS",
-					"nativek8s": "VanillaK8S",
-				}
-				distributionUnknown := true
-				for distServerVersion, distName := range ClusterDistNameToServerVersion {
-					if strings.Contains(version, distServerVersion) {
-						configInfo.Cfg.Global.ClusterDistribution = distName
-						distributionUnknown = false
-						break
-					}
-				}
-				if distributionUnknown {
-					configInfo.Cfg.Global.ClusterDistribution = ClusterDistNameToServerVersion["nativek8s"]
+			// Extract the version string from the version info
+			version := versionInfo.GitVersion
+			var ClusterDistNameToServerVersion = map[string]string{
+				"gke":       "Anthos",
+				"racher":    "Rancher",
+				"rke":       "Rancher",
+				"docker":    "DockerEE",
+				"dockeree":  "DockerEE",
+				"openshift": "Openshift",
+				"wcp":       "Supervisor",
+				"vmware":    "TanzuKubernetesCluster",
+				"eks":       "EKS",
+				"aks":       "AKS",
+				"nativek8s": "VanillaK8S",
+			}
+			distributionUnknown := true
+			for distServerVersion, distName := range ClusterDistNameToServerVersion {
+				if strings.Contains(version, distServerVersion) {
+					configInfo.Cfg.Global.ClusterDistribution = distName
+					distributionUnknown = false
+					break
 				}
 			}
+			if distributionUnknown {
+				configInfo.Cfg.Global.ClusterDistribution = ClusterDistNameToServerVersion["nativek8s"]
+			}
 		}
+	}
+	return configInfo
+}
 
+// startSyncerOperators starts the operators of the syncer.
+func startSyncerOperators(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavor,
+	configInfo *config.ConfigurationInfo, coInitParams *interface{}) {
+	log := logger.GetLogger(ctx)
+	// Initialize CNS Operator for Supervisor clusters.
+	if clusterFlavor == cnstypes.CnsClusterFlavorWorkload {
 		go func() {
 			defer func() {
-				log.Info("Cleaning up vc sessions cns operator")
+				log.Info("Cleaning up vc sessions storage pool service")
 				if r := recover(); r != nil {
 					cleanupSessions(ctx, r)
 				}
 			}()
-			if err := manager.InitCnsOperator(ctx, clusterFlavor, configInfo, coInitParams); err != nil {
-				log.Errorf("Error initializing Cns Operator. Error: %+v", err)
+			if err := storagepool.InitStoragePoolService(ctx, configInfo, coInitParams); err != nil {
+				log.Errorf("Error initializing StoragePool Service. Error: %+v", err)
 				utils.LogoutAllvCenterSessions(ctx)
 				os.Exit(0)
 			}
 		}()
+	}
 
-		if clusterFlavor == cnstypes.CnsClusterFlavorWorkload &&
-			commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.BYOKEncryption) {
-			// Start BYOK Operator for Supervisor clusters.
-			go func() {
-				defer func() {
-					log.Info("Cleaning up vc sessions BYOK operator")
-					if r := recover(); r != nil {
-						cleanupSessions(ctx, r)
-					}
-				}()
-				if err := startByokOperator(ctx, clusterFlavor, configInfo); err != nil {
-					log.Errorf("Error initializing BYOK Operator. Error: %+v", err)
-					utils.LogoutAllvCenterSessions(ctx)
-					os.Exit(0)
+	go func() {
+		defer func() {
+			log.Info("Cleaning up vc sessions cns operator")
+			if r := recover(); r != nil {
+				cleanupSessions(ctx, r)
+			}
+		}()
+		if err := manager.InitCnsOperator(ctx, clusterFlavor, configInfo, coInitParams); err != nil {
+			log.Errorf("Error initializing Cns Operator. Error: %+v", err)
+			utils.LogoutAllvCenterSessions(ctx)
+			os.Exit(0)
+		}
+	}()
+
+	if clusterFlavor == cnstypes.CnsClusterFlavorWorkload &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.BYOKEncryption) {
+		// Start BYOK Operator for Supervisor clusters.
+		go func() {
+			defer func() {
+				log.Info("Cleaning up vc sessions BYOK operator")
+				if r := recover(); r != nil {
+					cleanupSessions(ctx, r)
 				}
 			}()
-		}
+			if err := startByokOperator(ctx, clusterFlavor, configInfo); err != nil {
+				log.Errorf("Error initializing BYOK Operator. Error: %+v", err)
+				utils.LogoutAllvCenterSessions(ctx)
+				os.Exit(0)
+			}
+		}()
+	}
 
-		if clusterFlavor == cnstypes.CnsClusterFlavorWorkload &&
-			commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.SVPVCSnapshotProtectionFinalizer) {
-			// Start K8s Operator for Supervisor clusters.
-			go func() {
-				defer func() {
-					log.Info("Cleaning up vc sessions K8s operator")
-					if r := recover(); r != nil {
-						cleanupSessions(ctx, r)
-					}
-				}()
-				if err := startK8sOperator(ctx, clusterFlavor); err != nil {
-					log.Errorf("Error initializing K8s Operator. Error: %+v", err)
-					utils.LogoutAllvCenterSessions(ctx)
-					os.Exit(0)
+	if clusterFlavor == cnstypes.CnsClusterFlavorWorkload &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.SVPVCSnapshotProtectionFinalizer) {
+		// Start K8s Operator for Supervisor clusters.
+		go func() {
+			defer func() {
+				log.Info("Cleaning up vc sessions K8s operator")
+				if r := recover(); r != nil {
+					cleanupSessions(ctx, r)
 				}
 			}()
-		}
+			if err := startK8sOperator(ctx, clusterFlavor); err != nil {
+				log.Errorf("Error initializing K8s Operator. Error: %+v", err)
+				utils.LogoutAllvCenterSessions(ctx)
+				os.Exit(0)
+			}
+		}()
+	}
+}
 
-		syncer.PeriodicSyncIntervalInMin = *periodicSyncIntervalInMin
-		if err := syncer.InitMetadataSyncer(ctx, clusterFlavor, configInfo); err != nil {
-			log.Errorf("Error initializing Metadata Syncer. Error: %+v", err)
-			utils.LogoutAllvCenterSessions(ctx)
-			os.Exit(0)
-		}
+// runMetadataSyncer runs the metadata syncer until it fails.
+func runMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavor,
+	configInfo *config.ConfigurationInfo) {
+	log := logger.GetLogger(ctx)
+	syncer.PeriodicSyncIntervalInMin = *periodicSyncIntervalInMin
+	if err := syncer.InitMetadataSyncer(ctx, clusterFlavor, configInfo); err != nil {
+		log.Errorf("Error initializing Metadata Syncer. Error: %+v", err)
+		utils.LogoutAllvCenterSessions(ctx)
+		os.Exit(0)
 	}
 }
 
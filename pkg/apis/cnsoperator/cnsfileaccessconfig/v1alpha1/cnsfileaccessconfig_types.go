@@ -20,6 +20,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// CnsFileAccessPermission is the NFS access permission granted to the client
+// of a CnsFileAccessConfig.
+type CnsFileAccessPermission string
+
+const (
+	// CnsFileAccessPermissionReadOnly grants read-only access to the file volume.
+	CnsFileAccessPermissionReadOnly CnsFileAccessPermission = "READ_ONLY"
+	// CnsFileAccessPermissionReadWrite grants read-write access to the file volume.
+	CnsFileAccessPermissionReadWrite CnsFileAccessPermission = "READ_WRITE"
+)
+
 // CnsFileAccessConfigSpec defines the desired state of CnsFileAccessConfig
 // +k8s:openapi-gen=true
 type CnsFileAccessConfigSpec struct {
@@ -27,10 +38,26 @@ type CnsFileAccessConfigSpec struct {
 	// This is guaranteed to be unique in Supervisor cluster.
 	PvcName string `json:"pvcName"`
 
-	// VmName is the name of VirtualMachine instance on SV cluster
-	// Support for PodVm will be added in the near future and
-	// either VMName or PodVMName needs to be set.
+	// VmName is the name of VirtualMachine instance on SV cluster.
+	// Either VMName or PodVMName needs to be set.
 	VMName string `json:"vmName,omitempty"`
+
+	// PodVMName is the name of the vSphere Pod on SV cluster.
+	// Either VMName or PodVMName needs to be set.
+	PodVMName string `json:"podVMName,omitempty"`
+
+	// Permission is the access permission granted to the VM or PodVM on the
+	// file volume. Valid values are READ_ONLY and READ_WRITE. Defaults to
+	// READ_WRITE when not set. The file volume is accessed through the
+	// external IP of the VM or PodVM, so VMs sharing an external IP must
+	// request the same Permission and RootSquash, otherwise the request is
+	// rejected with an error in the status.
+	// +kubebuilder:validation:Enum=READ_ONLY;READ_WRITE
+	Permission CnsFileAccessPermission `json:"permission,omitempty"`
+
+	// RootSquash indicates whether root access from the VM or PodVM should
+	// be squashed on the file volume. Root access is allowed by default.
+	RootSquash bool `json:"rootSquash,omitempty"`
 }
 
 // CnsFileAccessConfigStatus defines the observed state of CnsFileAccessConfig
@@ -52,6 +79,18 @@ type CnsFileAccessConfigStatus struct {
 	// This field must only be set by the entity completing the config
 	// operation, i.e. the CNS Operator.
 	Error string `json:"error,omitempty"`
+
+	// Permission is the access permission last configured on the file
+	// volume for the VM or PodVM. A change of Spec.Permission is
+	// reconciled onto the file volume when it differs from this field.
+	// This field must only be set by the CNS Operator.
+	Permission CnsFileAccessPermission `json:"permission,omitempty"`
+
+	// RootSquash is the root squash setting last configured on the file
+	// volume for the VM or PodVM. A change of Spec.RootSquash is
+	// reconciled onto the file volume when it differs from this field.
+	// This field must only be set by the CNS Operator.
+	RootSquash bool `json:"rootSquash,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CnsImportVolumeSpec defines the desired state of CnsImportVolume
+type CnsImportVolumeSpec struct {
+	// StorageClassName is the name of the StorageClass set on the PVs and
+	// PVCs created for the imported disks. The StorageClass must use the
+	// vSphere CSI provisioner.
+	StorageClassName string `json:"storageClassName"`
+
+	// VolumeMode can either be Block (for raw block volume) or
+	// Filesystem. Default value is Filesystem.
+	VolumeMode v1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// Disks is the list of individual disks to be imported.
+	Disks []CnsImportVolumeDisk `json:"disks,omitempty"`
+
+	// DatastoreFolder, if specified, imports every virtual disk found in
+	// the given datastore folder.
+	DatastoreFolder *CnsImportVolumeDatastoreFolder `json:"datastoreFolder,omitempty"`
+}
+
+// CnsImportVolumeDisk describes a single disk to be imported.
+type CnsImportVolumeDisk struct {
+	// PVCName is the name of the PVC to be created for the disk in the
+	// namespace of the CnsImportVolume instance.
+	PVCName string `json:"pvcName"`
+
+	// VolumeID is the ID of an existing FCD or CNS block volume.
+	// VolumeID and DiskURLPath cannot be specified together.
+	VolumeID string `json:"volumeID,omitempty"`
+
+	// DiskURLPath is URL path to an existing virtual disk. The disk is
+	// registered as an FCD before being imported.
+	// VolumeID and DiskURLPath cannot be specified together.
+	// Format:
+	// https://<vc_ip>/folder/<vm_vmdk_path>?dcPath=<datacenterName>&dsName=<datastoreName>
+	DiskURLPath string `json:"diskURLPath,omitempty"`
+}
+
+// CnsImportVolumeDatastoreFolder describes a datastore folder whose virtual
+// disks are to be imported.
+type CnsImportVolumeDatastoreFolder struct {
+	// FolderURLPath is URL path to the datastore folder.
+	// Format:
+	// https://<vc_ip>/folder/<folder_path>?dcPath=<datacenterName>&dsName=<datastoreName>
+	FolderURLPath string `json:"folderURLPath"`
+
+	// PVCNamePrefix is the prefix used to name the PVCs created for the
+	// disks found in the folder. PVCs are named
+	// <PVCNamePrefix>-<disk file name without extension>.
+	PVCNamePrefix string `json:"pvcNamePrefix"`
+}
+
+// CnsImportVolumeDiskStatus contains the import status of a single disk.
+type CnsImportVolumeDiskStatus struct {
+	// PVCName is the name of the PVC created for the disk.
+	PVCName string `json:"pvcName"`
+
+	// Source is the VolumeID or DiskURLPath the disk was imported from.
+	Source string `json:"source"`
+
+	// VolumeID is the CNS volume ID of the imported disk.
+	VolumeID string `json:"volumeID,omitempty"`
+
+	// PVName is the name of the PV created for the disk.
+	PVName string `json:"pvName,omitempty"`
+
+	// Imported indicates the disk is successfully imported.
+	Imported bool `json:"imported"`
+
+	// The last error encountered while importing the disk, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// CnsImportVolumeStatus defines the observed state of CnsImportVolume
+type CnsImportVolumeStatus struct {
+	// Completed indicates all the disks in the request are imported.
+	// This field must only be set by the entity completing the import
+	// operation, i.e. the CNS Operator.
+	Completed bool `json:"completed"`
+
+	// Disks contains the import status of each disk in the request.
+	Disks []CnsImportVolumeDiskStatus `json:"disks,omitempty"`
+
+	// The last error encountered during import operation, if any.
+	// This field must only be set by the entity completing the import
+	// operation, i.e. the CNS Operator.
+	Error string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CnsImportVolume is the Schema for the cnsimportvolumes API
+// +kubebuilder:subresource:status
+type CnsImportVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CnsImportVolumeSpec   `json:"spec,omitempty"`
+	Status CnsImportVolumeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CnsImportVolumeList contains a list of CnsImportVolume
+type CnsImportVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CnsImportVolume `json:"items"`
+}
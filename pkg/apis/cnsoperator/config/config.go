@@ -41,3 +41,8 @@ const EmbedStoragePolicyQuotaCRFileName = "cns.vmware.com_storagepolicyquotas.ya
 var EmbedStoragePolicyUsageCRFile embed.FS
 
 const EmbedStoragePolicyUsageCRFileName = "cns.vmware.com_storagepolicyusages.yaml"
+
+//go:embed cnsimportvolume_crd.yaml
+var EmbedCnsImportVolumeCRFile embed.FS
+
+const EmbedCnsImportVolumeCRFileName = "cnsimportvolume_crd.yaml"
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	cnsfileaccessconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsfileaccessconfig/v1alpha1"
+	cnsimportvolumev1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsimportvolume/v1alpha1"
 	cnsnodevmattachmentv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsnodevmattachment/v1alpha1"
 	cnsnodevmbatchattachmentv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsnodevmbatchattachment/v1alpha1"
 	cnsregistervolumev1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsregistervolume/v1alpha1"
@@ -61,6 +62,8 @@ var (
 	CnsRegisterVolumePlural = "cnsregistervolumes"
 	// CnsUnregisterVolumePlural is plural of CnsUnregisterVolume
 	CnsUnregisterVolumePlural = "cnsunregistervolumes"
+	// CnsImportVolumePlural is plural of CnsImportVolume
+	CnsImportVolumePlural = "cnsimportvolumes"
 	// CnsFileAccessConfigPlural is plural of CnsFileAccessConfig
 	CnsFileAccessConfigPlural = "cnsfileaccessconfigs"
 	// CnsStoragePolicyUsageSingular is singular of StoragePolicyUsage
@@ -119,6 +122,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&cnsunregistervolumev1alpha1.CnsUnregisterVolumeList{},
 	)
 
+	scheme.AddKnownTypes(
+		SchemeGroupVersion,
+		&cnsimportvolumev1alpha1.CnsImportVolume{},
+		&cnsimportvolumev1alpha1.CnsImportVolumeList{},
+	)
+
 	scheme.AddKnownTypes(
 		SchemeGroupVersion,
 		&cnsvolumemetadatav1alpha1.CnsVolumeMetadata{},
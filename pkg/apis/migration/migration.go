@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -84,6 +85,10 @@ type VolumeMigrationService interface {
 	// ProtectVolumeFromVMDeletion sets keepAfterDeleteVm control flag on the migrated volume
 	// Returns an error if not able to set keepAfterDeleteVm control flag on the volume
 	ProtectVolumeFromVMDeletion(ctx context.Context, volumeID string) error
+
+	// GetVCenterForVolumeID returns the vCenter server on which the migrated
+	// volume with the given volumeID is registered.
+	GetVCenterForVolumeID(ctx context.Context, volumeID string) (string, error)
 }
 
 // volumeMigration holds migrated volume information and provides functionality
@@ -91,12 +96,15 @@ type VolumeMigrationService interface {
 type volumeMigration struct {
 	// volumePath to volumeId map.
 	volumePathToVolumeID sync.Map
+	// volumeId to vCenter host map.
+	volumeIDToVCenter sync.Map
 	// mutexes for registerVolume API
 	registerVolumeMutexes sync.Map
 	// k8sClient helps operate on CnsVSphereVolumeMigration custom resource.
 	k8sClient client.Client
-	// volumeManager helps perform Volume Operations.
-	volumeManager *cnsvolume.Manager
+	// volumeManagers helps perform Volume Operations. It maps vCenter host
+	// to the volume manager for the vCenter.
+	volumeManagers map[string]cnsvolume.Manager
 	// cnsConfig helps retrieve vSphere CSI configuration for RegisterVolume
 	// Operation.
 	cnsConfig *cnsconfig.Config
@@ -120,8 +128,9 @@ var (
 )
 
 // GetVolumeMigrationService returns the singleton VolumeMigrationService.
+// volumeManagers maps each vCenter host to its volume manager.
 // Starts a cleanup routine to delete stale CRD instances if needed.
-func GetVolumeMigrationService(ctx context.Context, volumeManager *cnsvolume.Manager,
+func GetVolumeMigrationService(ctx context.Context, volumeManagers map[string]cnsvolume.Manager,
 	cnsConfig *cnsconfig.Config, runCleanupRoutine bool) (VolumeMigrationService, error) {
 	log := logger.GetLogger(ctx)
 	volumeMigrationInstanceLock.RLock()
@@ -147,7 +156,7 @@ func GetVolumeMigrationService(ctx context.Context, volumeManager *cnsvolume.Man
 			}
 			volumeMigrationInstance = &volumeMigration{
 				volumePathToVolumeID: sync.Map{},
-				volumeManager:        volumeManager,
+				volumeManagers:       volumeManagers,
 				cnsConfig:            cnsConfig,
 			}
 			volumeMigrationInstance.k8sClient, volumeMigrationServiceInitErr =
@@ -178,6 +187,10 @@ func GetVolumeMigrationService(ctx context.Context, volumeManager *cnsvolume.Man
 						}
 						volumeMigrationInstance.volumePathToVolumeID.Store(
 							volumeMigrationObject.Spec.VolumePath, volumeMigrationObject.Spec.VolumeID)
+						if volumeMigrationObject.Spec.VCenterHost != "" {
+							volumeMigrationInstance.volumeIDToVCenter.Store(
+								volumeMigrationObject.Spec.VolumeID, volumeMigrationObject.Spec.VCenterHost)
+						}
 						log.Debugf("successfully added volumePath: %q, volumeID: %q mapping in the cache",
 							volumeMigrationObject.Spec.VolumePath, volumeMigrationObject.Spec.VolumeID)
 					},
@@ -191,6 +204,7 @@ func GetVolumeMigrationService(ctx context.Context, volumeManager *cnsvolume.Man
 							return
 						}
 						volumeMigrationInstance.volumePathToVolumeID.Delete(volumeMigrationObject.Spec.VolumePath)
+						volumeMigrationInstance.volumeIDToVCenter.Delete(volumeMigrationObject.Spec.VolumeID)
 						log.Debugf("successfully deleted volumePath: %q, volumeID: %q mapping from cache",
 							volumeMigrationObject.Spec.VolumePath, volumeMigrationObject.Spec.VolumeID)
 					},
@@ -228,18 +242,21 @@ func (volumeMigration *volumeMigration) GetVolumeID(ctx context.Context, volumeS
 		return info.(string), nil
 	}
 	if registerIfNotFound {
-		volumeID, protectedVolumeFromVMDeletion, err := volumeMigration.registerVolume(ctx, volumeSpec)
+		volumeID, protectedVolumeFromVMDeletion, host, err := volumeMigration.registerVolume(ctx, volumeSpec)
 		if err != nil {
 			log.Errorf("failed to register volume for volumeSpec: %v, with err: %v", volumeSpec, err)
 			return "", err
 		}
-		log.Infof("Successfully registered volumeSpec: %v with CNS. VolumeID: %v", volumeSpec, volumeID)
+		log.Infof("Successfully registered volumeSpec: %v with CNS on vCenter %q. VolumeID: %v",
+			volumeSpec, host, volumeID)
+		volumeMigration.volumeIDToVCenter.Store(volumeID, host)
 		cnsvSphereVolumeMigration := migrationv1alpha1.CnsVSphereVolumeMigration{
 			ObjectMeta: metav1.ObjectMeta{Name: volumeID},
 			Spec: migrationv1alpha1.CnsVSphereVolumeMigrationSpec{
 				VolumePath:                volumeSpec.VolumePath,
 				VolumeID:                  volumeID,
 				ProtectVolumeFromVMDelete: protectedVolumeFromVMDeletion,
+				VCenterHost:               host,
 			},
 		}
 		log.Debugf("Saving cnsvSphereVolumeMigration CR: %v", cnsvSphereVolumeMigration)
@@ -265,7 +282,11 @@ func (volumeMigration *volumeMigration) ProtectVolumeFromVMDeletion(ctx context.
 		return err
 	}
 	if !volumeMigrationResource.Spec.ProtectVolumeFromVMDelete {
-		err = (*volumeMigration.volumeManager).ProtectVolumeFromVMDeletion(ctx, volumeID)
+		_, volumeManager, err := volumeMigration.getVolumeManager(ctx, volumeID)
+		if err != nil {
+			return err
+		}
+		err = volumeManager.ProtectVolumeFromVMDeletion(ctx, volumeID)
 		if err != nil {
 			log.Errorf("failed to protect migrated volume from vm deletion. Volume ID: %q, err: %v", volumeID, err)
 			return err
@@ -315,14 +336,54 @@ func (volumeMigration *volumeMigration) GetVolumePath(ctx context.Context, volum
 	}
 	log.Infof("Could not retrieve mapping of volume path and VolumeID in the cache for VolumeID: %q. "+
 		"volume may not be registered", volumeID)
-	volumeIds := []cnstypes.CnsVolumeId{{Id: volumeID}}
-	var host string
-	if volumeMigration.cnsConfig == nil || len(volumeMigration.cnsConfig.VirtualCenter) == 0 {
+	if len(volumeMigration.volumeManagers) == 0 {
 		return "", logger.LogNewError(log, "could not find vcenter config")
 	}
-	for key := range volumeMigration.cnsConfig.VirtualCenter {
-		host = key
-		break
+	// The vCenter of a volume that is not registered yet is unknown. Look the
+	// volume up on every vCenter.
+	var filePath, host string
+	for _, vCenterHost := range volumeMigration.getVCenterHosts() {
+		filePath, err = volumeMigration.getVolumePathFromVCenter(ctx, vCenterHost, volumeID)
+		if err == nil {
+			host = vCenterHost
+			break
+		}
+		log.Debugf("failed to get volume path for VolumeID: %q from vCenter %q. err: %v", volumeID, vCenterHost, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	log.Infof("Successfully retrieved volume path: %q for VolumeID: %q from vCenter %q", filePath, volumeID, host)
+	volumeMigration.volumeIDToVCenter.Store(volumeID, host)
+	cnsvSphereVolumeMigration := migrationv1alpha1.CnsVSphereVolumeMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: volumeID},
+		Spec: migrationv1alpha1.CnsVSphereVolumeMigrationSpec{
+			VolumePath: filePath,
+			VolumeID:   volumeID,
+			// GetVolumePath is only called from CreateVolume CSI Controller which is creating FCD using CNS API, so
+			// we can mark ProtectVolumeFromVMDelete to true, as we will have required control flags on the FCD.
+			ProtectVolumeFromVMDelete: true,
+			VCenterHost:               host,
+		},
+	}
+	log.Debugf("Saving cnsvSphereVolumeMigration CR: %v", cnsvSphereVolumeMigration)
+	err = volumeMigration.saveVolumeInfo(ctx, &cnsvSphereVolumeMigration)
+	if err != nil {
+		log.Errorf("failed to save cnsvSphereVolumeMigration CR:%v, err: %v", cnsvSphereVolumeMigration, err)
+		return "", err
+	}
+	return filePath, nil
+}
+
+// getVolumePathFromVCenter retrieves the file path of the volume with the
+// given volumeID from the given vCenter.
+func (volumeMigration *volumeMigration) getVolumePathFromVCenter(ctx context.Context, host string,
+	volumeID string) (string, error) {
+	log := logger.GetLogger(ctx)
+	volumeIds := []cnstypes.CnsVolumeId{{Id: volumeID}}
+	volumeManager, found := volumeMigration.volumeManagers[host]
+	if !found {
+		return "", logger.LogNewErrorf(log, "could not get volume manager for the vCenter: %q", host)
 	}
 	vCenter, err := vsphere.GetVirtualCenterManager(ctx).GetVirtualCenter(ctx, host)
 	if err != nil {
@@ -338,7 +399,7 @@ func (volumeMigration *volumeMigration) GetVolumePath(ctx context.Context, volum
 	}
 	if bUseVslmAPIs {
 		log.Infof("Retrieving VStorageObject info using Vslm APIs")
-		vStorageObject, err := (*volumeMigration.volumeManager).RetrieveVStorageObject(ctx, volumeID)
+		vStorageObject, err := volumeManager.RetrieveVStorageObject(ctx, volumeID)
 		if err != nil {
 			return "", logger.LogNewErrorf(log,
 				"failed to retrieve VStorageObject for volume id: %q, err: %v", volumeID, err)
@@ -348,7 +409,7 @@ func (volumeMigration *volumeMigration) GetVolumePath(ctx context.Context, volum
 		fileBackingInfo = vStorageObject.Config.Backing.(*vim25types.BaseConfigInfoDiskFileBackingInfo)
 	} else {
 		log.Infof("Calling QueryVolumeInfo using: %v", volumeIds)
-		queryVolumeInfoResult, err := (*volumeMigration.volumeManager).QueryVolumeInfo(ctx, volumeIds)
+		queryVolumeInfoResult, err := volumeManager.QueryVolumeInfo(ctx, volumeIds)
 		if err != nil {
 			log.Errorf("QueryVolumeInfo failed for volumeID: %s, err: %v", volumeID, err)
 			return "", err
@@ -358,24 +419,85 @@ func (volumeMigration *volumeMigration) GetVolumePath(ctx context.Context, volum
 		cnsBlockVolumeInfo := interface{}(queryVolumeInfoResult.VolumeInfo).(*cnstypes.CnsBlockVolumeInfo)
 		fileBackingInfo = cnsBlockVolumeInfo.VStorageObject.Config.Backing.(*vim25types.BaseConfigInfoDiskFileBackingInfo)
 	}
-	log.Infof("Successfully retrieved volume path: %q for VolumeID: %q", fileBackingInfo.FilePath, volumeID)
-	cnsvSphereVolumeMigration := migrationv1alpha1.CnsVSphereVolumeMigration{
-		ObjectMeta: metav1.ObjectMeta{Name: volumeID},
-		Spec: migrationv1alpha1.CnsVSphereVolumeMigrationSpec{
-			VolumePath: fileBackingInfo.FilePath,
-			VolumeID:   volumeID,
-			// GetVolumePath is only called from CreateVolume CSI Controller which is creating FCD using CNS API, so
-			// we can mark ProtectVolumeFromVMDelete to true, as we will have required control flags on the FCD.
-			ProtectVolumeFromVMDelete: true,
-		},
+	return fileBackingInfo.FilePath, nil
+}
+
+// GetVCenterForVolumeID returns the vCenter server on which the migrated
+// volume with the given volumeID is registered.
+// For volumes registered before multi vCenter support, the vCenter is looked
+// up by querying the volume on each vCenter and is saved in the
+// CnsVSphereVolumeMigration CR.
+func (volumeMigration *volumeMigration) GetVCenterForVolumeID(ctx context.Context,
+	volumeID string) (string, error) {
+	log := logger.GetLogger(ctx)
+	if host, found := volumeMigration.volumeIDToVCenter.Load(volumeID); found {
+		return host.(string), nil
 	}
-	log.Debugf("Saving cnsvSphereVolumeMigration CR: %v", cnsvSphereVolumeMigration)
-	err = volumeMigration.saveVolumeInfo(ctx, &cnsvSphereVolumeMigration)
+	hosts := volumeMigration.getVCenterHosts()
+	if len(hosts) == 1 {
+		return hosts[0], nil
+	}
+	volumeMigrationResource := &migrationv1alpha1.CnsVSphereVolumeMigration{}
+	err := volumeMigration.k8sClient.Get(ctx, client.ObjectKey{Name: volumeID}, volumeMigrationResource)
 	if err != nil {
-		log.Errorf("failed to save cnsvSphereVolumeMigration CR:%v, err: %v", cnsvSphereVolumeMigration, err)
+		log.Errorf("error while getting CnsVSphereVolumeMigration CR for VolumeID: %q, err: %v", volumeID, err)
 		return "", err
 	}
-	return fileBackingInfo.FilePath, nil
+	if volumeMigrationResource.Spec.VCenterHost != "" {
+		volumeMigration.volumeIDToVCenter.Store(volumeID, volumeMigrationResource.Spec.VCenterHost)
+		return volumeMigrationResource.Spec.VCenterHost, nil
+	}
+	queryFilter := cnstypes.CnsQueryFilter{
+		VolumeIds: []cnstypes.CnsVolumeId{{Id: volumeID}},
+	}
+	for _, host := range hosts {
+		queryResult, err := volumeMigration.volumeManagers[host].QueryAllVolume(ctx, queryFilter,
+			cnstypes.CnsQuerySelection{})
+		if err != nil {
+			log.Warnf("failed to query volume %q on vCenter %q. err: %v", volumeID, host, err)
+			continue
+		}
+		if len(queryResult.Volumes) == 0 {
+			continue
+		}
+		log.Infof("Found migrated volume %q on vCenter %q", volumeID, host)
+		volumeMigrationResource.Spec.VCenterHost = host
+		err = volumeMigration.k8sClient.Update(ctx, volumeMigrationResource)
+		if err != nil {
+			log.Warnf("failed to update vCenter %q in CnsVSphereVolumeMigration CR for VolumeID: %q, err: %v",
+				host, volumeID, err)
+		}
+		volumeMigration.volumeIDToVCenter.Store(volumeID, host)
+		return host, nil
+	}
+	return "", logger.LogNewErrorf(log, "could not find vCenter for migrated volume %q", volumeID)
+}
+
+// getVolumeManager returns the vCenter host and the volume manager of the
+// vCenter on which the migrated volume with the given volumeID is registered.
+func (volumeMigration *volumeMigration) getVolumeManager(ctx context.Context,
+	volumeID string) (string, cnsvolume.Manager, error) {
+	log := logger.GetLogger(ctx)
+	host, err := volumeMigration.GetVCenterForVolumeID(ctx, volumeID)
+	if err != nil {
+		return "", nil, err
+	}
+	volumeManager, found := volumeMigration.volumeManagers[host]
+	if !found {
+		return "", nil, logger.LogNewErrorf(log, "could not get volume manager for the vCenter: %q", host)
+	}
+	return host, volumeManager, nil
+}
+
+// getVCenterHosts returns the sorted list of vCenter hosts with a volume
+// manager.
+func (volumeMigration *volumeMigration) getVCenterHosts() []string {
+	hosts := make([]string, 0, len(volumeMigration.volumeManagers))
+	for host := range volumeMigration.volumeManagers {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
 }
 
 // GetVolumePathFromMigrationServiceCache checks the in-memory cache for a volumeID
@@ -442,23 +564,19 @@ func (volumeMigration *volumeMigration) DeleteVolumeInfo(ctx context.Context, vo
 }
 
 // registerVolume takes VolumeSpec and helps register Volume with CNS.
-// Returns VolumeID for successful registration, otherwise return error.
+// Returns VolumeID and the vCenter host on which the volume is registered
+// for successful registration, otherwise return error.
 func (volumeMigration *volumeMigration) registerVolume(ctx context.Context,
-	volumeSpec *VolumeSpec) (string, bool, error) {
+	volumeSpec *VolumeSpec) (string, bool, string, error) {
 	log := logger.GetLogger(ctx)
 	value, _ := volumeMigration.registerVolumeMutexes.LoadOrStore(volumeSpec.VolumePath, &sync.Mutex{})
 	mtx := value.(*sync.Mutex)
 	mtx.Lock()
 	defer mtx.Unlock()
 
-	uuid, err := uuid.NewUUID()
-	if err != nil {
-		log.Errorf("failed to generate uuid")
-		return "", false, err
-	}
 	re := regexp.MustCompile(`\[([^\[\]]*)\]`)
 	if !re.MatchString(volumeSpec.VolumePath) {
-		return "", false, logger.LogNewErrorf(log,
+		return "", false, "", logger.LogNewErrorf(log,
 			"failed to extract datastore name from in-tree volume path: %q", volumeSpec.VolumePath)
 	}
 	datastoreFullPath := re.FindAllString(volumeSpec.VolumePath, -1)[0]
@@ -466,18 +584,106 @@ func (volumeMigration *volumeMigration) registerVolume(ctx context.Context,
 	datastoreFullPath = strings.Trim(strings.Trim(datastoreFullPath, "["), "]")
 	datastorePathSplit := strings.Split(datastoreFullPath, "/")
 	datastoreName := datastorePathSplit[len(datastorePathSplit)-1]
-	var datacenters string
-	var user string
-	var host string
 	if volumeMigration.cnsConfig == nil || len(volumeMigration.cnsConfig.VirtualCenter) == 0 {
-		return "", false, logger.LogNewError(log, "could not find vcenter config")
+		return "", false, "", logger.LogNewError(log, "could not find vcenter config")
+	}
+	host, err := volumeMigration.getVCenterForDatastore(ctx, datastoreName)
+	if err != nil {
+		return "", false, "", err
+	}
+	volumeID, protectedVolumeFromVMDeletion, err := volumeMigration.registerVolumeOnVCenter(ctx, host,
+		volumeSpec, vmdkPath, datastoreName)
+	if err != nil {
+		return "", false, "", logger.LogNewErrorf(log,
+			"registration failed for volumeSpec: %v on vCenter %q. err: %v", volumeSpec, host, err)
+	}
+	return volumeID, protectedVolumeFromVMDeletion, host, nil
+}
+
+// getVCenterForDatastore returns the vCenter host which has a datastore with
+// the given name. The in-tree volume path only holds the name of the
+// datastore, which is not unique across vCenters, so an error is returned
+// when several vCenters have a datastore with this name, or when the
+// datastores of a vCenter can't be listed. On a single vCenter deployment,
+// the only vCenter host is returned without looking up the datastore.
+func (volumeMigration *volumeMigration) getVCenterForDatastore(ctx context.Context,
+	datastoreName string) (string, error) {
+	log := logger.GetLogger(ctx)
+	var hosts []string
+	for host := range volumeMigration.cnsConfig.VirtualCenter {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	if len(hosts) == 1 {
+		return hosts[0], nil
+	}
+	var datastoreHosts []string
+	for _, host := range hosts {
+		// A vCenter whose datastores can't be listed may have a datastore
+		// with the same name, so the vCenter of the volume can't be told.
+		vCenter, err := vsphere.GetVirtualCenterManager(ctx).GetVirtualCenter(ctx, host)
+		if err != nil {
+			return "", logger.LogNewErrorf(log, "failed to get vCenter %q. err: %v", host, err)
+		}
+		dcs, err := vCenter.GetDatacenters(ctx)
+		if err != nil {
+			return "", logger.LogNewErrorf(log, "failed to get datacenters from vCenter %q. err: %v", host, err)
+		}
+		for _, dc := range dcs {
+			datastores, err := dc.GetAllDatastores(ctx)
+			if err != nil {
+				return "", logger.LogNewErrorf(log, "failed to get datastores in datacenter %q on vCenter %q. err: %v",
+					dc.InventoryPath, host, err)
+			}
+			if hasDatastoreWithName(datastores, datastoreName) {
+				datastoreHosts = append(datastoreHosts, host)
+				break
+			}
+		}
+	}
+	if len(datastoreHosts) == 0 {
+		return "", logger.LogNewErrorf(log, "datastore %q is not found on any vCenter", datastoreName)
+	}
+	if len(datastoreHosts) > 1 {
+		return "", logger.LogNewErrorf(log, "datastore %q is found on several vCenters %v. "+
+			"Unable to determine the vCenter of the volume", datastoreName, datastoreHosts)
+	}
+	log.Debugf("datastore %q is found on vCenter %q", datastoreName, datastoreHosts[0])
+	return datastoreHosts[0], nil
+}
+
+// hasDatastoreWithName returns true if any of the given datastores has the
+// given name.
+func hasDatastoreWithName(datastores map[string]*vsphere.DatastoreInfo, datastoreName string) bool {
+	for _, datastore := range datastores {
+		if datastore.Info != nil && datastore.Info.Name == datastoreName {
+			return true
+		}
+	}
+	return false
+}
+
+// registerVolumeOnVCenter registers the volume with the given vmdk path on
+// the datastore with the given name with CNS on the given vCenter.
+// Returns VolumeID for successful registration, otherwise return error.
+func (volumeMigration *volumeMigration) registerVolumeOnVCenter(ctx context.Context, host string,
+	volumeSpec *VolumeSpec, vmdkPath string, datastoreName string) (string, bool, error) {
+	log := logger.GetLogger(ctx)
+	uuid, err := uuid.NewUUID()
+	if err != nil {
+		log.Errorf("failed to generate uuid")
+		return "", false, err
 	}
-	for key, val := range volumeMigration.cnsConfig.VirtualCenter {
-		datacenters = val.Datacenters
-		user = val.User
-		host = key
-		break
+	vcConfig, found := volumeMigration.cnsConfig.VirtualCenter[host]
+	if !found {
+		return "", false, logger.LogNewErrorf(log, "could not find vcenter config for %q", host)
 	}
+	volumeManager, found := volumeMigration.volumeManagers[host]
+	if !found {
+		return "", false, logger.LogNewErrorf(log, "could not get volume manager for the vCenter: %q", host)
+	}
+	datacenters := vcConfig.Datacenters
+	user := vcConfig.User
 	// Get vCenter.
 	vCenter, err := vsphere.GetVirtualCenterManager(ctx).GetVirtualCenter(ctx, host)
 	if err != nil {
@@ -543,7 +749,7 @@ func (volumeMigration *volumeMigration) registerVolume(ctx context.Context,
 				vCenter.Client.ServiceContent.About.ApiVersion, err)
 		}
 		if bUseVslmAPIs {
-			backingObjectID, err := (*volumeMigration.volumeManager).RegisterDisk(ctx,
+			backingObjectID, err := volumeManager.RegisterDisk(ctx,
 				backingDiskURLPath, volumeSpec.VolumePath)
 			if err != nil {
 				return "", false, logger.LogNewErrorf(log,
@@ -557,7 +763,7 @@ func (volumeMigration *volumeMigration) registerVolume(ctx context.Context,
 		}
 		log.Debugf("vSphere CSI driver registering volume %q with create spec %+v",
 			volumeSpec.VolumePath, spew.Sdump(createSpec))
-		volumeInfo, _, err = (*volumeMigration.volumeManager).CreateVolume(ctx, createSpec, nil)
+		volumeInfo, _, err = volumeManager.CreateVolume(ctx, createSpec, nil)
 		if err != nil {
 			log.Warnf("failed to register volume %q with createSpec: %v. error: %+v",
 				volumeSpec.VolumePath, createSpec, err)
@@ -609,21 +815,30 @@ func (volumeMigration *volumeMigration) cleanupStaleCRDInstances() {
 			continue
 		}
 		log.Debugf("CnsVSphereVolumeMigrationList: %+v", volumeMigrationResourceList)
-		queryAllResult, err := utils.QueryAllVolumesForCluster(ctx, *volumeMigrationInstance.volumeManager,
-			volumeMigrationInstance.cnsConfig.Global.ClusterID, cnstypes.CnsQuerySelection{})
-		if err != nil {
-			log.Warnf("failed to queryAllVolume with err %+v", err)
+		// Volumes are looked up on every vCenter. The cleanup is skipped if
+		// any of the vCenters cannot be queried.
+		cnsVolumesMap := make(map[string]bool)
+		queryFailed := false
+		for _, host := range volumeMigrationInstance.getVCenterHosts() {
+			queryAllResult, err := utils.QueryAllVolumesForCluster(ctx, volumeMigrationInstance.volumeManagers[host],
+				volumeMigrationInstance.cnsConfig.Global.ClusterID, cnstypes.CnsQuerySelection{})
+			if err != nil {
+				log.Warnf("failed to queryAllVolume on vCenter %q with err %+v", host, err)
+				queryFailed = true
+				break
+			}
+			log.Debugf("QueryVolumeInfo successfully returned with result:  %v:", spew.Sdump(queryAllResult))
+			for _, vol := range queryAllResult.Volumes {
+				cnsVolumesMap[vol.VolumeId.Id] = true
+			}
+		}
+		if queryFailed {
 			continue
 		}
-		if len(queryAllResult.Volumes) == 0 {
+		if len(cnsVolumesMap) == 0 {
 			log.Debugf("No volumes found in Query Volume Result")
 			continue
 		}
-		log.Debugf("QueryVolumeInfo successfully returned with result:  %v:", spew.Sdump(queryAllResult))
-		cnsVolumesMap := make(map[string]bool)
-		for _, vol := range queryAllResult.Volumes {
-			cnsVolumesMap[vol.VolumeId.Id] = true
-		}
 		log.Debugf("cnsVolumesMap:  %v:", cnsVolumesMap)
 		k8sclient, err := k8s.NewClient(ctx)
 		if err != nil {
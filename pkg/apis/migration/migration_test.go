@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	vim25types "github.com/vmware/govmomi/vim25/types"
+
+	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	cnsconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+)
+
+func TestHasDatastoreWithName(t *testing.T) {
+	datastores := map[string]*vsphere.DatastoreInfo{
+		"ds:///vmfs/volumes/ds-1/": {Info: &vim25types.DatastoreInfo{Name: "datastore1"}},
+		"ds:///vmfs/volumes/ds-2/": {Info: &vim25types.DatastoreInfo{Name: "vsanDatastore"}},
+	}
+	if !hasDatastoreWithName(datastores, "vsanDatastore") {
+		t.Errorf("expected datastore vsanDatastore to be found")
+	}
+	if hasDatastoreWithName(datastores, "datastore2") {
+		t.Errorf("expected datastore datastore2 not to be found")
+	}
+}
+
+func TestGetVCenterForDatastoreOnSingleVCenter(t *testing.T) {
+	migrationService := &volumeMigration{
+		cnsConfig: &cnsconfig.Config{
+			VirtualCenter: map[string]*cnsconfig.VirtualCenterConfig{"vc-1": {}},
+		},
+	}
+	host, err := migrationService.getVCenterForDatastore(context.Background(), "datastore1")
+	if err != nil || host != "vc-1" {
+		t.Errorf("expected vCenter vc-1 for datastore1, got %q, err: %v", host, err)
+	}
+}
+
+func TestGetVCenterForVolumeIDFromCache(t *testing.T) {
+	ctx := context.Background()
+	migrationService := &volumeMigration{
+		volumeManagers: map[string]cnsvolume.Manager{"vc-2": nil, "vc-1": nil},
+	}
+	if hosts := migrationService.getVCenterHosts(); !reflect.DeepEqual(hosts, []string{"vc-1", "vc-2"}) {
+		t.Errorf("unexpected vCenter hosts %v", hosts)
+	}
+	migrationService.volumeIDToVCenter.Store("volume-1", "vc-2")
+	host, err := migrationService.GetVCenterForVolumeID(ctx, "volume-1")
+	if err != nil || host != "vc-2" {
+		t.Errorf("expected vCenter vc-2 for volume-1, got %q, err: %v", host, err)
+	}
+
+	// On a single vCenter deployment, the only vCenter is returned.
+	migrationService = &volumeMigration{
+		volumeManagers: map[string]cnsvolume.Manager{"vc-1": nil},
+	}
+	host, err = migrationService.GetVCenterForVolumeID(ctx, "volume-2")
+	if err != nil || host != "vc-1" {
+		t.Errorf("expected vCenter vc-1 for volume-2, got %q, err: %v", host, err)
+	}
+}
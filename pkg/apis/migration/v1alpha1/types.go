@@ -39,6 +39,9 @@ type CnsVSphereVolumeMigrationSpec struct {
 	VolumeID string `json:"volumeid"`
 	// ProtectVolumeFromVMDelete true means migrated volumes is protected from Node VM deletion
 	ProtectVolumeFromVMDelete bool `json:"protectvolumefromvmdelete"`
+	// VCenterHost is the vCenter server on which the volume is registered.
+	// It is empty for volumes registered before multi vCenter support.
+	VCenterHost string `json:"vcenterhost,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
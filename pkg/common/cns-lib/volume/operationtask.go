@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+// OperationTaskDetails holds the details of the last CNS task run by the volume
+// manager for a CSI operation, so that they can be reported to users when
+// the operation fails.
+type OperationTaskDetails struct {
+	mu         sync.Mutex
+	taskID     string
+	vCenter    string
+	datastores []string
+}
+
+type operationTaskDetailsKey struct{}
+
+// WithOperationTaskDetails returns a context in which the volume manager records the
+// details of the CNS tasks it runs into the returned OperationTaskDetails.
+func WithOperationTaskDetails(ctx context.Context) (context.Context, *OperationTaskDetails) {
+	details := &OperationTaskDetails{}
+	return context.WithValue(ctx, operationTaskDetailsKey{}, details), details
+}
+
+// GetTaskID returns the MoID of the last CNS task and the vCenter it ran on.
+func (d *OperationTaskDetails) GetTaskID() (taskID string, vCenter string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.taskID, d.vCenter
+}
+
+// GetDatastores returns the names of the datastores the last failed CNS create
+// task could place the volume on.
+func (d *OperationTaskDetails) GetDatastores() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.datastores...)
+}
+
+// recordTask records the given task in the OperationTaskDetails of ctx, if any.
+func recordTask(ctx context.Context, vCenter string, taskMoRef vim25types.ManagedObjectReference) {
+	details, ok := ctx.Value(operationTaskDetailsKey{}).(*OperationTaskDetails)
+	if !ok {
+		return
+	}
+	details.mu.Lock()
+	defer details.mu.Unlock()
+	details.taskID = taskMoRef.Value
+	details.vCenter = vCenter
+}
+
+// recordDatastores records the names of the candidate datastores of a failed
+// create task in the OperationTaskDetails of ctx, if any. The names are looked
+// up on vCenter, falling back to the MoID of datastores that cannot be read.
+func recordDatastores(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	datastores []vim25types.ManagedObjectReference) {
+	details, ok := ctx.Value(operationTaskDetailsKey{}).(*OperationTaskDetails)
+	if !ok || len(datastores) == 0 {
+		return
+	}
+	names := make(map[vim25types.ManagedObjectReference]string)
+	if vc != nil && vc.Client != nil {
+		var dsMoList []mo.Datastore
+		err := property.DefaultCollector(vc.Client.Client).Retrieve(ctx, datastores, []string{"name"}, &dsMoList)
+		if err != nil {
+			logger.GetLogger(ctx).Warnf("failed to retrieve the names of datastores %v. Err: %v", datastores, err)
+		}
+		for _, dsMo := range dsMoList {
+			names[dsMo.Reference()] = dsMo.Name
+		}
+	}
+	details.mu.Lock()
+	defer details.mu.Unlock()
+	details.datastores = nil
+	for _, ds := range datastores {
+		name, ok := names[ds]
+		if !ok || name == "" {
+			name = ds.Value
+		}
+		details.datastores = append(details.datastores, name)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+)
+
+func TestOperationTaskDetails(t *testing.T) {
+	// Nothing is recorded without OperationTaskDetails in the context.
+	recordTask(context.Background(), "vc1", vim25types.ManagedObjectReference{Type: "Task", Value: "task-1"})
+
+	ctx, details := WithOperationTaskDetails(context.Background())
+	recordDatastores(ctx, nil, []vim25types.ManagedObjectReference{
+		{Type: "Datastore", Value: "datastore-1"},
+		{Type: "Datastore", Value: "datastore-2"},
+	})
+	recordTask(ctx, "vc1", vim25types.ManagedObjectReference{Type: "Task", Value: "task-1"})
+	recordTask(ctx, "vc1", vim25types.ManagedObjectReference{Type: "Task", Value: "task-2"})
+
+	taskID, vCenter := details.GetTaskID()
+	if taskID != "task-2" || vCenter != "vc1" {
+		t.Errorf("expected task-2 on vc1, got %q on %q", taskID, vCenter)
+	}
+	if datastores := details.GetDatastores(); len(datastores) != 2 || datastores[1] != "datastore-2" {
+		t.Errorf("unexpected datastores %v", datastores)
+	}
+}
+
+func TestRecordDatastoresUsesNames(t *testing.T) {
+	simulator.Test(func(simCtx context.Context, c *vim25.Client) {
+		ds, err := find.NewFinder(c).DefaultDatastore(simCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vc := &cnsvsphere.VirtualCenter{Client: &govmomi.Client{Client: c}}
+		ctx, details := WithOperationTaskDetails(simCtx)
+		recordDatastores(ctx, vc, []vim25types.ManagedObjectReference{ds.Reference()})
+		if datastores := details.GetDatastores(); len(datastores) != 1 || datastores[0] != ds.Name() {
+			t.Errorf("expected datastore name %q, got %v", ds.Name(), datastores)
+		}
+	})
+}
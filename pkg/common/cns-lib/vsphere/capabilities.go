@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	prometheusclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/vmware/govmomi/cns"
+	"github.com/vmware/govmomi/vim25"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// CapabilityOnlineExtendVolume is the capability to expand attached volumes.
+	CapabilityOnlineExtendVolume = "online-extend-volume"
+	// CapabilityCnsSnapshot is the capability to use the CNS snapshot APIs.
+	CapabilityCnsSnapshot = "cns-snapshot"
+	// CapabilityCnsTransaction is the capability to use CNS transactions.
+	CapabilityCnsTransaction = "cns-transaction"
+	// CapabilityVSANFileServices is the capability to provision file volumes
+	// on vSAN file services.
+	CapabilityVSANFileServices = "vsan-file-services"
+	// CapabilityNVMe is the capability to attach volumes to NVMe controllers.
+	CapabilityNVMe = "nvme"
+)
+
+// Capabilities holds the features of a vCenter discovered when the driver
+// connects to it.
+type Capabilities struct {
+	// Host is the vCenter host the capabilities were discovered on.
+	Host string
+	// Version is the vCenter product version, e.g. "8.0.3".
+	Version string
+	// Build is the vCenter build number.
+	Build string
+	// APIVersion is the vSphere API version negotiated with the vCenter.
+	APIVersion string
+	// OnlineExtendVolume is true when attached volumes can be expanded.
+	OnlineExtendVolume bool
+	// CnsSnapshot is true when the CNS snapshot APIs are available.
+	CnsSnapshot bool
+	// CnsTransaction is true when the CNS APIs support transactions.
+	CnsTransaction bool
+	// VSANFileServices is true when file volumes can be provisioned on vSAN
+	// file services.
+	VSANFileServices bool
+	// NVMe is true when volumes can be attached to NVMe controllers. The
+	// driver requires vSphere 8.0 Update 3 or above for NVMe controllers.
+	NVMe bool
+	// DiscoveryTime is the time the capabilities were discovered.
+	DiscoveryTime time.Time
+}
+
+var (
+	// vCenterCapabilities is the map of vCenter host to the capabilities
+	// discovered on the last connection to the vCenter.
+	vCenterCapabilities = make(map[string]*Capabilities)
+	// vCenterCapabilitiesLock protects vCenterCapabilities.
+	vCenterCapabilitiesLock = &sync.RWMutex{}
+)
+
+// discoverCapabilities discovers the capabilities of the vCenter the given
+// client is connected to.
+func discoverCapabilities(ctx context.Context, host string, client *vim25.Client) (*Capabilities, error) {
+	about := client.ServiceContent.About
+	isvSphere70U3orAbove, err := IsvSphereVersion70U3orAbove(ctx, about)
+	if err != nil {
+		return nil, err
+	}
+	isvSphere80U3orAbove, err := IsvSphereVersion80U3orAbove(ctx, about)
+	if err != nil {
+		return nil, err
+	}
+	isvSphere91orAbove, err := IsvSphereVersion91orAbove(ctx, about)
+	if err != nil {
+		return nil, err
+	}
+	return &Capabilities{
+		Host:       host,
+		Version:    about.Version,
+		Build:      about.Build,
+		APIVersion: client.Version,
+		OnlineExtendVolume: client.Version != cns.ReleaseVSAN67u3 && client.Version != cns.ReleaseVSAN70 &&
+			client.Version != cns.ReleaseVSAN70u1,
+		CnsSnapshot:      isvSphere70U3orAbove,
+		CnsTransaction:   isvSphere91orAbove,
+		VSANFileServices: client.Version != cns.ReleaseVSAN67u3,
+		NVMe:             isvSphere80U3orAbove,
+		DiscoveryTime:    time.Now(),
+	}, nil
+}
+
+// refreshCapabilities discovers and caches the capabilities of the vCenter.
+// It is called whenever a new client is created for the vCenter, so that
+// the capabilities are refreshed after the vCenter is upgraded.
+func (vc *VirtualCenter) refreshCapabilities(ctx context.Context) {
+	log := logger.GetLogger(ctx)
+	capabilities, err := discoverCapabilities(ctx, vc.Config.Host, vc.Client.Client)
+	if err != nil {
+		// The capabilities are then discovered on each check.
+		log.Errorf("failed to discover the capabilities of vCenter %q. Err: %v", vc.Config.Host, err)
+		clearCapabilities(vc.Config.Host)
+		return
+	}
+	log.Infof("Discovered capabilities of vCenter %q: %+v", vc.Config.Host, *capabilities)
+	setCapabilities(capabilities)
+}
+
+// setCapabilities caches the capabilities of a vCenter and exposes them as
+// metrics.
+func setCapabilities(capabilities *Capabilities) {
+	vCenterCapabilitiesLock.Lock()
+	defer vCenterCapabilitiesLock.Unlock()
+	vCenterCapabilities[capabilities.Host] = capabilities
+
+	prometheus.VCenterInfo.DeletePartialMatch(prometheusclient.Labels{"vcenter": capabilities.Host})
+	prometheus.VCenterInfo.WithLabelValues(capabilities.Host, capabilities.Version, capabilities.Build,
+		capabilities.APIVersion).Set(1)
+	for capability, supported := range map[string]bool{
+		CapabilityOnlineExtendVolume: capabilities.OnlineExtendVolume,
+		CapabilityCnsSnapshot:        capabilities.CnsSnapshot,
+		CapabilityCnsTransaction:     capabilities.CnsTransaction,
+		CapabilityVSANFileServices:   capabilities.VSANFileServices,
+		CapabilityNVMe:               capabilities.NVMe,
+	} {
+		value := 0.0
+		if supported {
+			value = 1
+		}
+		prometheus.VCenterCapabilityGaugeVec.WithLabelValues(capabilities.Host, capability).Set(value)
+	}
+}
+
+// clearCapabilities removes the cached capabilities of a vCenter.
+func clearCapabilities(host string) {
+	vCenterCapabilitiesLock.Lock()
+	defer vCenterCapabilitiesLock.Unlock()
+	delete(vCenterCapabilities, host)
+	prometheus.VCenterInfo.DeletePartialMatch(prometheusclient.Labels{"vcenter": host})
+	prometheus.VCenterCapabilityGaugeVec.DeletePartialMatch(prometheusclient.Labels{"vcenter": host})
+}
+
+// GetCapabilities returns the capabilities discovered on the last connection
+// to the given vCenter. It returns false if the capabilities of the vCenter
+// have not been discovered.
+func GetCapabilities(host string) (*Capabilities, bool) {
+	vCenterCapabilitiesLock.RLock()
+	defer vCenterCapabilitiesLock.RUnlock()
+	capabilities, ok := vCenterCapabilities[host]
+	if !ok {
+		return nil, false
+	}
+	capabilitiesCopy := *capabilities
+	return &capabilitiesCopy, true
+}
+
+// GetAllCapabilities returns the discovered capabilities of all vCenters,
+// sorted by vCenter host.
+func GetAllCapabilities() []*Capabilities {
+	vCenterCapabilitiesLock.RLock()
+	defer vCenterCapabilitiesLock.RUnlock()
+	allCapabilities := make([]*Capabilities, 0, len(vCenterCapabilities))
+	for _, capabilities := range vCenterCapabilities {
+		capabilitiesCopy := *capabilities
+		allCapabilities = append(allCapabilities, &capabilitiesCopy)
+	}
+	sort.Slice(allCapabilities, func(i, j int) bool {
+		return allCapabilities[i].Host < allCapabilities[j].Host
+	})
+	return allCapabilities
+}
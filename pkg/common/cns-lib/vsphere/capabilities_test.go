@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/cns"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestDiscoverCapabilities(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		apiVersion string
+		expected   Capabilities
+	}{
+		{
+			name:       "vSAN 7.0",
+			version:    "7.0.0",
+			apiVersion: cns.ReleaseVSAN70,
+			expected:   Capabilities{VSANFileServices: true},
+		},
+		{
+			name:       "vSphere 7.0 Update 3",
+			version:    "7.0.3",
+			apiVersion: "7.0.3.0",
+			expected:   Capabilities{OnlineExtendVolume: true, CnsSnapshot: true, VSANFileServices: true},
+		},
+		{
+			name:       "vSphere 8.0 Update 3",
+			version:    "8.0.3",
+			apiVersion: "8.0.3.0",
+			expected: Capabilities{OnlineExtendVolume: true, CnsSnapshot: true, VSANFileServices: true,
+				NVMe: true},
+		},
+		{
+			name:       "vSphere 9.1",
+			version:    "9.1.0",
+			apiVersion: "9.1.0.0",
+			expected: Capabilities{OnlineExtendVolume: true, CnsSnapshot: true, CnsTransaction: true,
+				VSANFileServices: true, NVMe: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &vim25.Client{
+				Client: &soap.Client{Version: test.apiVersion},
+				ServiceContent: types.ServiceContent{
+					About: types.AboutInfo{Version: test.version, Build: "12345"},
+				},
+			}
+			capabilities, err := discoverCapabilities(context.TODO(), "vc1", client)
+			assert.NoError(t, err)
+			assert.False(t, capabilities.DiscoveryTime.IsZero())
+			test.expected.Host = "vc1"
+			test.expected.Version = test.version
+			test.expected.Build = "12345"
+			test.expected.APIVersion = test.apiVersion
+			test.expected.DiscoveryTime = capabilities.DiscoveryTime
+			assert.Equal(t, test.expected, *capabilities)
+		})
+	}
+
+	// The capabilities are not discovered when the version is not recognized.
+	client := &vim25.Client{
+		Client:         &soap.Client{},
+		ServiceContent: types.ServiceContent{About: types.AboutInfo{Version: "x.y.z"}},
+	}
+	_, err := discoverCapabilities(context.TODO(), "vc1", client)
+	assert.Error(t, err)
+}
+
+func TestCapabilitiesCache(t *testing.T) {
+	setCapabilities(&Capabilities{Host: "vc2", Version: "8.0.3", CnsSnapshot: true})
+	setCapabilities(&Capabilities{Host: "vc1", Version: "7.0.3"})
+	defer clearCapabilities("vc1")
+	defer clearCapabilities("vc2")
+
+	capabilities, ok := GetCapabilities("vc2")
+	assert.True(t, ok)
+	assert.True(t, capabilities.CnsSnapshot)
+	// The cached capabilities are not modified through the returned copy.
+	capabilities.CnsSnapshot = false
+	capabilities, _ = GetCapabilities("vc2")
+	assert.True(t, capabilities.CnsSnapshot)
+
+	allCapabilities := GetAllCapabilities()
+	assert.Len(t, allCapabilities, 2)
+	assert.Equal(t, "vc1", allCapabilities[0].Host)
+	assert.Equal(t, "vc2", allCapabilities[1].Host)
+
+	clearCapabilities("vc2")
+	_, ok = GetCapabilities("vc2")
+	assert.False(t, ok)
+	assert.Len(t, GetAllCapabilities(), 1)
+}
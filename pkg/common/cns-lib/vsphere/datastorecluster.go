@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+// ErrDatastoreClusterNotFound is returned when no datastore cluster matches
+// the given name.
+var ErrDatastoreClusterNotFound = errors.New("datastore cluster not found")
+
+// DatastoreCluster holds the StoragePod and Datacenter information of a
+// datastore cluster.
+type DatastoreCluster struct {
+	// StoragePod represents the govmomi StoragePod instance.
+	*object.StoragePod
+	// Datacenter represents the datacenter on which the datastore cluster
+	// resides.
+	Datacenter *Datacenter
+}
+
+// GetDatastoreClusterByName returns the datastore cluster with the given name
+// or inventory path, looking it up in all the datacenters of the vCenter.
+// ErrDatastoreClusterNotFound is returned if no datacenter has it.
+func (vc *VirtualCenter) GetDatastoreClusterByName(ctx context.Context, name string) (*DatastoreCluster, error) {
+	log := logger.GetLogger(ctx)
+	datacenters, err := vc.GetDatacenters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, dc := range datacenters {
+		finder := find.NewFinder(dc.Datacenter.Client(), false)
+		finder.SetDatacenter(dc.Datacenter)
+		pod, err := finder.DatastoreCluster(ctx, name)
+		if err != nil {
+			var notFoundErr *find.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				continue
+			}
+			log.Errorf("failed to find datastore cluster %q in datacenter %v. Error: %v", name, dc, err)
+			return nil, err
+		}
+		return &DatastoreCluster{StoragePod: pod, Datacenter: dc}, nil
+	}
+	return nil, ErrDatastoreClusterNotFound
+}
+
+// GetDatastoreClusterByMoID returns the datastore cluster with the given
+// managed object ID, e.g. "group-p1001", looking up the datacenter it resides
+// in. ErrDatastoreClusterNotFound is returned if no datacenter has it.
+func (vc *VirtualCenter) GetDatastoreClusterByMoID(ctx context.Context, moID string) (*DatastoreCluster, error) {
+	log := logger.GetLogger(ctx)
+	datacenters, err := vc.GetDatacenters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref := types.ManagedObjectReference{Type: "StoragePod", Value: moID}
+	path, err := find.InventoryPath(ctx, vc.Client.Client, ref)
+	if err != nil {
+		if soap.IsSoapFault(err) {
+			if _, ok := soap.ToSoapFault(err).VimFault().(types.ManagedObjectNotFound); ok {
+				return nil, ErrDatastoreClusterNotFound
+			}
+		}
+		log.Errorf("failed to get inventory path of datastore cluster %q. Error: %v", moID, err)
+		return nil, err
+	}
+	for _, dc := range datacenters {
+		if !strings.HasPrefix(path, dc.InventoryPath+"/") {
+			continue
+		}
+		pod := object.NewStoragePod(vc.Client.Client, ref)
+		pod.InventoryPath = path
+		return &DatastoreCluster{StoragePod: pod, Datacenter: dc}, nil
+	}
+	return nil, ErrDatastoreClusterNotFound
+}
+
+// GetMemberDatastores returns the "summary" and "host" properties of the
+// datastores in the datastore cluster.
+func (dsc *DatastoreCluster) GetMemberDatastores(ctx context.Context) ([]mo.Datastore, error) {
+	children, err := dsc.Children(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list datastores in datastore cluster %q. Error: %v", dsc.Name(), err)
+	}
+	var dsList []types.ManagedObjectReference
+	for _, child := range children {
+		if child.Reference().Type == "Datastore" {
+			dsList = append(dsList, child.Reference())
+		}
+	}
+	if len(dsList) == 0 {
+		return nil, fmt.Errorf("datastore cluster %q has no datastores", dsc.Name())
+	}
+	var dsMoList []mo.Datastore
+	pc := property.DefaultCollector(dsc.Client())
+	err = pc.Retrieve(ctx, dsList, []string{"summary", "host"}, &dsMoList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summary of datastores in datastore cluster %q. Error: %v",
+			dsc.Name(), err)
+	}
+	return dsMoList, nil
+}
+
+// IsStorageDRSEnabled returns true if Storage DRS is enabled on the datastore
+// cluster.
+func (dsc *DatastoreCluster) IsStorageDRSEnabled(ctx context.Context) (bool, error) {
+	var podMo mo.StoragePod
+	err := dsc.Properties(ctx, dsc.Reference(), []string{"podStorageDrsEntry"}, &podMo)
+	if err != nil {
+		return false, fmt.Errorf("failed to get Storage DRS configuration of datastore cluster %q. Error: %v",
+			dsc.Name(), err)
+	}
+	return podMo.PodStorageDrsEntry != nil && podMo.PodStorageDrsEntry.StorageDrsConfig.PodConfig.Enabled, nil
+}
+
+// RecommendDatastore asks Storage DRS for the member datastore to create a
+// disk of the given capacity on. The placement is requested as for a new VM
+// with a single disk, using the root resource pool of a host mounting one of
+// the given member datastores.
+func (dsc *DatastoreCluster) RecommendDatastore(ctx context.Context, name string, capacityMB int64,
+	members []mo.Datastore) (*types.ManagedObjectReference, error) {
+	log := logger.GetLogger(ctx)
+	folders, err := dsc.Datacenter.Folders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders of datacenter %v. Error: %v", dsc.Datacenter, err)
+	}
+	var pool *object.ResourcePool
+	for _, member := range members {
+		if len(member.Host) == 0 {
+			continue
+		}
+		pool, err = object.NewHostSystem(dsc.Client(), member.Host[0].Key).ResourcePool(ctx)
+		if err == nil {
+			break
+		}
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("failed to find a resource pool for the hosts of datastore cluster %q",
+			dsc.Name())
+	}
+	disk := &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			Key: -1,
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				DiskMode:        string(types.VirtualDiskModePersistent),
+				ThinProvisioned: types.NewBool(true),
+			},
+		},
+		CapacityInKB: capacityMB * 1024,
+	}
+	podRef := dsc.Reference()
+	poolRef := pool.Reference()
+	folderRef := folders.VmFolder.Reference()
+	spec := types.StoragePlacementSpec{
+		Type:         string(types.StoragePlacementSpecPlacementTypeCreate),
+		ResourcePool: &poolRef,
+		Folder:       &folderRef,
+		ConfigSpec: &types.VirtualMachineConfigSpec{
+			Name: name,
+			DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+				&types.VirtualDeviceConfigSpec{
+					Operation:     types.VirtualDeviceConfigSpecOperationAdd,
+					FileOperation: types.VirtualDeviceConfigSpecFileOperationCreate,
+					Device:        disk,
+				},
+			},
+		},
+		PodSelectionSpec: types.StorageDrsPodSelectionSpec{
+			StoragePod: &podRef,
+			InitialVmConfig: []types.VmPodConfigForPlacement{
+				{
+					StoragePod: podRef,
+					Disk: []types.PodDiskLocator{
+						{
+							DiskId:          disk.Key,
+							DiskBackingInfo: disk.Backing,
+						},
+					},
+				},
+			},
+		},
+	}
+	srm := object.NewStorageResourceManager(dsc.Client())
+	result, err := srm.RecommendDatastores(ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Storage DRS recommendations for datastore cluster %q. Error: %v",
+			dsc.Name(), err)
+	}
+	// The recommendations are only read, as the disk is created by CNS.
+	// Cancel them so that they do not remain pending on vCenter.
+	defer func() {
+		for _, recommendation := range result.Recommendations {
+			_, err := methods.CancelRecommendation(ctx, dsc.Client(), &types.CancelRecommendation{
+				This: srm.Reference(),
+				Key:  recommendation.Key,
+			})
+			if err != nil {
+				log.Warnf("failed to cancel Storage DRS recommendation %q for datastore cluster %q. Error: %v",
+					recommendation.Key, dsc.Name(), err)
+			}
+		}
+	}()
+	for _, recommendation := range result.Recommendations {
+		for _, action := range recommendation.Action {
+			if placement, ok := action.(*types.StoragePlacementAction); ok {
+				return &placement.Destination, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("storage DRS returned no recommendation for datastore cluster %q", dsc.Name())
+}
+
+// IsDatastoreInMaintenanceMode returns true if the datastore with the given
+// summary is in or entering maintenance mode.
+func IsDatastoreInMaintenanceMode(summary types.DatastoreSummary) bool {
+	return summary.MaintenanceMode != "" &&
+		summary.MaintenanceMode != string(types.DatastoreSummaryMaintenanceModeStateNormal)
+}
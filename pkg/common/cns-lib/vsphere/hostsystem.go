@@ -25,14 +25,18 @@ import (
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
 	ErrNoSharedDSFound     = errors.New("no shared datastores found among given hosts")
 	ErrNoAccessibleDSFound = errors.New("no accessible datastores found among given hosts")
+	// ErrHostNotFound is returned when no host matches the host topology value.
+	ErrHostNotFound = errors.New("host not found")
 )
 
 // HostSystem holds details of a host instance.
@@ -74,6 +78,105 @@ func (host *HostSystem) GetAllAccessibleDatastores(ctx context.Context) ([]*Data
 	return dsObjList, nil
 }
 
+// GetLocalDatastores gets the list of datastores which are accessible only
+// from the given host, such as datastores backed by the local disks of the host.
+func (host *HostSystem) GetLocalDatastores(ctx context.Context) ([]*DatastoreInfo, error) {
+	log := logger.GetLogger(ctx)
+	var hostSystemMo mo.HostSystem
+	err := host.Properties(ctx, host.Reference(), []string{"datastore"}, &hostSystemMo)
+	if err != nil {
+		log.Errorf("failed to retrieve datastores for host %v with err: %v", host, err)
+		return nil, err
+	}
+	if len(hostSystemMo.Datastore) == 0 {
+		return nil, nil
+	}
+
+	var dsMoList []mo.Datastore
+	pc := property.DefaultCollector(host.Client())
+	properties := []string{"info", "customValue", "summary", "host"}
+	err = pc.Retrieve(ctx, hostSystemMo.Datastore, properties, &dsMoList)
+	if err != nil {
+		log.Errorf("failed to get datastore managed objects from datastore objects %v with properties %v: %v",
+			hostSystemMo.Datastore, properties, err)
+		return nil, err
+	}
+	var dsObjList []*DatastoreInfo
+	for _, dsMo := range dsMoList {
+		if !isLocalDatastore(dsMo) {
+			continue
+		}
+		dsObjList = append(dsObjList,
+			&DatastoreInfo{
+				&Datastore{object.NewDatastore(host.Client(), dsMo.Reference()),
+					nil},
+				dsMo.Info.GetDatastoreInfo(), dsMo.CustomValue})
+	}
+	return dsObjList, nil
+}
+
+// isLocalDatastore checks if the datastore is accessible from a single host.
+func isLocalDatastore(dsMo mo.Datastore) bool {
+	if dsMo.Summary.MultipleHostAccess != nil && *dsMo.Summary.MultipleHostAccess {
+		return false
+	}
+	return len(dsMo.Host) == 1 && dsMo.Summary.Accessible
+}
+
+// GetTopologyValue returns the value identifying the host in the host
+// topology segment reported by the nodes running on it. It is the name of the
+// host, or its managed object ID if the name is not a valid label value.
+func (host *HostSystem) GetTopologyValue(ctx context.Context) (string, error) {
+	name, err := host.ObjectName(ctx)
+	if err != nil {
+		return "", err
+	}
+	return getHostTopologyValue(name, host.Reference().Value), nil
+}
+
+func getHostTopologyValue(name string, moID string) string {
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+	return moID
+}
+
+// GetHostSystemByTopologyValue returns the host identified by the given value
+// of the host topology segment. See HostSystem.GetTopologyValue.
+// ErrHostNotFound is returned if no host in the vCenter matches the value.
+// Host MoIDs are only unique within a vCenter, so callers looking up the value
+// in several vCenters must reject the values found in more than one of them.
+func (vc *VirtualCenter) GetHostSystemByTopologyValue(ctx context.Context, value string) (*HostSystem, error) {
+	log := logger.GetLogger(ctx)
+	containerView, err := view.NewManager(vc.Client.Client).CreateContainerView(ctx,
+		vc.Client.ServiceContent.RootFolder, []string{"HostSystem"}, true)
+	if err != nil {
+		log.Errorf("failed to create container view for hosts in vCenter %q. Error: %v", vc.Config.Host, err)
+		return nil, err
+	}
+	defer func() {
+		_ = containerView.Destroy(ctx)
+	}()
+	var hostMoList []mo.HostSystem
+	err = containerView.Retrieve(ctx, []string{"HostSystem"}, []string{"name"}, &hostMoList)
+	if err != nil {
+		log.Errorf("failed to retrieve hosts in vCenter %q. Error: %v", vc.Config.Host, err)
+		return nil, err
+	}
+	var hostRef *types.ManagedObjectReference
+	for _, hostMo := range hostMoList {
+		if getHostTopologyValue(hostMo.Name, hostMo.Reference().Value) == value {
+			ref := hostMo.Reference()
+			hostRef = &ref
+			break
+		}
+	}
+	if hostRef == nil {
+		return nil, ErrHostNotFound
+	}
+	return &HostSystem{object.NewHostSystem(vc.Client.Client, *hostRef)}, nil
+}
+
 // GetHostVsanNodeUUID gets the vSAN NodeUuid for this host.
 func (host *HostSystem) GetHostVsanNodeUUID(ctx context.Context) (string, error) {
 	log := logger.GetLogger(ctx)
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestIsLocalDatastore(t *testing.T) {
+	newDatastore := func(hosts int, multipleHostAccess *bool, accessible bool) mo.Datastore {
+		ds := mo.Datastore{
+			Summary: types.DatastoreSummary{
+				MultipleHostAccess: multipleHostAccess,
+				Accessible:         accessible,
+			},
+		}
+		for i := 0; i < hosts; i++ {
+			ds.Host = append(ds.Host, types.DatastoreHostMount{})
+		}
+		return ds
+	}
+	tests := []struct {
+		name     string
+		ds       mo.Datastore
+		expected bool
+	}{
+		{"local", newDatastore(1, types.NewBool(false), true), true},
+		{"local without multipleHostAccess", newDatastore(1, nil, true), true},
+		{"local inaccessible", newDatastore(1, types.NewBool(false), false), false},
+		{"shared", newDatastore(3, types.NewBool(true), true), false},
+		{"shared mounted on a single host", newDatastore(1, types.NewBool(true), true), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isLocalDatastore(test.ds); actual != test.expected {
+				t.Errorf("expected isLocalDatastore to return %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetHostTopologyValue(t *testing.T) {
+	if value := getHostTopologyValue("esx-01.example.com", "host-10"); value != "esx-01.example.com" {
+		t.Errorf("expected host name as topology value, got %q", value)
+	}
+	if value := getHostTopologyValue("10.20.30.40", "host-10"); value != "10.20.30.40" {
+		t.Errorf("expected host IP as topology value, got %q", value)
+	}
+	longName := strings.Repeat("a", 60) + ".example.com"
+	if value := getHostTopologyValue(longName, "host-10"); value != "host-10" {
+		t.Errorf("expected host moref as topology value for a long host name, got %q", value)
+	}
+}
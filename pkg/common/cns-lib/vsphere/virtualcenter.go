@@ -315,6 +315,7 @@ func (vc *VirtualCenter) connect(ctx context.Context) error {
 			return err
 		}
 		log.Infof("VirtualCenter.connect() successfully created new client")
+		vc.refreshCapabilities(ctx)
 		return nil
 	}
 
@@ -386,6 +387,8 @@ func (vc *VirtualCenter) connect(ctx context.Context) error {
 		}
 		vc.VsanClient.RoundTripper = &MetricRoundTripper{"vsan", vc.VsanClient.RoundTripper}
 	}
+	// Rediscover the capabilities as the vCenter may have been upgraded.
+	vc.refreshCapabilities(ctx)
 	return nil
 }
 
@@ -493,6 +496,19 @@ func (vc *VirtualCenter) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// CheckHealth verifies that the session with the virtual center is
+// authenticated and the CNS and PBM clients are connected. An expired session
+// is re-established as part of the check.
+func (vc *VirtualCenter) CheckHealth(ctx context.Context) error {
+	if err := vc.ConnectCns(ctx); err != nil {
+		return fmt.Errorf("failed to connect CNS client to vCenter %q: %v", vc.Config.Host, err)
+	}
+	if err := vc.ConnectPbm(ctx); err != nil {
+		return fmt.Errorf("failed to connect PBM client to vCenter %q: %v", vc.Config.Host, err)
+	}
+	return nil
+}
+
 // GetHostsByCluster return hosts inside the cluster using cluster moref.
 func (vc *VirtualCenter) GetHostsByCluster(ctx context.Context,
 	clusterMorefValue string) ([]*HostSystem, error) {
@@ -21,8 +21,6 @@ import (
 	"errors"
 	"sync"
 
-	"github.com/vmware/govmomi/cns"
-
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 )
 
@@ -144,6 +142,7 @@ func (m *defaultVirtualCenterManager) UnregisterVirtualCenter(ctx context.Contex
 		log.Warnf("failed to disconnect VC %s, couldn't unregister", host)
 	}
 	vc.DisconnectCns(ctx)
+	clearCapabilities(host)
 	m.virtualCenters.Delete(host)
 	log.Infof("Successfully unregistered VC %s", host)
 	return nil
@@ -163,76 +162,74 @@ func (m *defaultVirtualCenterManager) UnregisterAllVirtualCenters(ctx context.Co
 
 // IsvSANFileServicesSupported checks if vSAN file services is supported or not.
 func (m *defaultVirtualCenterManager) IsvSANFileServicesSupported(ctx context.Context, host string) (bool, error) {
-	log := logger.GetLogger(ctx)
-	is67u3Release, err := isVsan67u3Release(ctx, m, host)
+	capabilities, err := m.getCapabilities(ctx, host)
 	if err != nil {
-		log.Errorf("Failed to identify the vCenter release with error: %+v", err)
 		return false, err
 	}
-	return !is67u3Release, nil
+	if !capabilities.VSANFileServices {
+		logger.GetLogger(ctx).Infof("vSAN file services are not supported on vCenter version %q",
+			capabilities.APIVersion)
+	}
+	return capabilities.VSANFileServices, nil
 }
 
 // IsOnlineExtendVolumeSupported checks if online extend volume is supported or not.
 func (m *defaultVirtualCenterManager) IsOnlineExtendVolumeSupported(ctx context.Context, host string) (bool, error) {
-	log := logger.GetLogger(ctx)
-
-	// Get VC instance.
-	vcenter, err := m.GetVirtualCenter(ctx, host)
+	capabilities, err := m.getCapabilities(ctx, host)
 	if err != nil {
-		log.Errorf("Failed to get vCenter. Err: %v", err)
 		return false, err
 	}
-	vCenterVersion := vcenter.Client.Version
-	if vCenterVersion != cns.ReleaseVSAN67u3 && vCenterVersion != cns.ReleaseVSAN70 &&
-		vCenterVersion != cns.ReleaseVSAN70u1 {
-		return true, nil
+	if !capabilities.OnlineExtendVolume {
+		logger.GetLogger(ctx).Infof("Online volume expansion is not supported on vCenter version %q",
+			capabilities.APIVersion)
 	}
-	log.Infof("Online volume expansion is not supported on vCenter version %q", vCenterVersion)
-	return false, nil
+	return capabilities.OnlineExtendVolume, nil
 }
 
 // IsCnsSnapshotSupported checks if cns snapshot is supported or not.
 func (m *defaultVirtualCenterManager) IsCnsSnapshotSupported(ctx context.Context, host string) (bool, error) {
-	log := logger.GetLogger(ctx)
-
-	// Get VC instance.
-	vcenter, err := m.GetVirtualCenter(ctx, host)
+	capabilities, err := m.getCapabilities(ctx, host)
 	if err != nil {
-		log.Errorf("Failed to get vCenter. Err: %v", err)
 		return false, err
 	}
-	vcVersion := vcenter.Client.ServiceContent.About.Version
-	isvSphere70U3orAbove, err := IsvSphereVersion70U3orAbove(ctx, vcenter.Client.ServiceContent.About)
-	if err != nil {
-		return false, logger.LogNewErrorf(log, "Error while checking the vSphere Version %q , Err= %+v",
-			vcVersion, err)
-	}
-	if isvSphere70U3orAbove {
-		return true, nil
+	if !capabilities.CnsSnapshot {
+		logger.GetLogger(ctx).Infof("CNS Snapshot features are not supported on vCenter version %q",
+			capabilities.Version)
 	}
-	log.Infof("CNS Snapshot features are not supported on vCenter version %q", vcVersion)
-	return false, nil
+	return capabilities.CnsSnapshot, nil
 }
 
 // IsCnsTransactionSupported checks if cns transaction is supported or not.
 func (m *defaultVirtualCenterManager) IsCnsTransactionSupported(ctx context.Context, host string) (bool, error) {
-	log := logger.GetLogger(ctx)
+	capabilities, err := m.getCapabilities(ctx, host)
+	if err != nil {
+		return false, err
+	}
+	if !capabilities.CnsTransaction {
+		logger.GetLogger(ctx).Infof("CNS Transaction feature is not supported on vCenter version %q",
+			capabilities.Version)
+	}
+	return capabilities.CnsTransaction, nil
+}
 
+// getCapabilities returns the capabilities of the given vCenter, served from
+// the capabilities discovered when connecting to the vCenter.
+func (m *defaultVirtualCenterManager) getCapabilities(ctx context.Context, host string) (*Capabilities, error) {
+	log := logger.GetLogger(ctx)
 	// Get VC instance.
 	vcenter, err := m.GetVirtualCenter(ctx, host)
 	if err != nil {
 		log.Errorf("Failed to get vCenter. Err: %v", err)
-		return false, err
+		return nil, err
 	}
-	vcVersion := vcenter.Client.ServiceContent.About.Version
-	isvSphereVersion91orAbove, err := IsvSphereVersion91orAbove(ctx, vcenter.Client.ServiceContent.About)
-	if err != nil {
-		return false, logger.LogNewErrorf(log, "Error while checking the vSphere Version %q , Err= %+v",
-			vcVersion, err)
+	if capabilities, ok := GetCapabilities(host); ok {
+		return capabilities, nil
 	}
-	if isvSphereVersion91orAbove {
-		return true, nil
+	// The capabilities are not cached when the discovery failed on connect.
+	capabilities, err := discoverCapabilities(ctx, host, vcenter.Client.Client)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "Error while checking the vSphere Version %q , Err= %+v",
+			vcenter.Client.ServiceContent.About.Version, err)
 	}
-	log.Infof("CNS Transaction feature is not supported on vCenter version %q", vcVersion)
-	return false, nil
+	return capabilities, nil
 }
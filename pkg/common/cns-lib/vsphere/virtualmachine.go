@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -37,6 +38,9 @@ var (
 	// ErrNoSharedDatastoresFound is raised when no shared datastores are found among the given NodeVMs.
 	ErrNoSharedDatastoresFound = errors.New("no shared datastores found among given NodeVMs")
 	ErrInvalidVC               = errors.New("invalid VC Object")
+	// ErrNoFreeControllerSlot is returned when no more controllers can be
+	// added to a VM whose controllers are full.
+	ErrNoFreeControllerSlot = errors.New("no free controller slot")
 )
 
 // VirtualMachine holds details of a virtual machine instance.
@@ -123,6 +127,223 @@ const (
 	providerPrefix = "vsphere://"
 )
 
+const (
+	// maxTargetsPerSCSIController is the number of disks which can be
+	// attached to a SCSI controller, excluding the unit reserved for the
+	// controller itself.
+	maxTargetsPerSCSIController = 15
+	// maxTargetsPerPVSCSIController is the number of disks which can be
+	// attached to a ParaVirtual SCSI controller on hardware version 14 or
+	// later.
+	maxTargetsPerPVSCSIController = 64
+	// maxNamespacesPerNVMeController is the number of disks which can be
+	// attached to a NVMe controller.
+	maxNamespacesPerNVMeController = 15
+	// maxNamespacesPerNVMeControllerInVMX21 is the number of disks which can
+	// be attached to a NVMe controller on hardware version 21 or later.
+	maxNamespacesPerNVMeControllerInVMX21 = 64
+
+	// ControllerTypePVSCSI is the ParaVirtual SCSI controller type.
+	ControllerTypePVSCSI = "pvscsi"
+	// ControllerTypeNVMe is the NVMe controller type.
+	ControllerTypeNVMe = "nvme"
+)
+
+// controllerProvisioningLocks holds a mutex per VM UUID to serialize
+// controller provisioning on the VM.
+var controllerProvisioningLocks sync.Map
+
+// GetBlockVolumeAttachLimit returns the number of block volumes which can be
+// attached to the VM, based on its SCSI and NVMe controllers and its hardware
+// version.
+func (vm *VirtualMachine) GetBlockVolumeAttachLimit(ctx context.Context) (int64, error) {
+	log := logger.GetLogger(ctx)
+	var vmMo mo.VirtualMachine
+	err := vm.Properties(ctx, vm.Reference(), []string{"config.version", "config.hardware.device"}, &vmMo)
+	if err != nil {
+		log.Errorf("failed to get config of VM %v. err: %v", vm, err)
+		return 0, err
+	}
+	if vmMo.Config == nil {
+		return 0, logger.LogNewErrorf(log, "config of VM %v is not available", vm)
+	}
+	hardwareVersion, err := types.ParseHardwareVersion(vmMo.Config.Version)
+	if err != nil {
+		return 0, logger.LogNewErrorf(log, "failed to parse hardware version of VM %v. err: %v", vm, err)
+	}
+	limit := GetBlockVolumeAttachLimit(object.VirtualDeviceList(vmMo.Config.Hardware.Device), hardwareVersion)
+	log.Debugf("Block volume attach limit of VM %v with hardware version %q is %d", vm,
+		vmMo.Config.Version, limit)
+	return limit, nil
+}
+
+// GetBlockVolumeAttachLimit returns the number of block volumes which can be
+// attached to the SCSI and NVMe controllers in the given devices. Slots used
+// by disks which are not FCDs, such as the OS disk, are excluded.
+func GetBlockVolumeAttachLimit(devices object.VirtualDeviceList, hardwareVersion types.HardwareVersion) int64 {
+	var limit int64
+	controllerKeys := make(map[int32]bool)
+	for _, device := range devices {
+		if capacity, ok := getControllerCapacity(device, hardwareVersion); ok {
+			controllerKeys[device.GetVirtualDevice().Key] = true
+			limit += capacity
+		}
+	}
+	for _, device := range devices {
+		disk, ok := device.(*types.VirtualDisk)
+		if !ok || !controllerKeys[disk.ControllerKey] {
+			continue
+		}
+		if disk.VDiskId == nil || disk.VDiskId.Id == "" {
+			limit--
+		}
+	}
+	return max(limit, 0)
+}
+
+// HasFreeControllerSlot returns true if any of the controllers of the given
+// type in the given devices can accept another disk. For ControllerTypePVSCSI
+// all the SCSI controllers are considered, since disks attached without an
+// NVMe controller requirement can be placed on any of them.
+func HasFreeControllerSlot(devices object.VirtualDeviceList, hardwareVersion types.HardwareVersion,
+	controllerType string) bool {
+	diskCount := make(map[int32]int64)
+	for _, device := range devices {
+		if disk, ok := device.(*types.VirtualDisk); ok {
+			diskCount[disk.ControllerKey]++
+		}
+	}
+	for _, device := range devices {
+		if !isControllerOfType(device, controllerType) {
+			continue
+		}
+		capacity, ok := getControllerCapacity(device, hardwareVersion)
+		if ok && diskCount[device.GetVirtualDevice().Key] < capacity {
+			return true
+		}
+	}
+	return false
+}
+
+// isControllerOfType returns true if the given device is a controller which
+// disks are attached to for the given controller type.
+func isControllerOfType(device types.BaseVirtualDevice, controllerType string) bool {
+	switch controllerType {
+	case ControllerTypePVSCSI:
+		_, ok := device.(types.BaseVirtualSCSIController)
+		return ok
+	case ControllerTypeNVMe:
+		_, ok := device.(*types.VirtualNVMEController)
+		return ok
+	}
+	return false
+}
+
+// getControllerCapacity returns the number of disks which can be attached to
+// the given device if it is a SCSI or NVMe controller.
+func getControllerCapacity(device types.BaseVirtualDevice, hardwareVersion types.HardwareVersion) (int64, bool) {
+	switch device.(type) {
+	case *types.ParaVirtualSCSIController:
+		if hardwareVersion >= types.VMX14 {
+			return maxTargetsPerPVSCSIController, true
+		}
+		return maxTargetsPerSCSIController, true
+	case types.BaseVirtualSCSIController:
+		return maxTargetsPerSCSIController, true
+	case *types.VirtualNVMEController:
+		if hardwareVersion >= types.VMX21 {
+			return maxNamespacesPerNVMeControllerInVMX21, true
+		}
+		return maxNamespacesPerNVMeController, true
+	}
+	return 0, false
+}
+
+// EnsureFreeControllerSlot makes sure the VM has a controller of the given
+// type which can accept another disk. If all the controllers of that type are
+// full, a new controller of the given type is hot-added to the VM. controllerType is
+// either ControllerTypePVSCSI or ControllerTypeNVMe. sharingMode is only
+// applied to ParaVirtual SCSI controllers.
+// ErrNoFreeControllerSlot is returned if the VM already has the maximum
+// number of controllers of the given type.
+func (vm *VirtualMachine) EnsureFreeControllerSlot(ctx context.Context, controllerType string,
+	sharingMode types.VirtualSCSISharing) error {
+	log := logger.GetLogger(ctx)
+	// Serialize controller provisioning per VM so that concurrent attach
+	// requests do not add more than one controller when a single one is
+	// needed.
+	lock, _ := controllerProvisioningLocks.LoadOrStore(vm.UUID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	var vmMo mo.VirtualMachine
+	err := vm.Properties(ctx, vm.Reference(), []string{"config.version", "config.hardware.device"}, &vmMo)
+	if err != nil {
+		log.Errorf("failed to get config of VM %v. err: %v", vm, err)
+		return err
+	}
+	if vmMo.Config == nil {
+		return logger.LogNewErrorf(log, "config of VM %v is not available", vm)
+	}
+	hardwareVersion, err := types.ParseHardwareVersion(vmMo.Config.Version)
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to parse hardware version of VM %v. err: %v", vm, err)
+	}
+	devices := object.VirtualDeviceList(vmMo.Config.Hardware.Device)
+	if HasFreeControllerSlot(devices, hardwareVersion, controllerType) {
+		return nil
+	}
+	controller, err := newBlockVolumeController(devices, hardwareVersion, controllerType, sharingMode)
+	if err != nil {
+		log.Errorf("failed to create %s controller for VM %v. err: %v", controllerType, vm, err)
+		return err
+	}
+	log.Infof("All the %s controllers of VM %v are full. Adding %s controller %q", controllerType, vm, controllerType,
+		devices.Name(controller))
+	err = vm.AddDevice(ctx, controller)
+	if err != nil {
+		log.Errorf("failed to add %s controller to VM %v. err: %v", controllerType, vm, err)
+		return err
+	}
+	log.Infof("Successfully added %s controller to VM %v", controllerType, vm)
+	return nil
+}
+
+// newBlockVolumeController returns a new controller of the given type to be
+// added to a VM with the given devices and hardware version.
+func newBlockVolumeController(devices object.VirtualDeviceList, hardwareVersion types.HardwareVersion,
+	controllerType string, sharingMode types.VirtualSCSISharing) (types.BaseVirtualDevice, error) {
+	switch controllerType {
+	case ControllerTypePVSCSI:
+		device, err := devices.CreateSCSIController(ControllerTypePVSCSI)
+		if err != nil {
+			return nil, err
+		}
+		controller := device.(types.BaseVirtualSCSIController).GetVirtualSCSIController()
+		if controller.BusNumber < 0 {
+			return nil, fmt.Errorf("%w: VM has the maximum number of SCSI controllers", ErrNoFreeControllerSlot)
+		}
+		if sharingMode != "" {
+			controller.SharedBus = sharingMode
+		}
+		return device, nil
+	case ControllerTypeNVMe:
+		if hardwareVersion < types.VMX13 {
+			return nil, fmt.Errorf("NVMe controllers require hardware version %s or later, VM has %s",
+				types.VMX13, hardwareVersion)
+		}
+		device, err := devices.CreateNVMEController()
+		if err != nil {
+			return nil, err
+		}
+		if device.(*types.VirtualNVMEController).BusNumber < 0 {
+			return nil, fmt.Errorf("%w: VM has the maximum number of NVMe controllers", ErrNoFreeControllerSlot)
+		}
+		return device, nil
+	}
+	return nil, fmt.Errorf("unsupported controller type %q", controllerType)
+}
+
 // GetUUIDFromVMReference fetches the UUID of the VM by looking at the config.uuid property from the VM ref.
 func GetUUIDFromVMReference(ctx context.Context, vc *VirtualCenter, vmRef types.ManagedObjectReference) (
 	string, error) {
@@ -19,6 +19,7 @@ package vsphere
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	"github.com/vmware/govmomi/vapi/rest"
 	_ "github.com/vmware/govmomi/vapi/simulator"
 	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 var (
@@ -180,3 +182,197 @@ func TestIsInZoneRegion(t *testing.T) {
 		t.Fatalf("VM should belong to specified zone and region")
 	}
 }
+
+// TestGetBlockVolumeAttachLimit verifies the attach limit computed from the
+// controllers and disks of a VM for different hardware versions.
+func TestGetBlockVolumeAttachLimit(t *testing.T) {
+	pvscsi := &types.ParaVirtualSCSIController{}
+	pvscsi.Key = 1000
+	lsiLogic := &types.VirtualLsiLogicController{}
+	lsiLogic.Key = 1001
+	nvme := &types.VirtualNVMEController{}
+	nvme.Key = 31000
+	sata := &types.VirtualAHCIController{}
+	sata.Key = 15000
+	osDisk := &types.VirtualDisk{}
+	osDisk.ControllerKey = pvscsi.Key
+	sataDisk := &types.VirtualDisk{}
+	sataDisk.ControllerKey = sata.Key
+	fcd := &types.VirtualDisk{VDiskId: &types.ID{Id: "fcd-1"}}
+	fcd.ControllerKey = pvscsi.Key
+
+	tests := []struct {
+		name            string
+		devices         object.VirtualDeviceList
+		hardwareVersion types.HardwareVersion
+		expected        int64
+	}{
+		{
+			name:            "PVSCSI on vmx-13",
+			devices:         object.VirtualDeviceList{pvscsi, osDisk},
+			hardwareVersion: types.VMX13,
+			expected:        14,
+		},
+		{
+			name:            "PVSCSI on vmx-14",
+			devices:         object.VirtualDeviceList{pvscsi, osDisk, fcd},
+			hardwareVersion: types.VMX14,
+			expected:        63,
+		},
+		{
+			name:            "LSI Logic and NVMe on vmx-20",
+			devices:         object.VirtualDeviceList{lsiLogic, nvme},
+			hardwareVersion: types.VMX20,
+			expected:        30,
+		},
+		{
+			name:            "PVSCSI and NVMe on vmx-21 with OS disk on SATA",
+			devices:         object.VirtualDeviceList{pvscsi, nvme, sata, sataDisk},
+			hardwareVersion: types.VMX21,
+			expected:        128,
+		},
+		{
+			name:            "no controllers",
+			devices:         object.VirtualDeviceList{sata, sataDisk},
+			hardwareVersion: types.VMX21,
+			expected:        0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit := GetBlockVolumeAttachLimit(test.devices, test.hardwareVersion)
+			if limit != test.expected {
+				t.Fatalf("expected attach limit %d, got %d", test.expected, limit)
+			}
+		})
+	}
+}
+
+// TestHasFreeControllerSlot verifies free controller slots are detected from
+// the disks attached to each controller of the given type.
+func TestHasFreeControllerSlot(t *testing.T) {
+	pvscsi := &types.ParaVirtualSCSIController{}
+	pvscsi.Key = 1000
+	nvme := &types.VirtualNVMEController{}
+	nvme.Key = 31000
+	var pvscsiDisks, nvmeDisks object.VirtualDeviceList
+	for i := 0; i < maxTargetsPerSCSIController; i++ {
+		disk := &types.VirtualDisk{}
+		disk.ControllerKey = pvscsi.Key
+		pvscsiDisks = append(pvscsiDisks, disk)
+		disk = &types.VirtualDisk{}
+		disk.ControllerKey = nvme.Key
+		nvmeDisks = append(nvmeDisks, disk)
+	}
+
+	tests := []struct {
+		name            string
+		devices         object.VirtualDeviceList
+		hardwareVersion types.HardwareVersion
+		controllerType  string
+		expected        bool
+	}{
+		{
+			name:            "full PVSCSI on vmx-13",
+			devices:         append(object.VirtualDeviceList{pvscsi}, pvscsiDisks...),
+			hardwareVersion: types.VMX13,
+			controllerType:  ControllerTypePVSCSI,
+			expected:        false,
+		},
+		{
+			name:            "PVSCSI with 15 disks on vmx-14",
+			devices:         append(object.VirtualDeviceList{pvscsi}, pvscsiDisks...),
+			hardwareVersion: types.VMX14,
+			controllerType:  ControllerTypePVSCSI,
+			expected:        true,
+		},
+		{
+			name:            "full PVSCSI and empty NVMe on vmx-13",
+			devices:         append(object.VirtualDeviceList{pvscsi, nvme}, pvscsiDisks...),
+			hardwareVersion: types.VMX13,
+			controllerType:  ControllerTypeNVMe,
+			expected:        true,
+		},
+		{
+			name:            "full PVSCSI and empty NVMe on vmx-13 for PVSCSI",
+			devices:         append(object.VirtualDeviceList{pvscsi, nvme}, pvscsiDisks...),
+			hardwareVersion: types.VMX13,
+			controllerType:  ControllerTypePVSCSI,
+			expected:        false,
+		},
+		{
+			name:            "empty PVSCSI without NVMe for NVMe",
+			devices:         object.VirtualDeviceList{pvscsi},
+			hardwareVersion: types.VMX21,
+			controllerType:  ControllerTypeNVMe,
+			expected:        false,
+		},
+		{
+			name: "full PVSCSI and NVMe on vmx-13",
+			devices: append(append(object.VirtualDeviceList{pvscsi, nvme}, pvscsiDisks...),
+				nvmeDisks...),
+			hardwareVersion: types.VMX13,
+			controllerType:  ControllerTypeNVMe,
+			expected:        false,
+		},
+		{
+			name:            "no controllers",
+			devices:         object.VirtualDeviceList{},
+			hardwareVersion: types.VMX21,
+			controllerType:  ControllerTypePVSCSI,
+			expected:        false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			free := HasFreeControllerSlot(test.devices, test.hardwareVersion, test.controllerType)
+			if free != test.expected {
+				t.Fatalf("expected free controller slot %t, got %t", test.expected, free)
+			}
+		})
+	}
+}
+
+// TestNewBlockVolumeController verifies the controllers created for the
+// supported controller types and the limits on the number of controllers.
+func TestNewBlockVolumeController(t *testing.T) {
+	var devices object.VirtualDeviceList
+	for i := int32(0); i < 3; i++ {
+		pvscsi := &types.ParaVirtualSCSIController{}
+		pvscsi.Key = 1000 + i
+		pvscsi.BusNumber = i
+		devices = append(devices, pvscsi)
+	}
+
+	device, err := newBlockVolumeController(devices, types.VMX14, ControllerTypePVSCSI,
+		types.VirtualSCSISharingPhysicalSharing)
+	if err != nil {
+		t.Fatalf("failed to create PVSCSI controller. err: %v", err)
+	}
+	pvscsi, ok := device.(*types.ParaVirtualSCSIController)
+	if !ok {
+		t.Fatalf("expected ParaVirtualSCSIController, got %T", device)
+	}
+	if pvscsi.BusNumber != 3 || pvscsi.SharedBus != types.VirtualSCSISharingPhysicalSharing {
+		t.Fatalf("unexpected bus number %d or sharing mode %q", pvscsi.BusNumber, pvscsi.SharedBus)
+	}
+
+	_, err = newBlockVolumeController(append(devices, pvscsi), types.VMX14, ControllerTypePVSCSI,
+		types.VirtualSCSISharingNoSharing)
+	if !errors.Is(err, ErrNoFreeControllerSlot) {
+		t.Fatalf("expected ErrNoFreeControllerSlot, got %v", err)
+	}
+
+	device, err = newBlockVolumeController(devices, types.VMX14, ControllerTypeNVMe, "")
+	if err != nil {
+		t.Fatalf("failed to create NVMe controller. err: %v", err)
+	}
+	if _, ok := device.(*types.VirtualNVMEController); !ok {
+		t.Fatalf("expected VirtualNVMEController, got %T", device)
+	}
+
+	_, err = newBlockVolumeController(devices, types.VMX11, ControllerTypeNVMe, "")
+	if err == nil {
+		t.Fatalf("expected error creating NVMe controller on vmx-11")
+	}
+}
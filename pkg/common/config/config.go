@@ -28,6 +28,7 @@ import (
 	"strings"
 
 	cnstypes "github.com/vmware/govmomi/cns/types"
+	vim25types "github.com/vmware/govmomi/vim25/types"
 	vsanfstypes "github.com/vmware/govmomi/vsan/vsanfs/types"
 	"gopkg.in/gcfg.v1"
 	corev1 "k8s.io/api/core/v1"
@@ -86,8 +87,22 @@ const (
 	// interval after which stale CnsVSphereVolumeMigration CRs will be cleaned up.
 	// Current default value is set to 15 minutes.
 	DefaultCnsVolumeOperationRequestCleanupIntervalInMin = 15
+	// DefaultTopologyDriftCheckIntervalInMin is the default time interval
+	// after which the topology labels of the NodeVMs are re-evaluated for drift.
+	// Current default value is set to 30 minutes.
+	DefaultTopologyDriftCheckIntervalInMin = 30
+	// DefaultDatastoreEvacuationIntervalInMin is the default time interval
+	// after which the datastores under maintenance are checked for volumes
+	// to relocate.
+	DefaultDatastoreEvacuationIntervalInMin = 10
+	// DefaultMaxDatastoreEvacuationsPerInterval is the default maximum number
+	// of volumes relocated off the datastores under maintenance in an interval.
+	DefaultMaxDatastoreEvacuationsPerInterval = 2
 	// DefaultGlobalMaxSnapshotsPerBlockVolume is the default maximum number of block volume snapshots per volume.
 	DefaultGlobalMaxSnapshotsPerBlockVolume = 3
+	// DefaultControllerProvisioningType is the default type of the
+	// controllers hot-added to node VMs.
+	DefaultControllerProvisioningType = "pvscsi"
 	// MaxNumberOfTopologyCategories is the max number of topology domains/categories allowed.
 	MaxNumberOfTopologyCategories = 5
 	// TopologyLabelsDomain is the domain name used to identify user-defined
@@ -471,9 +486,21 @@ func validateConfig(ctx context.Context, cfg *Config) error {
 		cfg.Global.CnsVolumeOperationRequestCleanupIntervalInMin =
 			DefaultCnsVolumeOperationRequestCleanupIntervalInMin
 	}
+	if cfg.Global.TopologyDriftCheckIntervalInMin == 0 {
+		cfg.Global.TopologyDriftCheckIntervalInMin = DefaultTopologyDriftCheckIntervalInMin
+	}
+	if cfg.Global.DatastoreEvacuationIntervalInMin == 0 {
+		cfg.Global.DatastoreEvacuationIntervalInMin = DefaultDatastoreEvacuationIntervalInMin
+	}
+	if cfg.Global.MaxDatastoreEvacuationsPerInterval == 0 {
+		cfg.Global.MaxDatastoreEvacuationsPerInterval = DefaultMaxDatastoreEvacuationsPerInterval
+	}
 	if cfg.Snapshot.GlobalMaxSnapshotsPerBlockVolume == 0 {
 		cfg.Snapshot.GlobalMaxSnapshotsPerBlockVolume = DefaultGlobalMaxSnapshotsPerBlockVolume
 	}
+	if err := validateControllerProvisioningConfig(ctx, &cfg.ControllerProvisioning); err != nil {
+		return err
+	}
 
 	// Labels section validation - the customer can either provide topology
 	// domain info using zone,region parameters or by using the topologyCategories
@@ -515,6 +542,39 @@ func validateConfig(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// validateControllerProvisioningConfig validates the ControllerProvisioning
+// section and sets the defaults for the unspecified parameters.
+func validateControllerProvisioningConfig(ctx context.Context, cfg *ControllerProvisioningConfig) error {
+	log := logger.GetLogger(ctx)
+	if cfg.ControllerType == "" {
+		cfg.ControllerType = DefaultControllerProvisioningType
+	}
+	if cfg.SharingMode == "" {
+		cfg.SharingMode = string(vim25types.VirtualSCSISharingNoSharing)
+	}
+	switch cfg.ControllerType {
+	case "pvscsi":
+		switch vim25types.VirtualSCSISharing(cfg.SharingMode) {
+		case vim25types.VirtualSCSISharingNoSharing:
+		case vim25types.VirtualSCSISharingVirtualSharing, vim25types.VirtualSCSISharingPhysicalSharing:
+			log.Warnf("sharing-mode %q in ControllerProvisioning section applies to all the disks placed on "+
+				"the hot-added controllers, including the disks of volumes which are not shared", cfg.SharingMode)
+		default:
+			return logger.LogNewErrorf(log, "invalid sharing-mode %q in ControllerProvisioning section",
+				cfg.SharingMode)
+		}
+	case "nvme":
+		if cfg.SharingMode != string(vim25types.VirtualSCSISharingNoSharing) {
+			return logger.LogNewErrorf(log, "sharing-mode %q is not supported for nvme controllers",
+				cfg.SharingMode)
+		}
+	default:
+		return logger.LogNewErrorf(log, "invalid controller-type %q in ControllerProvisioning section",
+			cfg.ControllerType)
+	}
+	return nil
+}
+
 // ReadConfig parses vSphere cloud config file and stores it into VSphereConfig.
 // Environment variables are also checked.
 func ReadConfig(ctx context.Context, config io.Reader) (*Config, error) {
@@ -278,3 +278,35 @@ func isConfigEqual(actual *Config, expected *Config) bool {
 	}
 	return true
 }
+
+func TestControllerProvisioningConfigDefaults(t *testing.T) {
+	cfg := &Config{
+		VirtualCenter: idealVCConfig,
+	}
+	err := validateConfig(ctx, cfg)
+	if err != nil {
+		t.Errorf("Unexpected error during config validation - %+v", *cfg)
+	}
+	if cfg.ControllerProvisioning.ControllerType != DefaultControllerProvisioningType ||
+		cfg.ControllerProvisioning.SharingMode != "noSharing" {
+		t.Errorf("Default controller provisioning config incorrect: %+v", cfg.ControllerProvisioning)
+	}
+}
+
+func TestControllerProvisioningConfigInvalid(t *testing.T) {
+	invalidConfigs := []ControllerProvisioningConfig{
+		{Enabled: true, ControllerType: "lsilogic"},
+		{Enabled: true, ControllerType: "pvscsi", SharingMode: "shared"},
+		{Enabled: true, ControllerType: "nvme", SharingMode: "physicalSharing"},
+	}
+	for _, controllerProvisioning := range invalidConfigs {
+		cfg := &Config{
+			VirtualCenter:          idealVCConfig,
+			ControllerProvisioning: controllerProvisioning,
+		}
+		err := validateConfig(ctx, cfg)
+		if err == nil {
+			t.Errorf("Expected error for controller provisioning config %+v", controllerProvisioning)
+		}
+	}
+}
@@ -68,6 +68,23 @@ type Config struct {
 		// CSIFetchPreferredDatastoresIntervalInMin specifies the interval
 		// after which the preferred datastores cache is refreshed in the driver.
 		CSIFetchPreferredDatastoresIntervalInMin int `gcfg:"csi-fetch-preferred-datastores-intervalinmin"`
+		// TopologyDriftCheckIntervalInMin specifies the interval after which
+		// the topology labels of the NodeVMs are re-evaluated for drift.
+		TopologyDriftCheckIntervalInMin int `gcfg:"topology-drift-check-intervalinmin"`
+		// CordonNodesOnTopologyDrift cordons a node when its topology labels
+		// drift while it has volumes attached whose node affinity depends on
+		// the drifted labels. The node is uncordoned when the drift is resolved.
+		CordonNodesOnTopologyDrift bool `gcfg:"cordon-nodes-on-topology-drift"`
+		// EvacuateDatastoresUnderMaintenance relocates the volumes placed on
+		// datastores which are in or entering maintenance mode, or are tagged
+		// for drain, to other datastores.
+		EvacuateDatastoresUnderMaintenance bool `gcfg:"evacuate-datastores-under-maintenance"`
+		// DatastoreEvacuationIntervalInMin specifies the interval after which
+		// the datastores under maintenance are checked for volumes to relocate.
+		DatastoreEvacuationIntervalInMin int `gcfg:"datastore-evacuation-intervalinmin"`
+		// MaxDatastoreEvacuationsPerInterval specifies the maximum number of
+		// volumes relocated off the datastores under maintenance in an interval.
+		MaxDatastoreEvacuationsPerInterval int `gcfg:"max-datastore-evacuations-per-interval"`
 
 		// QueryLimit specifies the number of volumes that can be fetched by CNS QueryAll API at a time
 		QueryLimit int `gcfg:"query-limit"`
@@ -86,6 +103,9 @@ type Config struct {
 	// Snapshot configurations.
 	Snapshot SnapshotConfig
 
+	// ControllerProvisioning configurations.
+	ControllerProvisioning ControllerProvisioningConfig
+
 	// Guest Cluster configurations, only used by GC
 	GC GCConfig
 
@@ -193,5 +213,24 @@ type SnapshotConfig struct {
 	GranularMaxSnapshotsPerBlockVolumeInVVOL int `gcfg:"granular-max-snapshots-per-block-volume-vvol"`
 }
 
+// ControllerProvisioningConfig contains the configuration for hot-adding
+// controllers to node VMs during volume attach.
+type ControllerProvisioningConfig struct {
+	// Enabled turns on hot-adding a controller to the node VM when all its
+	// SCSI and NVMe controllers are full.
+	Enabled bool `gcfg:"enabled"`
+	// ControllerType is the type of the controller added to the node VM.
+	// Supported values are "pvscsi" and "nvme". Defaults to "pvscsi".
+	ControllerType string `gcfg:"controller-type"`
+	// SharingMode is the bus sharing mode of the ParaVirtual SCSI controllers
+	// added to the node VM. Supported values are "noSharing",
+	// "virtualSharing" and "physicalSharing". Defaults to "noSharing".
+	// The bus sharing mode of a controller applies to all the disks on it,
+	// and CNS may place any disk attached to the node VM on a hot-added
+	// controller, including disks which are not shared. Only set a sharing
+	// mode when all the volumes attached to the node VMs may be shared.
+	SharingMode string `gcfg:"sharing-mode"`
+}
+
 // EnvClusterFlavor is the k8s cluster type on which CSI Driver is being deployed
 const EnvClusterFlavor = "CLUSTER_FLAVOR"
@@ -96,4 +96,18 @@ const (
 	VimFaultCNSFault = VimFaultPrefix + "CnsFault"
 	// VimFaultNotSupported is the fault returned from CNS when the operation is not supported on the object.
 	VimFaultNotSupported = VimFaultPrefix + "NotSupported"
+	// VimFaultResourceInUse is the fault returned from CNS when the object is in use, e.g. a volume
+	// which is still attached.
+	VimFaultResourceInUse = VimFaultPrefix + "ResourceInUse"
+	// VimFaultNoDiskSpace is the fault returned when the datastore does not have enough free space.
+	VimFaultNoDiskSpace = VimFaultPrefix + "NoDiskSpace"
+	// VimFaultInsufficientStorageSpace is the fault returned when there is not enough storage space
+	// to satisfy the request.
+	VimFaultInsufficientStorageSpace = VimFaultPrefix + "InsufficientStorageSpace"
+	// VimFaultHostCommunication is the fault returned when the host cannot be reached.
+	VimFaultHostCommunication = VimFaultPrefix + "HostCommunication"
+	// VimFaultNotAuthenticated is the fault returned when the vCenter session is not authenticated.
+	VimFaultNotAuthenticated = VimFaultPrefix + "NotAuthenticated"
+	// CSIInvalidConfigFaultPrefix is the prefix of the faults caused by an invalid configuration.
+	CSIInvalidConfigFaultPrefix = "csi.fault.invalidconfig."
 )
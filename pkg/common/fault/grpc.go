@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fault
+
+import (
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+)
+
+const (
+	// FaultErrorReason is the reason of the ErrorInfo attached to the status
+	// of the errors caused by a fault.
+	FaultErrorReason = "CNS_FAULT"
+	// FaultTypeMetadataKey is the key of the fault type in the metadata of the
+	// ErrorInfo attached to the status of the errors caused by a fault.
+	FaultTypeMetadataKey = "faultType"
+)
+
+// faultCodes maps fault types to the gRPC code returned for them, so that
+// the sidecars back off on the errors which retrying cannot fix.
+var faultCodes = map[string]codes.Code{
+	// Out of space.
+	VimFaultNoDiskSpace:              codes.ResourceExhausted,
+	VimFaultInsufficientStorageSpace: codes.ResourceExhausted,
+	// Host or vCenter unreachable.
+	VimFaultInvalidHostState:  codes.Unavailable,
+	VimFaultHostNotConnected:  codes.Unavailable,
+	VimFaultHostCommunication: codes.Unavailable,
+	VimFaultNotAuthenticated:  codes.Unavailable,
+	CSIVCenterNotFoundFault:   codes.Unavailable,
+	// Concurrent operations on the same object.
+	VimFaultTaskInProgress:         codes.Aborted,
+	CSIResourceUpdateConflictFault: codes.Aborted,
+	// Misconfiguration.
+	VimFaultInvalidDatastore: codes.FailedPrecondition,
+	VimFaultInvalidState:     codes.FailedPrecondition,
+	VimFaultNotSupported:     codes.FailedPrecondition,
+	VimFaultResourceInUse:    codes.FailedPrecondition,
+	// Invalid requests and missing objects.
+	VimFaultInvalidArgument: codes.InvalidArgument,
+	CSIInvalidArgumentFault: codes.InvalidArgument,
+	VimFaultNotFound:        codes.NotFound,
+	CSINotFoundFault:        codes.NotFound,
+	CSIUnimplementedFault:   codes.Unimplemented,
+}
+
+// GetGRPCCode returns the gRPC code for the given fault type, or
+// codes.Internal if the fault type has no specific code. The non-storage
+// prefix added to the fault types is ignored.
+func GetGRPCCode(faultType string) codes.Code {
+	faultType = strings.TrimPrefix(faultType, CSINonStorageFaultPrefix)
+	if code, ok := faultCodes[faultType]; ok {
+		return code
+	}
+	if strings.HasPrefix(faultType, CSIInvalidConfigFaultPrefix) {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// ToGRPCError returns err with the gRPC code of the given fault type and the
+// fault type attached as status details. The code of err is only replaced
+// if it is codes.Internal or codes.Unknown, as more specific codes are set
+// on purpose by the controllers. nil is returned if err is nil.
+func ToGRPCError(faultType string, err error) error {
+	return toGRPCError(faultType, err, false)
+}
+
+// ToGRPCErrorForRemoval is ToGRPCError for DeleteVolume and
+// ControllerUnpublishVolume. The code of err is not replaced with
+// codes.NotFound, as these RPCs must succeed when the volume or node is
+// already gone, so a NotFound fault they fail with is about another object.
+func ToGRPCErrorForRemoval(faultType string, err error) error {
+	return toGRPCError(faultType, err, true)
+}
+
+// toGRPCError implements ToGRPCError and ToGRPCErrorForRemoval.
+func toGRPCError(faultType string, err error, skipNotFound bool) error {
+	if err == nil || faultType == "" {
+		return err
+	}
+	st, _ := status.FromError(err)
+	code := st.Code()
+	if code == codes.Internal || code == codes.Unknown {
+		if faultCode := GetGRPCCode(faultType); !skipNotFound || faultCode != codes.NotFound {
+			code = faultCode
+		}
+	}
+	faultStatus, detailsErr := status.New(code, st.Message()).WithDetails(&errdetails.ErrorInfo{
+		Reason:   FaultErrorReason,
+		Domain:   csitypes.Name,
+		Metadata: map[string]string{FaultTypeMetadataKey: faultType},
+	})
+	if detailsErr != nil {
+		return status.Error(code, st.Message())
+	}
+	return faultStatus.Err()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetGRPCCode(t *testing.T) {
+	tests := map[string]codes.Code{
+		VimFaultNoDiskSpace:                                 codes.ResourceExhausted,
+		VimFaultTaskInProgress:                              codes.Aborted,
+		CSINonStorageFaultPrefix + VimFaultHostNotConnected: codes.Unavailable,
+		CSIVSanFileServiceDisabledFault:                     codes.FailedPrecondition,
+		CSIInvalidStoragePolicyConfigurationFault:           codes.FailedPrecondition,
+		CSIInternalFault:                                    codes.Internal,
+		VimFaultCNSFault:                                    codes.Internal,
+	}
+	for faultType, expected := range tests {
+		assert.Equal(t, expected, GetGRPCCode(faultType), faultType)
+	}
+}
+
+func TestToGRPCError(t *testing.T) {
+	assert.NoError(t, ToGRPCError(VimFaultTaskInProgress, nil))
+	plainErr := errors.New("failed")
+	assert.Equal(t, plainErr, ToGRPCError("", plainErr))
+
+	err := ToGRPCError(VimFaultTaskInProgress, status.Error(codes.Internal, "failed to delete volume"))
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	assert.Equal(t, "failed to delete volume", st.Message())
+	if assert.Len(t, st.Details(), 1) {
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, VimFaultTaskInProgress, info.Metadata[FaultTypeMetadataKey])
+		}
+	}
+
+	// Specific codes set by the controllers are kept.
+	err = ToGRPCError(VimFaultTaskInProgress, status.Error(codes.NotFound, "volume not found"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestToGRPCErrorForRemoval(t *testing.T) {
+	err := ToGRPCErrorForRemoval(VimFaultNotFound, status.Error(codes.Internal, "failed to detach volume"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, codes.NotFound, status.Code(ToGRPCError(VimFaultNotFound,
+		status.Error(codes.Internal, "failed to attach volume"))))
+
+	err = ToGRPCErrorForRemoval(VimFaultTaskInProgress, status.Error(codes.Internal, "failed to delete volume"))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
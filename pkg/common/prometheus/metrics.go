@@ -95,6 +95,8 @@ const (
 	PrometheusPassStatus = "pass"
 	// PrometheusFailStatus represents an unsuccessful API run.
 	PrometheusFailStatus = "fail"
+	// PrometheusRetryStatus represents an unsuccessful run which is retried.
+	PrometheusRetryStatus = "retry"
 )
 
 var (
@@ -160,6 +162,121 @@ var (
 		// Possible status - "pass", "fail"
 		[]string{"status"})
 
+	// DatastoreEvacuationPendingVolumesGaugeVec is a gauge metric to observe the
+	// number of volumes left to relocate off each datastore under maintenance.
+	DatastoreEvacuationPendingVolumesGaugeVec = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vsphere_datastore_evacuation_pending_volumes",
+		Help: "Gauge for number of volumes left to relocate off datastores under maintenance",
+	}, []string{"vcenter", "datastore"})
+
+	// DatastoreEvacuationRelocationsCounterVec is a counter metric to observe the
+	// volumes relocated off datastores under maintenance.
+	DatastoreEvacuationRelocationsCounterVec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vsphere_datastore_evacuation_relocations_total",
+		Help: "Counter for volumes relocated off datastores under maintenance",
+	},
+		// Possible status - "pass", "fail"
+		[]string{"status"})
+
+	// CnsVolumeOperationRequestAPICallsCounterVec is a counter metric to observe
+	// the API server round trips made to read and persist the
+	// CnsVolumeOperationRequest instances.
+	CnsVolumeOperationRequestAPICallsCounterVec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vsphere_cnsvolumeoperationrequest_api_calls_total",
+		Help: "Counter for API server calls made for CnsVolumeOperationRequest instances",
+	},
+		// Possible verb - "get", "list", "create", "update", "delete"
+		// Possible status - "pass", "fail"
+		[]string{"verb", "status"})
+
+	// CnsVolumeOperationRequestCoalescedWritesCounter is a counter metric to
+	// observe the operation details persisted with the update of another
+	// operation on the same CnsVolumeOperationRequest instance, or skipped as
+	// they did not change the instance.
+	CnsVolumeOperationRequestCoalescedWritesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "vsphere_cnsvolumeoperationrequest_coalesced_writes_total",
+		Help: "Counter for CnsVolumeOperationRequest writes saved by coalescing updates",
+	})
+
+	// VCenterInfo is a gauge metric to observe the version of the vCenters
+	// discovered by the driver.
+	VCenterInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vsphere_vcenter_info",
+		Help: "vCenter Info",
+	}, []string{"vcenter", "version", "build", "api_version"})
+
+	// VCenterCapabilityGaugeVec is a gauge metric to observe the capabilities
+	// discovered on each vCenter. The value is 1 when the capability is
+	// supported and 0 otherwise.
+	VCenterCapabilityGaugeVec = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vsphere_vcenter_capability",
+		Help: "Gauge for capabilities discovered on vCenters",
+	},
+		// Possible capability - "online-extend-volume", "cns-snapshot",
+		// "cns-transaction", "vsan-file-services", "nvme"
+		[]string{"vcenter", "capability"})
+
+	// MetadataSyncQueueDepthGauge is a gauge metric to observe the number of
+	// PV, PVC and pod events waiting in the metadata syncer work queue.
+	MetadataSyncQueueDepthGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vsphere_metadata_sync_queue_depth",
+		Help: "Gauge for number of events waiting in the metadata syncer work queue",
+	})
+
+	// MetadataSyncQueueOldestEventAgeGauge is a gauge metric to observe the
+	// time in seconds the oldest event in the metadata syncer work queue has
+	// been waiting to be synced to CNS.
+	MetadataSyncQueueOldestEventAgeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vsphere_metadata_sync_queue_oldest_event_age_seconds",
+		Help: "Gauge for age of the oldest event in the metadata syncer work queue",
+	})
+
+	// MetadataSyncEventsHistVec is a histogram vector metric to observe the
+	// time taken from the reception of a PV, PVC or pod event to the sync of
+	// the volume metadata to CNS.
+	MetadataSyncEventsHistVec = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vsphere_metadata_sync_events_histogram",
+		Help:    "Histogram vector for events synced by the metadata syncer work queue.",
+		Buckets: []float64{1, 2, 5, 10, 15, 20, 25, 30, 60, 120, 300, 600},
+	},
+		// Possible event_type - "pvc-updated", "pvc-deleted", "pv-updated",
+		// "pv-deleted", "pod-updated", "pod-deleted"
+		// Possible status - "pass", "fail", "retry"
+		[]string{"event_type", "status"})
+
+	// SyncerShardMembersGauge is a gauge metric to observe the number of
+	// syncer replicas sharing the work of the syncer.
+	SyncerShardMembersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "vsphere_syncer_shard_members",
+		Help: "Gauge for number of syncer replicas sharing the work of the syncer",
+	})
+
+	// SyncerShardOwnedGaugeVec is a gauge metric to observe the shards owned
+	// by this syncer replica. The value is 1 when the shard is owned and 0
+	// otherwise.
+	SyncerShardOwnedGaugeVec = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vsphere_syncer_shard_owned",
+		Help: "Gauge for shards owned by the syncer replica",
+	}, []string{"shard"})
+
+	// SyncerShardVolumesGaugeVec is a gauge metric to observe the number of
+	// PVs in each shard owned by this syncer replica, as of the last full
+	// sync.
+	SyncerShardVolumesGaugeVec = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vsphere_syncer_shard_volumes",
+		Help: "Gauge for number of PVs in the shards owned by the syncer replica",
+	}, []string{"shard"})
+
+	// SyncerShardFullSyncHistVec is a histogram vector metric to observe the
+	// full syncs of the shards of each vCenter owned by this syncer replica.
+	SyncerShardFullSyncHistVec = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vsphere_syncer_shard_full_sync_histogram",
+		Help:    "Histogram vector for full syncs of the shards owned by the syncer replica.",
+		Buckets: []float64{1, 2, 5, 10, 15, 20, 25, 30, 60, 120, 300, 600, 1200},
+	},
+		// Possible status - "pass", "fail"
+		[]string{"vcenter", "status"})
+
 	RequestOpsMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "vsphere_request_ops_seconds",
 		Help:    "Histogram vector for individual request to vCenter",
@@ -29,6 +29,7 @@ import (
 	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
 	cnsconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	commoncotypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/types"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest"
 )
 
@@ -50,6 +51,15 @@ type FakeK8SOrchestrator struct {
 	csiNodeTopologyInstances []interface{}
 	// PVCs for testing
 	pvcs []*v1.PersistentVolumeClaim
+	// Fault events recorded by the controllers under test
+	faultEventsMutex sync.Mutex
+	faultEvents      []RecordedFaultEvent
+}
+
+// RecordedFaultEvent is a fault event recorded in the FakeK8SOrchestrator.
+type RecordedFaultEvent struct {
+	Object v1.ObjectReference
+	Event  commoncotypes.FaultEvent
 }
 
 // volumeMigration holds mocked migrated volume information
@@ -77,6 +77,18 @@ func GetFakeContainerOrchestratorInterface(orchestratorType int) (commonco.COCom
 			"listview-tasks":                    "true",
 			"storage-quota-m2":                  "false",
 			"workload-domain-isolation":         "true",
+			"volume-clone":                      "true",
+			"static-volume-import":              "true",
+			"datastore-volume-migration":        "true",
+			"node-attach-limits":                "true",
+			"topology-drift-detection":          "true",
+			"host-local-volumes":                "true",
+			"node-fencing":                      "true",
+			"datastore-maintenance-awareness":   "true",
+			"multi-writer-block-volumes":        "true",
+			"metadata-sync-workqueue":           "true",
+			"incremental-full-sync":             "true",
+			"full-sync-schedule":                "true",
 			// From `wcp-cluster-capabilities` configmap in supervisor
 			"Workload_Domain_Isolation_Supported": "false",
 		}
@@ -313,6 +325,13 @@ func (nodeTopology *mockNodeVolumeTopology) GetNodeTopologyLabels(ctx context.Co
 	return nil, logger.LogNewError(log, "GetNodeTopologyLabels is not yet implemented.")
 }
 
+// GetNodeAttachLimit fetches the block volume attach limit of a node from the CSINodeTopology CR.
+func (nodeTopology *mockNodeVolumeTopology) GetNodeAttachLimit(ctx context.Context, info *commoncotypes.NodeInfo) (
+	int64, error) {
+	log := logger.GetLogger(ctx)
+	return 0, logger.LogNewError(log, "GetNodeAttachLimit is not yet implemented.")
+}
+
 // GetSharedDatastoresInTopology retrieves shared datastores of nodes which satisfy a given topology requirement.
 func (cntrlTopology *mockControllerVolumeTopology) GetSharedDatastoresInTopology(ctx context.Context,
 	reqParams interface{}) ([]*cnsvsphere.DatastoreInfo, error) {
@@ -643,6 +662,21 @@ func (c *FakeK8SOrchestrator) ListPVCs(ctx context.Context, namespace string) []
 	return filteredPVCs
 }
 
+// RecordFaultEvent records the fault event in the fake orchestrator.
+func (c *FakeK8SOrchestrator) RecordFaultEvent(ctx context.Context, object *v1.ObjectReference,
+	event commoncotypes.FaultEvent) {
+	c.faultEventsMutex.Lock()
+	defer c.faultEventsMutex.Unlock()
+	c.faultEvents = append(c.faultEvents, RecordedFaultEvent{Object: *object, Event: event})
+}
+
+// GetFaultEvents returns the fault events recorded in the fake orchestrator.
+func (c *FakeK8SOrchestrator) GetFaultEvents() []RecordedFaultEvent {
+	c.faultEventsMutex.Lock()
+	defer c.faultEventsMutex.Unlock()
+	return append([]RecordedFaultEvent(nil), c.faultEvents...)
+}
+
 // SetPVCs sets the PVCs for testing
 func (c *FakeK8SOrchestrator) SetPVCs(pvcs []*v1.PersistentVolumeClaim) {
 	c.pvcs = pvcs
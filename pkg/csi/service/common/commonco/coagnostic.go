@@ -129,6 +129,9 @@ type COCommonInterface interface {
 	// If the PVC is not found in the cache, it returns an empty string and false.
 	GetPVCNamespacedNameByUID(uid string) (k8stypes.NamespacedName, bool)
 	ListPVCs(ctx context.Context, namespace string) []*v1.PersistentVolumeClaim
+	// RecordFaultEvent records a warning event with the details of a failed CNS
+	// operation on the given object. Events are rate-limited and deduplicated.
+	RecordFaultEvent(ctx context.Context, object *v1.ObjectReference, event types.FaultEvent)
 }
 
 // GetContainerOrchestratorInterface returns orchestrator object for a given
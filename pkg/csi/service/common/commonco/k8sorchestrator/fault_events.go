@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sorchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/types"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// faultEventComponent is the source component of the fault events.
+	faultEventComponent = "vsphere-csi-controller"
+	// faultEventBurstSize and faultEventQPS bound the rate of the fault
+	// events recorded on a single object, so that a volume failing on every
+	// retry of the sidecars does not flood the API server.
+	faultEventBurstSize = 10
+	faultEventQPS       = 1.0 / 60
+	// volumeSnapshotAPIVersion is the API version of the VolumeSnapshots.
+	volumeSnapshotAPIVersion = "snapshot.storage.k8s.io/v1"
+)
+
+// RecordFaultEvent records a warning event with the details of a failed CNS
+// operation on the given object. The UID of the object is looked up if it
+// is not set, so that the event is listed when describing the object.
+// Identical events are aggregated by the event correlator.
+func (c *K8sOrchestrator) RecordFaultEvent(ctx context.Context, object *v1.ObjectReference,
+	event types.FaultEvent) {
+	log := logger.GetLogger(ctx)
+	if object == nil || c.k8sClient == nil {
+		return
+	}
+	ref := object.DeepCopy()
+	if ref.UID == "" {
+		uid, err := c.getObjectUID(ctx, ref)
+		if err != nil {
+			log.Debugf("failed to get the UID of %s %s/%s for the fault event. Err: %v",
+				ref.Kind, ref.Namespace, ref.Name, err)
+		}
+		ref.UID = uid
+	}
+	if ref.APIVersion == "" {
+		ref.APIVersion = "v1"
+		if ref.Kind == "VolumeSnapshot" {
+			ref.APIVersion = volumeSnapshotAPIVersion
+		}
+	}
+	recorder := c.getFaultEventRecorder()
+	msg := faultEventMessage(event)
+	recorder.Event(ref, v1.EventTypeWarning, event.Reason, msg)
+	if ref.Kind != "PersistentVolumeClaim" || event.NodeName == "" {
+		return
+	}
+	pods, err := c.getPodsUsingPVC(ctx, ref.Namespace, ref.Name, event.NodeName)
+	if err != nil {
+		log.Debugf("failed to get the pods using PVC %s/%s on node %q for the fault event. Err: %v",
+			ref.Namespace, ref.Name, event.NodeName, err)
+		return
+	}
+	for _, pod := range pods {
+		podRef := &v1.ObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			UID:        pod.UID,
+		}
+		recorder.Event(podRef, v1.EventTypeWarning, event.Reason, msg)
+	}
+}
+
+// getPodsUsingPVC returns the pods scheduled on the given node which use
+// the given PVC.
+func (c *K8sOrchestrator) getPodsUsingPVC(ctx context.Context, namespace, pvcName,
+	nodeName string) ([]v1.Pod, error) {
+	podList, err := c.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pods []v1.Pod
+	for _, pod := range podList.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
+// getFaultEventRecorder returns the event recorder of the fault events,
+// creating it on first use.
+func (c *K8sOrchestrator) getFaultEventRecorder() record.EventRecorder {
+	c.faultEventRecorderOnce.Do(func() {
+		eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{
+			BurstSize: faultEventBurstSize,
+			QPS:       faultEventQPS,
+		})
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+			Interface: c.k8sClient.CoreV1().Events(""),
+		})
+		c.faultEventRecorder = eventBroadcaster.NewRecorder(scheme.Scheme,
+			v1.EventSource{Component: faultEventComponent})
+	})
+	return c.faultEventRecorder
+}
+
+// getObjectUID returns the UID of the PVC, VolumeSnapshot or Pod referenced
+// by ref.
+func (c *K8sOrchestrator) getObjectUID(ctx context.Context, ref *v1.ObjectReference) (k8stypes.UID, error) {
+	switch ref.Kind {
+	case "PersistentVolumeClaim":
+		if c.informerManager != nil {
+			pvc, err := c.informerManager.GetPVCLister().PersistentVolumeClaims(ref.Namespace).Get(ref.Name)
+			if err == nil {
+				return pvc.UID, nil
+			}
+		}
+		pvc, err := c.k8sClient.CoreV1().PersistentVolumeClaims(ref.Namespace).Get(ctx, ref.Name,
+			metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return pvc.UID, nil
+	case "VolumeSnapshot":
+		if c.snapshotterClient == nil {
+			return "", fmt.Errorf("snapshotter client is not initialized")
+		}
+		volumeSnapshot, err := c.snapshotterClient.SnapshotV1().VolumeSnapshots(ref.Namespace).Get(ctx,
+			ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return volumeSnapshot.UID, nil
+	case "Pod":
+		pod, err := c.k8sClient.CoreV1().Pods(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return pod.UID, nil
+	}
+	return "", fmt.Errorf("unsupported kind %q", ref.Kind)
+}
+
+// faultEventMessage returns the message of the event for the given fault.
+// The details which are not known are left out.
+func faultEventMessage(event types.FaultEvent) string {
+	var details []string
+	if event.FaultType != "" {
+		details = append(details, "fault: "+event.FaultType)
+	}
+	if event.VCenter != "" {
+		details = append(details, "vCenter: "+event.VCenter)
+	}
+	if event.Datastore != "" {
+		details = append(details, "datastore: "+event.Datastore)
+	}
+	if event.NodeName != "" {
+		details = append(details, "node: "+event.NodeName)
+	}
+	if event.TaskID != "" {
+		details = append(details, "CNS task: "+event.TaskID)
+	}
+	msg := event.Operation + " failed"
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+	if event.Message != "" {
+		msg += ": " + event.Message
+	}
+	return msg
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sorchestrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/types"
+)
+
+func TestFaultEventMessage(t *testing.T) {
+	msg := faultEventMessage(types.FaultEvent{
+		Operation: "CreateVolume",
+		FaultType: "vim.fault.NoDiskSpace",
+		VCenter:   "vc1.example.com",
+		Datastore: "datastore-1, datastore-2",
+		TaskID:    "task-42",
+		Message:   "failed to create volume",
+	})
+	assert.Equal(t, "CreateVolume failed (fault: vim.fault.NoDiskSpace, vCenter: vc1.example.com, "+
+		"datastore: datastore-1, datastore-2, CNS task: task-42): failed to create volume", msg)
+
+	// Unknown details are left out.
+	msg = faultEventMessage(types.FaultEvent{Operation: "CreateSnapshot", Message: "failed"})
+	assert.Equal(t, "CreateSnapshot failed: failed", msg)
+}
+
+func TestGetObjectUID(t *testing.T) {
+	c := &K8sOrchestrator{k8sClient: k8sfake.NewSimpleClientset(
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "ns", UID: "uid-1"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns", UID: "uid-2"}},
+	)}
+	uid, err := c.getObjectUID(ctx, &v1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: "ns",
+		Name: "pvc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "uid-1", string(uid))
+	uid, err = c.getObjectUID(ctx, &v1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "pod-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "uid-2", string(uid))
+	_, err = c.getObjectUID(ctx, &v1.ObjectReference{Kind: "VolumeSnapshot", Namespace: "ns", Name: "snap-1"})
+	assert.Error(t, err)
+}
@@ -49,6 +49,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	cnsoperatorv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator"
@@ -258,6 +259,10 @@ type K8sOrchestrator struct {
 	// this map contains UID of PVC with status Pending.
 	// When PVC's status turn to Bound, it is deleted from the map
 	pvcUIDCache sync.Map // key: PVC UID (string), value: namespaced name (string)
+	// faultEventRecorder records the events of the failed CNS operations.
+	// It is created on first use by getFaultEventRecorder.
+	faultEventRecorder     record.EventRecorder
+	faultEventRecorderOnce sync.Once
 }
 
 // K8sGuestInitParams lists the set of parameters required to run the init for
@@ -2102,8 +2107,12 @@ func (c *K8sOrchestrator) GetPVNameFromCSIVolumeID(volumeID string) (string, boo
 }
 
 // GetPVCNameFromCSIVolumeID returns `pvc name` and `pvc namespace` for the given volumeID using volumeIDToPvcMap.
+// Nothing is returned if the map is not initialized for the cluster flavor.
 func (c *K8sOrchestrator) GetPVCNameFromCSIVolumeID(volumeID string) (
 	pvcName string, pvcNamespace string, exists bool) {
+	if c.volumeIDToPvcMap == nil {
+		return
+	}
 	namespacedName, ok := c.volumeIDToPvcMap.get(volumeID)
 	if !ok {
 		return
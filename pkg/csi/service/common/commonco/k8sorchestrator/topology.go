@@ -744,6 +744,10 @@ func (volTopology *nodeVolumeTopology) GetNodeTopologyLabels(ctx context.Context
 			for _, label := range csiNodeTopologyInstance.Status.TopologyLabels {
 				accessibleTopology[label.Key] = label.Value
 			}
+			// Host is set only when host-local volumes are enabled.
+			if csiNodeTopologyInstance.Status.Host != "" {
+				accessibleTopology[common.TopologyLabelESXiHost] = csiNodeTopologyInstance.Status.Host
+			}
 			return accessibleTopology, nil
 		case csinodetopologyv1alpha1.CSINodeTopologyError:
 			// There was an error collecting topology information from nodes.
@@ -758,6 +762,22 @@ func (volTopology *nodeVolumeTopology) GetNodeTopologyLabels(ctx context.Context
 		nodeInfo.NodeName)
 }
 
+// GetNodeAttachLimit reads the block volume attach limit of a node from its
+// CSINodeTopology CR. Call it after GetNodeTopologyLabels succeeds, so that the
+// limit is computed for the current NodeVM.
+func (volTopology *nodeVolumeTopology) GetNodeAttachLimit(ctx context.Context, nodeInfo *commoncotypes.NodeInfo) (
+	int64, error) {
+	log := logger.GetLogger(ctx)
+	csiNodeTopology := &csinodetopologyv1alpha1.CSINodeTopology{}
+	err := volTopology.csiNodeTopologyK8sClient.Get(ctx, types.NamespacedName{Name: nodeInfo.NodeName},
+		csiNodeTopology)
+	if err != nil {
+		return 0, logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to get CsiNodeTopology for the node: %q. Error: %+v", nodeInfo.NodeName, err)
+	}
+	return csiNodeTopology.Status.MaxBlockVolumes, nil
+}
+
 func (volTopology *nodeVolumeTopology) updateNodeIDForTopology(
 	ctx context.Context,
 	nodeInfo *commoncotypes.NodeInfo,
@@ -119,4 +119,29 @@ type ControllerTopologyService interface {
 type NodeTopologyService interface {
 	// GetNodeTopologyLabels fetches the topology labels of a NodeVM given the NodeInfo.
 	GetNodeTopologyLabels(ctx context.Context, info *NodeInfo) (map[string]string, error)
+	// GetNodeAttachLimit fetches the block volume attach limit of a NodeVM given the NodeInfo.
+	GetNodeAttachLimit(ctx context.Context, info *NodeInfo) (int64, error)
+}
+
+// FaultEvent represents the details of a failed CNS operation reported as a
+// Kubernetes event on the affected object.
+type FaultEvent struct {
+	// Reason is the reason of the event, e.g. "ProvisioningFailed".
+	Reason string
+	// Operation is the CSI operation which failed, e.g. "CreateVolume".
+	Operation string
+	// FaultType is the CNS fault type of the failure.
+	FaultType string
+	// VCenter is the vCenter on which the CNS task ran.
+	VCenter string
+	// Datastore lists the datastores involved in the operation.
+	Datastore string
+	// TaskID is the MoID of the CNS task which failed.
+	TaskID string
+	// NodeName is the node the volume failed to be attached to. If set, the
+	// event recorded on a PVC is also recorded on the pods scheduled on the
+	// node which use the PVC.
+	NodeName string
+	// Message is the error returned for the operation.
+	Message string
 }
@@ -21,9 +21,13 @@ import (
 	"strings"
 
 	cnstypes "github.com/vmware/govmomi/cns/types"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
 
+	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
 	csiconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/k8sorchestrator"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/types"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 )
 
@@ -105,3 +109,25 @@ func SetInitParams(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavor,
 	}
 	log.Debugf("Container orchestrator init params: %+v", *initParams)
 }
+
+// RecordFaultEvent records an event with the details of a failed operation
+// and of the last CNS task it ran on the given PVC or VolumeSnapshot, so
+// that users do not have to search the controller logs for them. taskDetails
+// is nil for the flavors which do not run CNS tasks themselves. Nothing is
+// recorded if the object is not known.
+func RecordFaultEvent(ctx context.Context, kind, namespace, name string, event types.FaultEvent,
+	taskDetails *cnsvolume.OperationTaskDetails, err error) {
+	if namespace == "" || name == "" {
+		return
+	}
+	if taskDetails != nil {
+		event.TaskID, event.VCenter = taskDetails.GetTaskID()
+		event.Datastore = strings.Join(taskDetails.GetDatastores(), ", ")
+	}
+	event.Message = status.Convert(err).Message()
+	ContainerOrchestratorUtility.RecordFaultEvent(ctx, &v1.ObjectReference{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+	}, event)
+}
@@ -48,6 +48,38 @@ const (
 	// For Example: DatastoreURL: "ds:///vmfs/volumes/5c9bb20e-009c1e46-4b85-0200483b2a97/".
 	AttributeDatastoreURL = "datastoreurl"
 
+	// AttributeDatastoreCluster represents name or inventory path of the
+	// datastore cluster (StoragePod) in the StorageClass. The volume is placed
+	// on one of the member datastores of the datastore cluster.
+	// For Example: DatastoreCluster: "DatastoreCluster1".
+	AttributeDatastoreCluster = "datastorecluster"
+
+	// AttributeDatastoreClusterID represents the datastore cluster (StoragePod)
+	// in the StorageClass by its managed object ID, as shown in the vSphere
+	// Client URL of the datastore cluster. Unlike the name, it is unique in
+	// the vCenter. The volume is placed on one of its member datastores.
+	// For Example: DatastoreClusterID: "group-p1001".
+	AttributeDatastoreClusterID = "datastoreclusterid"
+
+	// AttributePlacementStrategy represents the strategy used to score the
+	// candidate datastores of a volume in the StorageClass.
+	// For Example: PlacementStrategy: "mostfreespace".
+	AttributePlacementStrategy = "placementstrategy"
+
+	// AttributePlacementTopN represents the number of top scored datastores
+	// passed to CNS for placing the volume. For Example: PlacementTopN: "2".
+	AttributePlacementTopN = "placementtopn"
+
+	// AttributePlacementTagCategory represents the tag category whose tags
+	// weigh the candidate datastores of a volume.
+	// For Example: PlacementTagCategory: "storage-tier".
+	AttributePlacementTagCategory = "placementtagcategory"
+
+	// AttributePlacementTagWeights represents the weights of the tags in the
+	// placement tag category as comma separated tag=weight pairs.
+	// For Example: PlacementTagWeights: "gold=3,silver=2".
+	AttributePlacementTagWeights = "placementtagweights"
+
 	// AttributeStoragePolicyName represents name of the Storage Policy in the
 	// Storage Class.
 	// For Example: StoragePolicy: "vSAN Default Storage Policy".
@@ -314,6 +346,11 @@ const (
 	// topology labels applied on the node by vSphere CSI driver.
 	TopologyLabelsDomain = "topology.csi.vmware.com"
 
+	// TopologyLabelESXiHost is the key of the topology segment reported by
+	// the nodes for the ESXi host running the NodeVM. It is used to place
+	// host-local volumes.
+	TopologyLabelESXiHost = TopologyLabelsDomain + "/esxi-host"
+
 	// AnnGuestClusterRequestedTopology is the key for guest cluster requested topology
 	AnnGuestClusterRequestedTopology = "csi.vsphere.volume-requested-topology"
 
@@ -336,6 +373,10 @@ const (
 	// created to tag preferred datastores in a topology-aware environment.
 	PreferredDatastoresCategory = "cns.vmware.topology-preferred-datastores"
 
+	// DrainDatastoresCategory points to the vSphere Category created to tag
+	// the datastores from which volumes should be drained.
+	DrainDatastoresCategory = "cns.vmware.datastore-drain"
+
 	// VolumeSnapshotNameKey represents the volumesnapshot CR name within
 	// the request parameters
 	VolumeSnapshotNameKey = "csi.storage.k8s.io/volumesnapshot/name"
@@ -353,6 +394,11 @@ const (
 	// Guest cluster.
 	SupervisorVolumeSnapshotAnnotationKey = "csi.vsphere.guest-initiated-csi-snapshot"
 
+	// SupervisorLinkedCloneSourceAnnotationKey represents the annotation key on VolumeSnapshot CR
+	// in Supervisor cluster which is used to indicate that the snapshot was taken by pvCSI to
+	// create a linked clone from a PVC. The value is the name of the source PVC.
+	SupervisorLinkedCloneSourceAnnotationKey = "csi.vsphere.guest-linked-clone-source"
+
 	// ConfigMapCSILimits is the ConfigMap name for CSI limits configuration
 	ConfigMapCSILimits = "cns-csi-limits"
 
@@ -434,6 +480,49 @@ const (
 	TKGsHA = "tkgs-ha"
 	// ListVolumes is the feature to support list volumes API
 	ListVolumes = "list-volumes"
+	// VolumeClone is the feature to support creating a volume from an existing
+	// PVC in the guest cluster.
+	VolumeClone = "volume-clone"
+	// StaticVolumeImport is the feature to support importing existing disks
+	// as PV/PVC pairs using CnsImportVolume in vanilla clusters.
+	StaticVolumeImport = "static-volume-import"
+	// DatastoreVolumeMigration is the feature to support migrating volumes
+	// between datastores using VolumeMigration in vanilla clusters.
+	DatastoreVolumeMigration = "datastore-volume-migration"
+	// NodeAttachLimits is the feature to report the block volume attach limit
+	// of a node computed from the controllers of the NodeVM.
+	NodeAttachLimits = "node-attach-limits"
+	// TopologyDriftDetection is the feature to periodically re-evaluate the
+	// topology labels of the NodeVMs and report drift in CSINodeTopology.
+	TopologyDriftDetection = "topology-drift-detection"
+	// HostLocalVolumes is the feature to provision block volumes on the local
+	// datastores of the ESXi host of the selected node in vanilla clusters.
+	HostLocalVolumes = "host-local-volumes"
+	// NodeFencing is the feature to force-detach the block volumes of nodes
+	// which are out of service or whose NodeVM is powered off.
+	NodeFencing = "node-fencing"
+	// DatastoreMaintenanceAwareness is the feature to exclude the datastores
+	// in or entering maintenance mode, or tagged for drain, from volume
+	// placement and to optionally relocate the volumes placed on them.
+	DatastoreMaintenanceAwareness = "datastore-maintenance-awareness"
+	// MultiWriterBlockVolumes is the feature to provision ReadWriteMany raw
+	// block volumes attached to multiple nodes with the multi-writer sharing
+	// mode in vanilla clusters.
+	MultiWriterBlockVolumes = "multi-writer-block-volumes"
+	// MetadataSyncWorkQueue is the feature to sync the PV, PVC and pod
+	// metadata to CNS from rate limited work queues in the metadata syncer,
+	// instead of from the informer callbacks. The queues are not persisted;
+	// the pending work is re-derived from the informer caches at startup.
+	MetadataSyncWorkQueue = "metadata-sync-workqueue"
+	// IncrementalFullSync is the feature to only query CNS in full sync for
+	// the volumes whose K8s objects changed since the previous full sync, and
+	// for a rotating window of the other volumes, with a periodic complete
+	// full sync.
+	IncrementalFullSync = "incremental-full-sync"
+	// FullSyncSchedule is the feature to schedule full sync per vCenter, with
+	// quiet windows, namespace scopes and a CNS calls budget, from the
+	// CsiFullSyncConfig.
+	FullSyncSchedule = "full-sync-schedule"
 	// PVtoBackingDiskObjectIdMapping is the feature to support pv to backingDiskObjectId mapping on vSphere CSI driver.
 	PVtoBackingDiskObjectIdMapping = "pv-to-backingdiskobjectid-mapping"
 	// Block Create Volume for datastores that are in suspended mode
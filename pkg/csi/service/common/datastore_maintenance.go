@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+// drainedDatastoresCacheTTL is the time the drained datastores of a vCenter
+// are cached for, so that a tag manager session is not opened on every
+// volume placement.
+const drainedDatastoresCacheTTL = time.Minute
+
+// drainedDatastores holds the drained datastores of a vCenter along with the
+// time they were listed.
+type drainedDatastores struct {
+	moIDs      map[string]struct{}
+	listedTime time.Time
+}
+
+var (
+	// drainedDatastoresCache maps a vCenter host to its drained datastores.
+	drainedDatastoresCache     = make(map[string]drainedDatastores)
+	drainedDatastoresCacheLock sync.Mutex
+	// listDrainedDatastores lists the drained datastores of a vCenter. It is
+	// a variable to be replaced in unit tests.
+	listDrainedDatastores = listDrainedDatastoresWithTags
+)
+
+// GetDrainedDatastores returns the MoIDs of the datastores tagged with a tag
+// in DrainDatastoresCategory in the given vCenter. An empty map is returned
+// if the category does not exist. The datastores are cached for
+// drainedDatastoresCacheTTL, and the returned map must not be modified.
+func GetDrainedDatastores(ctx context.Context, vc *cnsvsphere.VirtualCenter) (map[string]struct{}, error) {
+	drainedDatastoresCacheLock.Lock()
+	defer drainedDatastoresCacheLock.Unlock()
+	if cached, ok := drainedDatastoresCache[vc.Config.Host]; ok &&
+		time.Since(cached.listedTime) < drainedDatastoresCacheTTL {
+		return cached.moIDs, nil
+	}
+	drained, err := listDrainedDatastores(ctx, vc)
+	if err != nil {
+		return nil, err
+	}
+	drainedDatastoresCache[vc.Config.Host] = drainedDatastores{moIDs: drained, listedTime: time.Now()}
+	return drained, nil
+}
+
+// listDrainedDatastoresWithTags lists the datastores tagged with a tag in
+// DrainDatastoresCategory in the given vCenter.
+func listDrainedDatastoresWithTags(ctx context.Context, vc *cnsvsphere.VirtualCenter) (map[string]struct{}, error) {
+	log := logger.GetLogger(ctx)
+	drained := make(map[string]struct{})
+	tagMgr, err := cnsvsphere.GetTagManager(ctx, vc)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to create tag manager for vCenter %q. Error: %+v",
+			vc.Config.Host, err)
+	}
+	defer func() {
+		err := tagMgr.Logout(ctx)
+		if err != nil {
+			log.Errorf("failed to logout tagManager for vCenter %q. Error: %v", vc.Config.Host, err)
+		}
+	}()
+	tagIds, err := tagMgr.ListTagsForCategory(ctx, DrainDatastoresCategory)
+	if err != nil {
+		log.Debugf("failed to retrieve tags for category %q in vCenter %q. Reason: %+v",
+			DrainDatastoresCategory, vc.Config.Host, err)
+		return drained, nil
+	}
+	if len(tagIds) == 0 {
+		return drained, nil
+	}
+	attachedObjs, err := tagMgr.GetAttachedObjectsOnTags(ctx, tagIds)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to retrieve objects with tags %v in vCenter %q. Error: %+v",
+			tagIds, vc.Config.Host, err)
+	}
+	for _, attachedObj := range attachedObjs {
+		for _, obj := range attachedObj.ObjectIDs {
+			// Drain tag should only be applied to datastores.
+			if obj.Reference().Type != "Datastore" {
+				log.Warnf("Drain datastore tag applied on a non-datastore entity: %+v", obj.Reference())
+				continue
+			}
+			drained[obj.Reference().Value] = struct{}{}
+		}
+	}
+	return drained, nil
+}
+
+// GetDatastoresUnderMaintenance returns the MoIDs of the given datastores
+// which are in or entering maintenance mode, or are tagged for drain.
+func GetDatastoresUnderMaintenance(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	dsRefs []vimtypes.ManagedObjectReference) (map[string]struct{}, error) {
+	log := logger.GetLogger(ctx)
+	underMaintenance := make(map[string]struct{})
+	if len(dsRefs) == 0 {
+		return underMaintenance, nil
+	}
+	var dsMoList []mo.Datastore
+	pc := property.DefaultCollector(vc.Client.Client)
+	err := pc.Retrieve(ctx, dsRefs, []string{"summary"}, &dsMoList)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to get summary of datastores %v. Error: %+v", dsRefs, err)
+	}
+	for _, dsMo := range dsMoList {
+		if cnsvsphere.IsDatastoreInMaintenanceMode(dsMo.Summary) {
+			log.Debugf("Datastore %q is in maintenance mode %q", dsMo.Summary.Name, dsMo.Summary.MaintenanceMode)
+			underMaintenance[dsMo.Reference().Value] = struct{}{}
+		}
+	}
+	drained, err := GetDrainedDatastores(ctx, vc)
+	if err != nil {
+		return nil, err
+	}
+	for _, dsRef := range dsRefs {
+		if _, ok := drained[dsRef.Value]; ok {
+			log.Debugf("Datastore %v is tagged for drain", dsRef)
+			underMaintenance[dsRef.Value] = struct{}{}
+		}
+	}
+	return underMaintenance, nil
+}
+
+// FilterDatastoresUnderMaintenance returns the given datastores leaving out
+// the ones which are in or entering maintenance mode, or are tagged for drain.
+func FilterDatastoresUnderMaintenance(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	datastores []*cnsvsphere.DatastoreInfo) ([]*cnsvsphere.DatastoreInfo, error) {
+	log := logger.GetLogger(ctx)
+	var dsRefs []vimtypes.ManagedObjectReference
+	for _, ds := range datastores {
+		dsRefs = append(dsRefs, ds.Reference())
+	}
+	underMaintenance, err := GetDatastoresUnderMaintenance(ctx, vc, dsRefs)
+	if err != nil {
+		return nil, err
+	}
+	filtered := excludeDatastores(datastores, underMaintenance)
+	if len(filtered) != len(datastores) {
+		log.Infof("Excluded datastores under maintenance from placement. Remaining datastores: %+v", filtered)
+	}
+	return filtered, nil
+}
+
+// excludeDatastores returns the datastores whose MoID is not in excluded.
+func excludeDatastores(datastores []*cnsvsphere.DatastoreInfo,
+	excluded map[string]struct{}) []*cnsvsphere.DatastoreInfo {
+	var filtered []*cnsvsphere.DatastoreInfo
+	for _, ds := range datastores {
+		if _, ok := excluded[ds.Reference().Value]; !ok {
+			filtered = append(filtered, ds)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+)
+
+func TestGetDrainedDatastoresCache(t *testing.T) {
+	ctx := context.Background()
+	originalListDrainedDatastores := listDrainedDatastores
+	defer func() {
+		listDrainedDatastores = originalListDrainedDatastores
+		drainedDatastoresCache = make(map[string]drainedDatastores)
+	}()
+	drainedDatastoresCache = make(map[string]drainedDatastores)
+	calls := 0
+	var listErr error
+	listDrainedDatastores = func(ctx context.Context, vc *cnsvsphere.VirtualCenter) (map[string]struct{}, error) {
+		calls++
+		if listErr != nil {
+			return nil, listErr
+		}
+		return map[string]struct{}{vc.Config.Host + "-datastore-1": {}}, nil
+	}
+	vc1 := &cnsvsphere.VirtualCenter{Config: &cnsvsphere.VirtualCenterConfig{Host: "vc-1"}}
+	vc2 := &cnsvsphere.VirtualCenter{Config: &cnsvsphere.VirtualCenterConfig{Host: "vc-2"}}
+
+	drained, err := GetDrainedDatastores(ctx, vc1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"vc-1-datastore-1": {}}, drained)
+	// The drained datastores are cached per vCenter.
+	_, err = GetDrainedDatastores(ctx, vc1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	drained, err = GetDrainedDatastores(ctx, vc2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"vc-2-datastore-1": {}}, drained)
+	assert.Equal(t, 2, calls)
+
+	// The drained datastores are listed again once the cache expired, and
+	// errors are not cached.
+	drainedDatastoresCache["vc-1"] = drainedDatastores{listedTime: time.Now().Add(-drainedDatastoresCacheTTL)}
+	listErr = errors.New("failed")
+	_, err = GetDrainedDatastores(ctx, vc1)
+	assert.Error(t, err)
+	listErr = nil
+	drained, err = GetDrainedDatastores(ctx, vc1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"vc-1-datastore-1": {}}, drained)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
+)
+
+const (
+	// HealthzPath is the HTTP path of the liveness endpoint.
+	HealthzPath = "/healthz"
+	// ReadyzPath is the HTTP path of the readiness endpoint.
+	ReadyzPath = "/readyz"
+
+	// healthCheckTimeout is the time allowed for all the health checks to run.
+	healthCheckTimeout = 10 * time.Second
+
+	// Prefixes of the names of the per-vCenter health checks.
+	vCenterHealthCheckPrefix  = "vcenter/"
+	listViewHealthCheckPrefix = "cns-listview/"
+	// InformersHealthCheckName is the name of the health check of the
+	// Kubernetes informers.
+	InformersHealthCheckName = "informers"
+)
+
+// HealthCheck verifies the health of a component and returns an error
+// describing why the component is unhealthy.
+type HealthCheck func(ctx context.Context) error
+
+var (
+	healthChecksLock = &sync.RWMutex{}
+	// healthChecks maps the name of a component to its health check.
+	healthChecks = make(map[string]HealthCheck)
+)
+
+// RegisterHealthCheck registers the health check of the named component,
+// replacing any check previously registered with the same name.
+func RegisterHealthCheck(name string, check HealthCheck) {
+	healthChecksLock.Lock()
+	defer healthChecksLock.Unlock()
+	healthChecks[name] = check
+}
+
+// UnregisterHealthChecks removes the health checks of the components whose
+// name starts with the given prefix.
+func UnregisterHealthChecks(prefix string) {
+	healthChecksLock.Lock()
+	defer healthChecksLock.Unlock()
+	for name := range healthChecks {
+		if strings.HasPrefix(name, prefix) {
+			delete(healthChecks, name)
+		}
+	}
+}
+
+// RegisterVCenterHealthChecks registers the health checks of the vCenter
+// sessions, CNS and PBM clients and CNS ListViews of the given managers,
+// replacing the checks registered for a previous set of vCenters.
+func RegisterVCenterHealthChecks(managers *Managers) {
+	UnregisterHealthChecks(vCenterHealthCheckPrefix)
+	UnregisterHealthChecks(listViewHealthCheckPrefix)
+	vcManager := managers.VcenterManager
+	for host, volumeManager := range managers.VolumeManagers {
+		RegisterHealthCheck(vCenterHealthCheckPrefix+host, func(ctx context.Context) error {
+			vc, err := vcManager.GetVirtualCenter(ctx, host)
+			if err != nil {
+				return err
+			}
+			return vc.CheckHealth(ctx)
+		})
+		RegisterHealthCheck(listViewHealthCheckPrefix+host, newListViewHealthCheck(volumeManager))
+	}
+}
+
+// newListViewHealthCheck returns a health check failing when the CNS
+// ListView of the given volume manager is not ready.
+func newListViewHealthCheck(volumeManager cnsvolume.Manager) HealthCheck {
+	return func(ctx context.Context) error {
+		if !volumeManager.IsListViewReady() {
+			return fmt.Errorf("CNS ListView is not ready")
+		}
+		return nil
+	}
+}
+
+// RegisterInformersHealthCheck registers the health check verifying that
+// the Kubernetes informers have synced their caches.
+func RegisterInformersHealthCheck() {
+	RegisterHealthCheck(InformersHealthCheckName, k8s.CheckInformersSynced)
+}
+
+// HealthCheckResult is the outcome of the health check of a component.
+type HealthCheckResult struct {
+	Name string
+	Err  error
+}
+
+// RunHealthChecks runs all the registered health checks and returns their
+// results sorted by component name.
+func RunHealthChecks(ctx context.Context) []HealthCheckResult {
+	healthChecksLock.RLock()
+	checks := make(map[string]HealthCheck, len(healthChecks))
+	for name, check := range healthChecks {
+		checks[name] = check
+	}
+	healthChecksLock.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []HealthCheckResult
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			err := check(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, HealthCheckResult{Name: name, Err: err})
+		}(name, check)
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// GetFailedHealthChecks returns the reasons of the failed health checks
+// keyed by component name, or nil if all the components are healthy.
+func GetFailedHealthChecks(ctx context.Context) map[string]string {
+	var failed map[string]string
+	for _, result := range RunHealthChecks(ctx) {
+		if result.Err == nil {
+			continue
+		}
+		if failed == nil {
+			failed = make(map[string]string)
+		}
+		failed[result.Name] = result.Err.Error()
+	}
+	return failed
+}
+
+// RegisterHealthEndpoints registers the /healthz and /readyz endpoints on the
+// given mux. /healthz only reports that the process is serving, so that an
+// outage of a vCenter does not get the driver restarted, while /readyz
+// exposes the registered health checks.
+func RegisterHealthEndpoints(mux *http.ServeMux) {
+	mux.HandleFunc(HealthzPath, serveLiveness)
+	mux.HandleFunc(ReadyzPath, serveHealthChecks)
+}
+
+// serveLiveness responds with 200 as long as the process is serving.
+func serveLiveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(r.URL.Path + " check passed\n"))
+}
+
+// serveHealthChecks writes the result of every health check, one per line,
+// and responds with 503 if any of them failed.
+func serveHealthChecks(w http.ResponseWriter, r *http.Request) {
+	ctx := logger.NewContextWithLogger(r.Context())
+	log := logger.GetLogger(ctx)
+	var (
+		body    strings.Builder
+		healthy = true
+	)
+	for _, result := range RunHealthChecks(ctx) {
+		if result.Err != nil {
+			healthy = false
+			fmt.Fprintf(&body, "[-]%s failed: %v\n", result.Name, result.Err)
+			continue
+		}
+		fmt.Fprintf(&body, "[+]%s ok\n", result.Name)
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if healthy {
+		body.WriteString(r.URL.Path + " check passed\n")
+		w.WriteHeader(http.StatusOK)
+	} else {
+		log.Warnf("%s check failed:\n%s", r.URL.Path, body.String())
+		body.WriteString(r.URL.Path + " check failed\n")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write([]byte(body.String()))
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthChecks(t *testing.T) {
+	defer UnregisterHealthChecks("test/")
+	mux := http.NewServeMux()
+	RegisterHealthEndpoints(mux)
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	RegisterHealthCheck("test/vcenter", func(ctx context.Context) error { return nil })
+	assert.Empty(t, GetFailedHealthChecks(ctx))
+	recorder := serve(ReadyzPath)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "[+]test/vcenter ok")
+
+	RegisterHealthCheck("test/listview", func(ctx context.Context) error {
+		return errors.New("CNS ListView is not ready")
+	})
+	assert.Equal(t, map[string]string{"test/listview": "CNS ListView is not ready"}, GetFailedHealthChecks(ctx))
+	// Liveness does not depend on the health checks.
+	assert.Equal(t, http.StatusOK, serve(HealthzPath).Code)
+	recorder = serve(ReadyzPath)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	assert.Equal(t, []string{
+		"[-]test/listview failed: CNS ListView is not ready",
+		"[+]test/vcenter ok",
+		"/readyz check failed",
+	}, lines)
+
+	UnregisterHealthChecks("test/")
+	assert.Empty(t, GetFailedHealthChecks(ctx))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// vsanNamespace and vsanProportionalCapacity identify the object space
+	// reservation rule of vSAN storage policies. A reservation of 100 percent
+	// provisions eager-zeroed thick disks.
+	vsanNamespace            = "VSAN"
+	vsanProportionalCapacity = "proportionalCapacity"
+	// volumeAllocationNamespace and volumeAllocationType identify the
+	// provisioning type rule of VMFS and vVol storage policies.
+	volumeAllocationNamespace = "com.vmware.storage.volumeallocation"
+	volumeAllocationType      = "VolumeAllocationType"
+	// volumeAllocationFullyInitialized is the provisioning type of
+	// eager-zeroed thick disks, compared ignoring case and spaces.
+	volumeAllocationFullyInitialized = "fullyinitialized"
+)
+
+// multiWriterDatastoreTypes are the types of datastores on which disks can
+// be attached with the multi-writer sharing mode.
+var multiWriterDatastoreTypes = map[string]struct{}{
+	string(vimtypes.HostFileSystemVolumeFileSystemTypeVMFS): {},
+	string(vimtypes.HostFileSystemVolumeFileSystemTypeVsan): {},
+	string(vimtypes.HostFileSystemVolumeFileSystemTypeVVOL): {},
+}
+
+// IsEagerZeroedThickPolicy returns true if the given storage policy
+// provisions eager-zeroed thick disks, as required to attach disks with the
+// multi-writer sharing mode.
+func IsEagerZeroedThickPolicy(policy cnsvsphere.SpbmPolicyContent) bool {
+	for _, profile := range policy.Profiles {
+		for _, rule := range profile.Rules {
+			switch {
+			case rule.Ns == vsanNamespace && rule.CapID == vsanProportionalCapacity:
+				if rule.Value == "100" {
+					return true
+				}
+			case rule.Ns == volumeAllocationNamespace && rule.CapID == volumeAllocationType:
+				value := strings.ToLower(strings.ReplaceAll(rule.Value, " ", ""))
+				if value == volumeAllocationFullyInitialized {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// FilterMultiWriterDatastores returns the given datastores leaving out the
+// ones on which disks cannot be attached with the multi-writer sharing mode.
+func FilterMultiWriterDatastores(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	datastores []*cnsvsphere.DatastoreInfo) ([]*cnsvsphere.DatastoreInfo, error) {
+	log := logger.GetLogger(ctx)
+	if len(datastores) == 0 {
+		return nil, nil
+	}
+	var dsRefs []vimtypes.ManagedObjectReference
+	for _, ds := range datastores {
+		dsRefs = append(dsRefs, ds.Reference())
+	}
+	var dsMoList []mo.Datastore
+	pc := property.DefaultCollector(vc.Client.Client)
+	err := pc.Retrieve(ctx, dsRefs, []string{"summary"}, &dsMoList)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to get summary of datastores %v. Error: %+v", dsRefs, err)
+	}
+	excluded := make(map[string]struct{})
+	for _, dsMo := range dsMoList {
+		if _, ok := multiWriterDatastoreTypes[dsMo.Summary.Type]; !ok {
+			log.Debugf("Datastore %q of type %q does not support multi-writer disks",
+				dsMo.Summary.Name, dsMo.Summary.Type)
+			excluded[dsMo.Reference().Value] = struct{}{}
+		}
+	}
+	return excludeDatastores(datastores, excluded), nil
+}
@@ -354,13 +354,13 @@ func GetTopologyInfoFromNodes(ctx context.Context, reqParams interface{}) (
 		params.DatastoreURL, topologySegments)
 
 	// If the datastore is accessible from only one segment, return with it.
-	if len(topologySegments) == 1 {
+	if len(topologySegments) == 1 && !params.RequireAllNodesAccessible {
 		return topologySegments, nil
 	}
 
 	// If the selected datastore is preferred in a zone which matches the topology requirement
 	// given by customer, set this zone as the node affinity terms.
-	if common.PreferredDatastoresExist {
+	if common.PreferredDatastoresExist && !params.RequireAllNodesAccessible {
 		// Get the intersection between topology requirements and accessible topology domains for given datastore URL.
 		var combinedAccessibleTopology []map[string]string
 		for _, reqSegments := range params.RequestedTopologySegments {
@@ -0,0 +1,232 @@
+package placementengine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// PlacementStrategyMostFreeSpace prefers the datastores with the most
+	// free space.
+	PlacementStrategyMostFreeSpace = "mostfreespace"
+	// PlacementStrategySpread prefers the datastores with the fewest CNS
+	// volumes.
+	PlacementStrategySpread = "spread"
+	// PlacementStrategyBinPack prefers the datastores with the least free
+	// space which can still fit the volume.
+	PlacementStrategyBinPack = "binpack"
+	// PlacementStrategyWeightedTag prefers the datastores with the highest
+	// weighted tag in the placement tag category.
+	PlacementStrategyWeightedTag = "weightedtag"
+
+	// DefaultPlacementTopN is the number of top scored datastores passed to
+	// CNS when the StorageClass does not specify it.
+	DefaultPlacementTopN = 1
+)
+
+// DatastoreScorer scores the candidate datastores for placing a volume.
+// Datastores with a higher score are preferred.
+type DatastoreScorer interface {
+	// Score returns the score of each of the given datastores keyed by the
+	// datastore URL. Datastores missing in the returned map are not
+	// considered for placement.
+	Score(ctx context.Context, params DatastoreScoringParams,
+		datastores []*cnsvsphere.DatastoreInfo) (map[string]float64, error)
+}
+
+var (
+	datastoreScorersLock sync.RWMutex
+	datastoreScorers     = map[string]DatastoreScorer{
+		PlacementStrategyMostFreeSpace: mostFreeSpaceScorer{},
+		PlacementStrategySpread:        spreadScorer{},
+		PlacementStrategyBinPack:       binPackScorer{},
+		PlacementStrategyWeightedTag:   weightedTagScorer{},
+	}
+)
+
+// RegisterDatastoreScorer registers a scorer under the given strategy name,
+// replacing any scorer already registered with the name.
+func RegisterDatastoreScorer(name string, scorer DatastoreScorer) {
+	datastoreScorersLock.Lock()
+	defer datastoreScorersLock.Unlock()
+	datastoreScorers[name] = scorer
+}
+
+// GetDatastoreScorer returns the scorer registered under the given strategy
+// name.
+func GetDatastoreScorer(name string) (DatastoreScorer, error) {
+	datastoreScorersLock.RLock()
+	defer datastoreScorersLock.RUnlock()
+	scorer, ok := datastoreScorers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown placement strategy %q", name)
+	}
+	return scorer, nil
+}
+
+// SelectTopScoredDatastores scores the given datastores with the scorer and
+// returns the topN datastores with the highest score. Datastores with the
+// same score are ordered by free space.
+func SelectTopScoredDatastores(ctx context.Context, scorer DatastoreScorer, params DatastoreScoringParams,
+	datastores []*cnsvsphere.DatastoreInfo, topN int) ([]*cnsvsphere.DatastoreInfo, error) {
+	log := logger.GetLogger(ctx)
+	scores, err := scorer.Score(ctx, params, datastores)
+	if err != nil {
+		return nil, err
+	}
+	var scored []*cnsvsphere.DatastoreInfo
+	for _, ds := range datastores {
+		if _, ok := scores[ds.Info.Url]; ok {
+			scored = append(scored, ds)
+		}
+	}
+	if len(scored) == 0 {
+		return nil, logger.LogNewErrorf(log, "none of the datastores %+v can be used for placement", datastores)
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scores[scored[i].Info.Url] != scores[scored[j].Info.Url] {
+			return scores[scored[i].Info.Url] > scores[scored[j].Info.Url]
+		}
+		return scored[i].Info.FreeSpace > scored[j].Info.FreeSpace
+	})
+	if topN <= 0 {
+		topN = DefaultPlacementTopN
+	}
+	if len(scored) > topN {
+		scored = scored[:topN]
+	}
+	log.Infof("Top scored datastores selected for volume placement: %+v", scored)
+	return scored, nil
+}
+
+// mostFreeSpaceScorer scores the datastores by their free space.
+type mostFreeSpaceScorer struct{}
+
+func (mostFreeSpaceScorer) Score(ctx context.Context, params DatastoreScoringParams,
+	datastores []*cnsvsphere.DatastoreInfo) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	for _, ds := range datastores {
+		scores[ds.Info.Url] = float64(ds.Info.FreeSpace)
+	}
+	return scores, nil
+}
+
+// binPackScorer scores the datastores by the inverse of their free space,
+// leaving out the datastores which cannot fit the volume.
+type binPackScorer struct{}
+
+func (binPackScorer) Score(ctx context.Context, params DatastoreScoringParams,
+	datastores []*cnsvsphere.DatastoreInfo) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	for _, ds := range datastores {
+		if ds.Info.FreeSpace < params.CapacityMB*common.MbInBytes {
+			continue
+		}
+		scores[ds.Info.Url] = -float64(ds.Info.FreeSpace)
+	}
+	return scores, nil
+}
+
+// spreadScorer scores the datastores by the inverse of the number of CNS
+// volumes placed on them.
+type spreadScorer struct{}
+
+func (spreadScorer) Score(ctx context.Context, params DatastoreScoringParams,
+	datastores []*cnsvsphere.DatastoreInfo) (map[string]float64, error) {
+	log := logger.GetLogger(ctx)
+	if params.VolumeManager == nil {
+		return nil, logger.LogNewErrorf(log, "volume manager is required for placement strategy %q",
+			PlacementStrategySpread)
+	}
+	var dsMoRefs []vimtypes.ManagedObjectReference
+	scores := make(map[string]float64)
+	for _, ds := range datastores {
+		dsMoRefs = append(dsMoRefs, ds.Reference())
+		scores[ds.Info.Url] = 0
+	}
+	queryFilter := cnstypes.CnsQueryFilter{
+		Datastores: dsMoRefs,
+	}
+	querySelection := cnstypes.CnsQuerySelection{
+		Names: []string{string(cnstypes.QuerySelectionNameTypeDataStoreUrl)},
+	}
+	queryResult, err := params.VolumeManager.QueryAllVolume(ctx, queryFilter, querySelection)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to query volumes on datastores %+v. Error: %+v",
+			datastores, err)
+	}
+	for _, volume := range queryResult.Volumes {
+		if _, ok := scores[volume.DatastoreUrl]; ok {
+			scores[volume.DatastoreUrl]--
+		}
+	}
+	return scores, nil
+}
+
+// weightedTagScorer scores the datastores by the weight of the tag attached
+// to them in the placement tag category. Datastores without a weighted tag
+// score 0.
+type weightedTagScorer struct{}
+
+func (weightedTagScorer) Score(ctx context.Context, params DatastoreScoringParams,
+	datastores []*cnsvsphere.DatastoreInfo) (map[string]float64, error) {
+	log := logger.GetLogger(ctx)
+	if params.TagCategory == "" || len(params.TagWeights) == 0 {
+		return nil, logger.LogNewErrorf(log, "tag category and tag weights are required for "+
+			"placement strategy %q", PlacementStrategyWeightedTag)
+	}
+	tagManager, err := cnsvsphere.GetTagManager(ctx, params.Vcenter)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to create tag manager for vCenter %q. Error: %+v",
+			params.Vcenter.Config.Host, err)
+	}
+	defer func() {
+		err := tagManager.Logout(ctx)
+		if err != nil {
+			log.Errorf("failed to logout tagManager. Error: %v", err)
+		}
+	}()
+	category, err := tagManager.GetCategory(ctx, params.TagCategory)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to get tag category %q. Error: %+v",
+			params.TagCategory, err)
+	}
+	var dsMoRefs []mo.Reference
+	urlByMoID := make(map[string]string)
+	scores := make(map[string]float64)
+	for _, ds := range datastores {
+		dsMoRefs = append(dsMoRefs, ds.Reference())
+		urlByMoID[ds.Reference().Value] = ds.Info.Url
+		scores[ds.Info.Url] = 0
+	}
+	attachedTags, err := tagManager.GetAttachedTagsOnObjects(ctx, dsMoRefs)
+	if err != nil {
+		return nil, logger.LogNewErrorf(log, "failed to get tags attached to datastores %+v. Error: %+v",
+			datastores, err)
+	}
+	for _, attached := range attachedTags {
+		url, ok := urlByMoID[attached.ObjectID.Reference().Value]
+		if !ok {
+			continue
+		}
+		for _, tag := range attached.Tags {
+			if tag.CategoryID != category.ID {
+				continue
+			}
+			if weight, ok := params.TagWeights[tag.Name]; ok && float64(weight) > scores[url] {
+				scores[url] = float64(weight)
+			}
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,104 @@
+package placementengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+)
+
+func newTestDatastoreInfo(name string, freeSpaceMB int64) *cnsvsphere.DatastoreInfo {
+	return &cnsvsphere.DatastoreInfo{
+		Datastore: &cnsvsphere.Datastore{
+			Datastore: object.NewDatastore(nil, vimtypes.ManagedObjectReference{Type: "Datastore", Value: name}),
+		},
+		Info: &vimtypes.DatastoreInfo{
+			Name:      name,
+			Url:       "ds:///vmfs/volumes/" + name + "/",
+			FreeSpace: freeSpaceMB * common.MbInBytes,
+		},
+	}
+}
+
+func getDatastoreNames(datastores []*cnsvsphere.DatastoreInfo) []string {
+	var names []string
+	for _, ds := range datastores {
+		names = append(names, ds.Info.Name)
+	}
+	return names
+}
+
+func TestSelectTopScoredDatastores(t *testing.T) {
+	ctx := context.Background()
+	datastores := []*cnsvsphere.DatastoreInfo{
+		newTestDatastoreInfo("ds-1", 100),
+		newTestDatastoreInfo("ds-2", 500),
+		newTestDatastoreInfo("ds-3", 300),
+		newTestDatastoreInfo("ds-4", 50),
+	}
+	tests := []struct {
+		name     string
+		strategy string
+		topN     int
+		expected []string
+	}{
+		{
+			name:     "most free space",
+			strategy: PlacementStrategyMostFreeSpace,
+			topN:     2,
+			expected: []string{"ds-2", "ds-3"},
+		},
+		{
+			name:     "most free space with default topN",
+			strategy: PlacementStrategyMostFreeSpace,
+			expected: []string{"ds-2"},
+		},
+		{
+			name:     "bin-pack skips datastores which cannot fit the volume",
+			strategy: PlacementStrategyBinPack,
+			topN:     2,
+			expected: []string{"ds-1", "ds-3"},
+		},
+		{
+			name:     "topN larger than the number of datastores",
+			strategy: PlacementStrategyBinPack,
+			topN:     10,
+			expected: []string{"ds-1", "ds-3", "ds-2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scorer, err := GetDatastoreScorer(test.strategy)
+			if err != nil {
+				t.Fatalf("failed to get scorer for strategy %q. Error: %v", test.strategy, err)
+			}
+			selected, err := SelectTopScoredDatastores(ctx, scorer, DatastoreScoringParams{CapacityMB: 80},
+				datastores, test.topN)
+			if err != nil {
+				t.Fatalf("failed to select datastores. Error: %v", err)
+			}
+			names := getDatastoreNames(selected)
+			if len(names) != len(test.expected) {
+				t.Fatalf("expected datastores %v, got %v", test.expected, names)
+			}
+			for i := range names {
+				if names[i] != test.expected[i] {
+					t.Fatalf("expected datastores %v, got %v", test.expected, names)
+				}
+			}
+		})
+	}
+
+	scorer, _ := GetDatastoreScorer(PlacementStrategyBinPack)
+	_, err := SelectTopScoredDatastores(ctx, scorer, DatastoreScoringParams{CapacityMB: 1000}, datastores, 1)
+	if err == nil {
+		t.Errorf("expected error when no datastore can fit the volume")
+	}
+	if _, err = GetDatastoreScorer("unknown"); err == nil {
+		t.Errorf("expected error for unknown placement strategy")
+	}
+}
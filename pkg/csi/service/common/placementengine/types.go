@@ -1,6 +1,9 @@
 package placementengine
 
-import cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+import (
+	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+)
 
 // VanillaRetrieveTopologyInfoParams represents the params
 // required to be able to call GetTopologyInfoFromNodes in
@@ -18,6 +21,9 @@ type VanillaRetrieveTopologyInfoParams struct {
 	// RequestedTopologySegments represents the topology segments
 	// which need to be satisfied during volume provisioning for a particular VC.
 	RequestedTopologySegments []map[string]string
+	// RequireAllNodesAccessible restricts the topology information to the
+	// segments in which all the nodes have access to the selected datastore.
+	RequireAllNodesAccessible bool
 }
 
 // VanillaSharedDatastoresParams represents the params
@@ -33,3 +39,22 @@ type VanillaSharedDatastoresParams struct {
 	// name given in the Storage Class on the attempted VC.
 	StoragePolicyID string
 }
+
+// DatastoreScoringParams represents the params required
+// by a DatastoreScorer to score the candidate datastores.
+type DatastoreScoringParams struct {
+	// Vcenter holds the client connection to the VC on
+	// which volume provisioning is being attempted.
+	Vcenter *cnsvsphere.VirtualCenter
+	// VolumeManager is the volume manager of the VC, used to
+	// count the volumes placed on the candidate datastores.
+	VolumeManager cnsvolume.Manager
+	// CapacityMB is the size of the volume to be placed.
+	CapacityMB int64
+	// TagCategory is the name of the tag category whose tags
+	// weigh the candidate datastores.
+	TagCategory string
+	// TagWeights maps the names of the tags in TagCategory
+	// to their weights.
+	TagWeights map[string]int64
+}
@@ -93,6 +93,25 @@ type StorageClassParams struct {
 	StoragePolicyName string
 	CSIMigration      string
 	Datastore         string
+	// HostLocal is set when the volume is to be placed on a datastore local
+	// to the ESXi host of the node selected for the volume.
+	HostLocal bool
+	// DatastoreCluster is the name of the datastore cluster the volume is to
+	// be placed on. It cannot be specified together with DatastoreURL.
+	DatastoreCluster string
+	// DatastoreClusterID is the managed object ID of the datastore cluster
+	// the volume is to be placed on. It cannot be specified together with
+	// DatastoreCluster or DatastoreURL.
+	DatastoreClusterID string
+	// PlacementStrategy is the name of the strategy used to score the
+	// candidate datastores of the volume.
+	PlacementStrategy string
+	// PlacementTopN is the number of top scored datastores passed to CNS.
+	PlacementTopN int
+	// PlacementTagCategory and PlacementTagWeights weigh the candidate
+	// datastores by the tags attached to them.
+	PlacementTagCategory string
+	PlacementTagWeights  map[string]int64
 }
 
 type CryptoKeyID struct {
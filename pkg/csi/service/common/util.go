@@ -49,6 +49,10 @@ const (
 
 var ErrAvailabilityZoneCRNotRegistered = errors.New("AvailabilityZone custom resource not registered")
 
+// datastoreClusterIDRegex matches the managed object ID of a datastore
+// cluster given in the datastoreclusterid StorageClass parameter.
+var datastoreClusterIDRegex = regexp.MustCompile(`^group-p[0-9]+$`)
+
 // getVCenterInternal is the internal implementation that can be overridden for testing
 var getVCenterInternal = func(ctx context.Context, manager *Manager) (*cnsvsphere.VirtualCenter, error) {
 	var err error
@@ -170,6 +174,34 @@ func IsFileVolumeRequest(ctx context.Context, capabilities []*csi.VolumeCapabili
 	return false
 }
 
+// IsMultiWriterBlockVolumeRequest checks whether the request is for a raw
+// block volume written from multiple nodes.
+func IsMultiWriterBlockVolumeRequest(ctx context.Context, capabilities []*csi.VolumeCapability) bool {
+	for _, capability := range capabilities {
+		if capability.AccessMode.Mode == csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER &&
+			capability.GetBlock() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMultiWriterBlockVolumeCapabilities validates the given volume
+// capabilities of a multi-writer raw block volume.
+func IsValidMultiWriterBlockVolumeCapabilities(ctx context.Context, volCaps []*csi.VolumeCapability) error {
+	for _, volCap := range volCaps {
+		if volCap.GetBlock() == nil {
+			return fmt.Errorf("only block volume mode is supported for multi-writer block volumes")
+		}
+		if volCap.AccessMode.GetMode() != csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER &&
+			volCap.AccessMode.GetMode() != csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER {
+			return fmt.Errorf("%s access mode is not supported for multi-writer block volumes",
+				csi.VolumeCapability_AccessMode_Mode_name[int32(volCap.AccessMode.GetMode())])
+		}
+	}
+	return nil
+}
+
 // IsVolumeReadOnly checks the access mode in Volume Capability and decides
 // if volume is readonly or not.
 func IsVolumeReadOnly(capability *csi.VolumeCapability) bool {
@@ -253,10 +285,29 @@ func ParseStorageClassParams(ctx context.Context, params map[string]string,
 			param = strings.ToLower(param)
 			if param == AttributeDatastoreURL {
 				scParams.DatastoreURL = value
+			} else if param == AttributeDatastoreCluster {
+				scParams.DatastoreCluster = value
+			} else if param == AttributeDatastoreClusterID {
+				if !datastoreClusterIDRegex.MatchString(value) {
+					return nil, fmt.Errorf("invalid param: %q and value: %q. The datastore cluster ID must "+
+						"be its managed object ID, e.g. \"group-p1001\"", param, value)
+				}
+				scParams.DatastoreClusterID = value
+			} else if isPlacementParam(param) {
+				err := parsePlacementParam(scParams, param, value)
+				if err != nil {
+					return nil, err
+				}
 			} else if param == AttributeStoragePolicyName {
 				scParams.StoragePolicyName = value
 			} else if param == AttributeFsType {
 				log.Warnf("param 'fstype' is deprecated, please use 'csi.storage.k8s.io/fstype' instead")
+			} else if param == AttributeHostLocal {
+				hostLocal, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid param: %q and value: %q", param, value)
+				}
+				scParams.HostLocal = hostLocal
 			} else {
 				return nil, fmt.Errorf("invalid param: %q and value: %q", param, value)
 			}
@@ -267,12 +318,31 @@ func ParseStorageClassParams(ctx context.Context, params map[string]string,
 			param = strings.ToLower(param)
 			if param == AttributeDatastoreURL {
 				scParams.DatastoreURL = value
+			} else if param == AttributeDatastoreCluster {
+				scParams.DatastoreCluster = value
+			} else if param == AttributeDatastoreClusterID {
+				if !datastoreClusterIDRegex.MatchString(value) {
+					return nil, fmt.Errorf("invalid param: %q and value: %q. The datastore cluster ID must "+
+						"be its managed object ID, e.g. \"group-p1001\"", param, value)
+				}
+				scParams.DatastoreClusterID = value
+			} else if isPlacementParam(param) {
+				err := parsePlacementParam(scParams, param, value)
+				if err != nil {
+					return nil, err
+				}
 			} else if param == AttributeStoragePolicyName {
 				scParams.StoragePolicyName = value
 			} else if param == AttributeFsType {
 				log.Warnf("param 'fstype' is deprecated, please use 'csi.storage.k8s.io/fstype' instead")
 			} else if param == CSIMigrationParams {
 				scParams.CSIMigration = value
+			} else if param == AttributeHostLocal {
+				hostLocal, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid param: %q and value: %q", param, value)
+				}
+				scParams.HostLocal = hostLocal
 			} else {
 				otherParams[param] = value
 			}
@@ -301,9 +371,78 @@ func ParseStorageClassParams(ctx context.Context, params map[string]string,
 			}
 		}
 	}
+	if scParams.DatastoreCluster != "" && scParams.DatastoreClusterID != "" {
+		return nil, fmt.Errorf("parameters %q and %q cannot be specified together",
+			AttributeDatastoreCluster, AttributeDatastoreClusterID)
+	}
+	if scParams.DatastoreClusterID != "" && (scParams.DatastoreURL != "" || scParams.Datastore != "") {
+		return nil, fmt.Errorf("parameters %q and %q cannot be specified together",
+			AttributeDatastoreClusterID, AttributeDatastoreURL)
+	}
+	if scParams.DatastoreClusterID != "" && scParams.HostLocal {
+		return nil, fmt.Errorf("parameters %q and %q cannot be specified together",
+			AttributeDatastoreClusterID, AttributeHostLocal)
+	}
+	if scParams.DatastoreCluster != "" && (scParams.DatastoreURL != "" || scParams.Datastore != "") {
+		return nil, fmt.Errorf("parameters %q and %q cannot be specified together",
+			AttributeDatastoreCluster, AttributeDatastoreURL)
+	}
+	if scParams.DatastoreCluster != "" && scParams.HostLocal {
+		return nil, fmt.Errorf("parameters %q and %q cannot be specified together",
+			AttributeDatastoreCluster, AttributeHostLocal)
+	}
+	if scParams.PlacementStrategy == "" && (scParams.PlacementTopN != 0 ||
+		scParams.PlacementTagCategory != "" || len(scParams.PlacementTagWeights) != 0) {
+		return nil, fmt.Errorf("parameter %q is required with the other placement parameters",
+			AttributePlacementStrategy)
+	}
+	if scParams.PlacementStrategy != "" && (scParams.DatastoreURL != "" || scParams.Datastore != "" ||
+		scParams.DatastoreCluster != "" || scParams.DatastoreClusterID != "" || scParams.HostLocal) {
+		return nil, fmt.Errorf("parameter %q cannot be specified together with %q, %q, %q or %q",
+			AttributePlacementStrategy, AttributeDatastoreURL, AttributeDatastoreCluster,
+			AttributeDatastoreClusterID, AttributeHostLocal)
+	}
 	return scParams, nil
 }
 
+// isPlacementParam returns true if the given StorageClass parameter
+// configures the scoring of the candidate datastores.
+func isPlacementParam(param string) bool {
+	return param == AttributePlacementStrategy || param == AttributePlacementTopN ||
+		param == AttributePlacementTagCategory || param == AttributePlacementTagWeights
+}
+
+// parsePlacementParam parses the given placement parameter into scParams.
+func parsePlacementParam(scParams *StorageClassParams, param string, value string) error {
+	switch param {
+	case AttributePlacementStrategy:
+		scParams.PlacementStrategy = strings.ToLower(strings.TrimSpace(value))
+	case AttributePlacementTopN:
+		topN, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || topN <= 0 {
+			return fmt.Errorf("invalid param: %q and value: %q", param, value)
+		}
+		scParams.PlacementTopN = topN
+	case AttributePlacementTagCategory:
+		scParams.PlacementTagCategory = value
+	case AttributePlacementTagWeights:
+		scParams.PlacementTagWeights = make(map[string]int64)
+		for _, pair := range strings.Split(value, ",") {
+			tag, weight, found := strings.Cut(pair, "=")
+			tag = strings.TrimSpace(tag)
+			if !found || tag == "" {
+				return fmt.Errorf("invalid param: %q and value: %q", param, value)
+			}
+			parsedWeight, err := strconv.ParseInt(strings.TrimSpace(weight), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid param: %q and value: %q", param, value)
+			}
+			scParams.PlacementTagWeights[tag] = parsedWeight
+		}
+	}
+	return nil
+}
+
 // GetK8sCloudOperatorServicePort return the port to connect the
 // K8sCloudOperator gRPC service.
 // If environment variable POD_LISTENER_SERVICE_PORT is set and valid,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
@@ -36,6 +37,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
 )
 
 var (
@@ -622,3 +625,200 @@ func TestGetClusterComputeResourceMoIds_SingleClusterPerAZ(t *testing.T) {
 	gomega.Expect(multiple).To(gomega.BeFalse())
 	gomega.Expect(moIDs).To(gomega.ContainElements("domain-c1", "domain-c2"))
 }
+
+func TestParseStorageClassParamsWithHostLocal(t *testing.T) {
+	for _, csiMigrationFeatureState := range []bool{false, true} {
+		params := map[string]string{
+			AttributeStoragePolicyName: "policy1",
+			AttributeHostLocal:         "True",
+		}
+		scParams, err := ParseStorageClassParams(ctx, params, csiMigrationFeatureState)
+		if err != nil {
+			t.Fatalf("failed to parse params: %+v, err: %+v", params, err)
+		}
+		if !scParams.HostLocal || scParams.StoragePolicyName != "policy1" {
+			t.Errorf("Expected HostLocal to be set with storage policy %q. Actual: %+v", "policy1", scParams)
+		}
+
+		params[AttributeHostLocal] = "yes"
+		scParams, err = ParseStorageClassParams(ctx, params, csiMigrationFeatureState)
+		if err == nil {
+			t.Errorf("error expected but not received. scParam received from ParseStorageClassParams: %v",
+				scParams)
+		}
+	}
+}
+
+func TestParseStorageClassParamsWithDatastoreCluster(t *testing.T) {
+	for _, csiMigrationFeatureState := range []bool{false, true} {
+		params := map[string]string{
+			AttributeStoragePolicyName: "policy1",
+			AttributeDatastoreCluster:  "DatastoreCluster1",
+		}
+		scParams, err := ParseStorageClassParams(ctx, params, csiMigrationFeatureState)
+		if err != nil {
+			t.Fatalf("failed to parse params: %+v, err: %+v", params, err)
+		}
+		if scParams.DatastoreCluster != "DatastoreCluster1" || scParams.StoragePolicyName != "policy1" {
+			t.Errorf("Expected DatastoreCluster %q with storage policy %q. Actual: %+v", "DatastoreCluster1",
+				"policy1", scParams)
+		}
+
+		for param, value := range map[string]string{
+			AttributeDatastoreURL: "ds:///vmfs/volumes/vsan:52cdfa80721ff516-ea1e993113acfc77/",
+			AttributeHostLocal:    "true",
+		} {
+			invalidParams := map[string]string{
+				AttributeDatastoreCluster: "DatastoreCluster1",
+				param:                     value,
+			}
+			scParams, err = ParseStorageClassParams(ctx, invalidParams, csiMigrationFeatureState)
+			if err == nil {
+				t.Errorf("error expected but not received for params %+v. scParams received: %+v",
+					invalidParams, scParams)
+			}
+		}
+	}
+}
+
+func TestParseStorageClassParamsWithDatastoreClusterID(t *testing.T) {
+	for _, csiMigrationFeatureState := range []bool{false, true} {
+		params := map[string]string{
+			AttributeStoragePolicyName:  "policy1",
+			AttributeDatastoreClusterID: "group-p1001",
+		}
+		scParams, err := ParseStorageClassParams(ctx, params, csiMigrationFeatureState)
+		if err != nil {
+			t.Fatalf("failed to parse params: %+v, err: %+v", params, err)
+		}
+		if scParams.DatastoreClusterID != "group-p1001" || scParams.DatastoreCluster != "" {
+			t.Errorf("Expected DatastoreClusterID %q. Actual: %+v", "group-p1001", scParams)
+		}
+
+		for _, invalidParams := range []map[string]string{
+			{AttributeDatastoreClusterID: "DatastoreCluster1"},
+			{AttributeDatastoreClusterID: "ds:///vmfs/volumes/vsan:52cdfa80721ff516-ea1e993113acfc77/"},
+			{AttributeDatastoreClusterID: "group-p1001", AttributeDatastoreCluster: "DatastoreCluster1"},
+			{AttributeDatastoreClusterID: "group-p1001",
+				AttributeDatastoreURL: "ds:///vmfs/volumes/vsan:52cdfa80721ff516-ea1e993113acfc77/"},
+			{AttributeDatastoreClusterID: "group-p1001", AttributeHostLocal: "true"},
+			{AttributeDatastoreClusterID: "group-p1001", AttributePlacementStrategy: "spread"},
+		} {
+			scParams, err = ParseStorageClassParams(ctx, invalidParams, csiMigrationFeatureState)
+			if err == nil {
+				t.Errorf("error expected but not received for params %+v. scParams received: %+v",
+					invalidParams, scParams)
+			}
+		}
+	}
+}
+
+func TestParseStorageClassParamsWithPlacementStrategy(t *testing.T) {
+	for _, csiMigrationFeatureState := range []bool{false, true} {
+		params := map[string]string{
+			AttributeStoragePolicyName:    "policy1",
+			AttributePlacementStrategy:    "WeightedTag",
+			AttributePlacementTopN:        "2",
+			AttributePlacementTagCategory: "storage-tier",
+			AttributePlacementTagWeights:  "gold=3, silver=2",
+		}
+		scParams, err := ParseStorageClassParams(ctx, params, csiMigrationFeatureState)
+		if err != nil {
+			t.Fatalf("failed to parse params: %+v, err: %+v", params, err)
+		}
+		expectedWeights := map[string]int64{"gold": 3, "silver": 2}
+		if scParams.PlacementStrategy != "weightedtag" || scParams.PlacementTopN != 2 ||
+			scParams.PlacementTagCategory != "storage-tier" ||
+			!reflect.DeepEqual(scParams.PlacementTagWeights, expectedWeights) {
+			t.Errorf("unexpected placement params parsed from %+v: %+v", params, scParams)
+		}
+
+		for _, invalidParams := range []map[string]string{
+			{AttributePlacementStrategy: "spread", AttributePlacementTopN: "0"},
+			{AttributePlacementStrategy: "spread", AttributePlacementTopN: "two"},
+			{AttributePlacementStrategy: "weightedtag", AttributePlacementTagWeights: "gold"},
+			{AttributePlacementStrategy: "weightedtag", AttributePlacementTagWeights: "gold=high"},
+			{AttributePlacementTopN: "2"},
+			{AttributePlacementStrategy: "spread", AttributeDatastoreCluster: "DatastoreCluster1"},
+			{AttributePlacementStrategy: "spread", AttributeHostLocal: "true"},
+		} {
+			scParams, err = ParseStorageClassParams(ctx, invalidParams, csiMigrationFeatureState)
+			if err == nil {
+				t.Errorf("error expected but not received for params %+v. scParams received: %+v",
+					invalidParams, scParams)
+			}
+		}
+	}
+}
+
+func TestMultiWriterBlockVolumeCapabilities(t *testing.T) {
+	blockCap := func(mode csi.VolumeCapability_AccessMode_Mode) *csi.VolumeCapability {
+		return &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: mode},
+		}
+	}
+	mountCap := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: "ext4"}},
+		AccessMode: &csi.VolumeCapability_AccessMode{
+			Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER,
+		},
+	}
+	multiWriterCap := blockCap(csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER)
+
+	volCaps := []*csi.VolumeCapability{multiWriterCap}
+	if !IsMultiWriterBlockVolumeRequest(ctx, volCaps) {
+		t.Errorf("VolCap = %+v not reported as a multi-writer block volume", volCaps)
+	}
+	if err := IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps); err != nil {
+		t.Errorf("VolCap = %+v reported as invalid. Err: %+v", volCaps, err)
+	}
+	volCaps = []*csi.VolumeCapability{multiWriterCap, blockCap(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER)}
+	if err := IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps); err != nil {
+		t.Errorf("VolCap = %+v reported as invalid. Err: %+v", volCaps, err)
+	}
+
+	for _, volCaps := range [][]*csi.VolumeCapability{
+		{mountCap},
+		{blockCap(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER)},
+		{blockCap(csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY)},
+	} {
+		if IsMultiWriterBlockVolumeRequest(ctx, volCaps) {
+			t.Errorf("VolCap = %+v reported as a multi-writer block volume", volCaps)
+		}
+	}
+	for _, volCaps := range [][]*csi.VolumeCapability{
+		{multiWriterCap, mountCap},
+		{multiWriterCap, blockCap(csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY)},
+	} {
+		if err := IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps); err == nil {
+			t.Errorf("VolCap = %+v reported as valid for a multi-writer block volume", volCaps)
+		}
+	}
+}
+
+func TestIsEagerZeroedThickPolicy(t *testing.T) {
+	policy := func(ns, capID, value string) cnsvsphere.SpbmPolicyContent {
+		return cnsvsphere.SpbmPolicyContent{
+			Profiles: []cnsvsphere.SpbmPolicySubProfile{
+				{Rules: []cnsvsphere.SpbmPolicyRule{{Ns: ns, CapID: capID, Value: value}}},
+			},
+		}
+	}
+	tests := []struct {
+		policy   cnsvsphere.SpbmPolicyContent
+		expected bool
+	}{
+		{policy(vsanNamespace, vsanProportionalCapacity, "100"), true},
+		{policy(vsanNamespace, vsanProportionalCapacity, "50"), false},
+		{policy(volumeAllocationNamespace, volumeAllocationType, "Fully Initialized"), true},
+		{policy(volumeAllocationNamespace, volumeAllocationType, "Conserve space when possible"), false},
+		{policy("VSAN", "hostFailuresToTolerate", "1"), false},
+		{cnsvsphere.SpbmPolicyContent{}, false},
+	}
+	for _, test := range tests {
+		if IsEagerZeroedThickPolicy(test.policy) != test.expected {
+			t.Errorf("IsEagerZeroedThickPolicy(%+v) expected to return %v", test.policy, test.expected)
+		}
+	}
+}
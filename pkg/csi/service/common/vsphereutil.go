@@ -751,6 +751,44 @@ func AttachVolumeUtil(ctx context.Context, volumeManager cnsvolume.Manager,
 	return diskUUID, "", err
 }
 
+// AttachMultiWriterVolumeUtil is the helper function to attach CNS volume to
+// specified vm with the multi-writer sharing mode, so that the volume can be
+// attached to other VMs at the same time.
+func AttachMultiWriterVolumeUtil(ctx context.Context, volumeManager cnsvolume.Manager,
+	vm *vsphere.VirtualMachine, volumeID string, checkNVMeController bool) (string, string, error) {
+	log := logger.GetLogger(ctx)
+	log.Debugf("vSphere CSI driver is attaching volume: %q to vm: %q with multi-writer sharing mode",
+		volumeID, vm.String())
+	diskUUID, err := cnsvolume.IsDiskAttached(ctx, vm, volumeID, checkNVMeController)
+	if err != nil {
+		return "", csifault.CSIInternalFault, err
+	}
+	if diskUUID != "" {
+		log.Infof("Volume %q is already attached to VM %q. Disk UUID is %s", volumeID, vm.String(), diskUUID)
+		return diskUUID, "", nil
+	}
+	results, faultType, err := volumeManager.BatchAttachVolumes(ctx, vm, []cnsvolume.BatchAttachRequest{
+		{
+			VolumeID:    volumeID,
+			SharingMode: string(vim25types.VirtualDiskSharingSharingMultiWriter),
+			DiskMode:    string(vim25types.VirtualDiskModePersistent),
+		},
+	})
+	if len(results) == 1 && results[0].Error != nil {
+		faultType, err = results[0].FaultType, results[0].Error
+	}
+	if err != nil {
+		log.Errorf("failed to attach disk %q with VM: %q. err: %+v faultType %q", volumeID, vm.String(), err, faultType)
+		return "", faultType, err
+	}
+	if len(results) != 1 {
+		return "", csifault.CSIInternalFault, logger.LogNewErrorf(log,
+			"unexpected number of results %d attaching volume %q to VM %q", len(results), volumeID, vm.String())
+	}
+	log.Debugf("Successfully attached disk %s to VM %v. Disk UUID is %s", volumeID, vm, results[0].DiskUUID)
+	return results[0].DiskUUID, "", nil
+}
+
 // DetachVolumeUtil is the helper function to detach CNS volume from specified
 // vm.
 func DetachVolumeUtil(ctx context.Context, volumeManager cnsvolume.Manager,
@@ -20,18 +20,23 @@ import (
 	"context"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
 	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
 )
 
 // Version of the driver. This should be set via ldflags.
 var Version string
 
+// Probe only reports that the driver process is serving. It is used by the
+// livenessprobe sidecar, so it does not depend on vCenter or the informers,
+// whose health is exposed on the /readyz endpoint instead.
 func (driver *vsphereCSIDriver) Probe(
 	ctx context.Context,
 	req *csi.ProbeRequest) (
 	*csi.ProbeResponse, error) {
 
-	return &csi.ProbeResponse{}, nil
+	return &csi.ProbeResponse{Ready: wrapperspb.Bool(true)}, nil
 }
 
 func (driver *vsphereCSIDriver) GetPluginInfo(
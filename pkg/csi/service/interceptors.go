@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"os"
+	"path"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+)
+
+const (
+	// strippedSecret replaces the values of the secrets in the logged requests.
+	strippedSecret = "***stripped***"
+	// defaultCoalescedCallTimeout bounds the coalesced calls to the driver of
+	// the requests without a deadline.
+	defaultCoalescedCallTimeout = 5 * time.Minute
+)
+
+// coalescedMethods are the RPCs whose duplicate in-flight requests are
+// served by a single call to the driver. The external sidecars retry these
+// RPCs with identical requests when a previous attempt times out.
+var coalescedMethods = map[string]struct{}{
+	"CreateVolume":              {},
+	"DeleteVolume":              {},
+	"ControllerPublishVolume":   {},
+	"ControllerUnpublishVolume": {},
+	"ControllerExpandVolume":    {},
+	"CreateSnapshot":            {},
+	"DeleteSnapshot":            {},
+}
+
+// newUnaryInterceptors returns the chain of interceptors of the CSI server.
+// Requests are logged first, then duplicate in-flight requests are
+// coalesced before waiting for a concurrency slot, and panics are recovered
+// around the call to the driver.
+func newUnaryInterceptors(ctx context.Context) []grpc.UnaryServerInterceptor {
+	limiter := newConcurrencyLimiter(getMaxConcurrentRequests(ctx))
+	coalescer := &requestCoalescer{methods: coalescedMethods}
+	return []grpc.UnaryServerInterceptor{
+		logInterceptor,
+		coalescer.intercept,
+		limiter.intercept,
+		recoveryInterceptor,
+	}
+}
+
+// logInterceptor logs every request with its secrets stripped, and the
+// response or error returned for it.
+func logInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	log := logger.GetLogger(ctx)
+	start := time.Now()
+	log.Debugf("GRPC call: %s, request: %s", info.FullMethod, stripSecrets(req))
+	resp, err := handler(ctx, req)
+	if err != nil {
+		log.Infof("GRPC call: %s failed after %v. Error: %v", info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+	log.Debugf("GRPC call: %s succeeded after %v, response: %s", info.FullMethod, time.Since(start),
+		stripSecrets(resp))
+	return resp, nil
+}
+
+// recoveryInterceptor turns a panic raised while handling a request into a
+// codes.Internal error instead of crashing the driver.
+func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log := logger.GetLogger(ctx)
+			log.Errorf("GRPC call: %s panicked: %v\n%s", info.FullMethod, r, debug.Stack())
+			resp = nil
+			err = status.Errorf(codes.Internal, "panic while handling %s: %v", info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// concurrencyLimiter bounds the number of requests handled in parallel for
+// each RPC.
+type concurrencyLimiter struct {
+	// slots maps the name of an RPC to a channel with one buffered slot per
+	// request allowed in parallel.
+	slots map[string]chan struct{}
+}
+
+// newConcurrencyLimiter returns a concurrencyLimiter enforcing the given
+// limits, keyed by RPC name.
+func newConcurrencyLimiter(limits map[string]int) *concurrencyLimiter {
+	limiter := &concurrencyLimiter{slots: make(map[string]chan struct{})}
+	for method, limit := range limits {
+		limiter.slots[method] = make(chan struct{}, limit)
+	}
+	return limiter
+}
+
+// intercept waits for a free slot of the RPC before handling the request.
+// codes.DeadlineExceeded or codes.Canceled is returned if the request times
+// out or is cancelled while waiting, so that the CO retries it.
+func (l *concurrencyLimiter) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	slots, ok := l.slots[path.Base(info.FullMethod)]
+	if !ok {
+		return handler(ctx, req)
+	}
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, status.Errorf(status.FromContextError(ctx.Err()).Code(),
+			"%s request ended while waiting for one of %d concurrent requests to complete: %v",
+			path.Base(info.FullMethod), cap(slots), ctx.Err())
+	}
+	defer func() { <-slots }()
+	return handler(ctx, req)
+}
+
+// getMaxConcurrentRequests returns the per-RPC concurrency limits set in
+// the X_CSI_MAX_CONCURRENT_REQUESTS env variable. Invalid entries are
+// ignored.
+func getMaxConcurrentRequests(ctx context.Context) map[string]int {
+	log := logger.GetLogger(ctx)
+	limits := make(map[string]int)
+	v := os.Getenv(csitypes.EnvVarMaxConcurrentRequests)
+	if v == "" {
+		return limits
+	}
+	for _, entry := range strings.Split(v, ",") {
+		method, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if !found || strings.TrimSpace(method) == "" || err != nil || limit <= 0 {
+			log.Warnf("ignoring invalid entry %q in env variable %s", entry,
+				csitypes.EnvVarMaxConcurrentRequests)
+			continue
+		}
+		limits[strings.TrimSpace(method)] = limit
+	}
+	log.Infof("%s is set to %v", csitypes.EnvVarMaxConcurrentRequests, limits)
+	return limits
+}
+
+// requestCoalescer serves in-flight requests of the given RPCs for the same
+// volume or snapshot with a single call to the driver.
+type requestCoalescer struct {
+	group   singleflight.Group
+	methods map[string]struct{}
+}
+
+// intercept joins the in-flight call handling a request for the same volume
+// or snapshot as req, or starts one. The call is not cancelled when the
+// request which started it is, so that a retried request can pick up its
+// result, but it is bounded by the deadline of that request.
+func (c *requestCoalescer) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if _, ok := c.methods[path.Base(info.FullMethod)]; !ok {
+		return handler(ctx, req)
+	}
+	reqKey, ok := getCoalescingKey(req)
+	if !ok {
+		return handler(ctx, req)
+	}
+	timeout := defaultCoalescedCallTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	key := info.FullMethod + "/" + reqKey
+	resultCh := c.group.DoChan(key, func() (interface{}, error) {
+		callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		return handler(callCtx, req)
+	})
+	select {
+	case result := <-resultCh:
+		if result.Shared {
+			log := logger.GetLogger(ctx)
+			log.Infof("GRPC call: %s was coalesced with in-flight requests for %s", info.FullMethod, reqKey)
+		}
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// getCoalescingKey returns the key of the in-flight requests coalesced with
+// req: the name or ID of its volume or snapshot, and the node it is published
+// to. Retries of a request whose other parameters differ are coalesced.
+func getCoalescingKey(req interface{}) (string, bool) {
+	var key string
+	switch r := req.(type) {
+	case *csi.CreateVolumeRequest:
+		key = r.GetName()
+	case *csi.DeleteVolumeRequest:
+		key = r.GetVolumeId()
+	case *csi.ControllerPublishVolumeRequest:
+		key = r.GetVolumeId() + "/" + r.GetNodeId()
+	case *csi.ControllerUnpublishVolumeRequest:
+		key = r.GetVolumeId() + "/" + r.GetNodeId()
+	case *csi.ControllerExpandVolumeRequest:
+		key = r.GetVolumeId()
+	case *csi.CreateSnapshotRequest:
+		key = r.GetName()
+	case *csi.DeleteSnapshotRequest:
+		key = r.GetSnapshotId()
+	}
+	return key, key != "" && key != "/"
+}
+
+// stripSecrets returns the text representation of the given message with
+// the values of the fields marked as CSI secrets stripped.
+func stripSecrets(msg interface{}) string {
+	protoMsg, ok := msg.(proto.Message)
+	if !ok || protoMsg == nil || !protoMsg.ProtoReflect().IsValid() {
+		return "<nil>"
+	}
+	clone := proto.Clone(protoMsg)
+	stripMessageSecrets(clone.ProtoReflect())
+	return prototext.MarshalOptions{}.Format(clone)
+}
+
+// stripMessageSecrets replaces the values of the secret fields of msg and
+// of its nested messages.
+func stripMessageSecrets(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSecretField(fd) {
+			if fd.IsMap() {
+				var keys []protoreflect.MapKey
+				v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+					keys = append(keys, k)
+					return true
+				})
+				for _, k := range keys {
+					v.Map().Set(k, protoreflect.ValueOfString(strippedSecret))
+				}
+			}
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					stripMessageSecrets(mv.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					stripMessageSecrets(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			stripMessageSecrets(v.Message())
+		}
+		return true
+	})
+}
+
+// isSecretField returns true if the field is marked with the csi_secret
+// option.
+func isSecretField(fd protoreflect.FieldDescriptor) bool {
+	secret, ok := proto.GetExtension(fd.Options(), csi.E_CsiSecret).(bool)
+	return ok && secret
+}
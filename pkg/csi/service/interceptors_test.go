@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+)
+
+const createVolumeMethod = "/csi.v1.Controller/CreateVolume"
+
+func TestStripSecrets(t *testing.T) {
+	req := &csi.CreateVolumeRequest{
+		Name:       "pvc-1",
+		Secrets:    map[string]string{"password": "hunter2"},
+		Parameters: map[string]string{"storagepolicyname": "gold"},
+	}
+	stripped := stripSecrets(req)
+	assert.NotContains(t, stripped, "hunter2")
+	assert.Contains(t, stripped, strippedSecret)
+	assert.Contains(t, stripped, "gold")
+	// The request itself must be left untouched.
+	assert.Equal(t, "hunter2", req.Secrets["password"])
+	assert.Equal(t, "<nil>", stripSecrets((*csi.CreateVolumeResponse)(nil)))
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: createVolumeMethod}
+	_, err := recoveryInterceptor(context.Background(), &csi.CreateVolumeRequest{}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("nil pointer dereference")
+		})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	limiter := newConcurrencyLimiter(map[string]int{"CreateVolume": 1})
+	info := &grpc.UnaryServerInfo{FullMethod: createVolumeMethod}
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_, _ = limiter.intercept(context.Background(), &csi.CreateVolumeRequest{}, info,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				close(started)
+				<-release
+				return nil, nil
+			})
+	}()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := limiter.intercept(ctx, &csi.CreateVolumeRequest{}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	close(release)
+
+	// RPCs without a limit are not throttled.
+	_, err = limiter.intercept(context.Background(), &csi.DeleteVolumeRequest{},
+		&grpc.UnaryServerInfo{FullMethod: "/csi.v1.Controller/DeleteVolume"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	assert.NoError(t, err)
+}
+
+func TestGetMaxConcurrentRequests(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, getMaxConcurrentRequests(ctx))
+	t.Setenv(csitypes.EnvVarMaxConcurrentRequests, "CreateVolume=10, DeleteVolume = 5,Expand=0,Publish")
+	assert.Equal(t, map[string]int{"CreateVolume": 10, "DeleteVolume": 5}, getMaxConcurrentRequests(ctx))
+}
+
+func TestRequestCoalescer(t *testing.T) {
+	coalescer := &requestCoalescer{methods: coalescedMethods}
+	info := &grpc.UnaryServerInfo{FullMethod: createVolumeMethod}
+	var calls int32
+	release := make(chan struct{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &csi.CreateVolumeResponse{Volume: &csi.Volume{VolumeId: "vol-1"}}, nil
+	}
+
+	var wg sync.WaitGroup
+	responses := make([]interface{}, 3)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], _ = coalescer.intercept(context.Background(), &csi.CreateVolumeRequest{Name: "pvc-1"},
+				info, handler)
+		}(i)
+	}
+	// Give the duplicate requests time to join the in-flight call.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, resp := range responses {
+		assert.Equal(t, "vol-1", resp.(*csi.CreateVolumeResponse).Volume.VolumeId)
+	}
+
+	// Requests for different volumes are not coalesced.
+	_, _ = coalescer.intercept(context.Background(), &csi.CreateVolumeRequest{Name: "pvc-2"}, info, handler)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRequestCoalescerKey(t *testing.T) {
+	key, ok := getCoalescingKey(&csi.CreateVolumeRequest{Name: "pvc-1",
+		Parameters: map[string]string{"storagepolicyname": "gold"}})
+	assert.True(t, ok)
+	retryKey, _ := getCoalescingKey(&csi.CreateVolumeRequest{Name: "pvc-1",
+		Parameters: map[string]string{"storagepolicyname": "silver"}})
+	assert.Equal(t, key, retryKey)
+
+	key, _ = getCoalescingKey(&csi.ControllerPublishVolumeRequest{VolumeId: "vol-1", NodeId: "node-1"})
+	otherNodeKey, _ := getCoalescingKey(&csi.ControllerPublishVolumeRequest{VolumeId: "vol-1", NodeId: "node-2"})
+	assert.NotEqual(t, key, otherNodeKey)
+
+	_, ok = getCoalescingKey(&csi.DeleteVolumeRequest{})
+	assert.False(t, ok)
+	_, ok = getCoalescingKey(&csi.ControllerUnpublishVolumeRequest{})
+	assert.False(t, ok)
+}
+
+func TestRequestCoalescerDeadline(t *testing.T) {
+	coalescer := &requestCoalescer{methods: coalescedMethods}
+	info := &grpc.UnaryServerInfo{FullMethod: createVolumeMethod}
+	// The call to the driver hangs until its context is done.
+	handlerDone := make(chan struct{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		defer close(handlerDone)
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := coalescer.intercept(ctx, &csi.CreateVolumeRequest{Name: "pvc-1"}, info, handler)
+	assert.Error(t, err)
+
+	// The coalesced call is bounded by the deadline of the request which
+	// started it.
+	select {
+	case <-handlerDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the coalesced call was not bounded by the deadline of the request")
+	}
+}
@@ -62,7 +62,8 @@ func (driver *vsphereCSIDriver) NodeStageVolume(
 	}
 
 	// Check for block volume or file share.
-	if common.IsFileVolumeRequest(ctx, []*csi.VolumeCapability{volCap}) {
+	if !isMultiWriterBlockVolumeRequest(ctx, []*csi.VolumeCapability{volCap}) &&
+		common.IsFileVolumeRequest(ctx, []*csi.VolumeCapability{volCap}) {
 		log.Infof("NodeStageVolume: Volume %q detected as a file share volume. Ignoring staging for file volumes.",
 			volumeID)
 		return &csi.NodeStageVolumeResponse{}, nil
@@ -74,7 +75,7 @@ func (driver *vsphereCSIDriver) NodeStageVolume(
 	defer driver.volumeLocks.Release(volumeID)
 
 	caps := []*csi.VolumeCapability{volCap}
-	if err := common.IsValidVolumeCapabilities(ctx, caps); err != nil {
+	if err := isValidNodeVolumeCapabilities(ctx, caps); err != nil {
 		return nil, logger.LogNewErrorCodef(log, codes.InvalidArgument,
 			"NodeStageVolume failed: volume capability not supported. Err: %+v", err)
 	}
@@ -214,13 +215,13 @@ func (driver *vsphereCSIDriver) NodePublishVolume(
 	}
 	defer driver.volumeLocks.Release(volumeID)
 	caps := []*csi.VolumeCapability{volCap}
-	if err := common.IsValidVolumeCapabilities(ctx, caps); err != nil {
+	if err := isValidNodeVolumeCapabilities(ctx, caps); err != nil {
 		return nil, logger.LogNewErrorCodef(log, codes.InvalidArgument,
 			"NodePublishVolume failed: volume capability not supported. Err: %+v", err)
 	}
 
 	// Check if this is a MountVolume or BlockVolume.
-	if !common.IsFileVolumeRequest(ctx, caps) {
+	if isMultiWriterBlockVolumeRequest(ctx, caps) || !common.IsFileVolumeRequest(ctx, caps) {
 		var dev *osutils.Device
 		err = driver.osUtils.VerifyVolumeAttachedAndFillParams(ctx, req.GetPublishContext(), &params, &dev)
 		if err != nil {
@@ -383,7 +384,9 @@ func (driver *vsphereCSIDriver) NodeGetCapabilities(
 // attached is deterministic by inspecting SCSI controllers of the VM, but for
 // file volume, this is not deterministic. We can not set this limit on
 // MaxVolumesPerNode, since single driver is used for both block and file
-// volumes.
+// volumes. When the node-attach-limits feature is enabled in vanilla clusters,
+// the block volume attach limit computed by the syncer from the controllers of
+// the node VM is reported, bounded by MAX_VOLUMES_PER_NODE if set.
 func (driver *vsphereCSIDriver) NodeGetInfo(
 	ctx context.Context,
 	req *csi.NodeGetInfoRequest) (
@@ -478,6 +481,17 @@ func (driver *vsphereCSIDriver) NodeGetInfo(
 			NodeID:   nodeID,
 		}
 		accessibleTopology, err = topologyService.GetNodeTopologyLabels(ctx, &nodeInfo)
+		if err == nil && commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.NodeAttachLimits) {
+			// Report the attach limit computed from the controllers of the
+			// NodeVM, bounded by MAX_VOLUMES_PER_NODE if set.
+			var maxBlockVolumes int64
+			maxBlockVolumes, err = topologyService.GetNodeAttachLimit(ctx, &nodeInfo)
+			if err == nil {
+				maxVolumesPerNode = getMaxVolumesPerNode(maxVolumesPerNode, maxBlockVolumes)
+				log.Infof("NodeGetInfo: block volume attach limit of the node VM is %d, "+
+					"reporting MaxVolumesPerNode %d", maxBlockVolumes, maxVolumesPerNode)
+			}
+		}
 	}
 
 	if err != nil {
@@ -497,6 +511,20 @@ func (driver *vsphereCSIDriver) NodeGetInfo(
 	return nodeInfoResponse, nil
 }
 
+// getMaxVolumesPerNode returns the MaxVolumesPerNode to be reported given the
+// limit configured with MAX_VOLUMES_PER_NODE and the attach limit computed
+// from the controllers of the node VM. Zero means the limit is not set.
+func getMaxVolumesPerNode(configured, computed int64) int64 {
+	if computed <= 0 {
+		return configured
+	}
+	computed = min(computed, maxAllowedBlockVolumesPerNodeInvSphere8)
+	if configured > 0 && configured < computed {
+		return configured
+	}
+	return computed
+}
+
 // initVolumeTopologyService is a helper method to initialize
 // TopologyService in node.
 func initVolumeTopologyService(ctx context.Context) error {
@@ -585,7 +613,7 @@ func (driver *vsphereCSIDriver) NodeExpandVolume(
 	volCap := req.GetVolumeCapability()
 	if volCap != nil {
 		caps := []*csi.VolumeCapability{volCap}
-		if err := common.IsValidVolumeCapabilities(ctx, caps); err != nil {
+		if err := isValidNodeVolumeCapabilities(ctx, caps); err != nil {
 			return nil, logger.LogNewErrorCodef(log, codes.InvalidArgument,
 				"volume capability not supported. Err: %+v", err)
 		}
@@ -623,3 +651,21 @@ func (driver *vsphereCSIDriver) NodeExpandVolume(
 		CapacityBytes: int64(units.FileSize(reqVolSizeMB * common.MbInBytes)),
 	}, nil
 }
+
+// isMultiWriterBlockVolumeRequest returns true if the multi-writer block
+// volumes feature is enabled and the given capabilities request a raw block
+// volume shared by several nodes.
+func isMultiWriterBlockVolumeRequest(ctx context.Context, volCaps []*csi.VolumeCapability) bool {
+	return common.IsMultiWriterBlockVolumeRequest(ctx, volCaps) &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.MultiWriterBlockVolumes)
+}
+
+// isValidNodeVolumeCapabilities validates the given volume capabilities,
+// allowing raw block volumes shared by several nodes when the multi-writer
+// block volumes feature is enabled.
+func isValidNodeVolumeCapabilities(ctx context.Context, volCaps []*csi.VolumeCapability) error {
+	if isMultiWriterBlockVolumeRequest(ctx, volCaps) {
+		return common.IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps)
+	}
+	return common.IsValidVolumeCapabilities(ctx, volCaps)
+}
@@ -403,6 +403,18 @@ func TestNodeConstants(t *testing.T) {
 	assert.Equal(t, 255, maxAllowedBlockVolumesPerNodeInvSphere8)
 }
 
+func TestGetMaxVolumesPerNode(t *testing.T) {
+	// Attach limit not computed, MAX_VOLUMES_PER_NODE is reported as is.
+	assert.Equal(t, int64(59), getMaxVolumesPerNode(59, 0))
+	assert.Equal(t, int64(0), getMaxVolumesPerNode(0, 0))
+	// Computed attach limit is bounded by MAX_VOLUMES_PER_NODE.
+	assert.Equal(t, int64(59), getMaxVolumesPerNode(59, 63))
+	assert.Equal(t, int64(44), getMaxVolumesPerNode(59, 44))
+	assert.Equal(t, int64(63), getMaxVolumesPerNode(0, 63))
+	// Computed attach limit is bounded by the vSphere maximum.
+	assert.Equal(t, int64(maxAllowedBlockVolumesPerNodeInvSphere8), getMaxVolumesPerNode(0, 511))
+}
+
 func TestVolumeLockingMechanism(t *testing.T) {
 	driver := NewDriver().(*vsphereCSIDriver)
 
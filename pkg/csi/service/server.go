@@ -113,7 +113,8 @@ func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer,
 		return logger.LogNewErrorf(log, "failed to listen: %v", err)
 	}
 
-	server := grpc.NewServer()
+	ctx, _ := logger.GetNewContextWithLogger()
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(newUnaryInterceptors(ctx)...))
 	s.server = server
 
 	// Register the CSI services.
@@ -82,6 +82,9 @@ type TopologyCalculationParams struct {
 	TopologySegmentsMap map[string][]map[string]string
 	VolumeManager       cnsvolume.Manager
 	NodeManager         NodeManagerInterface
+	// MultiWriter restricts the topology to the segments in which all the
+	// nodes have access to the datastore of the multi-writer volume.
+	MultiWriter bool
 }
 
 // defaultTopologyCalculator is the default implementation of TopologyCalculatorInterface
@@ -129,7 +132,7 @@ func (d *defaultTopologyCalculator) CalculateAccessibleTopology(ctx context.Cont
 
 	// Find datastore topology from the retrieved datastoreURL.
 	return calculateAccessibleTopologiesForDatastore(ctx, params.VCenter,
-		params.TopologySegmentsMap[params.VCHost], allNodeVMs, datastoreURL, params.NodeManager)
+		params.TopologySegmentsMap[params.VCHost], allNodeVMs, datastoreURL, params.NodeManager, params.MultiWriter)
 }
 
 type controller struct {
@@ -158,7 +161,8 @@ var (
 	// The following variables hold feature states for CSI Migration
 	// and authorisation check.
 	csiMigrationEnabled, filterSuspendedDatastores,
-	isTopologyAwareFileVolumeEnabled, isCSITransactionSupportEnabled bool
+	isTopologyAwareFileVolumeEnabled, isCSITransactionSupportEnabled, isHostLocalVolumesEnabled,
+	isDatastoreMaintenanceAwarenessEnabled, isMultiWriterBlockVolumesEnabled bool
 
 	// variables for list volumes
 	volIDsInK8s             = make([]string, 0)
@@ -168,6 +172,11 @@ var (
 	// filters out all the potential shared datastores in a volume provisioning call.
 	errAllDSFilteredOut = errors.New("auth service could not find datastore for block volume provisioning")
 
+	// errNoDatastoreClusterMember is returned when none of the member
+	// datastores of the datastore cluster in the StorageClass can be used.
+	errNoDatastoreClusterMember = errors.New("no compatible datastore out of maintenance mode found in " +
+		"datastore cluster")
+
 	// variable for list snapshots
 	CNSSnapshotsForListSnapshots = make([]cnstypes.CnsSnapshotQueryResultEntry, 0)
 	CNSVolumeDetailsMap          = make([]map[string]*utils.CnsVolumeDetails, 0)
@@ -204,6 +213,11 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 		common.CnsMgrSuspendCreateVolume)
 	isTopologyAwareFileVolumeEnabled = commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
 		common.TopologyAwareFileVolume)
+	isHostLocalVolumesEnabled = commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.HostLocalVolumes)
+	isDatastoreMaintenanceAwarenessEnabled = commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
+		common.DatastoreMaintenanceAwareness)
+	isMultiWriterBlockVolumesEnabled = commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
+		common.MultiWriterBlockVolumes)
 
 	vcManager := cnsvsphere.GetVirtualCenterManager(ctx)
 	// Multi vCenter feature enabled
@@ -253,6 +267,11 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 		}
 	}
 
+	// Expose the health of the vCenter sessions, CNS ListViews and informers
+	// through the Probe call and the health endpoints.
+	common.RegisterVCenterHealthChecks(c.managers)
+	common.RegisterInformersHealthCheck()
+
 	log.Info("loading AuthorizationService")
 	authMgrs, err := common.GetAuthorizationServices(ctx, vCenters)
 	if err != nil {
@@ -358,16 +377,12 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 		return err
 	}
 	if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.CSIMigration) {
-		if len(c.managers.VcenterConfigs) == 1 {
-			log.Info("CSI Migration Feature is Enabled. Loading Volume Migration Service")
-			volumeManager := c.managers.VolumeManagers[c.managers.CnsConfig.Global.VCenterIP]
-			volumeMigrationService, err = migration.GetVolumeMigrationService(ctx, &volumeManager, config, false)
-			if err != nil {
-				log.Errorf("failed to get migration service. Err: %v", err)
-				return err
-			}
-		} else {
-			log.Infof("vSphere CSI Migration is not supported on the multi vCenter setup")
+		log.Info("CSI Migration Feature is Enabled. Loading Volume Migration Service")
+		volumeMigrationService, err = migration.GetVolumeMigrationService(ctx, c.managers.VolumeManagers,
+			config, false)
+		if err != nil {
+			log.Errorf("failed to get migration service. Err: %v", err)
+			return err
 		}
 	}
 
@@ -382,6 +397,7 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 	// Go module to keep the metrics http server running all the time.
 	go func() {
 		prometheus.CsiInfo.WithLabelValues(version).Set(1)
+		common.RegisterHealthEndpoints(http.DefaultServeMux)
 		for {
 			log.Info("Starting the http server to expose Prometheus metrics..")
 			http.Handle("/metrics", promhttp.Handler())
@@ -477,10 +493,11 @@ func (c *controller) filterDatastores(ctx context.Context, sharedDatastores []*c
 	return filteredDatastores, nil
 }
 
-// createBlockVolumeWithPlacementEngineForMultiVC creates a block volume based on the CreateVolumeRequest
+// createBlockVolumeWithPlacementEngineForMultiVC creates a block volume based on the CreateVolumeRequest.
+// esxiHost is the ESXi host of the selected node, used to place host-local volumes.
 // using the placement engine interface on a multi-VC environment.
-func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.Context, req *csi.CreateVolumeRequest) (
-	*csi.CreateVolumeResponse, string, error) {
+func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.Context, req *csi.CreateVolumeRequest,
+	esxiHost string) (*csi.CreateVolumeResponse, string, error) {
 	log := logger.GetLogger(ctx)
 	// Volume Size - Default is 10 GiB.
 	volSizeBytes := int64(common.DefaultGbDiskSize * common.GbInBytes)
@@ -498,55 +515,55 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 	}
 
 	if scParams.CSIMigration == "true" {
-		if len(c.managers.VcenterConfigs) > 1 {
-			return nil, csifault.CSIInternalFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
-				"vSphere CSI Migration is not supported on multi vCenter deployment")
-		} else {
-			if len(scParams.Datastore) != 0 {
-				log.Infof("Converting datastore name: %q to Datastore URL", scParams.Datastore)
-				// Get vCenter.
-				// Need to extract fault from err returned by GetVirtualCenter.
-				// Currently, just return "csi.fault.Internal".
-				vCenter, err := common.GetVCenterFromVCHost(ctx, c.managers.VcenterManager, c.managers.CnsConfig.Global.VCenterIP)
-				if err != nil {
-					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
-						"failed to get vCenter. err: %+v", err)
-				}
-				dcList, err := vCenter.GetDatacenters(ctx)
-				// Need to extract fault from err returned by GetDatacenters.
-				// Currently, just return "csi.fault.Internal".
-				if err != nil {
-					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
-						"failed to get datacenter list. err: %+v", err)
-				}
-				foundDatastoreURL := false
-				for _, dc := range dcList {
-					dsURLTodsInfoMap, err := dc.GetAllDatastores(ctx)
-					// Need to extract fault from err returned by GetAllDatastores.
-					// Currently, just return "csi.fault.Internal".
-					if err != nil {
-						return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
-							"failed to get dsURLTodsInfoMap. err: %+v", err)
-					}
-					for dsURL, dsInfo := range dsURLTodsInfoMap {
-						if dsInfo.Info.Name == scParams.Datastore {
-							scParams.DatastoreURL = dsURL
-							log.Infof("Found datastoreURL: %q for datastore name: %q", scParams.DatastoreURL, scParams.Datastore)
-							foundDatastoreURL = true
-							break
-						}
-					}
-					if foundDatastoreURL {
-						break
-					}
-				}
-				if !foundDatastoreURL {
-					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
-						"failed to find datastoreURL for datastore name: %q", scParams.Datastore)
-				}
-			} else if c.managers.VcenterConfigs[c.managers.CnsConfig.Global.VCenterIP].MigrationDataStoreURL != "" {
-				scParams.DatastoreURL = c.managers.VcenterConfigs[c.managers.CnsConfig.Global.VCenterIP].MigrationDataStoreURL
+		if len(scParams.Datastore) != 0 {
+			log.Infof("Converting datastore name: %q to Datastore URL", scParams.Datastore)
+			scParams.DatastoreURL, err = getDatastoreURLForDatastoreName(ctx, c, scParams.Datastore)
+			if err != nil {
+				// Error is already wrapped in CSI error code.
+				return nil, csifault.CSIInternalFault, err
 			}
+		} else if len(c.managers.VcenterConfigs) == 1 &&
+			c.managers.VcenterConfigs[c.managers.CnsConfig.Global.VCenterIP].MigrationDataStoreURL != "" {
+			scParams.DatastoreURL = c.managers.VcenterConfigs[c.managers.CnsConfig.Global.VCenterIP].MigrationDataStoreURL
+		}
+	}
+	if scParams.HostLocal {
+		if !isHostLocalVolumesEnabled {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log, codes.InvalidArgument,
+				"host-local volumes are not supported as %q feature is disabled", common.HostLocalVolumes)
+		}
+		if esxiHost == "" {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log, codes.InvalidArgument,
+				"ESXi host of the selected node not found in accessibility requirements of host-local volume %q. "+
+					"Use a StorageClass with volumeBindingMode WaitForFirstConsumer.", req.Name)
+		}
+		if scParams.DatastoreURL != "" || req.GetVolumeContentSource() != nil {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+				"datastore URL and volume content source are not supported for host-local volumes")
+		}
+	}
+	isMultiWriterBlockVolume := isMultiWriterBlockVolumeRequest(ctx, req.GetVolumeCapabilities())
+	if isMultiWriterBlockVolume {
+		if scParams.HostLocal || req.GetVolumeContentSource() != nil {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+				"host-local volumes and volume content source are not supported for multi-writer block volumes")
+		}
+		if scParams.StoragePolicyName == "" {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+				"a storage policy provisioning eager-zeroed thick disks is required for multi-writer block volumes")
+		}
+	}
+	if (scParams.DatastoreCluster != "" || scParams.DatastoreClusterID != "") &&
+		req.GetVolumeContentSource() != nil {
+		return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+			"volume content source is not supported with datastore cluster")
+	}
+	var datastoreScorer placementengine.DatastoreScorer
+	if scParams.PlacementStrategy != "" && req.GetVolumeContentSource() == nil {
+		datastoreScorer, err = placementengine.GetDatastoreScorer(scParams.PlacementStrategy)
+		if err != nil {
+			return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+				err.Error())
 		}
 	}
 	// Check if requested volume size and source snapshot size matches.
@@ -783,9 +800,27 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 	}
 
 	if !volTaskAlreadyRegistered {
-		// Iterate through each VC and its accessibility requirements to try and create a volume.
-		// If it fails for any reason, move to the next VC in list.
-		if topologyRequirement != nil {
+		if scParams.HostLocal {
+			// Look up the ESXi host in the VCs matching the accessibility requirements.
+			var vcHosts []string
+			for vcHost := range vcTopologySegmentsMap {
+				vcHosts = append(vcHosts, vcHost)
+			}
+			if len(vcHosts) == 0 {
+				for vcHost := range c.managers.VcenterConfigs {
+					vcHosts = append(vcHosts, vcHost)
+				}
+			}
+			vcHost, vcenter, volumeMgr, volumeInfo, faultType, err = c.createHostLocalBlockVolume(ctx, esxiHost,
+				vcHosts, scParams, &createVolumeSpec)
+			if err != nil {
+				return nil, faultType, err
+			}
+			log.Infof("host-local volume %q created on ESXi host %q in vCenter %q", volumeInfo.VolumeID.Id,
+				esxiHost, vcHost)
+		} else if topologyRequirement != nil {
+			// Iterate through each VC and its accessibility requirements to try and create a volume.
+			// If it fails for any reason, move to the next VC in list.
 			var topologySegmentsList []map[string]string
 			for vcHost, topologySegmentsList = range vcTopologySegmentsMap {
 				// Get VC instance.
@@ -854,10 +889,52 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 						"failed to filter datastores based on authorisation check in vCenter %q. Error: %+v",
 						vcHost, err)
 				}
+				if isDatastoreMaintenanceAwarenessEnabled && scParams.DatastoreURL == "" &&
+					snapshotDatastoreURL == "" {
+					sharedDatastores, err = common.FilterDatastoresUnderMaintenance(ctx, vcenter, sharedDatastores)
+					if err != nil {
+						return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+							"failed to filter datastores under maintenance in vCenter %q. Error: %+v", vcHost, err)
+					}
+					if len(sharedDatastores) == 0 {
+						errMsg := fmt.Sprintf("all the compatible datastores found for accessibility requirements "+
+							"%+v associated with vCenter %q are under maintenance", topologySegmentsList, vcHost)
+						log.Warn(errMsg)
+						combinedErrMssgs = append(combinedErrMssgs, errMsg)
+						continue
+					}
+				}
+				if isMultiWriterBlockVolume {
+					sharedDatastores, err = getMultiWriterDatastores(ctx, vcenter, storagePolicyID, sharedDatastores)
+					if err != nil {
+						log.Warn(err)
+						combinedErrMssgs = append(combinedErrMssgs, err.Error())
+						continue
+					}
+				}
+				if scParams.DatastoreCluster != "" || scParams.DatastoreClusterID != "" {
+					sharedDatastores, err = getDatastoreClusterPlacement(ctx, vcenter, scParams,
+						req.Name, volSizeMB, sharedDatastores)
+					if err != nil {
+						log.Warn(err)
+						combinedErrMssgs = append(combinedErrMssgs, err.Error())
+						continue
+					}
+				}
 				volumeMgr, err = GetVolumeManagerFromVCHost(ctx, c.managers, vcHost)
 				if err != nil {
 					return nil, csifault.CSIInternalFault, logger.LogNewErrorCode(log, codes.Internal, err.Error())
 				}
+				if datastoreScorer != nil {
+					sharedDatastores, err = placementengine.SelectTopScoredDatastores(ctx, datastoreScorer,
+						getDatastoreScoringParams(vcenter, volumeMgr, scParams, volSizeMB),
+						sharedDatastores, scParams.PlacementTopN)
+					if err != nil {
+						log.Warn(err)
+						combinedErrMssgs = append(combinedErrMssgs, err.Error())
+						continue
+					}
+				}
 				// Call CreateVolume.
 				// TODO: Few errors encountered  in CreateBlockVolumeUtilForMultiVC can be
 				// retried instead of moving unto next VC. Need to throw a custom error for such scenarios.
@@ -947,6 +1024,50 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 				return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
 					"failed to create volume. Error: %+v", err)
 			}
+			if isDatastoreMaintenanceAwarenessEnabled && scParams.DatastoreURL == "" &&
+				snapshotDatastoreURL == "" {
+				sharedDatastores, err = common.FilterDatastoresUnderMaintenance(ctx, vcenter, sharedDatastores)
+				if err != nil {
+					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+						"failed to create volume. Error: %+v", err)
+				}
+				if len(sharedDatastores) == 0 {
+					return nil, csifault.CSIInternalFault, logger.LogNewErrorCode(log, codes.Internal,
+						"failed to create volume. All the shared datastores are under maintenance.")
+				}
+			}
+			if isMultiWriterBlockVolume {
+				sharedDatastores, err = getMultiWriterDatastores(ctx, vcenter, storagePolicyID, sharedDatastores)
+				if err != nil {
+					return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log, codes.FailedPrecondition,
+						"failed to create volume. Error: %+v", err)
+				}
+			}
+			if scParams.DatastoreCluster != "" || scParams.DatastoreClusterID != "" {
+				sharedDatastores, err = getDatastoreClusterPlacement(ctx, vcenter, scParams,
+					req.Name, volSizeMB, sharedDatastores)
+				if errors.Is(err, cnsvsphere.ErrDatastoreClusterNotFound) {
+					return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log,
+						codes.InvalidArgument, "failed to create volume. Error: %+v", err)
+				}
+				if errors.Is(err, errNoDatastoreClusterMember) {
+					return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log,
+						codes.FailedPrecondition, "failed to create volume. Error: %+v", err)
+				}
+				if err != nil {
+					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+						"failed to create volume. Error: %+v", err)
+				}
+			}
+			if datastoreScorer != nil {
+				sharedDatastores, err = placementengine.SelectTopScoredDatastores(ctx, datastoreScorer,
+					getDatastoreScoringParams(vcenter, volumeMgr, scParams, volSizeMB),
+					sharedDatastores, scParams.PlacementTopN)
+				if err != nil {
+					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+						"failed to create volume. Error: %+v", err)
+				}
+			}
 			volumeInfo, faultType, err = common.CreateBlockVolumeUtilForMultiVC(ctx,
 				common.VanillaCreateBlockVolParamsForMultiVC{
 					Vcenter:              vcenter,
@@ -1020,7 +1141,12 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 
 	// For topology aware provisioning, populate the topology segments parameter
 	// in the CreateVolumeResponse struct.
-	if topologyRequirement != nil {
+	if scParams.HostLocal {
+		// Host-local volumes are accessible only from the nodes on the ESXi host.
+		resp.Volume.AccessibleTopology = []*csi.Topology{
+			{Segments: map[string]string{common.TopologyLabelESXiHost: esxiHost}},
+		}
+	} else if topologyRequirement != nil {
 		if volumeMgr == nil {
 			volumeMgr, err = GetVolumeManagerFromVCHost(ctx, c.managers, vcHost)
 			if err != nil {
@@ -1036,6 +1162,7 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 			TopologySegmentsMap: vcTopologySegmentsMap,
 			VolumeManager:       volumeMgr,
 			NodeManager:         c.nodeMgr,
+			MultiWriter:         isMultiWriterBlockVolume,
 		}
 
 		datastoreAccessibleTopology, err := c.topologyCalc.CalculateAccessibleTopology(ctx, params)
@@ -1076,10 +1203,12 @@ func (c *controller) createBlockVolumeWithPlacementEngineForMultiVC(ctx context.
 }
 
 // calculateAccessibleTopologiesForDatastore figures out the list of topologies from
-// which the given datastore is accessible when multi-VC FSS is enabled.
+// which the given datastore is accessible when multi-VC FSS is enabled. With
+// requireAllNodesAccessible, only the topologies in which all the nodes have
+// access to the datastore are returned.
 func calculateAccessibleTopologiesForDatastore(ctx context.Context, vcenter *cnsvsphere.VirtualCenter,
 	topologySegments []map[string]string, allNodeVMs []*cnsvsphere.VirtualMachine,
-	datastoreURL string, nodeMgr NodeManagerInterface) (
+	datastoreURL string, nodeMgr NodeManagerInterface, requireAllNodesAccessible bool) (
 	[]map[string]string, error) {
 	log := logger.GetLogger(ctx)
 	var datastoreAccessibleTopology []map[string]string
@@ -1115,6 +1244,7 @@ func calculateAccessibleTopologiesForDatastore(ctx context.Context, vcenter *cns
 			NodeNames:                 accessibleNodeNames,
 			DatastoreURL:              datastoreURL,
 			RequestedTopologySegments: topologySegments,
+			RequireAllNodesAccessible: requireAllNodesAccessible,
 		})
 	if err != nil {
 		return nil, logger.LogNewErrorCodef(log, codes.Internal,
@@ -1146,6 +1276,14 @@ func (c *controller) createFileVolume(ctx context.Context, req *csi.CreateVolume
 		return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.InvalidArgument,
 			"parsing storage class parameters failed with error: %+v", err)
 	}
+	if scParams.HostLocal {
+		return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+			"host-local volumes are supported only for block volumes")
+	}
+	if scParams.DatastoreCluster != "" || scParams.DatastoreClusterID != "" || scParams.PlacementStrategy != "" {
+		return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCode(log, codes.InvalidArgument,
+			"datastore cluster and placement strategy are supported only for block volumes")
+	}
 
 	var (
 		volTaskAlreadyRegistered bool
@@ -1473,6 +1611,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 	start := time.Now()
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 
 	volumeType := prometheus.PrometheusUnknownVolumeType
 	createVolumeInternal := func() (
@@ -1485,11 +1624,18 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 		// For all other cases, the faultType will be set to "csi.fault.Internal" for now.
 		// Later we may need to define different csi faults.
 		volumeCapabilities := req.GetVolumeCapabilities()
-		if err := common.IsValidVolumeCapabilities(ctx, volumeCapabilities); err != nil {
+		if err := isValidVolumeCapabilities(ctx, volumeCapabilities); err != nil {
 			return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.InvalidArgument,
 				"volume capability not supported. Err: %+v", err)
 		}
-		if common.IsFileVolumeRequest(ctx, volumeCapabilities) {
+		var esxiHost string
+		if isHostLocalVolumesEnabled {
+			// Nodes report the ESXi host running the NodeVM as a topology
+			// segment, which is used only to place host-local volumes.
+			req.AccessibilityRequirements, esxiHost = splitESXiHostTopology(req.GetAccessibilityRequirements())
+		}
+		if !isMultiWriterBlockVolumeRequest(ctx, volumeCapabilities) &&
+			common.IsFileVolumeRequest(ctx, volumeCapabilities) {
 			// Error out if TopologyRequirement is provided during file volume provisioning
 			// as this is not supported yet.
 			if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.TopologyAwareFileVolume) {
@@ -1534,9 +1680,10 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			}
 		}
 		volumeType = prometheus.PrometheusBlockVolumeType
-		return c.createBlockVolumeWithPlacementEngineForMultiVC(ctx, req)
+		return c.createBlockVolumeWithPlacementEngineForMultiVC(ctx, req, esxiHost)
 	}
 	resp, faultType, err := createVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("createVolumeInternal: returns fault %q", faultType)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -1547,6 +1694,12 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			prometheus.PrometheusCreateVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", req.Parameters[common.AttributePvcNamespace],
+			req.Parameters[common.AttributePvcName], commoncotypes.FaultEvent{
+				Reason:    "ProvisioningFailed",
+				Operation: "CreateVolume",
+				FaultType: faultType,
+			}, taskDetails, err)
 	} else {
 		log.Infof("Volume created successfully. Volume Handle: %q, PV Name: %q", resp.Volume.VolumeId, req.Name)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
@@ -1673,6 +1826,7 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 		return &csi.DeleteVolumeResponse{}, "", nil
 	}
 	resp, faultType, err := deleteVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("deleteVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -1698,6 +1852,7 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 	start := time.Now()
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 	volumeType := prometheus.PrometheusUnknownVolumeType
 
 	controllerPublishVolumeInternal := func() (
@@ -1721,8 +1876,11 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 			return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
 				"failed to get volume manager for volume Id: %q. Error: %v", req.VolumeId, err)
 		}
+		isMultiWriterBlockVolume := isMultiWriterBlockVolumeRequest(ctx,
+			[]*csi.VolumeCapability{req.GetVolumeCapability()})
 		// Check whether its a block or file volume.
-		if common.IsFileVolumeRequest(ctx, []*csi.VolumeCapability{req.GetVolumeCapability()}) {
+		if !isMultiWriterBlockVolume &&
+			common.IsFileVolumeRequest(ctx, []*csi.VolumeCapability{req.GetVolumeCapability()}) {
 			volumeType = prometheus.PrometheusFileVolumeType
 			// File Volume.
 			queryFilter := cnstypes.CnsQueryFilter{
@@ -1804,9 +1962,34 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 					"failed to find VirtualMachine for node:%q. Error: %v", req.NodeId, err)
 			}
 			log.Debugf("Found VirtualMachine for node:%q.", req.NodeId)
-			// faultType is returned from manager.AttachVolume.
-			diskUUID, faultType, err := common.AttachVolumeUtil(ctx, volumeManager, nodevm, req.VolumeId,
-				false)
+			controllerProvisioning := c.managers.CnsConfig.ControllerProvisioning
+			if controllerProvisioning.Enabled {
+				// Hot-add a controller to the node VM if all its controllers are full.
+				err = nodevm.EnsureFreeControllerSlot(ctx, controllerProvisioning.ControllerType,
+					types.VirtualSCSISharing(controllerProvisioning.SharingMode))
+				if err != nil {
+					if errors.Is(err, cnsvsphere.ErrNoFreeControllerSlot) {
+						return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.ResourceExhausted,
+							"failed to attach disk: %+q with node: %q err %+v", req.VolumeId, req.NodeId, err)
+					}
+					return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+						"failed to provision controller on node: %q for disk: %+q err %+v",
+						req.NodeId, req.VolumeId, err)
+				}
+			}
+			checkNVMeController := controllerProvisioning.Enabled &&
+				controllerProvisioning.ControllerType == cnsvsphere.ControllerTypeNVMe
+			var diskUUID, faultType string
+			if isMultiWriterBlockVolume {
+				// Shared disks are attached with the multi-writer sharing mode so
+				// that they can be attached to several nodes at the same time.
+				diskUUID, faultType, err = common.AttachMultiWriterVolumeUtil(ctx, volumeManager, nodevm,
+					req.VolumeId, checkNVMeController)
+			} else {
+				// faultType is returned from manager.AttachVolume.
+				diskUUID, faultType, err = common.AttachVolumeUtil(ctx, volumeManager, nodevm, req.VolumeId,
+					checkNVMeController)
+			}
 			if err != nil {
 				return nil, faultType, logger.LogNewErrorCodef(log, codes.Internal,
 					"failed to attach disk: %+q with node: %q err %+v", req.VolumeId, req.NodeId, err)
@@ -1820,6 +2003,7 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 		}, "", nil
 	}
 	resp, faultType, err := controllerPublishVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("controllerPublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -1830,6 +2014,13 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 			prometheus.PrometheusAttachVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		pvcName, pvcNamespace, _ := commonco.ContainerOrchestratorUtility.GetPVCNameFromCSIVolumeID(req.VolumeId)
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", pvcNamespace, pvcName, commoncotypes.FaultEvent{
+			Reason:    "FailedAttachVolume",
+			Operation: "ControllerPublishVolume",
+			FaultType: faultType,
+			NodeName:  req.NodeId,
+		}, taskDetails, err)
 	} else {
 		log.Infof("Volume %q attached successfully to node %q.", req.VolumeId, req.NodeId)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
@@ -1957,6 +2148,7 @@ func (c *controller) ControllerUnpublishVolume(ctx context.Context, req *csi.Con
 		return &csi.ControllerUnpublishVolumeResponse{}, "", nil
 	}
 	resp, faultType, err := controllerUnpublishVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("controllerUnpublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -2093,6 +2285,7 @@ func (c *controller) ControllerExpandVolume(ctx context.Context, req *csi.Contro
 	}
 
 	resp, faultType, err := controllerExpandVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	if err != nil {
 		log.Debugf("controllerExpandVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 		if csifault.IsNonStorageFault(faultType) {
@@ -2119,7 +2312,7 @@ func (c *controller) ValidateVolumeCapabilities(ctx context.Context, req *csi.Va
 	log.Infof("ControllerGetCapabilities: called with args %+v", req)
 	volCaps := req.GetVolumeCapabilities()
 	var confirmed *csi.ValidateVolumeCapabilitiesResponse_Confirmed
-	if err := common.IsValidVolumeCapabilities(ctx, volCaps); err == nil {
+	if err := isValidVolumeCapabilities(ctx, volCaps); err == nil {
 		confirmed = &csi.ValidateVolumeCapabilitiesResponse_Confirmed{VolumeCapabilities: volCaps}
 	}
 	return &csi.ValidateVolumeCapabilitiesResponse{
@@ -2236,6 +2429,7 @@ func (c *controller) ListVolumes(ctx context.Context, req *csi.ListVolumesReques
 		return resp, "", nil
 	}
 	listVolResponse, faultType, err := listVolumesInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("List volume response: %+v", listVolResponse)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -2322,7 +2516,7 @@ func (c *controller) processQueryResultsListVolumes(ctx context.Context, startin
 				volCounter += 1
 				volumeId := blockVolID
 				// this check is required as volumeMigrationService is not initialized
-				// when multi-vc is enabled and there is more than 1 vc
+				// when CSI migration is disabled
 				if volumeMigrationService != nil {
 					migratedVolumePath, err := volumeMigrationService.GetVolumePathFromMigrationServiceCache(ctx, blockVolID)
 					if err != nil && err == common.ErrNotFound {
@@ -2383,19 +2577,8 @@ func initVolumeMigrationService(ctx context.Context, c *controller) error {
 	// In case if feature state switch is enabled after controller is deployed,
 	// we need to initialize the volumeMigrationService.
 	var err error
-
-	// If this is multi-VC config, return without initializing service
-	// currently CSI migration is not supported in multi-VC config
-	if len(c.managers.VcenterConfigs) > 1 {
-		// Multi-VC case
-		return logger.LogNewErrorf(log,
-			"volume-migration feature is not supported on Multi-vCenter deployment")
-	} else {
-		// Single-VC case
-		volumeManager := c.managers.VolumeManagers[c.managers.CnsConfig.Global.VCenterIP]
-		volumeMigrationService, err = migration.GetVolumeMigrationService(ctx,
-			&volumeManager, c.managers.CnsConfig, false)
-	}
+	volumeMigrationService, err = migration.GetVolumeMigrationService(ctx,
+		c.managers.VolumeManagers, c.managers.CnsConfig, false)
 	if err != nil {
 		return logger.LogNewErrorCodef(log, codes.Internal,
 			"failed to get migration service. Err: %v", err)
@@ -2439,6 +2622,7 @@ func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshot
 	*csi.CreateSnapshotResponse, error) {
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 	var (
 		vCenterHost                              string
 		vCenterManager                           cnsvsphere.VirtualCenterManager
@@ -2587,6 +2771,11 @@ func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshot
 			prometheus.PrometheusCreateSnapshotOpType, volumeType, "NotComputed")
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
 			prometheus.PrometheusFailStatus, "NotComputed").Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "VolumeSnapshot", req.Parameters[common.VolumeSnapshotNamespaceKey],
+			req.Parameters[common.VolumeSnapshotNameKey], commoncotypes.FaultEvent{
+				Reason:    "SnapshotCreationFailed",
+				Operation: "CreateSnapshot",
+			}, taskDetails, err)
 	} else {
 		log.Infof("Snapshot for volume %q created successfully.", req.GetSourceVolumeId())
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
@@ -18,11 +18,15 @@ package vanilla
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	cnstypes "github.com/vmware/govmomi/cns/types"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -30,11 +34,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/migration"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/node"
 	cnsvolume "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	csifault "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/fault"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/placementengine"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeinfo"
 )
@@ -52,7 +60,20 @@ func validateVanillaDeleteVolumeRequest(ctx context.Context, req *csi.DeleteVolu
 // otherwise returns nil.
 func validateVanillaControllerPublishVolumeRequest(ctx context.Context,
 	req *csi.ControllerPublishVolumeRequest) error {
-	return common.ValidateControllerPublishVolumeRequest(ctx, req)
+	volCaps := []*csi.VolumeCapability{req.GetVolumeCapability()}
+	if req.GetVolumeCapability() == nil || !isMultiWriterBlockVolumeRequest(ctx, volCaps) {
+		return common.ValidateControllerPublishVolumeRequest(ctx, req)
+	}
+	log := logger.GetLogger(ctx)
+	if len(req.VolumeId) == 0 {
+		return logger.LogNewErrorCode(log, codes.InvalidArgument, "volume ID is a required parameter")
+	} else if len(req.NodeId) == 0 {
+		return logger.LogNewErrorCode(log, codes.InvalidArgument, "node ID is a required parameter")
+	}
+	if err := common.IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps); err != nil {
+		return logger.LogNewErrorCodef(log, codes.InvalidArgument, "volume capability not supported. Err: %+v", err)
+	}
+	return nil
 }
 
 // validateControllerUnpublishVolumeRequest is the helper function to validate
@@ -229,8 +250,14 @@ func getVCenterAndVolumeManagerForVolumeID(ctx context.Context, controller *cont
 		// Multi vCenter Deployment
 		vCenter, err = volumeInfoService.GetvCenterForVolumeID(ctx, volumeId)
 		if err != nil {
-			return "", nil, logger.LogNewErrorCodef(log, codes.Internal,
-				"failed to get vCenter for the volumeID: %q with err=%+v", volumeId, err)
+			// Migrated in-tree volumes may not have a CnsVolumeInfo CR yet.
+			// Look up their vCenter from the volume migration service.
+			var migrationErr error
+			vCenter, migrationErr = getVCenterForMigratedVolume(ctx, controller, volumeId)
+			if migrationErr != nil {
+				return "", nil, logger.LogNewErrorCodef(log, codes.Internal,
+					"failed to get vCenter for the volumeID: %q with err=%+v", volumeId, migrationErr)
+			}
 		}
 		if volumeManager, volumeManagerfound = controller.managers.VolumeManagers[vCenter]; !volumeManagerfound {
 			return vCenter, nil, logger.LogNewErrorCodef(log, codes.Internal,
@@ -255,6 +282,81 @@ func getVCenterAndVolumeManagerForVolumeID(ctx context.Context, controller *cont
 	return vCenter, volumeManager, nil
 }
 
+// getDatastoreURLForDatastoreName returns the URL of the datastore with the
+// given name. On a multi vCenter deployment, the datastore is looked up on
+// every vCenter and must be found on exactly one of them.
+func getDatastoreURLForDatastoreName(ctx context.Context, c *controller, datastoreName string) (string, error) {
+	log := logger.GetLogger(ctx)
+	vCenterHosts := []string{c.managers.CnsConfig.Global.VCenterIP}
+	if len(c.managers.VcenterConfigs) > 1 {
+		vCenterHosts = make([]string, 0, len(c.managers.VcenterConfigs))
+		for host := range c.managers.VcenterConfigs {
+			vCenterHosts = append(vCenterHosts, host)
+		}
+		sort.Strings(vCenterHosts)
+	}
+	var datastoreURL, datastoreVCenter string
+	for _, host := range vCenterHosts {
+		// Need to extract fault from err returned by GetVirtualCenter.
+		// Currently, just return "csi.fault.Internal".
+		vCenter, err := common.GetVCenterFromVCHost(ctx, c.managers.VcenterManager, host)
+		if err != nil {
+			return "", logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get vCenter. err: %+v", err)
+		}
+		dcList, err := vCenter.GetDatacenters(ctx)
+		if err != nil {
+			return "", logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get datacenter list. err: %+v", err)
+		}
+		for _, dc := range dcList {
+			dsURLTodsInfoMap, err := dc.GetAllDatastores(ctx)
+			if err != nil {
+				return "", logger.LogNewErrorCodef(log, codes.Internal,
+					"failed to get dsURLTodsInfoMap. err: %+v", err)
+			}
+			for dsURL, dsInfo := range dsURLTodsInfoMap {
+				if dsInfo.Info.Name != datastoreName {
+					continue
+				}
+				if datastoreVCenter != "" && datastoreVCenter != host {
+					return "", logger.LogNewErrorCodef(log, codes.InvalidArgument,
+						"datastore name: %q is found on vCenters %q and %q", datastoreName, datastoreVCenter, host)
+				}
+				datastoreURL = dsURL
+				datastoreVCenter = host
+				log.Infof("Found datastoreURL: %q for datastore name: %q on vCenter %q",
+					datastoreURL, datastoreName, host)
+				break
+			}
+		}
+	}
+	if datastoreURL == "" {
+		return "", logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to find datastoreURL for datastore name: %q", datastoreName)
+	}
+	return datastoreURL, nil
+}
+
+// getVCenterForMigratedVolume returns the vCenter on which the migrated
+// in-tree volume with the given volumeID or volume path is registered.
+func getVCenterForMigratedVolume(ctx context.Context, c *controller, volumeID string) (string, error) {
+	if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.CSIMigration) {
+		return "", fmt.Errorf("volume-migration feature switch is disabled")
+	}
+	if err := initVolumeMigrationService(ctx, c); err != nil {
+		return "", err
+	}
+	if strings.Contains(volumeID, ".vmdk") {
+		var err error
+		volumeID, err = volumeMigrationService.GetVolumeID(ctx, &migration.VolumeSpec{VolumePath: volumeID}, false)
+		if err != nil {
+			return "", err
+		}
+	}
+	return volumeMigrationService.GetVCenterForVolumeID(ctx, volumeID)
+}
+
 // getVCenterManagerForVCenter returns vCenter manager for the given volumeId.
 func getVCenterManagerForVCenter(ctx context.Context, controller *controller) vsphere.VirtualCenterManager {
 	return controller.managers.VcenterManager
@@ -271,3 +373,319 @@ func GetVolumeManagerFromVCHost(ctx context.Context, managers *common.Managers,
 	}
 	return volumeMgr, nil
 }
+
+// splitESXiHostTopology removes the ESXi host topology segment reported by the
+// nodes from the accessibility requirements, as it is used only to place
+// host-local volumes. It returns the remaining accessibility requirements, or
+// nil if no other segment is left, and the ESXi host of the most preferred
+// topology, which is the topology of the selected node when the volume binding
+// mode of the StorageClass is WaitForFirstConsumer.
+func splitESXiHostTopology(topologyRequirement *csi.TopologyRequirement) (*csi.TopologyRequirement, string) {
+	if topologyRequirement == nil {
+		return nil, ""
+	}
+	var esxiHost string
+	for _, topology := range append(topologyRequirement.GetPreferred(), topologyRequirement.GetRequisite()...) {
+		if host, exists := topology.GetSegments()[common.TopologyLabelESXiHost]; exists {
+			esxiHost = host
+			break
+		}
+	}
+	removeESXiHostSegment := func(topologies []*csi.Topology) []*csi.Topology {
+		var result []*csi.Topology
+		for _, topology := range topologies {
+			segments := make(map[string]string)
+			for key, value := range topology.GetSegments() {
+				if key != common.TopologyLabelESXiHost {
+					segments[key] = value
+				}
+			}
+			if len(segments) == 0 {
+				continue
+			}
+			// Nodes on different hosts in the same topology domain report
+			// the same segments once the host segment is removed.
+			duplicate := false
+			for _, existing := range result {
+				if reflect.DeepEqual(existing.Segments, segments) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				result = append(result, &csi.Topology{Segments: segments})
+			}
+		}
+		return result
+	}
+	requisite := removeESXiHostSegment(topologyRequirement.GetRequisite())
+	preferred := removeESXiHostSegment(topologyRequirement.GetPreferred())
+	if len(requisite) == 0 && len(preferred) == 0 {
+		return nil, esxiHost
+	}
+	return &csi.TopologyRequirement{Requisite: requisite, Preferred: preferred}, esxiHost
+}
+
+// createHostLocalBlockVolume creates a block volume on a local datastore of
+// the given ESXi host. The vCenter managing the host is looked up among the
+// given vCenters. The volume is not created if the host is found in more than
+// one vCenter, since host topology values such as host MoIDs are not unique
+// across vCenters. It returns the vCenter the volume is created in along with
+// the volume information.
+func (c *controller) createHostLocalBlockVolume(ctx context.Context, esxiHost string, vcHosts []string,
+	scParams *common.StorageClassParams, createVolumeSpec *common.CreateVolumeSpec) (
+	string, *vsphere.VirtualCenter, cnsvolume.Manager, *cnsvolume.CnsVolumeInfo, string, error) {
+	log := logger.GetLogger(ctx)
+	sort.Strings(vcHosts)
+	var (
+		matchingVCHosts []string
+		vcenter         *vsphere.VirtualCenter
+		host            *vsphere.HostSystem
+	)
+	for _, vcHost := range vcHosts {
+		vc, err := common.GetVCenterFromVCHost(ctx, c.managers.VcenterManager, vcHost)
+		if err != nil {
+			return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get vCenter instance for host %q. Error: %+v", vcHost, err)
+		}
+		hostSystem, err := vc.GetHostSystemByTopologyValue(ctx, esxiHost)
+		if err != nil {
+			if errors.Is(err, vsphere.ErrHostNotFound) {
+				log.Debugf("ESXi host %q not found in vCenter %q", esxiHost, vcHost)
+				continue
+			}
+			return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to find ESXi host %q in vCenter %q. Error: %+v", esxiHost, vcHost, err)
+		}
+		matchingVCHosts = append(matchingVCHosts, vcHost)
+		vcenter, host = vc, hostSystem
+	}
+	if len(matchingVCHosts) == 0 {
+		return "", nil, nil, nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log,
+			codes.InvalidArgument, "ESXi host %q of the selected node is not found in vCenters %v", esxiHost, vcHosts)
+	}
+	if len(matchingVCHosts) > 1 {
+		return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log,
+			codes.FailedPrecondition, "ESXi host %q is found in several vCenters %v. "+
+				"Unable to determine the host to create the host-local volume on", esxiHost, matchingVCHosts)
+	}
+	vcHost := matchingVCHosts[0]
+	var err error
+	var storagePolicyID string
+	if scParams.StoragePolicyName != "" {
+		storagePolicyID, err = vcenter.GetStoragePolicyIDByName(ctx, scParams.StoragePolicyName)
+		if err != nil {
+			return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get policy ID for storage policy name %q in vCenter %q. Error: %+v",
+				scParams.StoragePolicyName, vcHost, err)
+		}
+	}
+	localDatastores, err := host.GetLocalDatastores(ctx)
+	if err != nil {
+		return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to get local datastores of ESXi host %q. Error: %+v", esxiHost, err)
+	}
+	if len(localDatastores) == 0 {
+		return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log,
+			codes.ResourceExhausted, "no local datastores found on ESXi host %q", esxiHost)
+	}
+	// Filter datastores based on user access.
+	localDatastores, err = c.filterDatastores(ctx, localDatastores, vcHost)
+	if err != nil {
+		return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to filter local datastores of ESXi host %q. Error: %+v", esxiHost, err)
+	}
+	if isDatastoreMaintenanceAwarenessEnabled {
+		localDatastores, err = common.FilterDatastoresUnderMaintenance(ctx, vcenter, localDatastores)
+		if err != nil {
+			return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to filter local datastores under maintenance of ESXi host %q. Error: %+v", esxiHost, err)
+		}
+		if len(localDatastores) == 0 {
+			return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log,
+				codes.ResourceExhausted, "all the local datastores of ESXi host %q are under maintenance", esxiHost)
+		}
+	}
+	log.Infof("Creating host-local volume %q on the local datastores %v of ESXi host %q",
+		createVolumeSpec.Name, localDatastores, esxiHost)
+
+	volumeMgr, err := GetVolumeManagerFromVCHost(ctx, c.managers, vcHost)
+	if err != nil {
+		return "", nil, nil, nil, csifault.CSIInternalFault, logger.LogNewErrorCode(log, codes.Internal,
+			err.Error())
+	}
+	volumeInfo, faultType, err := common.CreateBlockVolumeUtilForMultiVC(ctx,
+		common.VanillaCreateBlockVolParamsForMultiVC{
+			Vcenter:          vcenter,
+			VolumeManager:    volumeMgr,
+			CNSConfig:        c.managers.CnsConfig,
+			StoragePolicyID:  storagePolicyID,
+			Spec:             createVolumeSpec,
+			SharedDatastores: localDatastores,
+			ClusterFlavor:    cnstypes.CnsClusterFlavorVanilla,
+		},
+		common.CreateBlockVolumeOptions{
+			IsCSITransactionSupportEnabled: isCSITransactionSupportEnabled,
+		})
+	if err != nil && cnsvolume.IsNotSupportedFaultType(ctx, faultType) {
+		log.Warnf("NotSupported fault is detected: retrying CreateVolume without VolumeID in spec.")
+		volumeInfo, faultType, err = common.CreateBlockVolumeUtilForMultiVC(ctx,
+			common.VanillaCreateBlockVolParamsForMultiVC{
+				Vcenter:          vcenter,
+				VolumeManager:    volumeMgr,
+				CNSConfig:        c.managers.CnsConfig,
+				StoragePolicyID:  storagePolicyID,
+				Spec:             createVolumeSpec,
+				SharedDatastores: localDatastores,
+				ClusterFlavor:    cnstypes.CnsClusterFlavorVanilla,
+			},
+			common.CreateBlockVolumeOptions{
+				IsCSITransactionSupportEnabled: false,
+			})
+	}
+	if err != nil {
+		return "", nil, nil, nil, faultType, logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to create host-local volume on ESXi host %q. Error: %+v", esxiHost, err)
+	}
+	return vcHost, vcenter, volumeMgr, volumeInfo, "", nil
+}
+
+// selectDatastoreClusterMembers returns the datastores out of sharedDatastores
+// which are members of a datastore cluster and can take new volumes, i.e. are
+// accessible and not in or entering maintenance mode. The datastores are
+// ordered by free space, most free space first.
+func selectDatastoreClusterMembers(members []mo.Datastore,
+	sharedDatastores []*vsphere.DatastoreInfo) []*vsphere.DatastoreInfo {
+	freeSpace := make(map[string]int64)
+	for _, member := range members {
+		if !member.Summary.Accessible || vsphere.IsDatastoreInMaintenanceMode(member.Summary) {
+			continue
+		}
+		freeSpace[member.Reference().Value] = member.Summary.FreeSpace
+	}
+	var selected []*vsphere.DatastoreInfo
+	for _, ds := range sharedDatastores {
+		if _, ok := freeSpace[ds.Reference().Value]; ok {
+			selected = append(selected, ds)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return freeSpace[selected[i].Reference().Value] > freeSpace[selected[j].Reference().Value]
+	})
+	return selected
+}
+
+// getDatastoreClusterPlacement returns the datastore out of sharedDatastores
+// to create the volume on, as a member of the datastore cluster given by name
+// or managed object ID in scParams. The datastore recommended by Storage DRS is used if it
+// is enabled on the datastore cluster. Otherwise, or if Storage DRS has no
+// usable recommendation, the member with the most free space is used.
+func getDatastoreClusterPlacement(ctx context.Context, vc *vsphere.VirtualCenter,
+	scParams *common.StorageClassParams, volumeName string, capacityMB int64,
+	sharedDatastores []*vsphere.DatastoreInfo) ([]*vsphere.DatastoreInfo, error) {
+	log := logger.GetLogger(ctx)
+	var (
+		datastoreCluster string
+		dsc              *vsphere.DatastoreCluster
+		err              error
+	)
+	if scParams.DatastoreClusterID != "" {
+		datastoreCluster = scParams.DatastoreClusterID
+		dsc, err = vc.GetDatastoreClusterByMoID(ctx, datastoreCluster)
+	} else {
+		datastoreCluster = scParams.DatastoreCluster
+		dsc, err = vc.GetDatastoreClusterByName(ctx, datastoreCluster)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datastore cluster %q in vCenter %q. Error: %w",
+			datastoreCluster, vc.Config.Host, err)
+	}
+	members, err := dsc.GetMemberDatastores(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates := selectDatastoreClusterMembers(members, sharedDatastores)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w %q in vCenter %q", errNoDatastoreClusterMember, datastoreCluster,
+			vc.Config.Host)
+	}
+	sdrsEnabled, err := dsc.IsStorageDRSEnabled(ctx)
+	if err != nil {
+		log.Warnf("failed to check if Storage DRS is enabled on datastore cluster %q. Error: %v",
+			datastoreCluster, err)
+	}
+	if sdrsEnabled {
+		recommended, err := dsc.RecommendDatastore(ctx, volumeName, capacityMB, members)
+		if err != nil {
+			log.Warnf("Storage DRS recommendation for volume %q failed, falling back to the datastore "+
+				"with the most free space. Error: %v", volumeName, err)
+		} else {
+			for _, ds := range candidates {
+				if ds.Reference().Value == recommended.Value {
+					log.Infof("Storage DRS recommended datastore %q in datastore cluster %q for volume %q",
+						ds.Info.Url, datastoreCluster, volumeName)
+					return []*vsphere.DatastoreInfo{ds}, nil
+				}
+			}
+			log.Warnf("datastore %q recommended by Storage DRS for volume %q is not compatible, falling "+
+				"back to the datastore with the most free space", recommended.Value, volumeName)
+		}
+	}
+	log.Infof("Selected datastore %q with the most free space in datastore cluster %q for volume %q",
+		candidates[0].Info.Url, datastoreCluster, volumeName)
+	return candidates[:1], nil
+}
+
+// getDatastoreScoringParams returns the params to score the candidate
+// datastores of a volume with the placement strategy given in scParams.
+func getDatastoreScoringParams(vc *vsphere.VirtualCenter, volumeMgr cnsvolume.Manager,
+	scParams *common.StorageClassParams, capacityMB int64) placementengine.DatastoreScoringParams {
+	return placementengine.DatastoreScoringParams{
+		Vcenter:       vc,
+		VolumeManager: volumeMgr,
+		CapacityMB:    capacityMB,
+		TagCategory:   scParams.PlacementTagCategory,
+		TagWeights:    scParams.PlacementTagWeights,
+	}
+}
+
+// isMultiWriterBlockVolumeRequest returns true if the multi-writer block
+// volumes feature is enabled and the given capabilities request a raw block
+// volume written from multiple nodes.
+func isMultiWriterBlockVolumeRequest(ctx context.Context, volCaps []*csi.VolumeCapability) bool {
+	return isMultiWriterBlockVolumesEnabled && common.IsMultiWriterBlockVolumeRequest(ctx, volCaps)
+}
+
+// isValidVolumeCapabilities validates the given volume capabilities,
+// including the capabilities of multi-writer block volumes.
+func isValidVolumeCapabilities(ctx context.Context, volCaps []*csi.VolumeCapability) error {
+	if isMultiWriterBlockVolumeRequest(ctx, volCaps) {
+		return common.IsValidMultiWriterBlockVolumeCapabilities(ctx, volCaps)
+	}
+	return common.IsValidVolumeCapabilities(ctx, volCaps)
+}
+
+// getMultiWriterDatastores verifies that the storage policy provisions
+// eager-zeroed thick disks and returns the given datastores on which the disks
+// can be attached with the multi-writer sharing mode.
+func getMultiWriterDatastores(ctx context.Context, vc *vsphere.VirtualCenter, storagePolicyID string,
+	datastores []*vsphere.DatastoreInfo) ([]*vsphere.DatastoreInfo, error) {
+	policies, err := vc.PbmRetrieveContent(ctx, []string{storagePolicyID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve content of storage policy %q in vCenter %q. Error: %v",
+			storagePolicyID, vc.Config.Host, err)
+	}
+	if len(policies) == 0 || !common.IsEagerZeroedThickPolicy(policies[0]) {
+		return nil, fmt.Errorf("storage policy %q in vCenter %q does not provision eager-zeroed thick disks "+
+			"required by multi-writer block volumes", storagePolicyID, vc.Config.Host)
+	}
+	filtered, err := common.FilterMultiWriterDatastores(ctx, vc, datastores)
+	if err != nil {
+		return nil, err
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("none of the datastores %+v in vCenter %q support multi-writer disks",
+			datastores, vc.Config.Host)
+	}
+	return filtered, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	cnstypes "github.com/vmware/govmomi/cns/types"
 	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/vapi/rest"
 	_ "github.com/vmware/govmomi/vapi/simulator"
@@ -35,6 +36,7 @@ import (
 	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -808,3 +810,90 @@ func setupRealTagsInVCSim(ctx context.Context, vcenter *cnsvsphere.VirtualCenter
 	log.Infof("Successfully set up real tags in vcsim")
 	return nil
 }
+
+func TestSplitESXiHostTopology(t *testing.T) {
+	zoneKey := "topology.csi.vmware.com/k8s-zone"
+	topologyRequirement := &csi.TopologyRequirement{
+		Requisite: []*csi.Topology{
+			{Segments: map[string]string{zoneKey: "zone-a", common.TopologyLabelESXiHost: "esx-02"}},
+			{Segments: map[string]string{zoneKey: "zone-a", common.TopologyLabelESXiHost: "esx-01"}},
+		},
+		Preferred: []*csi.Topology{
+			{Segments: map[string]string{zoneKey: "zone-a", common.TopologyLabelESXiHost: "esx-01"}},
+			{Segments: map[string]string{zoneKey: "zone-a", common.TopologyLabelESXiHost: "esx-02"}},
+		},
+	}
+	remaining, esxiHost := splitESXiHostTopology(topologyRequirement)
+	if esxiHost != "esx-01" {
+		t.Errorf("expected ESXi host %q of the most preferred topology, got %q", "esx-01", esxiHost)
+	}
+	if remaining == nil || len(remaining.Requisite) != 1 || len(remaining.Preferred) != 1 {
+		t.Fatalf("expected a single requisite and preferred topology, got %+v", remaining)
+	}
+	if remaining.Preferred[0].Segments[zoneKey] != "zone-a" ||
+		len(remaining.Preferred[0].Segments) != 1 {
+		t.Errorf("unexpected preferred topology %+v", remaining.Preferred[0])
+	}
+
+	// Only the ESXi host segment is reported by nodes which do not belong to
+	// any topology domain.
+	remaining, esxiHost = splitESXiHostTopology(&csi.TopologyRequirement{
+		Requisite: []*csi.Topology{{Segments: map[string]string{common.TopologyLabelESXiHost: "esx-03"}}},
+	})
+	if remaining != nil || esxiHost != "esx-03" {
+		t.Errorf("expected nil topology requirement and ESXi host %q, got %+v and %q", "esx-03",
+			remaining, esxiHost)
+	}
+
+	remaining, esxiHost = splitESXiHostTopology(nil)
+	if remaining != nil || esxiHost != "" {
+		t.Errorf("expected nil topology requirement and no ESXi host, got %+v and %q", remaining, esxiHost)
+	}
+}
+
+func TestSelectDatastoreClusterMembers(t *testing.T) {
+	newMember := func(name string, freeSpace int64, accessible bool, maintenanceMode string) mo.Datastore {
+		member := mo.Datastore{
+			Summary: vimtypes.DatastoreSummary{
+				Name:            name,
+				FreeSpace:       freeSpace,
+				Accessible:      accessible,
+				MaintenanceMode: maintenanceMode,
+			},
+		}
+		member.Self = vimtypes.ManagedObjectReference{Type: "Datastore", Value: name}
+		return member
+	}
+	newShared := func(name string) *cnsvsphere.DatastoreInfo {
+		return &cnsvsphere.DatastoreInfo{
+			Datastore: &cnsvsphere.Datastore{
+				Datastore: object.NewDatastore(nil, vimtypes.ManagedObjectReference{Type: "Datastore", Value: name}),
+			},
+			Info: &vimtypes.DatastoreInfo{Name: name, Url: "ds:///vmfs/volumes/" + name + "/"},
+		}
+	}
+	members := []mo.Datastore{
+		newMember("ds-1", 100, true, "normal"),
+		newMember("ds-2", 300, true, ""),
+		newMember("ds-3", 500, true, "enteringMaintenance"),
+		newMember("ds-4", 700, false, "normal"),
+		newMember("ds-5", 900, true, "normal"),
+	}
+	// ds-5 is not accessible from the nodes, ds-6 is not a member.
+	shared := []*cnsvsphere.DatastoreInfo{newShared("ds-1"), newShared("ds-2"), newShared("ds-3"),
+		newShared("ds-4"), newShared("ds-6")}
+
+	selected := selectDatastoreClusterMembers(members, shared)
+	var names []string
+	for _, ds := range selected {
+		names = append(names, ds.Info.Name)
+	}
+	if len(names) != 2 || names[0] != "ds-2" || names[1] != "ds-1" {
+		t.Errorf("expected datastores [ds-2 ds-1], got %v", names)
+	}
+
+	selected = selectDatastoreClusterMembers(members[2:4], shared)
+	if len(selected) != 0 {
+		t.Errorf("expected no datastores to be selected, got %v", selected)
+	}
+}
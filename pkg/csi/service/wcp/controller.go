@@ -264,6 +264,13 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 		log.Errorf("checkAPI failed for vcenter API version: %s, err=%v", vc.Client.ServiceContent.About.ApiVersion, err)
 		return err
 	}
+	// Expose the health of the vCenter session, CNS ListView and informers
+	// through the Probe call and the health endpoints.
+	common.RegisterVCenterHealthChecks(&common.Managers{
+		VcenterManager: c.manager.VcenterManager,
+		VolumeManagers: map[string]cnsvolume.Manager{vcenterconfig.Host: volumeManager},
+	})
+	common.RegisterInformersHealthCheck()
 
 	go cnsvolume.ClearTaskInfoObjects()
 	go cnsvolume.ClearInvalidTasksFromListView(false)
@@ -374,6 +381,7 @@ func (c *controller) Init(config *cnsconfig.Config, version string) error {
 	// Go module to keep the metrics http server running all the time.
 	go func() {
 		prometheus.CsiInfo.WithLabelValues(version).Set(1)
+		common.RegisterHealthEndpoints(http.DefaultServeMux)
 		for {
 			log.Info("Starting the http server to expose Prometheus metrics..")
 			http.Handle("/metrics", promhttp.Handler())
@@ -1697,6 +1705,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 	start := time.Now()
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 
 	volumeType := prometheus.PrometheusUnknownVolumeType
 	createVolumeInternal := func() (
@@ -1762,6 +1771,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 		return c.createBlockVolume(ctx, req, isWorkloadDomainIsolationEnabled, clusterMoIds)
 	}
 	resp, faultType, err := createVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("createVolumeInternal: returns fault %q", faultType)
 
 	if err != nil {
@@ -1773,6 +1783,12 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			prometheus.PrometheusCreateVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", req.Parameters[common.AttributePvcNamespace],
+			req.Parameters[common.AttributePvcName], commoncotypes.FaultEvent{
+				Reason:    "ProvisioningFailed",
+				Operation: "CreateVolume",
+				FaultType: faultType,
+			}, taskDetails, err)
 	} else {
 		log.Infof("Volume created successfully. Volume Handle: %q, PV Name: %q", resp.Volume.VolumeId, req.Name)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
@@ -1834,6 +1850,7 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 		return &csi.DeleteVolumeResponse{}, "", nil
 	}
 	resp, faultType, err := deleteVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("deleteVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 
 	if err != nil {
@@ -1870,6 +1887,7 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 	start := time.Now()
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 	volumeType := prometheus.PrometheusUnknownVolumeType
 
 	controllerPublishVolumeInternal := func() (
@@ -2018,6 +2036,7 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 		return resp, "", nil
 	}
 	resp, faultType, err := controllerPublishVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("controllerPublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 
 	if err != nil {
@@ -2029,6 +2048,13 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 			prometheus.PrometheusAttachVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		pvcName, pvcNamespace, _ := commonco.ContainerOrchestratorUtility.GetPVCNameFromCSIVolumeID(req.VolumeId)
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", pvcNamespace, pvcName, commoncotypes.FaultEvent{
+			Reason:    "FailedAttachVolume",
+			Operation: "ControllerPublishVolume",
+			FaultType: faultType,
+			NodeName:  req.NodeId,
+		}, taskDetails, err)
 	} else {
 		log.Infof("Volume %q attached successfully.", req.VolumeId)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
@@ -2287,6 +2313,7 @@ func (c *controller) ControllerUnpublishVolume(ctx context.Context, req *csi.Con
 		}
 	}
 	resp, faultType, err := controllerUnpublishVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("controllerUnpublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 
 	if err != nil {
@@ -2438,6 +2465,7 @@ func (c *controller) ListVolumes(ctx context.Context, req *csi.ListVolumesReques
 		return response, "", nil
 	}
 	resp, faultType, err := controllerListVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
 			faultType = csifault.AddCsiNonStoragePrefix(ctx, faultType)
@@ -2492,6 +2520,7 @@ func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshot
 
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
+	ctx, taskDetails := cnsvolume.WithOperationTaskDetails(ctx)
 	log.Infof("WCP CreateSnapshot: called with args %+v", req)
 	isBlockVolumeSnapshotWCPEnabled := commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.BlockVolumeSnapshot)
 	if !isBlockVolumeSnapshotWCPEnabled {
@@ -2655,6 +2684,11 @@ func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshot
 	if err != nil {
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
 			prometheus.PrometheusFailStatus, "NotComputed").Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "VolumeSnapshot", req.Parameters[common.VolumeSnapshotNamespaceKey],
+			req.Parameters[common.VolumeSnapshotNameKey], commoncotypes.FaultEvent{
+				Reason:    "SnapshotCreationFailed",
+				Operation: "CreateSnapshot",
+			}, taskDetails, err)
 	} else {
 		log.Infof("Snapshot for volume %q created successfully.", req.GetSourceVolumeId())
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
@@ -2908,6 +2942,7 @@ func (c *controller) ControllerExpandVolume(ctx context.Context, req *csi.Contro
 		return resp, "", nil
 	}
 	resp, faultType, err := controllerExpandVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("controllerExpandVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 
 	if err != nil {
@@ -58,6 +58,7 @@ import (
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/utils"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
+	commoncotypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco/types"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
 	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
@@ -324,7 +325,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 		// Get PVC name and disk size for the supervisor cluster
 		// We use default prefix 'pvc-' for pvc created in the guest cluster, it is mandatory.
 		supervisorPVCName := c.tanzukubernetesClusterUID + "-" + req.Name[4:]
-		var volumeSnapshotName string
+		var volumeSnapshotName, sourceVolumeName string
 
 		// Volume Size - Default is 10 GiB
 		volSizeBytes := int64(common.DefaultGbDiskSize * common.GbInBytes)
@@ -333,14 +334,20 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 		}
 		volSizeMB := int64(common.RoundUpSize(volSizeBytes, common.MbInBytes))
 		volumeSource := req.GetVolumeContentSource()
-		if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.BlockVolumeSnapshot) &&
-			volumeSource != nil {
-			sourceSnapshot := volumeSource.GetSnapshot()
-			if sourceSnapshot == nil {
+		if volumeSource != nil {
+			isBlockVolumeSnapshotEnabled := commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
+				common.BlockVolumeSnapshot)
+			isVolumeCloneEnabled := commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.VolumeClone)
+			switch {
+			case isBlockVolumeSnapshotEnabled && volumeSource.GetSnapshot() != nil:
+				volumeSnapshotName = volumeSource.GetSnapshot().GetSnapshotId()
+			case isVolumeCloneEnabled && volumeSource.GetVolume() != nil:
+				// Within the Guest, volumeID is the supervisor PVC name.
+				sourceVolumeName = volumeSource.GetVolume().GetVolumeId()
+			case isBlockVolumeSnapshotEnabled || isVolumeCloneEnabled:
 				return nil, csifault.CSIInvalidArgumentFault,
 					logger.LogNewErrorCode(log, codes.InvalidArgument, "unsupported VolumeContentSource type")
 			}
-			volumeSnapshotName = sourceSnapshot.GetSnapshotId()
 		}
 
 		// Get supervisorStorageClass and accessMode
@@ -387,6 +394,26 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			ctx, supervisorPVCName, metav1.GetOptions{})
 		if err != nil {
 			if errors.IsNotFound(err) {
+				if sourceVolumeName != "" {
+					// Validate the source PVC only when the clone is not created yet, so that
+					// retries of CreateVolume are not affected by later changes of the source.
+					err = validateSupervisorCloneSource(ctx, c.supervisorClient, c.supervisorNamespace,
+						c.tanzukubernetesClusterUID, sourceVolumeName, volSizeBytes, isFileVolumeRequest)
+					if err != nil {
+						return nil, csifault.CSIInvalidArgumentFault, err
+					}
+				}
+				claimSourcePVCName := sourceVolumeName
+				if sourceVolumeName != "" && isLinkedCloneRequest {
+					// Supervisor creates linked clones from a VolumeSnapshot only, so take
+					// a snapshot of the source volume and create the linked clone from it.
+					volumeSnapshotName, err = c.createLinkedCloneSourceSnapshot(ctx, supervisorPVCName,
+						sourceVolumeName, req.Parameters)
+					if err != nil {
+						return nil, csifault.CSIInternalFault, err
+					}
+					claimSourcePVCName = ""
+				}
 				diskSize := strconv.FormatInt(volSizeMB, 10) + "Mi"
 				labels := make(map[string]string)
 				annotations := make(map[string]string)
@@ -420,7 +447,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 				}
 				claim := getPersistentVolumeClaimSpecWithStorageClass(supervisorPVCName, c.supervisorNamespace,
 					diskSize, supervisorStorageClass, getAccessMode(accessMode), annotations, labels, finalizers,
-					volumeSnapshotName, isLinkedCloneRequest)
+					volumeSnapshotName, claimSourcePVCName, isLinkedCloneRequest)
 				log.Debugf("PVC claim spec is %+v", spew.Sdump(claim))
 				pvc, err = c.supervisorClient.CoreV1().PersistentVolumeClaims(c.supervisorNamespace).Create(
 					ctx, claim, metav1.CreateOptions{})
@@ -482,9 +509,9 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			attributes[common.AttributeDiskType] = common.DiskTypeBlockVolume
 		}
 
-		if isLinkedCloneRequest {
+		if isLinkedCloneRequest && sourceVolumeName == "" {
 			// Add a linked clone attribute to PV to be able to determine the volume is a LinkedClone even if the PVC
-			// is deleted.
+			// is deleted. Linked clones created from a PVC have no VolumeSnapshot source in the guest cluster.
 			volumeSnapshotUID, err := commonco.ContainerOrchestratorUtility.
 				GetLinkedCloneVolumeSnapshotSourceUUID(ctx, pvcName, pvcNamespace)
 			if err != nil {
@@ -514,6 +541,16 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 				},
 			}
 		}
+		// Set the Volume VolumeContentSource in the CreateVolumeResponse
+		if sourceVolumeName != "" {
+			resp.Volume.ContentSource = &csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Volume{
+					Volume: &csi.VolumeContentSource_VolumeSource{
+						VolumeId: sourceVolumeName,
+					},
+				},
+			}
+		}
 
 		// Calculate node affinity terms for topology aware provisioning.
 		var accessibleTopologies []map[string]string
@@ -551,6 +588,7 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 		return resp, "", nil
 	}
 	resp, faultType, err := createVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("createVolumeInternal: returns fault %q", faultType)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -561,6 +599,12 @@ func (c *controller) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			prometheus.PrometheusCreateVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", req.Parameters[common.AttributePvcNamespace],
+			req.Parameters[common.AttributePvcName], commoncotypes.FaultEvent{
+				Reason:    "ProvisioningFailed",
+				Operation: "CreateVolume",
+				FaultType: faultType,
+			}, nil, err)
 	} else {
 		log.Infof("Volume created successfully. Volume Handle: %q, PV Name: %q", resp.Volume.VolumeId, req.Name)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateVolumeOpType,
@@ -603,6 +647,10 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 			if errors.IsNotFound(err) {
 				log.Debugf("PVC: %q not found in the Supervisor cluster. Assuming the volume is already deleted.",
 					req.VolumeId)
+				err = c.deleteLinkedCloneSourceSnapshot(ctx, req.VolumeId)
+				if err != nil {
+					return nil, csifault.CSIInternalFault, err
+				}
 				return &csi.DeleteVolumeResponse{}, "", nil
 			}
 			msg := fmt.Sprintf("failed to retrieve supervisor PVC %q in %q namespace. Error: %+v",
@@ -653,6 +701,10 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 			if errors.IsNotFound(err) {
 				log.Debugf("PVC: %q not found in the Supervisor cluster. Assuming this volume to be deleted.",
 					req.VolumeId)
+				err = c.deleteLinkedCloneSourceSnapshot(ctx, req.VolumeId)
+				if err != nil {
+					return nil, csifault.CSIInternalFault, err
+				}
 				return &csi.DeleteVolumeResponse{}, "", nil
 			}
 			msg := fmt.Sprintf("DeleteVolume Request: %+v has failed. Error: %+v", req, err)
@@ -670,10 +722,15 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 			log.Error(msg)
 			return nil, csifault.CSIInternalFault, status.Error(codes.Internal, msg)
 		}
+		err = c.deleteLinkedCloneSourceSnapshot(ctx, req.VolumeId)
+		if err != nil {
+			return nil, csifault.CSIInternalFault, err
+		}
 		log.Infof("DeleteVolume: Volume deleted successfully. VolumeID: %q", req.VolumeId)
 		return &csi.DeleteVolumeResponse{}, "", nil
 	}
 	resp, faultType, err := deleteVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("deleteVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -692,6 +749,116 @@ func (c *controller) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 	return resp, err
 }
 
+// createLinkedCloneSourceSnapshot creates the VolumeSnapshot of the source PVC
+// in the supervisor cluster from which a linked clone requested with a PVC
+// source is created. The VolumeSnapshot is named after the supervisor PVC of
+// the linked clone and is deleted along with it. It returns the name of the
+// VolumeSnapshot once it is ready to use.
+func (c *controller) createLinkedCloneSourceSnapshot(ctx context.Context, supervisorPVCName string,
+	sourcePVCName string, params map[string]string) (string, error) {
+	log := logger.GetLogger(ctx)
+	var supervisorVolumeSnapshotClass string
+	for param := range params {
+		if strings.ToLower(param) == common.AttributeSupervisorVolumeSnapshotClass {
+			supervisorVolumeSnapshotClass = params[param]
+		}
+	}
+	_, err := c.supervisorSnapshotterClient.SnapshotV1().VolumeSnapshots(c.supervisorNamespace).Get(
+		ctx, supervisorPVCName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get volumesnapshot with name: %s on namespace: %s in supervisorCluster. Error: %+v",
+				supervisorPVCName, c.supervisorNamespace, err)
+		}
+		annotations := map[string]string{
+			common.SupervisorVolumeSnapshotAnnotationKey:    "true",
+			common.SupervisorLinkedCloneSourceAnnotationKey: sourcePVCName,
+		}
+		labels := make(map[string]string)
+		finalizers := []string{}
+		if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.SVPVCSnapshotProtectionFinalizer) {
+			key := fmt.Sprintf("%s/%s", c.tanzukubernetesClusterName, c.guestClusterDist)
+			labels[key] = c.tanzukubernetesClusterUID
+			finalizers = append(finalizers, cnsoperatortypes.CNSSnapshotFinalizer)
+		}
+		supVolumeSnapshot := constructVolumeSnapshotWithVolumeSnapshotClass(supervisorPVCName,
+			c.supervisorNamespace, supervisorVolumeSnapshotClass, sourcePVCName, annotations, labels, finalizers)
+		if supervisorVolumeSnapshotClass == "" {
+			// Use the default VolumeSnapshotClass of the supervisor cluster.
+			supVolumeSnapshot.Spec.VolumeSnapshotClassName = nil
+		}
+		_, err = c.supervisorSnapshotterClient.SnapshotV1().VolumeSnapshots(c.supervisorNamespace).Create(
+			ctx, supVolumeSnapshot, metav1.CreateOptions{})
+		if err != nil {
+			return "", logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to create volumesnapshot with name: %s on namespace: %s in supervisorCluster. Error: %+v",
+				supervisorPVCName, c.supervisorNamespace, err)
+		}
+		log.Infof("Created VolumeSnapshot %s/%s of PVC %q on the supervisor cluster for a linked clone",
+			c.supervisorNamespace, supervisorPVCName, sourcePVCName)
+	}
+	isReady, _, err := common.IsVolumeSnapshotReady(ctx, c.supervisorSnapshotterClient,
+		supervisorPVCName, c.supervisorNamespace, time.Duration(getSnapshotTimeoutInMin(ctx))*time.Minute)
+	if !isReady {
+		// Return DeadlineExceeded so that external-provisioner keeps retrying.
+		return "", logger.LogNewErrorCodef(log, codes.DeadlineExceeded,
+			"volumesnapshot: %s on namespace: %s in supervisor cluster was not Ready. Error: %+v",
+			supervisorPVCName, c.supervisorNamespace, err)
+	}
+	return supervisorPVCName, nil
+}
+
+// deleteLinkedCloneSourceSnapshot deletes the VolumeSnapshot created in the
+// supervisor cluster by createLinkedCloneSourceSnapshot for the given
+// supervisor PVC, if any. It is a no-op for volumes which are not linked
+// clones created from a PVC.
+func (c *controller) deleteLinkedCloneSourceSnapshot(ctx context.Context, supervisorPVCName string) error {
+	log := logger.GetLogger(ctx)
+	if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.LinkedCloneSupportFSS) {
+		return nil
+	}
+	vs, err := c.supervisorSnapshotterClient.SnapshotV1().VolumeSnapshots(c.supervisorNamespace).Get(
+		ctx, supervisorPVCName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to get volumesnapshot with name: %s on namespace: %s in supervisorCluster. Error: %+v",
+			supervisorPVCName, c.supervisorNamespace, err)
+	}
+	if !metav1.HasAnnotation(vs.ObjectMeta, common.SupervisorLinkedCloneSourceAnnotationKey) {
+		return nil
+	}
+	if idx := slices.Index(vs.Finalizers, cnsoperatortypes.CNSSnapshotFinalizer); idx != -1 {
+		vs.Finalizers = slices.Delete(vs.Finalizers, idx, idx+1)
+		_, err = c.supervisorSnapshotterClient.SnapshotV1().VolumeSnapshots(c.supervisorNamespace).Update(
+			ctx, vs, metav1.UpdateOptions{})
+		if err != nil {
+			return logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to update supervisor VolumeSnapshot %q in %q namespace. Error: %+v",
+				supervisorPVCName, c.supervisorNamespace, err)
+		}
+	}
+	err = c.supervisorSnapshotterClient.SnapshotV1().VolumeSnapshots(c.supervisorNamespace).Delete(
+		ctx, supervisorPVCName, *metav1.NewDeleteOptions(0))
+	if err != nil && !errors.IsNotFound(err) {
+		return logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to delete the supervisor volumesnapshot %s/%s, Error: %+v",
+			c.supervisorNamespace, supervisorPVCName, err)
+	}
+	err = common.WaitForVolumeSnapshotDeleted(ctx, c.supervisorSnapshotterClient,
+		supervisorPVCName, c.supervisorNamespace, time.Duration(getSnapshotTimeoutInMin(ctx))*time.Minute)
+	if err != nil {
+		return logger.LogNewErrorCodef(log, codes.Internal,
+			"volumeSnapshot: %s on namespace: %s in supervisor cluster was not deleted. Error: %+v",
+			supervisorPVCName, c.supervisorNamespace, err)
+	}
+	log.Infof("Deleted VolumeSnapshot %s/%s created for the linked clone", c.supervisorNamespace, supervisorPVCName)
+	return nil
+}
+
 // ControllerPublishVolume attaches a volume to the Node VM.
 // volume id and node name is retrieved from ControllerPublishVolumeRequest
 func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.ControllerPublishVolumeRequest) (
@@ -739,6 +906,7 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 	}
 
 	resp, faultType, err := controllerPublishVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	if err != nil {
 		log.Debugf("controllerPublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 		if csifault.IsNonStorageFault(faultType) {
@@ -749,6 +917,13 @@ func (c *controller) ControllerPublishVolume(ctx context.Context, req *csi.Contr
 			prometheus.PrometheusAttachVolumeOpType, volumeType, faultType)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
 			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+		pvcName, pvcNamespace, _ := commonco.ContainerOrchestratorUtility.GetPVCNameFromCSIVolumeID(req.VolumeId)
+		commonco.RecordFaultEvent(ctx, "PersistentVolumeClaim", pvcNamespace, pvcName, commoncotypes.FaultEvent{
+			Reason:    "FailedAttachVolume",
+			Operation: "ControllerPublishVolume",
+			FaultType: faultType,
+			NodeName:  req.NodeId,
+		}, nil, err)
 	} else {
 		log.Infof("Volume %q attached successfully to node %q", req.VolumeId, req.NodeId)
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusAttachVolumeOpType,
@@ -1073,13 +1248,7 @@ func (c *controller) ControllerUnpublishVolume(ctx context.Context, req *csi.Con
 			log.Error(msg)
 			return nil, csifault.CSIInternalFault, status.Error(codes.Internal, msg)
 		}
-		var isFileVolume bool
-		for _, accessMode := range svPVC.Spec.AccessModes {
-			if accessMode == corev1.ReadWriteMany || accessMode == corev1.ReadOnlyMany {
-				isFileVolume = true
-			}
-		}
-		if isFileVolume {
+		if isFileVolumePVC(svPVC) {
 			volumeType = prometheus.PrometheusFileVolumeType
 			if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.FileVolume) {
 				return controllerUnpublishForFileVolume(ctx, req, c)
@@ -1091,6 +1260,7 @@ func (c *controller) ControllerUnpublishVolume(ctx context.Context, req *csi.Con
 		return controllerUnpublishForBlockVolume(ctx, req, c)
 	}
 	resp, faultType, err := controllerUnpublishVolumeInternal()
+	err = csifault.ToGRPCErrorForRemoval(faultType, err)
 	log.Debugf("controllerUnpublishVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -1476,6 +1646,7 @@ func (c *controller) ControllerExpandVolume(ctx context.Context, req *csi.Contro
 		return resp, "", nil
 	}
 	resp, faultType, err := controllerExpandVolumeInternal()
+	err = csifault.ToGRPCError(faultType, err)
 	log.Debugf("controllerExpandVolumeInternal: returns fault %q for volume %q", faultType, req.VolumeId)
 	if err != nil {
 		if csifault.IsNonStorageFault(faultType) {
@@ -1510,13 +1681,149 @@ func (c *controller) ValidateVolumeCapabilities(ctx context.Context, req *csi.Va
 	}, nil
 }
 
+// ListVolumes lists the supervisor PVCs created for this guest cluster along
+// with the guest nodes each of them is published to. Pagination is delegated
+// to the supervisor API server, so the StartingToken is the continue token
+// returned by the previous list call.
 func (c *controller) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (
 	*csi.ListVolumesResponse, error) {
-
+	start := time.Now()
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
-	log.Infof("ListVolumes: called with args %+v", req)
-	return nil, status.Error(codes.Unimplemented, "")
+	volumeType := prometheus.PrometheusUnknownVolumeType
+	if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.ListVolumes) {
+		return nil, logger.LogNewErrorCode(log, codes.Unimplemented, "List Volumes")
+	}
+
+	listVolumesInternal := func() (*csi.ListVolumesResponse, string, error) {
+		log.Debugf("ListVolumes: called with args %+v", req)
+		maxEntries := int64(commonconfig.DefaultQueryLimit)
+		if req.MaxEntries != 0 {
+			maxEntries = int64(req.MaxEntries)
+		}
+		// Only list the PVCs which belong to this guest cluster, which are
+		// labeled with its UID by CreateVolume, so that the pages are not
+		// filled with the PVCs of the other guest clusters in the namespace.
+		labelSelector := fmt.Sprintf("%s/%s=%s", c.tanzukubernetesClusterName, c.guestClusterDist,
+			c.tanzukubernetesClusterUID)
+		pvcList, err := c.supervisorClient.CoreV1().PersistentVolumeClaims(c.supervisorNamespace).List(ctx,
+			metav1.ListOptions{
+				LabelSelector: labelSelector,
+				Limit:         maxEntries,
+				Continue:      req.StartingToken,
+			})
+		if err != nil {
+			if errors.IsResourceExpired(err) || errors.IsGone(err) || errors.IsBadRequest(err) {
+				return nil, csifault.CSIInvalidArgumentFault, logger.LogNewErrorCodef(log, codes.Aborted,
+					"startingToken %q is not valid. Error: %+v", req.StartingToken, err)
+			}
+			return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to list PVCs in supervisor namespace %q. Error: %+v", c.supervisorNamespace, err)
+		}
+
+		// Fetch the published nodes only once per page.
+		blockVolumeToNodes, err := c.getBlockVolumeToNodesMap(ctx)
+		if err != nil {
+			return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+				"failed to get published nodes for block volumes. Error: %+v", err)
+		}
+		var fileVolumeToNodes map[string][]string
+		if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.FileVolume) {
+			fileVolumeToNodes, err = c.getFileVolumeToNodesMap(ctx)
+			if err != nil {
+				return nil, csifault.CSIInternalFault, logger.LogNewErrorCodef(log, codes.Internal,
+					"failed to get published nodes for file volumes. Error: %+v", err)
+			}
+		}
+
+		var entries []*csi.ListVolumesResponse_Entry
+		for _, svPVC := range pvcList.Items {
+			// Skip PVCs which are not bound yet, as CreateVolume has not returned them.
+			if svPVC.Status.Phase != corev1.ClaimBound {
+				continue
+			}
+			publishedNodeIds := blockVolumeToNodes[svPVC.Name]
+			volumeType = prometheus.PrometheusBlockVolumeType
+			if isFileVolumePVC(&svPVC) {
+				publishedNodeIds = fileVolumeToNodes[svPVC.Name]
+				volumeType = prometheus.PrometheusFileVolumeType
+			}
+			entries = append(entries, &csi.ListVolumesResponse_Entry{
+				Volume: &csi.Volume{
+					VolumeId:      svPVC.Name,
+					CapacityBytes: svPVC.Status.Capacity.Storage().Value(),
+				},
+				Status: &csi.ListVolumesResponse_VolumeStatus{
+					PublishedNodeIds: publishedNodeIds,
+				},
+			})
+		}
+		log.Debugf("ListVolumes served %d results, token for next set: %q", len(entries), pvcList.Continue)
+		return &csi.ListVolumesResponse{
+			Entries:   entries,
+			NextToken: pvcList.Continue,
+		}, "", nil
+	}
+
+	resp, faultType, err := listVolumesInternal()
+	err = csifault.ToGRPCError(faultType, err)
+	if err != nil {
+		if csifault.IsNonStorageFault(faultType) {
+			faultType = csifault.AddCsiNonStoragePrefix(ctx, faultType)
+		}
+		log.Errorf("Operation failed, reporting failure status to Prometheus."+
+			" Operation Type: %q, Volume Type: %q, Fault Type: %q",
+			prometheus.PrometheusListVolumeOpType, volumeType, faultType)
+		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusListVolumeOpType,
+			prometheus.PrometheusFailStatus, faultType).Observe(time.Since(start).Seconds())
+	} else {
+		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusListVolumeOpType,
+			prometheus.PrometheusPassStatus, faultType).Observe(time.Since(start).Seconds())
+	}
+	return resp, err
+}
+
+// getBlockVolumeToNodesMap reads the volume status of all VirtualMachines in the
+// supervisor namespace and returns a map of supervisor PVC name to the names of
+// the guest nodes the volume is attached to. Volumes which are being detached are
+// still reported as published until VM Operator removes them from the status.
+func (c *controller) getBlockVolumeToNodesMap(ctx context.Context) (map[string][]string, error) {
+	vmList, err := utils.ListVirtualMachines(ctx, c.vmOperatorClient, c.supervisorNamespace)
+	if err != nil {
+		return nil, err
+	}
+	volumeToNodes := make(map[string][]string)
+	for _, vm := range vmList.Items {
+		for _, volume := range vm.Status.Volumes {
+			if !volume.Attached {
+				continue
+			}
+			name := removeDetachingSuffixFromVolumeName(volume.Name)
+			volumeToNodes[name] = append(volumeToNodes[name], vm.Name)
+		}
+	}
+	return volumeToNodes, nil
+}
+
+// getFileVolumeToNodesMap returns a map of supervisor PVC name to the names of the
+// guest nodes which have been granted access to the file volume through a
+// CnsFileAccessConfig instance.
+func (c *controller) getFileVolumeToNodesMap(ctx context.Context) (map[string][]string, error) {
+	cnsFileAccessConfigList := &cnsfileaccessconfigv1alpha1.CnsFileAccessConfigList{}
+	err := c.cnsOperatorClient.List(ctx, cnsFileAccessConfigList, client.InNamespace(c.supervisorNamespace))
+	if err != nil {
+		return nil, err
+	}
+	volumeToNodes := make(map[string][]string)
+	for _, cnsFileAccessConfig := range cnsFileAccessConfigList.Items {
+		if !cnsFileAccessConfig.Status.Done || cnsFileAccessConfig.DeletionTimestamp != nil ||
+			cnsFileAccessConfig.Spec.VMName == "" {
+			continue
+		}
+		volumeToNodes[cnsFileAccessConfig.Spec.PvcName] = append(volumeToNodes[cnsFileAccessConfig.Spec.PvcName],
+			cnsFileAccessConfig.Spec.VMName)
+	}
+	return volumeToNodes, nil
 }
 
 func (c *controller) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (
@@ -1561,8 +1868,16 @@ func (c *controller) ControllerGetCapabilities(ctx context.Context, req *csi.Con
 	ctx = logger.NewContextWithLogger(ctx)
 	log := logger.GetLogger(ctx)
 	log.Infof("ControllerGetCapabilities: called with args %+v", req)
-	var caps []*csi.ControllerServiceCapability
-	for _, cap := range controllerCaps {
+	caps := slices.Clone(controllerCaps)
+	if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.ListVolumes) {
+		caps = append(caps, csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+			csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES)
+	}
+	if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.VolumeClone) {
+		caps = append(caps, csi.ControllerServiceCapability_RPC_CLONE_VOLUME)
+	}
+	var capabilities []*csi.ControllerServiceCapability
+	for _, cap := range caps {
 		c := &csi.ControllerServiceCapability{
 			Type: &csi.ControllerServiceCapability_Rpc{
 				Rpc: &csi.ControllerServiceCapability_RPC{
@@ -1570,9 +1885,9 @@ func (c *controller) ControllerGetCapabilities(ctx context.Context, req *csi.Con
 				},
 			},
 		}
-		caps = append(caps, c)
+		capabilities = append(capabilities, c)
 	}
-	return &csi.ControllerGetCapabilitiesResponse{Capabilities: caps}, nil
+	return &csi.ControllerGetCapabilitiesResponse{Capabilities: capabilities}, nil
 }
 
 func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (
@@ -1703,6 +2018,11 @@ func (c *controller) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshot
 	if err != nil {
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
 			prometheus.PrometheusFailStatus, "NotComputed").Observe(time.Since(start).Seconds())
+		commonco.RecordFaultEvent(ctx, "VolumeSnapshot", req.Parameters[common.VolumeSnapshotNamespaceKey],
+			req.Parameters[common.VolumeSnapshotNameKey], commoncotypes.FaultEvent{
+				Reason:    "SnapshotCreationFailed",
+				Operation: "CreateSnapshot",
+			}, nil, err)
 	} else {
 		log.Infof("Snapshot for volume %q created successfully.", req.GetSourceVolumeId())
 		prometheus.CsiControlOpsHistVec.WithLabelValues(volumeType, prometheus.PrometheusCreateSnapshotOpType,
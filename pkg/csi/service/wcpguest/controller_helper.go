@@ -31,6 +31,7 @@ import (
 	snap "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	"google.golang.org/grpc/codes"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -89,6 +90,8 @@ func validateGuestClusterCreateVolumeRequest(ctx context.Context, req *csi.Creat
 				return logger.LogNewErrorCodef(log, codes.InvalidArgument,
 					"Volume parameter %s is not a valid GC CSI parameter", param)
 			}
+		case common.AttributeSupervisorVolumeSnapshotClass:
+			// Used to snapshot the source volume of a linked clone created from a PVC.
 		case common.AttributePvcNamespace:
 		case common.AttributePvcName:
 		case common.AttributePvName:
@@ -201,6 +204,56 @@ func checkPVCCondition(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 	return false
 }
 
+// validateSupervisorCloneSource validates that the supervisor PVC used as the
+// source of a clone belongs to this guest cluster, is a bound block volume and
+// is not larger than the requested size of the clone.
+func validateSupervisorCloneSource(ctx context.Context, client clientset.Interface, namespace string,
+	tanzukubernetesClusterUID string, sourcePVCName string, requestedBytes int64, isFileVolumeRequest bool) error {
+	log := logger.GetLogger(ctx)
+	if isFileVolumeRequest {
+		return logger.LogNewErrorCode(log, codes.InvalidArgument, "cloning of file volumes is not supported")
+	}
+	if !strings.HasPrefix(sourcePVCName, tanzukubernetesClusterUID+"-") {
+		return logger.LogNewErrorCodef(log, codes.InvalidArgument,
+			"source volume %q does not belong to this guest cluster", sourcePVCName)
+	}
+	sourcePVC, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, sourcePVCName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return logger.LogNewErrorCodef(log, codes.NotFound,
+				"source volume %q not found in supervisor namespace %q", sourcePVCName, namespace)
+		}
+		return logger.LogNewErrorCodef(log, codes.Internal,
+			"failed to get source volume %q in supervisor namespace %q. Error: %+v", sourcePVCName, namespace, err)
+	}
+	if isFileVolumePVC(sourcePVC) {
+		return logger.LogNewErrorCodef(log, codes.InvalidArgument,
+			"source volume %q is a file volume. Cloning of file volumes is not supported", sourcePVCName)
+	}
+	if sourcePVC.Status.Phase != v1.ClaimBound {
+		return logger.LogNewErrorCodef(log, codes.FailedPrecondition,
+			"source volume %q is not bound yet", sourcePVCName)
+	}
+	sourceBytes := sourcePVC.Status.Capacity.Storage().Value()
+	if sourceBytes > requestedBytes {
+		return logger.LogNewErrorCodef(log, codes.OutOfRange,
+			"requested size %d bytes is smaller than the size %d bytes of source volume %q",
+			requestedBytes, sourceBytes, sourcePVCName)
+	}
+	return nil
+}
+
+// isFileVolumePVC returns true if the supervisor PVC is backed by a file volume,
+// i.e. it requests ReadWriteMany or ReadOnlyMany access mode.
+func isFileVolumePVC(pvc *v1.PersistentVolumeClaim) bool {
+	for _, accessMode := range pvc.Spec.AccessModes {
+		if accessMode == v1.ReadWriteMany || accessMode == v1.ReadOnlyMany {
+			return true
+		}
+	}
+	return false
+}
+
 // getAccessMode returns the PersistentVolumeAccessMode for the PVC Spec given VolumeCapability_AccessMode
 func getAccessMode(accessMode csi.VolumeCapability_AccessMode_Mode) v1.PersistentVolumeAccessMode {
 	switch accessMode {
@@ -218,7 +271,7 @@ func getAccessMode(accessMode csi.VolumeCapability_AccessMode_Mode) v1.Persisten
 // getPersistentVolumeClaimSpecWithStorageClass return the PersistentVolumeClaim spec with specified storage class
 func getPersistentVolumeClaimSpecWithStorageClass(pvcName string, namespace string, diskSize string,
 	storageClassName string, pvcAccessMode v1.PersistentVolumeAccessMode, annotations map[string]string,
-	labels map[string]string, finalizers []string, volumeSnapshotName string,
+	labels map[string]string, finalizers []string, volumeSnapshotName string, sourcePVCName string,
 	isLinkedCloneRequest bool) *v1.PersistentVolumeClaim {
 	claim := &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -250,6 +303,12 @@ func getPersistentVolumeClaimSpecWithStorageClass(pvcName string, namespace stri
 		}
 		claim.Spec.DataSource = localObjectReference
 	}
+	if sourcePVCName != "" {
+		claim.Spec.DataSource = &v1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: sourcePVCName,
+		}
+	}
 
 	if isLinkedCloneRequest {
 		claim.Annotations[common.AttributeIsLinkedClone] = "true"
@@ -24,9 +24,13 @@ import (
 	"testing"
 	"time"
 
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	snapshotclientfake "github.com/kubernetes-csi/external-snapshotter/client/v8/clientset/versioned/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	vmoperatortypes "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -35,11 +39,15 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	cnsoperatorv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator"
+	cnsfileaccessconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsfileaccessconfig/v1alpha1"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	csifault "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/fault"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/unittestcommon"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
@@ -849,3 +857,245 @@ func TestPatchObjectErrorHandling(t *testing.T) {
 		assert.NoError(t, err) // Fake client allows this
 	})
 }
+
+func TestGuestListVolumes(t *testing.T) {
+	ctx := context.Background()
+	var err error
+	commonco.ContainerOrchestratorUtility, err =
+		unittestcommon.GetFakeContainerOrchestratorInterface(common.Kubernetes)
+	require.NoError(t, err)
+
+	const (
+		clusterName  = "tkc"
+		clusterDist  = "TKGService"
+		clusterUID   = "tkc-uid"
+		svNamespace  = "sv-namespace"
+		blockPVCName = clusterUID + "-block"
+		filePVCName  = clusterUID + "-file"
+	)
+	newPVC := func(name, ownerUID string, accessMode v1.PersistentVolumeAccessMode,
+		phase v1.PersistentVolumeClaimPhase) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: svNamespace,
+				Labels:    map[string]string{clusterName + "/" + clusterDist: ownerUID},
+			},
+			Spec: v1.PersistentVolumeClaimSpec{AccessModes: []v1.PersistentVolumeAccessMode{accessMode}},
+			Status: v1.PersistentVolumeClaimStatus{
+				Phase:    phase,
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		}
+	}
+	supervisorClient := testclient.NewClientset(
+		newPVC(blockPVCName, clusterUID, v1.ReadWriteOnce, v1.ClaimBound),
+		newPVC(filePVCName, clusterUID, v1.ReadWriteMany, v1.ClaimBound),
+		newPVC(clusterUID+"-pending", clusterUID, v1.ReadWriteOnce, v1.ClaimPending),
+		// The PVCs of the other guest clusters are filtered out by the label
+		// selector, even when their name has the prefix of this cluster.
+		newPVC(clusterUID+"-other", "other-cluster-uid", v1.ReadWriteOnce, v1.ClaimBound),
+	)
+
+	vmScheme := runtime.NewScheme()
+	require.NoError(t, vmoperatortypes.AddToScheme(vmScheme))
+	vmOperatorClient := ctrlclientfake.NewClientBuilder().WithScheme(vmScheme).WithObjects(
+		&vmoperatortypes.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Namespace: svNamespace},
+			Status: vmoperatortypes.VirtualMachineStatus{
+				Volumes: []vmoperatortypes.VirtualMachineVolumeStatus{
+					{Name: blockPVCName, Attached: true, DiskUUID: "disk-uuid"},
+				},
+			},
+		}).Build()
+
+	cnsScheme := runtime.NewScheme()
+	require.NoError(t, cnsoperatorv1alpha1.SchemeBuilder.AddToScheme(cnsScheme))
+	cnsOperatorClient := ctrlclientfake.NewClientBuilder().WithScheme(cnsScheme).WithObjects(
+		&cnsfileaccessconfigv1alpha1.CnsFileAccessConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2-" + filePVCName, Namespace: svNamespace},
+			Spec:       cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{VMName: "node-2", PvcName: filePVCName},
+			Status:     cnsfileaccessconfigv1alpha1.CnsFileAccessConfigStatus{Done: true},
+		}).Build()
+
+	c := &controller{
+		supervisorClient:           supervisorClient,
+		vmOperatorClient:           vmOperatorClient,
+		cnsOperatorClient:          cnsOperatorClient,
+		supervisorNamespace:        svNamespace,
+		tanzukubernetesClusterName: clusterName,
+		tanzukubernetesClusterUID:  clusterUID,
+		guestClusterDist:           clusterDist,
+	}
+	resp, err := c.ListVolumes(ctx, &csi.ListVolumesRequest{})
+	require.NoError(t, err)
+
+	publishedNodes := make(map[string][]string)
+	for _, entry := range resp.Entries {
+		publishedNodes[entry.Volume.VolumeId] = entry.Status.PublishedNodeIds
+	}
+	assert.Equal(t, map[string][]string{
+		blockPVCName: {"node-1"},
+		filePVCName:  {"node-2"},
+	}, publishedNodes)
+
+	capsResp, err := c.ControllerGetCapabilities(ctx, &csi.ControllerGetCapabilitiesRequest{})
+	require.NoError(t, err)
+	var hasListVolumesPublishedNodes bool
+	for _, cap := range capsResp.Capabilities {
+		if cap.GetRpc().GetType() == csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES {
+			hasListVolumesPublishedNodes = true
+		}
+	}
+	assert.True(t, hasListVolumesPublishedNodes)
+}
+
+func TestValidateSupervisorCloneSource(t *testing.T) {
+	ctx := context.Background()
+	const (
+		clusterUID  = "tkc-uid"
+		svNamespace = "sv-namespace"
+	)
+	newPVC := func(name string, accessMode v1.PersistentVolumeAccessMode, phase v1.PersistentVolumeClaimPhase,
+		size string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: svNamespace},
+			Spec:       v1.PersistentVolumeClaimSpec{AccessModes: []v1.PersistentVolumeAccessMode{accessMode}},
+			Status: v1.PersistentVolumeClaimStatus{
+				Phase:    phase,
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+			},
+		}
+	}
+	supervisorClient := testclient.NewClientset(
+		newPVC(clusterUID+"-block", v1.ReadWriteOnce, v1.ClaimBound, "2Gi"),
+		newPVC(clusterUID+"-file", v1.ReadWriteMany, v1.ClaimBound, "2Gi"),
+		newPVC(clusterUID+"-pending", v1.ReadWriteOnce, v1.ClaimPending, "2Gi"),
+		newPVC("other-uid-block", v1.ReadWriteOnce, v1.ClaimBound, "2Gi"),
+	)
+	gi := int64(common.GbInBytes)
+	tests := []struct {
+		name           string
+		source         string
+		requestedBytes int64
+		isFileVolume   bool
+		wantCode       codes.Code
+	}{
+		{name: "valid source", source: clusterUID + "-block", requestedBytes: 2 * gi, wantCode: codes.OK},
+		{name: "larger clone", source: clusterUID + "-block", requestedBytes: 4 * gi, wantCode: codes.OK},
+		{name: "smaller clone", source: clusterUID + "-block", requestedBytes: gi, wantCode: codes.OutOfRange},
+		{name: "file volume request", source: clusterUID + "-block", requestedBytes: 2 * gi, isFileVolume: true,
+			wantCode: codes.InvalidArgument},
+		{name: "file volume source", source: clusterUID + "-file", requestedBytes: 2 * gi,
+			wantCode: codes.InvalidArgument},
+		{name: "unbound source", source: clusterUID + "-pending", requestedBytes: 2 * gi,
+			wantCode: codes.FailedPrecondition},
+		{name: "source of other cluster", source: "other-uid-block", requestedBytes: 2 * gi,
+			wantCode: codes.InvalidArgument},
+		{name: "missing source", source: clusterUID + "-missing", requestedBytes: 2 * gi, wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSupervisorCloneSource(ctx, supervisorClient, svNamespace, clusterUID, tt.source,
+				tt.requestedBytes, tt.isFileVolume)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGetPersistentVolumeClaimSpecWithSourcePVC(t *testing.T) {
+	claim := getPersistentVolumeClaimSpecWithStorageClass("tkc-uid-clone", testNamespace, "1Gi", testStorageClass,
+		v1.ReadWriteOnce, map[string]string{}, map[string]string{}, nil, "", "tkc-uid-source", false)
+	require.NotNil(t, claim.Spec.DataSource)
+	assert.Nil(t, claim.Spec.DataSource.APIGroup)
+	assert.Equal(t, "PersistentVolumeClaim", claim.Spec.DataSource.Kind)
+	assert.Equal(t, "tkc-uid-source", claim.Spec.DataSource.Name)
+}
+
+func TestLinkedCloneSourceSnapshot(t *testing.T) {
+	ctx := context.Background()
+	var err error
+	commonco.ContainerOrchestratorUtility, err =
+		unittestcommon.GetFakeContainerOrchestratorInterface(common.Kubernetes)
+	require.NoError(t, err)
+	fakeCO := commonco.ContainerOrchestratorUtility.(*unittestcommon.FakeK8SOrchestrator)
+	require.NoError(t, fakeCO.EnableFSS(ctx, common.LinkedCloneSupportFSS))
+	defer func() {
+		_ = fakeCO.DisableFSS(ctx, common.LinkedCloneSupportFSS)
+	}()
+
+	const (
+		svNamespace   = "sv-namespace"
+		clonePVCName  = "tkc-uid-clone"
+		sourcePVCName = "tkc-uid-source"
+	)
+	snapshotClient := snapshotclientfake.NewSimpleClientset()
+	// Mark the snapshots ready as soon as they are created.
+	snapshotClient.PrependReactor("create", "volumesnapshots",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			vs := action.(k8stesting.CreateAction).GetObject().(*snapshotv1.VolumeSnapshot)
+			ready := true
+			vs.Status = &snapshotv1.VolumeSnapshotStatus{ReadyToUse: &ready}
+			return false, nil, nil
+		})
+	c := &controller{
+		supervisorNamespace:         svNamespace,
+		supervisorSnapshotterClient: snapshotClient,
+	}
+
+	snapshotName, err := c.createLinkedCloneSourceSnapshot(ctx, clonePVCName, sourcePVCName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, clonePVCName, snapshotName)
+	vs, err := snapshotClient.SnapshotV1().VolumeSnapshots(svNamespace).Get(ctx, clonePVCName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, sourcePVCName, *vs.Spec.Source.PersistentVolumeClaimName)
+	assert.Nil(t, vs.Spec.VolumeSnapshotClassName)
+	assert.Equal(t, sourcePVCName, vs.Annotations[common.SupervisorLinkedCloneSourceAnnotationKey])
+
+	// Retries reuse the existing snapshot.
+	snapshotName, err = c.createLinkedCloneSourceSnapshot(ctx, clonePVCName, sourcePVCName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, clonePVCName, snapshotName)
+
+	// Snapshots which were not taken for a linked clone are left alone.
+	_, err = snapshotClient.SnapshotV1().VolumeSnapshots(svNamespace).Create(ctx, &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: sourcePVCName, Namespace: svNamespace},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.deleteLinkedCloneSourceSnapshot(ctx, sourcePVCName))
+	_, err = snapshotClient.SnapshotV1().VolumeSnapshots(svNamespace).Get(ctx, sourcePVCName, metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	require.NoError(t, c.deleteLinkedCloneSourceSnapshot(ctx, clonePVCName))
+	_, err = snapshotClient.SnapshotV1().VolumeSnapshots(svNamespace).Get(ctx, clonePVCName, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestCreateVolumeFailureRecordsFaultEvent(t *testing.T) {
+	ctx := context.Background()
+	var err error
+	commonco.ContainerOrchestratorUtility, err =
+		unittestcommon.GetFakeContainerOrchestratorInterface(common.Kubernetes)
+	require.NoError(t, err)
+	fakeCO := commonco.ContainerOrchestratorUtility.(*unittestcommon.FakeK8SOrchestrator)
+
+	c := &controller{}
+	// The request has no volume capabilities, so it fails validation.
+	_, err = c.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name: testVolumeName,
+		Parameters: map[string]string{
+			common.AttributePvcName:      "test-pvc",
+			common.AttributePvcNamespace: "test-ns",
+		},
+	})
+	require.Error(t, err)
+
+	events := fakeCO.GetFaultEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, "PersistentVolumeClaim", events[0].Object.Kind)
+	assert.Equal(t, "test-ns", events[0].Object.Namespace)
+	assert.Equal(t, "test-pvc", events[0].Object.Name)
+	assert.Equal(t, "ProvisioningFailed", events[0].Event.Reason)
+	assert.Equal(t, csifault.CSIInvalidArgumentFault, events[0].Event.FaultType)
+	assert.Empty(t, events[0].Event.TaskID)
+}
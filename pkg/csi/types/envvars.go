@@ -47,4 +47,10 @@ const (
 	// Depending on the value, either controller and node service will be
 	// activated (The identity service is always activated).
 	EnvVarMode = "X_CSI_MODE"
+
+	// EnvVarMaxConcurrentRequests is the name of the environment variable
+	// used to limit the number of concurrent requests per CSI RPC, as a
+	// comma-separated list of <method>=<limit> pairs such as
+	// "CreateVolume=10,DeleteVolume=10". RPCs not listed are unlimited.
+	EnvVarMaxConcurrentRequests = "X_CSI_MAX_CONCURRENT_REQUESTS"
 )
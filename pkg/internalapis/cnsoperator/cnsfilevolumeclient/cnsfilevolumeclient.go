@@ -19,6 +19,7 @@ package cnsfilevolumeclient
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +35,15 @@ import (
 	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
 )
 
+const (
+	// PodVMClientPrefix prefixes the names of PodVMs in the
+	// ExternalIPtoClientVms lists.
+	PodVMClientPrefix = "POD_VM:"
+	// GuestVMClientPrefix prefixes the names of guest VMs in the
+	// ExternalIPtoClientVms lists.
+	GuestVMClientPrefix = "GUEST_VM:"
+)
+
 // FileVolumeClient exposes an interface to support
 // configuration of CNS file volume ACL's.
 type FileVolumeClient interface {
@@ -50,11 +60,22 @@ type FileVolumeClient interface {
 	// clientVMIP for a given file volume. fileVolumeName is used
 	// to uniquely identify CnsFileVolumeClient instances.
 	RemoveClientVMFromIPList(ctx context.Context, fileVolumeName, clientVMName, clientVMIP string) error
+	// GetNetPermissionForIP returns the net permission last configured on
+	// the file volume for the given clientVMIP, or nil if none is recorded.
+	GetNetPermissionForIP(ctx context.Context, fileVolumeName, clientVMIP string) (
+		*v1alpha1.CnsFileVolumeNetPermission, error)
+	// SetNetPermissionForIP records the net permission configured on the
+	// file volume for the given clientVMIP.
+	SetNetPermissionForIP(ctx context.Context, fileVolumeName, clientVMIP string,
+		permission v1alpha1.CnsFileVolumeNetPermission) error
 	// GetVMIPFromVMName returns the VMIP associated with a
 	// given client VM name.
 	GetVMIPFromVMName(ctx context.Context, fileVolumeName string, clientVMName string) (string, int, error)
 	// CnsFileVolumeClientExistsForPvc returns true if CnsFileVolumeClient for a PVC is found.
 	CnsFileVolumeClientExistsForPvc(ctx context.Context, fileVolumeName string) (bool, error)
+	// MigrateClientVMNames prefixes the client VM names recorded without
+	// a prefix in all CnsFileVolumeClient instances with GuestVMClientPrefix.
+	MigrateClientVMNames(ctx context.Context) error
 }
 
 // fileVolumeClient maintains a client to the API
@@ -288,6 +309,7 @@ func (f *fileVolumeClient) RemoveClientVMFromIPList(ctx context.Context,
 			if len(instance.Spec.ExternalIPtoClientVms[clientVMIP]) == 0 {
 				log.Debugf("Deleting entry for IP %s from spec.ExternalIPtoClientVms", clientVMIP)
 				delete(instance.Spec.ExternalIPtoClientVms, clientVMIP)
+				delete(instance.Spec.ExternalIPtoNetPermission, clientVMIP)
 			}
 			if len(instance.Spec.ExternalIPtoClientVms) == 0 {
 				log.Infof("Deleting cnsfilevolumeclient instance %s from API server", fileVolumeName)
@@ -326,6 +348,106 @@ func (f *fileVolumeClient) RemoveClientVMFromIPList(ctx context.Context,
 	return nil
 }
 
+// GetNetPermissionForIP returns the net permission last configured on the
+// file volume for the given IP address.
+// Callers need to specify fileVolumeName as a combination of
+// "<SV-namespace>/<SV-PVC-name>". This combination is used to uniquely
+// identify CnsFileVolumeClient instances.
+// Returns nil if the instance doesn't exist OR if no net permission is
+// recorded for the input IP address.
+// Returns an error if any operations fails.
+func (f *fileVolumeClient) GetNetPermissionForIP(ctx context.Context,
+	fileVolumeName, clientVMIP string) (*v1alpha1.CnsFileVolumeNetPermission, error) {
+	log := logger.GetLogger(ctx)
+
+	log.Infof("Fetching net permission from cnsfilevolumeclient %s for IP address %s", fileVolumeName, clientVMIP)
+	actual, _ := f.volumeLock.LoadOrStore(fileVolumeName, &sync.Mutex{})
+	instanceLock, ok := actual.(*sync.Mutex)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast lock for cnsfilevolumeclient instance: %s", fileVolumeName)
+	}
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+
+	instance := &v1alpha1.CnsFileVolumeClient{}
+	instanceNamespace, instanceName, err := cache.SplitMetaNamespaceKey(fileVolumeName)
+	if err != nil {
+		log.Errorf("failed to split key %s with error: %+v", fileVolumeName, err)
+		return nil, err
+	}
+	instanceKey := types.NamespacedName{
+		Namespace: instanceNamespace,
+		Name:      instanceName,
+	}
+	err = f.client.Get(ctx, instanceKey, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Infof("Cnsfilevolumeclient instance %s not found. No net permission recorded", fileVolumeName)
+			return nil, nil
+		}
+		log.Errorf("failed to get cnsfilevolumeclient instance %s with error: %+v", fileVolumeName, err)
+		return nil, err
+	}
+	permission, ok := instance.Spec.ExternalIPtoNetPermission[clientVMIP]
+	if !ok {
+		return nil, nil
+	}
+	return &permission, nil
+}
+
+// SetNetPermissionForIP records the net permission configured on the file
+// volume for the given IP address.
+// Callers need to specify fileVolumeName as a combination of
+// "<SV-namespace>/<SV-PVC-name>". This combination is used to uniquely
+// identify CnsFileVolumeClient instances.
+// The instance is expected to exist already, i.e. a client VM must have been
+// added for the IP address through AddClientVMToIPList.
+// Returns an error if the operation cannot be persisted on the API server.
+func (f *fileVolumeClient) SetNetPermissionForIP(ctx context.Context,
+	fileVolumeName, clientVMIP string, permission v1alpha1.CnsFileVolumeNetPermission) error {
+	log := logger.GetLogger(ctx)
+
+	log.Infof("Setting net permission %+v on cnsfilevolumeclient %s for IP address %s",
+		permission, fileVolumeName, clientVMIP)
+	actual, _ := f.volumeLock.LoadOrStore(fileVolumeName, &sync.Mutex{})
+	instanceLock, ok := actual.(*sync.Mutex)
+	if !ok {
+		return fmt.Errorf("failed to cast lock for cnsfilevolumeclient instance: %s", fileVolumeName)
+	}
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+
+	instance := &v1alpha1.CnsFileVolumeClient{}
+	instanceNamespace, instanceName, err := cache.SplitMetaNamespaceKey(fileVolumeName)
+	if err != nil {
+		log.Errorf("failed to split key %s with error: %+v", fileVolumeName, err)
+		return err
+	}
+	instanceKey := types.NamespacedName{
+		Namespace: instanceNamespace,
+		Name:      instanceName,
+	}
+	err = f.client.Get(ctx, instanceKey, instance)
+	if err != nil {
+		log.Errorf("failed to get cnsfilevolumeclient instance %s with error: %+v", fileVolumeName, err)
+		return err
+	}
+	if existing, ok := instance.Spec.ExternalIPtoNetPermission[clientVMIP]; ok && existing == permission {
+		log.Debugf("Net permission for IP %s is already up to date. Returning.", clientVMIP)
+		return nil
+	}
+	if instance.Spec.ExternalIPtoNetPermission == nil {
+		instance.Spec.ExternalIPtoNetPermission = make(map[string]v1alpha1.CnsFileVolumeNetPermission)
+	}
+	instance.Spec.ExternalIPtoNetPermission[clientVMIP] = permission
+	log.Debugf("Updating cnsfilevolumeclient instance %s with spec: %+v", fileVolumeName, instance)
+	err = f.client.Update(ctx, instance)
+	if err != nil {
+		log.Errorf("failed to update cnsfilevolumeclient instance %s with error: %+v", fileVolumeName, err)
+	}
+	return err
+}
+
 // GetVMIPFromVMName returns the VM IP associated with a
 // given client VM name.
 // Callers need to specify fileVolumeName as a combination of
@@ -436,3 +558,83 @@ func removeFinalizer(ctx context.Context, client client.Client,
 
 	return nil
 }
+
+// MigrateClientVMNames prefixes the client VM names which are recorded
+// without a prefix in the ExternalIPtoClientVms lists of all the
+// CnsFileVolumeClient instances with GuestVMClientPrefix. Client VMs used
+// to be recorded by name only, when only guest VMs could access file
+// volumes.
+// Returns an error if any operations fails.
+func (f *fileVolumeClient) MigrateClientVMNames(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+
+	instanceList := &v1alpha1.CnsFileVolumeClientList{}
+	err := f.client.List(ctx, instanceList)
+	if err != nil {
+		log.Errorf("failed to list cnsfilevolumeclient instances with error: %+v", err)
+		return err
+	}
+	for i := range instanceList.Items {
+		fileVolumeName := instanceList.Items[i].Namespace + "/" + instanceList.Items[i].Name
+		err = f.migrateClientVMNames(ctx, fileVolumeName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateClientVMNames prefixes the client VM names recorded without a
+// prefix in the given CnsFileVolumeClient instance with GuestVMClientPrefix.
+func (f *fileVolumeClient) migrateClientVMNames(ctx context.Context, fileVolumeName string) error {
+	log := logger.GetLogger(ctx)
+
+	actual, _ := f.volumeLock.LoadOrStore(fileVolumeName, &sync.Mutex{})
+	instanceLock, ok := actual.(*sync.Mutex)
+	if !ok {
+		return fmt.Errorf("failed to cast lock for cnsfilevolumeclient instance: %s", fileVolumeName)
+	}
+	instanceLock.Lock()
+	defer instanceLock.Unlock()
+
+	instance := &v1alpha1.CnsFileVolumeClient{}
+	instanceNamespace, instanceName, err := cache.SplitMetaNamespaceKey(fileVolumeName)
+	if err != nil {
+		log.Errorf("failed to split key %s with error: %+v", fileVolumeName, err)
+		return err
+	}
+	instanceKey := types.NamespacedName{
+		Namespace: instanceNamespace,
+		Name:      instanceName,
+	}
+	err = f.client.Get(ctx, instanceKey, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		log.Errorf("failed to get cnsfilevolumeclient instance %s with error: %+v", fileVolumeName, err)
+		return err
+	}
+	migrated := false
+	for clientVMIP, clientVMNames := range instance.Spec.ExternalIPtoClientVms {
+		for index, clientVMName := range clientVMNames {
+			if strings.HasPrefix(clientVMName, PodVMClientPrefix) ||
+				strings.HasPrefix(clientVMName, GuestVMClientPrefix) {
+				continue
+			}
+			log.Infof("Prefixing clientVM %s for IP address %s in cnsfilevolumeclient instance %s with %q",
+				clientVMName, clientVMIP, fileVolumeName, GuestVMClientPrefix)
+			clientVMNames[index] = GuestVMClientPrefix + clientVMName
+			migrated = true
+		}
+	}
+	if !migrated {
+		return nil
+	}
+	log.Debugf("Updating cnsfilevolumeclient instance %s with spec: %+v", fileVolumeName, instance)
+	err = f.client.Update(ctx, instance)
+	if err != nil {
+		log.Errorf("failed to update cnsfilevolumeclient instance %s with error: %+v", fileVolumeName, err)
+	}
+	return err
+}
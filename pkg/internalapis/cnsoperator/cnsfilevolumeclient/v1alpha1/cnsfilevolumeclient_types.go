@@ -27,8 +27,23 @@ type CnsFileVolumeClientSpec struct {
 	// Keys are External IP Addresses that have access to a volume.
 	// Values are list of names of ClientVms. Each ClientVm in the list mounts this volume and
 	// is exposed to the external network by this IP address.
-	// PodVMs names will be prefixed with "POD_VM" and guest VMs will be prefix with "GUEST_VM".
+	// PodVMs names will be prefixed with "POD_VM:" and guest VMs will be prefixed with "GUEST_VM:".
 	ExternalIPtoClientVms map[string][]string `json:"externalIPtoClientVms,omitempty"`
+
+	// ExternalIPtoNetPermission maintains a mapping of External IP address to the NFS net
+	// permission last configured on the volume for that IP address.
+	// CNS does not expose the ACL of a file volume, so this is used to detect drift between
+	// the permission requested by the ClientVms and the one configured on the volume.
+	ExternalIPtoNetPermission map[string]CnsFileVolumeNetPermission `json:"externalIPtoNetPermission,omitempty"`
+}
+
+// CnsFileVolumeNetPermission is the NFS net permission configured on a file volume
+// for an External IP address.
+type CnsFileVolumeNetPermission struct {
+	// Permissions is the vSAN file share access type, i.e. READ_ONLY or READ_WRITE.
+	Permissions string `json:"permissions"`
+	// AllowRoot indicates whether root access is allowed from the External IP address.
+	AllowRoot bool `json:"allowRoot"`
 }
 
 // +kubebuilder:object:root=true
@@ -82,6 +82,21 @@ func (in *CnsFileVolumeClientList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnsFileVolumeNetPermission) DeepCopyInto(out *CnsFileVolumeNetPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnsFileVolumeNetPermission.
+func (in *CnsFileVolumeNetPermission) DeepCopy() *CnsFileVolumeNetPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(CnsFileVolumeNetPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CnsFileVolumeClientSpec) DeepCopyInto(out *CnsFileVolumeClientSpec) {
 	*out = *in
@@ -100,6 +115,13 @@ func (in *CnsFileVolumeClientSpec) DeepCopyInto(out *CnsFileVolumeClientSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ExternalIPtoNetPermission != nil {
+		in, out := &in.ExternalIPtoNetPermission, &out.ExternalIPtoNetPermission
+		*out = make(map[string]CnsFileVolumeNetPermission, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnsFileVolumeClientSpec.
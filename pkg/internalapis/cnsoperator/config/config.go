@@ -11,3 +11,13 @@ const EmbedCnsFileVolumeClientFileName = "cnsfilevolumeclient_crd.yaml"
 var EmbedTriggerCsiFullSync embed.FS
 
 const EmbedTriggerCsiFullSyncName = "triggercsifullsync_crd.yaml"
+
+//go:embed csifullsyncconfig_crd.yaml
+var EmbedCsiFullSyncConfig embed.FS
+
+const EmbedCsiFullSyncConfigName = "csifullsyncconfig_crd.yaml"
+
+//go:embed volumemigration_crd.yaml
+var EmbedVolumeMigrationFile embed.FS
+
+const EmbedVolumeMigrationFileName = "volumemigration_crd.yaml"
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CsiFullSyncConfigCRName is the name of the instance
+// configuring the schedule and scope of full sync.
+const CsiFullSyncConfigCRName = "csifullsyncconfig"
+
+// CsiFullSyncConfigSpec is the spec for CsiFullSyncConfig
+type CsiFullSyncConfigSpec struct {
+	// Default is the full sync schedule of the vCenters
+	// which do not have their own schedule in VCenters.
+	Default FullSyncSchedule `json:"default,omitempty"`
+
+	// VCenters holds the full sync schedules of specific vCenters.
+	// The schedule of a vCenter replaces the default schedule.
+	VCenters []VCenterFullSyncSchedule `json:"vCenters,omitempty"`
+}
+
+// VCenterFullSyncSchedule is the full sync schedule of a vCenter.
+type VCenterFullSyncSchedule struct {
+	// Host is the vCenter host, as set in the vSphere config secret.
+	Host string `json:"host"`
+
+	FullSyncSchedule `json:",inline"`
+}
+
+// FullSyncSchedule is the schedule and scope of the full sync of a vCenter.
+type FullSyncSchedule struct {
+	// IntervalMinutes is the interval in minutes between two full syncs.
+	// If unset, the interval set in the syncer configuration is used.
+	// +kubebuilder:validation:Minimum=1
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+
+	// QuietWindows are the time windows during which full sync is not started.
+	QuietWindows []QuietWindow `json:"quietWindows,omitempty"`
+
+	// IncludeNamespaces restricts full sync to the volumes of the PVCs in
+	// these namespaces. If unset, the volumes of all namespaces are synced.
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// ExcludeNamespaces excludes the volumes of the PVCs in these namespaces
+	// from full sync.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// MaxCnsCallsPerMinute is the maximum number of CNS calls per minute
+	// made by full sync. If unset, the CNS calls are not limited.
+	// +kubebuilder:validation:Minimum=1
+	MaxCnsCallsPerMinute int `json:"maxCnsCallsPerMinute,omitempty"`
+}
+
+// QuietWindow is a daily time window during which full sync is not started.
+type QuietWindow struct {
+	// Start is the start time of the window in HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the end time of the window in HH:MM format.
+	// A window ending before its start ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// Days are the days of the week the window starts on, such as "Monday".
+	// If unset, the window starts every day.
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+
+	// TimeZone is the IANA time zone of Start and End, such as
+	// "America/Los_Angeles". If unset, UTC is used.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CsiFullSyncConfig is the Schema for the CsiFullSyncConfig API
+type CsiFullSyncConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines a specification of the CsiFullSyncConfig.
+	Spec CsiFullSyncConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CsiFullSyncConfigList contains a list of CsiFullSyncConfig
+type CsiFullSyncConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CsiFullSyncConfig `json:"items"`
+}
+
+// GetSchedule returns the full sync schedule of the given vCenter.
+func (in *CsiFullSyncConfig) GetSchedule(vc string) FullSyncSchedule {
+	for _, schedule := range in.Spec.VCenters {
+		if schedule.Host == vc {
+			return schedule.FullSyncSchedule
+		}
+	}
+	return in.Spec.Default
+}
@@ -58,6 +58,49 @@ type TriggerCsiFullSyncStatus struct {
 	// The last error encountered during CSI full sync operation, if any.
 	// Previous error will be cleared when a new full sync is in progress.
 	Error string `json:"error,omitempty"`
+
+	// VCenters indicates the full sync schedule and status of each vCenter,
+	// when full sync is scheduled by the CsiFullSyncConfig.
+	VCenters []VCenterFullSyncStatus `json:"vCenters,omitempty"`
+}
+
+// VCenterFullSyncStatus contains the full sync schedule and status of a vCenter
+type VCenterFullSyncStatus struct {
+	// Host is the vCenter host.
+	Host string `json:"host"`
+
+	// IntervalMinutes indicates the interval in minutes between two full syncs.
+	IntervalMinutes int `json:"intervalMinutes"`
+
+	// IncludeNamespaces indicates the namespaces full sync is restricted to.
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// ExcludeNamespaces indicates the namespaces excluded from full sync.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// MaxCnsCallsPerMinute indicates the maximum number of CNS calls per minute
+	// made by full sync. It is unset if the CNS calls are not limited.
+	MaxCnsCallsPerMinute int `json:"maxCnsCallsPerMinute,omitempty"`
+
+	// InQuietWindow indicates whether the vCenter is in a quiet window,
+	// during which full sync is not started.
+	InQuietWindow bool `json:"inQuietWindow"`
+
+	// InProgress indicates whether a full sync of the vCenter is in progress.
+	InProgress bool `json:"inProgress"`
+
+	// LastRunStartTimeStamp indicates last run full sync start timestamp.
+	LastRunStartTimeStamp *metav1.Time `json:"lastRunStartTimeStamp,omitempty"`
+
+	// LastRunEndTimeStamp indicates last run full sync end timestamp.
+	LastRunEndTimeStamp *metav1.Time `json:"lastRunEndTimeStamp,omitempty"`
+
+	// NextRunTimeStamp indicates the earliest time the next full sync is
+	// started, if it is not in a quiet window.
+	NextRunTimeStamp *metav1.Time `json:"nextRunTimeStamp,omitempty"`
+
+	// The error encountered during the last full sync of the vCenter, if any.
+	Error string `json:"error,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
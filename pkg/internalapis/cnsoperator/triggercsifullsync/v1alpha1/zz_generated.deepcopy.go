@@ -28,7 +28,7 @@ func (in *TriggerCsiFullSync) DeepCopyInto(out *TriggerCsiFullSync) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -102,6 +102,29 @@ func (in *TriggerCsiFullSyncSpec) DeepCopy() *TriggerCsiFullSyncSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerCsiFullSyncStatus) DeepCopyInto(out *TriggerCsiFullSyncStatus) {
 	*out = *in
+	if in.LastSuccessfulStartTimeStamp != nil {
+		in, out := &in.LastSuccessfulStartTimeStamp, &out.LastSuccessfulStartTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulEndTimeStamp != nil {
+		in, out := &in.LastSuccessfulEndTimeStamp, &out.LastSuccessfulEndTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRunStartTimeStamp != nil {
+		in, out := &in.LastRunStartTimeStamp, &out.LastRunStartTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRunEndTimeStamp != nil {
+		in, out := &in.LastRunEndTimeStamp, &out.LastRunEndTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.VCenters != nil {
+		in, out := &in.VCenters, &out.VCenters
+		*out = make([]VCenterFullSyncStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -114,3 +137,41 @@ func (in *TriggerCsiFullSyncStatus) DeepCopy() *TriggerCsiFullSyncStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VCenterFullSyncStatus) DeepCopyInto(out *VCenterFullSyncStatus) {
+	*out = *in
+	if in.IncludeNamespaces != nil {
+		in, out := &in.IncludeNamespaces, &out.IncludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRunStartTimeStamp != nil {
+		in, out := &in.LastRunStartTimeStamp, &out.LastRunStartTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRunEndTimeStamp != nil {
+		in, out := &in.LastRunEndTimeStamp, &out.LastRunEndTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTimeStamp != nil {
+		in, out := &in.NextRunTimeStamp, &out.NextRunTimeStamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VCenterFullSyncStatus.
+func (in *VCenterFullSyncStatus) DeepCopy() *VCenterFullSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VCenterFullSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
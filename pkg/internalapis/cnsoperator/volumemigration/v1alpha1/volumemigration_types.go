@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeMigrationVolumeState is the migration state of a single volume.
+type VolumeMigrationVolumeState string
+
+const (
+	// VolumeMigrationPending means the volume is waiting to be migrated.
+	VolumeMigrationPending VolumeMigrationVolumeState = "Pending"
+	// VolumeMigrationInProgress means the volume is being relocated.
+	VolumeMigrationInProgress VolumeMigrationVolumeState = "InProgress"
+	// VolumeMigrationSucceeded means the volume is relocated to the target
+	// datastore.
+	VolumeMigrationSucceeded VolumeMigrationVolumeState = "Succeeded"
+	// VolumeMigrationFailed means the volume could not be relocated.
+	VolumeMigrationFailed VolumeMigrationVolumeState = "Failed"
+)
+
+// VolumeMigrationSpec defines the desired state of VolumeMigration.
+// PVCs and PVCSelector select the PVCs whose volumes are migrated. All
+// vSphere CSI block volumes are candidates only if AllVolumes is set.
+// SourceDatastoreURL further restricts the selection to volumes placed on
+// the given datastore. The selection is evaluated once, when the migration
+// starts.
+type VolumeMigrationSpec struct {
+	// PVCs is the list of PVCs whose volumes are to be migrated.
+	PVCs []VolumeMigrationPVCReference `json:"pvcs,omitempty"`
+
+	// PVCSelector selects the PVCs whose volumes are to be migrated by labels.
+	PVCSelector *metav1.LabelSelector `json:"pvcSelector,omitempty"`
+
+	// AllVolumes selects all vSphere CSI block volumes for migration. It
+	// cannot be specified together with PVCs or PVCSelector.
+	AllVolumes bool `json:"allVolumes,omitempty"`
+
+	// SourceDatastoreURL restricts the migration to volumes placed on the
+	// given datastore.
+	SourceDatastoreURL string `json:"sourceDatastoreURL,omitempty"`
+
+	// TargetDatastoreURL is the URL of the datastore the volumes are
+	// migrated to.
+	// TargetDatastoreURL and TargetDatastoreCluster cannot be specified
+	// together.
+	TargetDatastoreURL string `json:"targetDatastoreURL,omitempty"`
+
+	// TargetDatastoreCluster is the name of the datastore cluster the
+	// volumes are migrated to. Each batch of volumes is placed on the member
+	// datastore with the most free space which is not in maintenance mode.
+	// TargetDatastoreURL and TargetDatastoreCluster cannot be specified
+	// together.
+	TargetDatastoreCluster string `json:"targetDatastoreCluster,omitempty"`
+
+	// MaxConcurrentMigrations is the maximum number of volumes relocated at
+	// a time. Defaults to 4.
+	MaxConcurrentMigrations int32 `json:"maxConcurrentMigrations,omitempty"`
+}
+
+// VolumeMigrationPVCReference refers to a PVC by namespace and name.
+type VolumeMigrationPVCReference struct {
+	// Namespace of the PVC.
+	Namespace string `json:"namespace"`
+
+	// Name of the PVC.
+	Name string `json:"name"`
+}
+
+// VolumeMigrationVolumeStatus contains the migration status of a single
+// volume.
+type VolumeMigrationVolumeStatus struct {
+	// PVName is the name of the PV of the volume.
+	PVName string `json:"pvName"`
+
+	// PVCNamespace is the namespace of the PVC bound to the PV.
+	PVCNamespace string `json:"pvcNamespace,omitempty"`
+
+	// PVCName is the name of the PVC bound to the PV.
+	PVCName string `json:"pvcName,omitempty"`
+
+	// VolumeID is the CNS volume ID of the volume.
+	VolumeID string `json:"volumeID"`
+
+	// SourceDatastoreURL is the URL of the datastore the volume was placed
+	// on when the migration started.
+	SourceDatastoreURL string `json:"sourceDatastoreURL,omitempty"`
+
+	// TargetDatastoreURL is the URL of the datastore the volume is
+	// migrated to.
+	TargetDatastoreURL string `json:"targetDatastoreURL,omitempty"`
+
+	// State is the migration state of the volume.
+	State VolumeMigrationVolumeState `json:"state"`
+
+	// StartTime is the time the relocation of the volume started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the relocation of the volume completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// The last error encountered while migrating the volume, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeMigrationStatus defines the observed state of VolumeMigration
+type VolumeMigrationStatus struct {
+	// Completed indicates all the selected volumes are processed.
+	// Volumes which failed to migrate are reported in Volumes.
+	Completed bool `json:"completed"`
+
+	// Volumes contains the migration status of each selected volume.
+	Volumes []VolumeMigrationVolumeStatus `json:"volumes,omitempty"`
+
+	// The last error encountered during migration, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeMigration is the Schema for the volumemigrations API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+type VolumeMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeMigrationSpec   `json:"spec,omitempty"`
+	Status VolumeMigrationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeMigrationList contains a list of VolumeMigration
+type VolumeMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeMigration `json:"items"`
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsvolumeoperationrequest
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	restclient "k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	cnsvolumeoprequestv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest/v1alpha1"
+)
+
+const (
+	// cacheSyncTimeout is the time to wait for the informer cache to sync
+	// before falling back to reads on the API server.
+	cacheSyncTimeout = 2 * time.Minute
+	// localWriteTTL is the time after which an instance written by the store
+	// is read from the informer cache even if the cache has not reported it,
+	// so that a missed watch event does not pin a stale instance in memory.
+	localWriteTTL = 5 * time.Minute
+)
+
+// localWrite is an instance written by the store which the informer cache
+// may not have observed yet.
+type localWrite struct {
+	// instance is the written instance, or nil if the instance was deleted.
+	instance  *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest
+	timestamp time.Time
+}
+
+// startInformerCache starts an informer cache of the CnsVolumeOperationRequest
+// instances in the CSI namespace and serves the reads of the store from it
+// once it is synced. Reads keep going to the API server if the cache cannot
+// be started.
+func (or *operationRequestStore) startInformerCache(ctx context.Context, config *restclient.Config) {
+	log := logger.GetLogger(ctx)
+	informerCache, err := crcache.New(config, crcache.Options{
+		Scheme:            or.k8sclient.Scheme(),
+		DefaultNamespaces: map[string]crcache.Config{csiNamespace: {}},
+	})
+	if err != nil {
+		log.Warnf("failed to create CnsVolumeOperationRequest informer cache, reads will be served "+
+			"by the API server. Error: %v", err)
+		return
+	}
+	informer, err := informerCache.GetInformer(ctx, &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{})
+	if err != nil {
+		log.Warnf("failed to get CnsVolumeOperationRequest informer, reads will be served "+
+			"by the API server. Error: %v", err)
+		return
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: or.observeInstance,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			or.observeInstance(newObj)
+		},
+		DeleteFunc: or.observeDeletion,
+	})
+	if err != nil {
+		log.Warnf("failed to add CnsVolumeOperationRequest event handler, reads will be served "+
+			"by the API server. Error: %v", err)
+		return
+	}
+	go func() {
+		// The cache lives as long as the store, which is never torn down.
+		if err := informerCache.Start(context.Background()); err != nil {
+			log.Errorf("CnsVolumeOperationRequest informer cache stopped with error: %v", err)
+		}
+	}()
+	syncCtx, cancel := context.WithTimeout(ctx, cacheSyncTimeout)
+	defer cancel()
+	if !informerCache.WaitForCacheSync(syncCtx) {
+		log.Warn("timed out waiting for CnsVolumeOperationRequest informer cache to sync, reads will be " +
+			"served by the API server")
+		return
+	}
+	or.cacheReader = informerCache
+	log.Info("CnsVolumeOperationRequest reads are served by the informer cache")
+}
+
+// getInstance returns the CnsVolumeOperationRequest instance with the given
+// name. The instance is read from the instances written by the store, then
+// from the informer cache, unless fromAPIServer is set or the cache is not
+// available.
+func (or *operationRequestStore) getInstance(ctx context.Context, name string, fromAPIServer bool) (
+	*cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest, error) {
+	instanceKey := client.ObjectKey{Name: name, Namespace: csiNamespace}
+	instance := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{}
+	if !fromAPIServer && or.cacheReader != nil {
+		if written, found := or.getLocalWrite(name); found {
+			if written == nil {
+				return nil, apierrors.NewNotFound(schema.GroupResource{
+					Group:    cnsvolumeoprequestv1alpha1.SchemeGroupVersion.Group,
+					Resource: CRDPlural,
+				}, name)
+			}
+			return written, nil
+		}
+		if err := or.cacheReader.Get(ctx, instanceKey, instance); err != nil {
+			return nil, err
+		}
+		return instance, nil
+	}
+	err := or.k8sclient.Get(ctx, instanceKey, instance)
+	observeAPICall("get", err)
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// listInstances returns the CnsVolumeOperationRequest instances. The
+// instances are listed from the informer cache in a single page if it is
+// available, or from the API server one page at a time starting at the
+// given continue token.
+func (or *operationRequestStore) listInstances(ctx context.Context, continueToken string) (
+	*cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestList, error) {
+	list := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestList{}
+	if or.cacheReader != nil {
+		err := or.cacheReader.List(ctx, list, client.InNamespace(csiNamespace))
+		return list, err
+	}
+	err := or.k8sclient.List(ctx, list, &client.ListOptions{
+		Limit:    5000,
+		Continue: continueToken,
+	})
+	observeAPICall("list", err)
+	return list, err
+}
+
+// recordLocalWrite records the instance written by the store under the given
+// name, or its deletion if instance is nil, until the informer cache
+// observes it.
+func (or *operationRequestStore) recordLocalWrite(name string,
+	instance *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest) {
+	if or.cacheReader == nil {
+		return
+	}
+	or.localWritesLock.Lock()
+	defer or.localWritesLock.Unlock()
+	if or.localWrites == nil {
+		or.localWrites = make(map[string]*localWrite)
+	}
+	or.localWrites[name] = &localWrite{
+		instance:  instance.DeepCopy(),
+		timestamp: time.Now(),
+	}
+}
+
+// getLocalWrite returns a copy of the instance written by the store under
+// the given name, and whether the store wrote it recently. A nil instance
+// is returned if the store deleted it.
+func (or *operationRequestStore) getLocalWrite(name string) (
+	*cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest, bool) {
+	or.localWritesLock.Lock()
+	defer or.localWritesLock.Unlock()
+	written, found := or.localWrites[name]
+	if !found {
+		return nil, false
+	}
+	if time.Since(written.timestamp) > localWriteTTL {
+		delete(or.localWrites, name)
+		return nil, false
+	}
+	return written.instance.DeepCopy(), true
+}
+
+// observeInstance forgets the instance written by the store once the
+// informer cache reports the same version of it.
+func (or *operationRequestStore) observeInstance(obj interface{}) {
+	instance, ok := obj.(*cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest)
+	if !ok {
+		return
+	}
+	or.localWritesLock.Lock()
+	defer or.localWritesLock.Unlock()
+	written, found := or.localWrites[instance.Name]
+	if found && written.instance != nil && written.instance.ResourceVersion == instance.ResourceVersion {
+		delete(or.localWrites, instance.Name)
+	}
+}
+
+// observeDeletion forgets the deletion of an instance by the store once the
+// informer cache reports it.
+func (or *operationRequestStore) observeDeletion(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	instance, ok := obj.(*cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest)
+	if !ok {
+		return
+	}
+	or.localWritesLock.Lock()
+	defer or.localWritesLock.Unlock()
+	written, found := or.localWrites[instance.Name]
+	if found && written.instance == nil {
+		delete(or.localWrites, instance.Name)
+	}
+}
+
+// observeAPICall counts a call to the API server with the given verb.
+func observeAPICall(verb string, err error) {
+	status := prometheus.PrometheusPassStatus
+	if err != nil {
+		status = prometheus.PrometheusFailStatus
+	}
+	prometheus.CnsVolumeOperationRequestAPICallsCounterVec.WithLabelValues(verb, status).Inc()
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsvolumeoperationrequest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cnsvolumeoprequestv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest/v1alpha1"
+)
+
+// setupCachedTestEnvironment creates a store whose informer cache never
+// observes the writes, and counts the updates made on the API server.
+func setupCachedTestEnvironment(t *testing.T, funcs interceptor.Funcs) (*operationRequestStore, *int32) {
+	scheme := runtime.NewScheme()
+	if err := cnsvolumeoprequestv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add scheme: %v", err)
+	}
+	var updates int32
+	update := funcs.Update
+	funcs.Update = func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+		atomic.AddInt32(&updates, 1)
+		if update != nil {
+			return update(ctx, c, obj, opts...)
+		}
+		return c.Update(ctx, obj, opts...)
+	}
+	store := &operationRequestStore{
+		k8sclient:   fakeclient.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(funcs).Build(),
+		cacheReader: fakeclient.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+	csiNamespace = "vmware-system-csi"
+	isCSITransactionSupportEnabled = true
+	isPodVMOnStretchSupervisorFSSEnabled = false
+	return store, &updates
+}
+
+func TestGetRequestDetailsReadsOwnWrites(t *testing.T) {
+	store, _ := setupCachedTestEnvironment(t, interceptor.Funcs{})
+	ctx := context.Background()
+	name := "pvc-read-own-writes"
+
+	err := store.StoreRequestDetails(ctx, createTestVolumeOperationDetails(name, "", "", "task-1",
+		TaskInvocationStatusInProgress, "", nil))
+	if err != nil {
+		t.Fatalf("Failed to store details: %v", err)
+	}
+	details, err := store.GetRequestDetails(ctx, name)
+	if err != nil {
+		t.Fatalf("Expected the stored details to be read before the cache observes them: %v", err)
+	}
+	if details.OperationDetails.TaskID != "task-1" {
+		t.Errorf("Expected TaskID task-1, got %s", details.OperationDetails.TaskID)
+	}
+
+	// Once the cache observes the write, reads are served by the cache.
+	instance, _ := store.getLocalWrite(name)
+	store.observeInstance(instance)
+	if _, err = store.GetRequestDetails(ctx, name); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the read to be served by the cache, got error: %v", err)
+	}
+
+	// Deletions are also read before the cache observes them.
+	err = store.StoreRequestDetails(ctx, createTestVolumeOperationDetails(name, "", "", "task-1",
+		TaskInvocationStatusSuccess, "", nil))
+	if err != nil {
+		t.Fatalf("Failed to store details: %v", err)
+	}
+	if err = store.DeleteRequestDetails(ctx, name); err != nil {
+		t.Fatalf("Failed to delete details: %v", err)
+	}
+	if _, err = store.GetRequestDetails(ctx, name); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected NotFound after delete, got error: %v", err)
+	}
+}
+
+func TestStoreRequestDetailsSkipsNoOpUpdatesAndCompacts(t *testing.T) {
+	store, updates := setupCachedTestEnvironment(t, interceptor.Funcs{})
+	ctx := context.Background()
+	name := "pvc-compaction"
+
+	attempt1 := createTestVolumeOperationDetails(name, "", "", "", TaskInvocationStatusInProgress, "", nil)
+	attempt2 := createTestVolumeOperationDetails(name, "", "", "", TaskInvocationStatusInProgress, "", nil)
+	attempt2WithTask := createTestVolumeOperationDetails(name, "", "", "task-2", TaskInvocationStatusInProgress,
+		"", nil)
+	attempt2WithTask.OperationDetails.TaskInvocationTimestamp = attempt2.OperationDetails.TaskInvocationTimestamp
+	for _, details := range []*VolumeOperationRequestDetails{attempt1, attempt2, attempt2WithTask, attempt2WithTask} {
+		if err := store.StoreRequestDetails(ctx, details); err != nil {
+			t.Fatalf("Failed to store details: %v", err)
+		}
+	}
+	// The last write did not change the instance.
+	if got := atomic.LoadInt32(updates); got != 2 {
+		t.Errorf("Expected 2 updates, got %d", got)
+	}
+
+	success := createTestVolumeOperationDetails(name, "volume-2", "", "task-2", TaskInvocationStatusSuccess, "", nil)
+	success.OperationDetails.TaskInvocationTimestamp = attempt2.OperationDetails.TaskInvocationTimestamp
+	if err := store.StoreRequestDetails(ctx, success); err != nil {
+		t.Fatalf("Failed to store details: %v", err)
+	}
+	instance, err := getCRDDirectly(ctx, store, name)
+	if err != nil {
+		t.Fatalf("Failed to get CRD directly: %v", err)
+	}
+	if len(instance.Status.LatestOperationDetails) != 1 ||
+		instance.Status.LatestOperationDetails[0].TaskStatus != TaskInvocationStatusSuccess {
+		t.Errorf("Expected the aborted attempt to be compacted, got %+v", instance.Status.LatestOperationDetails)
+	}
+}
+
+func TestStoreRequestDetailsCoalescesConcurrentWrites(t *testing.T) {
+	release := make(chan struct{})
+	store, updates := setupCachedTestEnvironment(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			<-release
+			return c.Create(ctx, obj, opts...)
+		},
+	})
+	ctx := context.Background()
+	name := "pvc-coalesced"
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = store.StoreRequestDetails(ctx, createTestVolumeOperationDetails(name, "", "", "",
+			TaskInvocationStatusInProgress, "", nil))
+	}()
+	// Wait for the create to be in flight before queueing the updates.
+	waitForWrites(t, store, name, 0)
+	for i, taskID := range []string{"task-1", "task-1"} {
+		status := TaskInvocationStatusInProgress
+		if i == 1 {
+			status = TaskInvocationStatusSuccess
+		}
+		wg.Add(1)
+		go func(i int, details *VolumeOperationRequestDetails) {
+			defer wg.Done()
+			errs[i+1] = store.StoreRequestDetails(ctx, details)
+		}(i, createTestVolumeOperationDetails(name, "volume-1", "", taskID, status, "", nil))
+		waitForWrites(t, store, name, i+1)
+	}
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("Failed to store details: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(updates); got != 1 {
+		t.Errorf("Expected the queued writes to be coalesced into 1 update, got %d", got)
+	}
+	details, err := store.GetRequestDetails(ctx, name)
+	if err != nil {
+		t.Fatalf("Failed to get details: %v", err)
+	}
+	if details.OperationDetails.TaskStatus != TaskInvocationStatusSuccess {
+		t.Errorf("Expected TaskStatus %s, got %s", TaskInvocationStatusSuccess, details.OperationDetails.TaskStatus)
+	}
+}
+
+// waitForWrites waits for the given number of operations to be queued
+// behind the in-flight write of the instance.
+func waitForWrites(t *testing.T, store *operationRequestStore, name string, queued int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		store.writesLock.Lock()
+		queue, inFlight := store.writes[name]
+		count := 0
+		for _, batch := range queue {
+			count += len(batch.operations)
+		}
+		store.writesLock.Unlock()
+		if inFlight && count == queued {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued writes of %s", queued, name)
+}
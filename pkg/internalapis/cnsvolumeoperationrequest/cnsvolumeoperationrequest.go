@@ -25,11 +25,14 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	csiconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 	cnsvolumeoperationrequestconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest/config"
 	cnsvolumeoprequestv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest/v1alpha1"
@@ -67,10 +70,23 @@ type VolumeOperationRequest interface {
 
 // operationRequestStore implements the VolumeOperationsRequest interface.
 // This implementation persists the operation information on etcd via a client
-// to the API server. Reads are also done directly on etcd; there is no caching
-// layer involved.
+// to the API server. Reads are served from an informer cache, overlaid with
+// the instances written by this store which the cache has not observed yet so
+// that callers always read their own writes. Concurrent writes of the same
+// instance are coalesced into a single update.
 type operationRequestStore struct {
 	k8sclient client.Client
+	// cacheReader reads the instances from the informer cache. Reads are
+	// done on the API server if the cache is not available.
+	cacheReader client.Reader
+	// localWrites holds the instances written by this store until the
+	// informer cache observes them, keyed by instance name.
+	localWrites     map[string]*localWrite
+	localWritesLock sync.Mutex
+	// writes holds the batches of operations queued behind the in-flight
+	// write of an instance, keyed by instance name.
+	writes     map[string][]*writeBatch
+	writesLock sync.Mutex
 }
 
 var (
@@ -128,6 +144,7 @@ func InitVolumeOperationRequestInterface(ctx context.Context, cleanupInterval in
 		operationRequestStoreInstance = &operationRequestStore{
 			k8sclient: k8sclient,
 		}
+		operationRequestStoreInstance.startInformerCache(ctx, config)
 		go operationRequestStoreInstance.cleanupStaleInstances(cleanupInterval)
 	}
 	// Store PodVMOnStretchedSupervisor FSS value for later use.
@@ -153,8 +170,7 @@ func (or *operationRequestStore) GetRequestDetails(
 	instanceKey := client.ObjectKey{Name: name, Namespace: csiNamespace}
 	log.Debugf("Getting CnsVolumeOperationRequest instance with name %s/%s", instanceKey.Namespace, instanceKey.Name)
 
-	instance := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{}
-	err := or.k8sclient.Get(ctx, instanceKey, instance)
+	instance, err := or.getInstance(ctx, name, false)
 	if err != nil {
 		return nil, err
 	}
@@ -197,70 +213,114 @@ func (or *operationRequestStore) StoreRequestDetails(
 		return logger.LogNewError(log, "cannot store empty operation")
 	}
 	log.Debugf("Storing CnsVolumeOperationRequest instance with spec %v", spew.Sdump(operationToStore))
+	return or.storeOperation(ctx, operationToStore)
+}
 
-	operationDetailsToStore := convertToCnsVolumeOperationRequestDetails(*operationToStore.OperationDetails)
-	instance := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{}
-	instanceKey := client.ObjectKey{Name: operationToStore.Name, Namespace: csiNamespace}
-
-	if err := or.k8sclient.Get(ctx, instanceKey, instance); err != nil {
-		if apierrors.IsNotFound(err) {
+// writeOperations applies the given operations in order to the
+// CnsVolumeOperationRequest instance with the given name and persists the
+// result with a single create or update on the API server. The update is
+// skipped if the operations do not change the instance.
+func (or *operationRequestStore) writeOperations(ctx context.Context, name string,
+	operationsToStore []*VolumeOperationRequestDetails) error {
+	log := logger.GetLogger(ctx)
+	instanceKey := client.ObjectKey{Name: name, Namespace: csiNamespace}
+	latestTaskID := operationsToStore[len(operationsToStore)-1].OperationDetails.TaskID
+	attempt := 0
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		// The cache may be stale after a conflict, read the instance from
+		// the API server on the retries.
+		attempt++
+		instance, err := or.getInstance(ctx, name, attempt > 1)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Errorf("failed to get CnsVolumeOperationRequest instance %s/%s with error: %v",
+				instanceKey.Namespace, instanceKey.Name, err)
+			return err
+		}
+		if err != nil {
 			// Create new instance on API server if it doesnt exist.
 			// Implies that this is the first time this object is
 			// being stored.
-			newInstance := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      instanceKey.Name,
-					Namespace: instanceKey.Namespace,
-				},
-				Spec: cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestSpec{
-					Name: instanceKey.Name,
-				},
-				Status: cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestStatus{
-					VolumeID:              operationToStore.VolumeID,
-					SnapshotID:            operationToStore.SnapshotID,
-					Capacity:              operationToStore.Capacity,
-					FirstOperationDetails: *operationDetailsToStore,
-					LatestOperationDetails: []cnsvolumeoprequestv1alpha1.OperationDetails{
-						*operationDetailsToStore,
-					},
-				},
-			}
-			if isPodVMOnStretchSupervisorFSSEnabled && operationToStore.QuotaDetails != nil {
-				newInstance.Status.StorageQuotaDetails = &cnsvolumeoprequestv1alpha1.QuotaDetails{
-					Reserved:                            operationToStore.QuotaDetails.Reserved,
-					StoragePolicyId:                     operationToStore.QuotaDetails.StoragePolicyId,
-					StorageClassName:                    operationToStore.QuotaDetails.StorageClassName,
-					Namespace:                           operationToStore.QuotaDetails.Namespace,
-					AggregatedSnapshotSize:              operationToStore.QuotaDetails.AggregatedSnapshotSize,
-					SnapshotLatestOperationCompleteTime: operationToStore.QuotaDetails.SnapshotLatestOperationCompleteTime,
-				}
+			newInstance := newOperationRequestInstance(instanceKey, operationsToStore[0])
+			for _, operationToStore := range operationsToStore[1:] {
+				newInstance = applyOperation(ctx, newInstance, operationToStore)
 			}
 			err = or.k8sclient.Create(ctx, newInstance)
+			observeAPICall("create", err)
 			if err != nil {
-				log.Errorf(
-					"failed to create CnsVolumeOperationRequest instance %s/%s with error: %v",
-					instanceKey.Namespace,
-					instanceKey.Name,
-					err,
-				)
+				log.Errorf("failed to create CnsVolumeOperationRequest instance %s/%s with error: %v",
+					instanceKey.Namespace, instanceKey.Name, err)
 				return err
 			}
-			log.Debugf(
-				"Created CnsVolumeOperationRequest instance %s/%s with latest information for task with ID: %s",
-				instanceKey.Namespace,
-				instanceKey.Name,
-				operationDetailsToStore.TaskID,
-			)
+			or.recordLocalWrite(name, newInstance)
+			log.Debugf("Created CnsVolumeOperationRequest instance %s/%s with latest information for task with ID: %s",
+				instanceKey.Namespace, instanceKey.Name, latestTaskID)
 			return nil
 		}
-		log.Errorf(
-			"failed to get CnsVolumeOperationRequest instance %s/%s with error: %v",
-			instanceKey.Namespace,
-			instanceKey.Name,
-			err,
-		)
-		return err
+
+		updatedInstance := instance
+		for _, operationToStore := range operationsToStore {
+			updatedInstance = applyOperation(ctx, updatedInstance, operationToStore)
+		}
+		if equality.Semantic.DeepEqual(instance.Status, updatedInstance.Status) {
+			log.Debugf("CnsVolumeOperationRequest instance %s/%s is up to date for task with ID: %s",
+				instanceKey.Namespace, instanceKey.Name, latestTaskID)
+			prometheus.CnsVolumeOperationRequestCoalescedWritesCounter.Inc()
+			return nil
+		}
+
+		// Store the local instance on the API server.
+		err = or.k8sclient.Update(ctx, updatedInstance)
+		observeAPICall("update", err)
+		if err != nil {
+			log.Errorf("failed to update CnsVolumeOperationRequest instance %s/%s with error: %v",
+				instanceKey.Namespace, instanceKey.Name, err)
+			return err
+		}
+		or.recordLocalWrite(name, updatedInstance)
+		log.Debugf("Updated CnsVolumeOperationRequest instance %s/%s with latest information for task with ID: %s",
+			instanceKey.Namespace, instanceKey.Name, latestTaskID)
+		return nil
+	})
+}
+
+// newOperationRequestInstance returns a new CnsVolumeOperationRequest
+// instance holding the details of the given operation.
+func newOperationRequestInstance(instanceKey client.ObjectKey,
+	operationToStore *VolumeOperationRequestDetails) *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest {
+	operationDetailsToStore := convertToCnsVolumeOperationRequestDetails(*operationToStore.OperationDetails)
+	newInstance := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instanceKey.Name,
+			Namespace: instanceKey.Namespace,
+		},
+		Spec: cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestSpec{
+			Name: instanceKey.Name,
+		},
+		Status: cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestStatus{
+			VolumeID:              operationToStore.VolumeID,
+			SnapshotID:            operationToStore.SnapshotID,
+			Capacity:              operationToStore.Capacity,
+			FirstOperationDetails: *operationDetailsToStore,
+			LatestOperationDetails: []cnsvolumeoprequestv1alpha1.OperationDetails{
+				*operationDetailsToStore,
+			},
+		},
 	}
+	if isPodVMOnStretchSupervisorFSSEnabled && operationToStore.QuotaDetails != nil {
+		newInstance.Status.StorageQuotaDetails = convertToCnsVolumeOperationRequestQuotaDetails(
+			*operationToStore.QuotaDetails)
+	}
+	return newInstance
+}
+
+// applyOperation returns a copy of the given CnsVolumeOperationRequest
+// instance updated with the details of the given operation.
+func applyOperation(ctx context.Context, instance *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest,
+	operationToStore *VolumeOperationRequestDetails) *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest {
+	log := logger.GetLogger(ctx)
+	operationDetailsToStore := convertToCnsVolumeOperationRequestDetails(*operationToStore.OperationDetails)
 
 	// Create a deep copy since we modify the object.
 	updatedInstance := instance.DeepCopy()
@@ -296,14 +356,8 @@ func (or *operationRequestStore) StoreRequestDetails(
 	updatedInstance.Status.SnapshotID = operationToStore.SnapshotID
 	updatedInstance.Status.Capacity = operationToStore.Capacity
 	if isPodVMOnStretchSupervisorFSSEnabled && operationToStore.QuotaDetails != nil {
-		updatedInstance.Status.StorageQuotaDetails = &cnsvolumeoprequestv1alpha1.QuotaDetails{
-			Reserved:                            operationToStore.QuotaDetails.Reserved,
-			StoragePolicyId:                     operationToStore.QuotaDetails.StoragePolicyId,
-			StorageClassName:                    operationToStore.QuotaDetails.StorageClassName,
-			Namespace:                           operationToStore.QuotaDetails.Namespace,
-			AggregatedSnapshotSize:              operationToStore.QuotaDetails.AggregatedSnapshotSize,
-			SnapshotLatestOperationCompleteTime: operationToStore.QuotaDetails.SnapshotLatestOperationCompleteTime,
-		}
+		updatedInstance.Status.StorageQuotaDetails = convertToCnsVolumeOperationRequestQuotaDetails(
+			*operationToStore.QuotaDetails)
 	}
 
 	// Modify FirstOperationDetails only if TaskID's match or the initial TaskID is empty.
@@ -339,24 +393,25 @@ func (or *operationRequestStore) StoreRequestDetails(
 		}
 	}
 
-	// Store the local instance on the API server.
-	err := or.k8sclient.Update(ctx, updatedInstance)
-	if err != nil {
-		log.Errorf(
-			"failed to update CnsVolumeOperationRequest instance %s/%s with error: %v",
-			instanceKey.Namespace,
-			instanceKey.Name,
-			err,
-		)
-		return err
+	// The attempts which preceded a successful operation are not needed
+	// anymore, compact them to keep the instance small.
+	if operationDetailsToStore.TaskStatus == TaskInvocationStatusSuccess && !hasInProgressOperation(updatedInstance) {
+		updatedInstance.Status.LatestOperationDetails = []cnsvolumeoprequestv1alpha1.OperationDetails{
+			*operationDetailsToStore,
+		}
 	}
-	log.Debugf(
-		"Updated CnsVolumeOperationRequest instance %s/%s with latest information for task with ID: %s",
-		instanceKey.Namespace,
-		instanceKey.Name,
-		operationDetailsToStore.TaskID,
-	)
-	return nil
+	return updatedInstance
+}
+
+// hasInProgressOperation returns true if any operation of the given
+// instance is still in progress.
+func hasInProgressOperation(instance *cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequest) bool {
+	for _, operationDetail := range instance.Status.LatestOperationDetails {
+		if operationDetail.TaskStatus == TaskInvocationStatusInProgress {
+			return true
+		}
+	}
+	return false
 }
 
 // DeleteRequestDetails deletes the input CnsVolumeOperationRequest instance
@@ -371,6 +426,7 @@ func (or *operationRequestStore) DeleteRequestDetails(ctx context.Context, name
 			Namespace: csiNamespace,
 		},
 	})
+	observeAPICall("delete", err)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			log.Errorf("failed to delete CnsVolumeOperationRequest instance %s/%s with error: %v",
@@ -378,6 +434,7 @@ func (or *operationRequestStore) DeleteRequestDetails(ctx context.Context, name
 			return err
 		}
 	}
+	or.recordLocalWrite(name, nil)
 	return nil
 }
 
@@ -392,12 +449,7 @@ func (or *operationRequestStore) cleanupStaleInstances(cleanupInterval int) {
 		continueToken := ""
 		log.Infof("Cleaning up stale CnsVolumeOperationRequest instances.")
 		for {
-			listOptions := &client.ListOptions{
-				Limit:    5000,
-				Continue: continueToken,
-			}
-			cnsVolumeOperationRequestList := &cnsvolumeoprequestv1alpha1.CnsVolumeOperationRequestList{}
-			err := or.k8sclient.List(ctx, cnsVolumeOperationRequestList, listOptions)
+			cnsVolumeOperationRequestList, err := or.listInstances(ctx, continueToken)
 			if err != nil {
 				log.Errorf("failed to list CnsVolumeOperationRequests with error %v. Abandoning "+
 					"CnsVolumeOperationRequests clean up ...", err)
@@ -111,3 +111,18 @@ func convertToCnsVolumeOperationRequestDetails(
 		Error:                   details.Error,
 	}
 }
+
+// convertToCnsVolumeOperationRequestQuotaDetails converts an object of type
+// QuotaDetails to the QuotaDetails type defined by the
+// CnsVolumeOperationRequest Custom Resource.
+func convertToCnsVolumeOperationRequestQuotaDetails(
+	details QuotaDetails) *cnsvolumeoprequestv1alpha1.QuotaDetails {
+	return &cnsvolumeoprequestv1alpha1.QuotaDetails{
+		Reserved:                            details.Reserved,
+		StoragePolicyId:                     details.StoragePolicyId,
+		StorageClassName:                    details.StorageClassName,
+		Namespace:                           details.Namespace,
+		AggregatedSnapshotSize:              details.AggregatedSnapshotSize,
+		SnapshotLatestOperationCompleteTime: details.SnapshotLatestOperationCompleteTime,
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsvolumeoperationrequest
+
+import (
+	"context"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+)
+
+// writeBatch is a group of operations persisted with a single write of a
+// CnsVolumeOperationRequest instance.
+type writeBatch struct {
+	operations []*VolumeOperationRequestDetails
+	// done is closed once the operations are persisted, or failed to be
+	// persisted with err.
+	done chan struct{}
+	err  error
+}
+
+// storeOperation persists the given operation. If a write of the same
+// instance is in flight, the operation is queued and persisted with the
+// other operations queued in the meantime once that write completes, so
+// that a burst of updates of an instance costs a single round trip to the
+// API server.
+func (or *operationRequestStore) storeOperation(ctx context.Context,
+	operationToStore *VolumeOperationRequestDetails) error {
+	name := operationToStore.Name
+	or.writesLock.Lock()
+	if or.writes == nil {
+		or.writes = make(map[string][]*writeBatch)
+	}
+	if queue, inFlight := or.writes[name]; inFlight {
+		var batch *writeBatch
+		if len(queue) > 0 && canCoalesce(queue[len(queue)-1], operationToStore) {
+			batch = queue[len(queue)-1]
+			prometheus.CnsVolumeOperationRequestCoalescedWritesCounter.Inc()
+		} else {
+			batch = &writeBatch{done: make(chan struct{})}
+			or.writes[name] = append(queue, batch)
+		}
+		batch.operations = append(batch.operations, operationToStore)
+		or.writesLock.Unlock()
+		<-batch.done
+		return batch.err
+	}
+	// An entry without queued batches marks the write as in flight.
+	or.writes[name] = nil
+	or.writesLock.Unlock()
+
+	err := or.writeOperations(ctx, name, []*VolumeOperationRequestDetails{operationToStore})
+	// The queued batches are not written on behalf of this caller, so they
+	// must not be cancelled with its context.
+	go or.flushWrites(context.WithoutCancel(ctx), name)
+	return err
+}
+
+// flushWrites writes the batches queued for the instance with the given
+// name until the queue is empty.
+func (or *operationRequestStore) flushWrites(ctx context.Context, name string) {
+	for {
+		or.writesLock.Lock()
+		queue := or.writes[name]
+		if len(queue) == 0 {
+			delete(or.writes, name)
+			or.writesLock.Unlock()
+			return
+		}
+		batch := queue[0]
+		or.writes[name] = queue[1:]
+		or.writesLock.Unlock()
+		batch.err = or.writeOperations(ctx, name, batch.operations)
+		close(batch.done)
+	}
+}
+
+// canCoalesce returns true if the operation can be persisted with the
+// operations of the given batch. Operations carrying storage quota details
+// are written on their own, as the syncer accounts the reserved storage on
+// every update of the instances.
+func canCoalesce(batch *writeBatch, operationToStore *VolumeOperationRequestDetails) bool {
+	if !isPodVMOnStretchSupervisorFSSEnabled {
+		return true
+	}
+	if operationToStore.QuotaDetails != nil {
+		return false
+	}
+	for _, queued := range batch.operations {
+		if queued.QuotaDetails != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -54,6 +54,34 @@ type CSINodeTopologyStatus struct {
 	// ErrorMessage will contain the error string when `Status` field is set to "Error".
 	// It will be empty when the `Status` field is set to "Success".
 	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// MaxBlockVolumes is the number of block volumes which can be attached
+	// to the NodeVM, computed from its SCSI and NVMe controllers.
+	// It is zero when the attach limit of the NodeVM is not computed.
+	//+optional
+	MaxBlockVolumes int64 `json:"maxBlockVolumes,omitempty"`
+
+	// Host identifies the ESXi host running the NodeVM. It is reported by the
+	// node as the value of the "topology.csi.vmware.com/esxi-host" topology
+	// segment, which is used to place host-local volumes.
+	// It is empty when host-local volumes are not enabled.
+	//+optional
+	Host string `json:"host,omitempty"`
+
+	// DriftedTopologyLabels consists of the topology-related labels currently
+	// applied to the NodeVM or its ancestors in the VC which differ from
+	// TopologyLabels, for example after the NodeVM is moved to a host in
+	// another zone. A label removed from the NodeVM is reported with an empty
+	// value. TopologyLabels are not updated on drift as the labels on the
+	// kubernetes node are only applied when the node registers.
+	// It is empty when no drift is detected.
+	//+optional
+	DriftedTopologyLabels []TopologyLabel `json:"driftedTopologyLabels,omitempty"`
+
+	// TopologyDriftDetectedTime is the time the drift reported in
+	// DriftedTopologyLabels was detected.
+	//+optional
+	TopologyDriftDetectedTime *metav1.Time `json:"topologyDriftDetectedTime,omitempty"`
 }
 
 // TopologyLabel will consist of a key-value pair.
@@ -106,6 +106,15 @@ func (in *CSINodeTopologyStatus) DeepCopyInto(out *CSINodeTopologyStatus) {
 		*out = make([]TopologyLabel, len(*in))
 		copy(*out, *in)
 	}
+	if in.DriftedTopologyLabels != nil {
+		in, out := &in.DriftedTopologyLabels, &out.DriftedTopologyLabels
+		*out = make([]TopologyLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopologyDriftDetectedTime != nil {
+		in, out := &in.TopologyDriftDetectedTime, &out.TopologyDriftDetectedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSINodeTopologyStatus.
@@ -27,7 +27,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	cnsfilevolclientv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/cnsfilevolumeclient/v1alpha1"
+	csifullsyncconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/csifullsyncconfig/v1alpha1"
 	triggercsifullsyncv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/triggercsifullsync/v1alpha1"
+	volumemigrationv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/volumemigration/v1alpha1"
 	cnscsisvfeaturestatesv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/featurestates/v1alpha1"
 )
 
@@ -46,6 +48,12 @@ var (
 
 	// TriggerCsiFullSyncPlural is plural of TriggerCsiFullSyncPlural
 	TriggerCsiFullSyncPlural = "triggercsifullsyncs"
+
+	// CsiFullSyncConfigPlural is plural of CsiFullSyncConfig
+	CsiFullSyncConfigPlural = "csifullsyncconfigs"
+
+	// VolumeMigrationPlural is plural of VolumeMigration
+	VolumeMigrationPlural = "volumemigrations"
 )
 
 var (
@@ -80,6 +88,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&triggercsifullsyncv1alpha1.TriggerCsiFullSyncList{},
 	)
 
+	scheme.AddKnownTypes(
+		SchemeGroupVersion,
+		&csifullsyncconfigv1alpha1.CsiFullSyncConfig{},
+		&csifullsyncconfigv1alpha1.CsiFullSyncConfigList{},
+	)
+
+	scheme.AddKnownTypes(
+		SchemeGroupVersion,
+		&volumemigrationv1alpha1.VolumeMigration{},
+		&volumemigrationv1alpha1.VolumeMigrationList{},
+	)
+
 	scheme.AddKnownTypes(
 		SchemeGroupVersion,
 		&cnscsisvfeaturestatesv1alpha1.CnsCsiSvFeatureStates{},
@@ -0,0 +1,8 @@
+package config
+
+import "embed"
+
+//go:embed cns.vmware.com_vcentercapabilities.yaml
+var EmbedVCenterCapabilityFile embed.FS
+
+const EmbedVCenterCapabilityFileName = "cns.vmware.com_vcentercapabilities.yaml"
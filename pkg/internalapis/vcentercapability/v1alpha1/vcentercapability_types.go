@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VCenterCapabilitySpec defines the desired state of VCenterCapability.
+type VCenterCapabilitySpec struct {
+	// Host is the vCenter host the capabilities are discovered on.
+	Host string `json:"host"`
+}
+
+// VCenterCapabilityStatus defines the observed state of VCenterCapability.
+type VCenterCapabilityStatus struct {
+	// Version is the vCenter product version.
+	//+optional
+	Version string `json:"version,omitempty"`
+
+	// Build is the vCenter build number.
+	//+optional
+	Build string `json:"build,omitempty"`
+
+	// VSphereAPIVersion is the vSphere API version negotiated with the vCenter.
+	//+optional
+	VSphereAPIVersion string `json:"vsphereAPIVersion,omitempty"`
+
+	// OnlineExtendVolume is true when attached volumes can be expanded.
+	OnlineExtendVolume bool `json:"onlineExtendVolume"`
+
+	// CnsSnapshot is true when the CNS snapshot APIs are available.
+	CnsSnapshot bool `json:"cnsSnapshot"`
+
+	// CnsTransaction is true when the CNS APIs support transactions.
+	CnsTransaction bool `json:"cnsTransaction"`
+
+	// VSANFileServices is true when file volumes can be provisioned on vSAN
+	// file services.
+	VSANFileServices bool `json:"vsanFileServices"`
+
+	// NVMe is true when volumes can be attached to NVMe controllers.
+	NVMe bool `json:"nvme"`
+
+	// SnapshotLimits are the maximum number of snapshots per block volume
+	// configured for the driver.
+	//+optional
+	SnapshotLimits *SnapshotLimits `json:"snapshotLimits,omitempty"`
+
+	// LastDiscoveryTime is the time the capabilities were discovered, on the
+	// last connection of the driver to the vCenter.
+	//+optional
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+
+	// ErrorMessage is set when the capabilities of the vCenter could not be
+	// discovered. The capabilities are not reported in this case.
+	//+optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// SnapshotLimits holds the maximum number of snapshots per block volume.
+type SnapshotLimits struct {
+	// MaxSnapshotsPerBlockVolume is the maximum number of snapshots per block
+	// volume on any datastore.
+	MaxSnapshotsPerBlockVolume int `json:"maxSnapshotsPerBlockVolume"`
+
+	// MaxSnapshotsPerBlockVolumeInVSAN is the maximum number of snapshots per
+	// block volume on vSAN datastores. It is zero when not configured.
+	//+optional
+	MaxSnapshotsPerBlockVolumeInVSAN int `json:"maxSnapshotsPerBlockVolumeInVSAN,omitempty"`
+
+	// MaxSnapshotsPerBlockVolumeInVVOL is the maximum number of snapshots per
+	// block volume on vVol datastores. It is zero when not configured.
+	//+optional
+	MaxSnapshotsPerBlockVolumeInVVOL int `json:"maxSnapshotsPerBlockVolumeInVVOL,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+//+kubebuilder:printcolumn:name="Build",type=string,JSONPath=`.status.build`
+//+kubebuilder:printcolumn:name="Discovered",type=date,JSONPath=`.status.lastDiscoveryTime`
+
+// VCenterCapability is the Schema for the vcentercapabilities API. It reports
+// the capabilities discovered by the driver on a vCenter.
+type VCenterCapability struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VCenterCapabilitySpec   `json:"spec"`
+	Status VCenterCapabilityStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VCenterCapabilityList contains a list of VCenterCapability.
+type VCenterCapabilityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VCenterCapability `json:"items"`
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcentercapability
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	cnsconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	vcentercapabilityconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/vcentercapability/config"
+	vcentercapabilityv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/vcentercapability/v1alpha1"
+	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
+)
+
+const (
+	// CRDSingular represents the singular name of vcentercapability CRD.
+	CRDSingular = "vcentercapability"
+	// CRDPlural represents the plural name of vcentercapability CRD.
+	CRDPlural = "vcentercapabilities"
+
+	// publishInterval is the interval at which the VCenterCapability
+	// instances are updated with the capabilities discovered on the vCenters.
+	publishInterval = time.Minute
+)
+
+// getCapabilities returns the capabilities discovered on the given vCenter.
+// It is a variable to be replaced in unit tests.
+var getCapabilities = cnsvsphere.GetCapabilities
+
+// StartPublisher creates the VCenterCapability CRD and starts publishing the
+// capabilities discovered on each vCenter registered with the driver in a
+// VCenterCapability instance named after the vCenter host. getConfig returns
+// the current configuration of the driver, from which the snapshot limits
+// are published. getConfig is nil when the snapshot limits are not set in
+// the driver configuration. The instances are only published while isOwner
+// returns true, so that a single instance of the driver owns them.
+func StartPublisher(ctx context.Context, getConfig func() *cnsconfig.Config, isOwner func() bool) error {
+	log := logger.GetLogger(ctx)
+	err := k8s.CreateCustomResourceDefinitionFromManifest(ctx,
+		vcentercapabilityconfig.EmbedVCenterCapabilityFile, vcentercapabilityconfig.EmbedVCenterCapabilityFileName)
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to create %q CRD. Err: %v", CRDSingular, err)
+	}
+	config, err := k8s.GetKubeConfig(ctx)
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to get kubeconfig. Err: %v", err)
+	}
+	k8sClient, err := k8s.NewClientForGroup(ctx, config, vcentercapabilityv1alpha1.GroupName)
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to create k8sClient for %s publisher. Err: %v", CRDSingular, err)
+	}
+	vcManager := cnsvsphere.GetVirtualCenterManager(ctx)
+	go func() {
+		ticker := time.NewTicker(publishInterval)
+		defer ticker.Stop()
+		for {
+			var hosts []string
+			for _, vc := range vcManager.GetAllVirtualCenters() {
+				hosts = append(hosts, vc.Config.Host)
+			}
+			var cfg *cnsconfig.Config
+			if getConfig != nil {
+				cfg = getConfig()
+			}
+			// The instances are not deleted before the vCenters are registered.
+			if isOwner() && len(hosts) != 0 {
+				if err := publish(ctx, k8sClient, hosts, cfg); err != nil {
+					log.Warnf("failed to publish the capabilities of the vCenters. Err: %v", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Infof("Started publishing the capabilities of the vCenters in %s instances", CRDSingular)
+	return nil
+}
+
+// publish reconciles the VCenterCapability instances with the capabilities
+// discovered on the given vCenters. Instances are only updated when the
+// capabilities change, and the instances of vCenters no longer registered
+// with the driver are deleted.
+func publish(ctx context.Context, k8sClient client.Client, hosts []string, cfg *cnsconfig.Config) error {
+	log := logger.GetLogger(ctx)
+	desired := make(map[string]*vcentercapabilityv1alpha1.VCenterCapability)
+	for _, host := range hosts {
+		name := strings.ToLower(host)
+		desired[name] = &vcentercapabilityv1alpha1.VCenterCapability{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       vcentercapabilityv1alpha1.VCenterCapabilitySpec{Host: host},
+			Status:     getStatus(host, cfg),
+		}
+	}
+
+	instances := &vcentercapabilityv1alpha1.VCenterCapabilityList{}
+	if err := k8sClient.List(ctx, instances); err != nil {
+		return fmt.Errorf("failed to list %s instances: %w", CRDSingular, err)
+	}
+	for i := range instances.Items {
+		instance := &instances.Items[i]
+		want, ok := desired[instance.Name]
+		if !ok {
+			log.Infof("Deleting %s instance %q as the vCenter is no longer registered", CRDSingular, instance.Name)
+			if err := k8sClient.Delete(ctx, instance); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s instance %q: %w", CRDSingular, instance.Name, err)
+			}
+			continue
+		}
+		delete(desired, instance.Name)
+		if equality.Semantic.DeepEqual(instance.Status, want.Status) {
+			continue
+		}
+		instance.Status = want.Status
+		if err := k8sClient.Status().Update(ctx, instance); err != nil {
+			return fmt.Errorf("failed to update %s instance %q: %w", CRDSingular, instance.Name, err)
+		}
+		log.Infof("Updated %s instance %q with status %+v", CRDSingular, instance.Name, instance.Status)
+	}
+	for name, instance := range desired {
+		status := instance.Status
+		if err := k8sClient.Create(ctx, instance); err != nil {
+			return fmt.Errorf("failed to create %s instance %q: %w", CRDSingular, name, err)
+		}
+		// The status is a subresource, so it is not persisted on create.
+		instance.Status = status
+		if err := k8sClient.Status().Update(ctx, instance); err != nil {
+			return fmt.Errorf("failed to update %s instance %q: %w", CRDSingular, name, err)
+		}
+		log.Infof("Created %s instance %q with status %+v", CRDSingular, name, instance.Status)
+	}
+	return nil
+}
+
+// getStatus returns the status of the VCenterCapability instance of the
+// given vCenter.
+func getStatus(host string, cfg *cnsconfig.Config) vcentercapabilityv1alpha1.VCenterCapabilityStatus {
+	capabilities, ok := getCapabilities(host)
+	if !ok {
+		return vcentercapabilityv1alpha1.VCenterCapabilityStatus{
+			ErrorMessage: fmt.Sprintf("capabilities of vCenter %q have not been discovered as the driver is "+
+				"not connected to it or could not identify its version", host),
+		}
+	}
+	// The time is persisted with a precision of a second.
+	discoveryTime := metav1.NewTime(capabilities.DiscoveryTime.Truncate(time.Second))
+	status := vcentercapabilityv1alpha1.VCenterCapabilityStatus{
+		Version:            capabilities.Version,
+		Build:              capabilities.Build,
+		VSphereAPIVersion:  capabilities.APIVersion,
+		OnlineExtendVolume: capabilities.OnlineExtendVolume,
+		CnsSnapshot:        capabilities.CnsSnapshot,
+		CnsTransaction:     capabilities.CnsTransaction,
+		VSANFileServices:   capabilities.VSANFileServices,
+		NVMe:               capabilities.NVMe,
+		LastDiscoveryTime:  &discoveryTime,
+	}
+	if cfg != nil {
+		status.SnapshotLimits = &vcentercapabilityv1alpha1.SnapshotLimits{
+			MaxSnapshotsPerBlockVolume:       cfg.Snapshot.GlobalMaxSnapshotsPerBlockVolume,
+			MaxSnapshotsPerBlockVolumeInVSAN: cfg.Snapshot.GranularMaxSnapshotsPerBlockVolumeInVSAN,
+			MaxSnapshotsPerBlockVolumeInVVOL: cfg.Snapshot.GranularMaxSnapshotsPerBlockVolumeInVVOL,
+		}
+	}
+	return status
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcentercapability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	cnsconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	vcentercapabilityv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/vcentercapability/v1alpha1"
+)
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := vcentercapabilityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add scheme: %v", err)
+	}
+	stale := &vcentercapabilityv1alpha1.VCenterCapability{
+		ObjectMeta: metav1.ObjectMeta{Name: "vc3"},
+		Spec:       vcentercapabilityv1alpha1.VCenterCapabilitySpec{Host: "vc3"},
+	}
+	k8sClient := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(stale).
+		WithStatusSubresource(&vcentercapabilityv1alpha1.VCenterCapability{}).Build()
+
+	discoveryTime := time.Now()
+	getCapabilities = func(host string) (*cnsvsphere.Capabilities, bool) {
+		if host != "VC1" {
+			return nil, false
+		}
+		return &cnsvsphere.Capabilities{Host: host, Version: "8.0.3", Build: "24022515", CnsSnapshot: true,
+			NVMe: true, DiscoveryTime: discoveryTime}, true
+	}
+	defer func() { getCapabilities = cnsvsphere.GetCapabilities }()
+	cfg := &cnsconfig.Config{}
+	cfg.Snapshot.GlobalMaxSnapshotsPerBlockVolume = 3
+
+	if err := publish(ctx, k8sClient, []string{"VC1", "vc2"}, cfg); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+
+	vc1 := &vcentercapabilityv1alpha1.VCenterCapability{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "vc1"}, vc1); err != nil {
+		t.Fatalf("Failed to get instance of vc1: %v", err)
+	}
+	if vc1.Spec.Host != "VC1" || vc1.Status.Version != "8.0.3" || !vc1.Status.CnsSnapshot ||
+		!vc1.Status.NVMe || vc1.Status.CnsTransaction || vc1.Status.ErrorMessage != "" {
+		t.Errorf("Unexpected instance of vc1: %+v", vc1)
+	}
+	if vc1.Status.SnapshotLimits == nil || vc1.Status.SnapshotLimits.MaxSnapshotsPerBlockVolume != 3 {
+		t.Errorf("Expected the snapshot limits to be published, got %+v", vc1.Status.SnapshotLimits)
+	}
+	vc2 := &vcentercapabilityv1alpha1.VCenterCapability{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "vc2"}, vc2); err != nil {
+		t.Fatalf("Failed to get instance of vc2: %v", err)
+	}
+	if vc2.Status.ErrorMessage == "" || vc2.Status.LastDiscoveryTime != nil {
+		t.Errorf("Expected an error for the vCenter without discovered capabilities, got %+v", vc2.Status)
+	}
+	err := k8sClient.Get(ctx, client.ObjectKey{Name: "vc3"}, &vcentercapabilityv1alpha1.VCenterCapability{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the instance of the unregistered vCenter to be deleted, got %v", err)
+	}
+
+	// The instances are not updated when the capabilities do not change.
+	if err := publish(ctx, k8sClient, []string{"VC1", "vc2"}, cfg); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	unchanged := &vcentercapabilityv1alpha1.VCenterCapability{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "vc1"}, unchanged); err != nil {
+		t.Fatalf("Failed to get instance of vc1: %v", err)
+	}
+	if unchanged.ResourceVersion != vc1.ResourceVersion {
+		t.Errorf("Expected the instance of vc1 not to be updated, got resource version %s, want %s",
+			unchanged.ResourceVersion, vc1.ResourceVersion)
+	}
+}
@@ -18,6 +18,8 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -288,6 +290,53 @@ func (im *InformerManager) Listen() (stopCh <-chan struct{}) {
 	return im.stopCh
 }
 
+// getUnsyncedInformers returns the names of the informers of the informer
+// manager which have not synced their caches yet.
+func (im *InformerManager) getUnsyncedInformers() []string {
+	informers := []struct {
+		name     string
+		informer cache.SharedInformer
+	}{
+		{"node", im.nodeInformer},
+		{"configmap", im.configMapInformer},
+		{"persistentvolume", im.pvInformer},
+		{"persistentvolumeclaim", im.pvcInformer},
+		{"namespace", im.namespaceInformer},
+		{"pod", im.podInformer},
+		{"volumeattachment", im.volumeAttachmentInformer},
+	}
+	var unsynced []string
+	for _, i := range informers {
+		if i.informer != nil && !i.informer.HasSynced() {
+			unsynced = append(unsynced, i.name)
+		}
+	}
+	return unsynced
+}
+
+// CheckInformersSynced returns an error naming the informers of the
+// in-cluster and supervisor informer managers which have not synced their
+// caches yet.
+func CheckInformersSynced(ctx context.Context) error {
+	var unsynced []string
+	inClusterInformerInstanceLock.Lock()
+	if inClusterInformerManagerInstance != nil {
+		unsynced = append(unsynced, inClusterInformerManagerInstance.getUnsyncedInformers()...)
+	}
+	inClusterInformerInstanceLock.Unlock()
+	supervisorInformerInstanceLock.Lock()
+	if supervisorInformerManagerInstance != nil {
+		for _, name := range supervisorInformerManagerInstance.getUnsyncedInformers() {
+			unsynced = append(unsynced, "supervisor "+name)
+		}
+	}
+	supervisorInformerInstanceLock.Unlock()
+	if len(unsynced) > 0 {
+		return fmt.Errorf("informers not synced: %s", strings.Join(unsynced, ", "))
+	}
+	return nil
+}
+
 // NewConfigMapListener creates a new configmap listener in the given namespace.
 // NOTE: This creates a NewSharedIndexInformer everytime and does not use the informer factory.
 // Only use this function when you need a configmap listener in a different namespace than the
@@ -70,6 +70,7 @@ import (
 	cnsvolumeinfov1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeinfo/v1alpha1"
 	cnsvolumeoprequestv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsvolumeoperationrequest/v1alpha1"
 	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
+	vcentercapabilityv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/vcentercapability/v1alpha1"
 )
 
 const (
@@ -283,6 +284,11 @@ func NewClientForGroup(ctx context.Context, config *restclient.Config, groupName
 			log.Errorf("failed to add CNSVolumeInfo to scheme with error: %+v", err)
 			return nil, err
 		}
+		err = vcentercapabilityv1alpha1.AddToScheme(scheme)
+		if err != nil {
+			log.Errorf("failed to add VCenterCapability to scheme with error: %+v", err)
+			return nil, err
+		}
 
 		err = storagepoolAPIs.AddToScheme(scheme)
 		if err != nil {
@@ -254,6 +254,36 @@ func (h *CSISupervisorMutationWebhook) Handle(ctx context.Context, req admission
 	return admission.Allowed("")
 }
 
+// getClientVmUID returns the UID of the PodVM if isPodVM is true,
+// otherwise it returns the UID of the VM.
+func getClientVmUID(ctx context.Context,
+	vmName string, isPodVM bool, namespace string) (string, error) {
+	if isPodVM {
+		return getPodVmUID(ctx, vmName, namespace)
+	}
+	return getVmUID(ctx, vmName, namespace)
+}
+
+// getPodVmUID returns the UID for the given PodVM.
+func getPodVmUID(ctx context.Context, podVMName string, namespace string) (string, error) {
+	log := logger.GetLogger(ctx)
+
+	k8sClient, err := k8s.NewClient(ctx)
+	if err != nil {
+		log.Errorf("failed to create k8s client. Error: %s", err)
+		return "", err
+	}
+
+	pod, err := k8sClient.CoreV1().Pods(namespace).Get(ctx, podVMName, v1.GetOptions{})
+	if err != nil {
+		log.Errorf("failed to obtain PodVM %s. Error: %s", podVMName, err)
+		return "", err
+	}
+
+	log.Infof("Found UID %s for PodVM %s", string(pod.UID), podVMName)
+	return string(pod.UID), nil
+}
+
 // getVmUID returns the VM UID for the given VM
 func getVmUID(ctx context.Context,
 	vmName string, namespace string) (string, error) {
@@ -347,9 +377,14 @@ func (h *CSISupervisorMutationWebhook) mutateNewCnsFileAccessConfig(ctx context.
 	}
 
 	// Obtain VM's UID
-	vmUID, err := getVmUID(ctx, newCnsFileAccessConfig.Spec.VMName, newCnsFileAccessConfig.Namespace)
+	vmName := newCnsFileAccessConfig.Spec.VMName
+	isPodVM := newCnsFileAccessConfig.Spec.PodVMName != ""
+	if isPodVM {
+		vmName = newCnsFileAccessConfig.Spec.PodVMName
+	}
+	vmUID, err := getClientVmUID(ctx, vmName, isPodVM, newCnsFileAccessConfig.Namespace)
 	if err != nil {
-		log.Errorf("faield to get VM UID for VM %s. Err: %s", newCnsFileAccessConfig.Spec.VMName, err)
+		log.Errorf("faield to get VM UID for VM %s. Err: %s", vmName, err)
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
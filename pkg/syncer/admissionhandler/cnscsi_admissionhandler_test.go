@@ -264,6 +264,11 @@ func (m *MockCOCommonInterface) HandleLateEnablementOfCapability(ctx context.Con
 	m.Called(ctx, clusterFlavor, capability, gcPort, gcEndpoint)
 }
 
+func (m *MockCOCommonInterface) RecordFaultEvent(ctx context.Context, object *corev1.ObjectReference,
+	event types.FaultEvent) {
+	m.Called(ctx, object, event)
+}
+
 // MockCryptoClient is a mock implementation of crypto.Client
 type MockCryptoClient struct {
 	mock.Mock
@@ -25,7 +25,8 @@ const (
 	KubernetesAdmin          = "kubernetes-admin"
 )
 
-// validateCreateCnsFileAccessConfig validates if a CnsFileAccessConfig CR with the same VM and PVC already exists.
+// validateCreateCnsFileAccessConfig validates the spec of a new CnsFileAccessConfig CR and
+// verifies if a CnsFileAccessConfig CR with the same VM (or PodVM) and PVC already exists.
 // If it already exists, do not allow creation of another CR.
 func validateCreateCnsFileAccessConfig(ctx context.Context, clientConfig *rest.Config,
 	req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
@@ -44,6 +45,16 @@ func validateCreateCnsFileAccessConfig(ctx context.Context, clientConfig *rest.C
 		}
 	}
 
+	err := validateCnsFileAccessConfigSpec(cnsFileAccessConfig.Spec)
+	if err != nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("Invalid CnsFileAccessConfig spec: %v", err),
+			},
+		}
+	}
+
 	// This validation is not required for PVCSI service account.
 	isPvCSIServiceAccount, err := validatePvCSIServiceAccount(req.UserInfo.Username)
 	if err != nil {
@@ -64,10 +75,15 @@ func validateCreateCnsFileAccessConfig(ctx context.Context, clientConfig *rest.C
 	}
 
 	vm := cnsFileAccessConfig.Spec.VMName
+	isPodVM := false
+	if cnsFileAccessConfig.Spec.PodVMName != "" {
+		vm = cnsFileAccessConfig.Spec.PodVMName
+		isPodVM = true
+	}
 	pvc := cnsFileAccessConfig.Spec.PvcName
 	namespace := cnsFileAccessConfig.Namespace
 	existingCnsFileAccessConfigName, err := cnsFileAccessConfigAlreadyExists(ctx,
-		clientConfig, namespace, vm, pvc)
+		clientConfig, namespace, vm, isPodVM, pvc)
 	if err != nil {
 		return &admissionv1.AdmissionResponse{
 			Allowed: false,
@@ -95,11 +111,34 @@ func validateCreateCnsFileAccessConfig(ctx context.Context, clientConfig *rest.C
 
 }
 
+// validateCnsFileAccessConfigSpec verifies that exactly one of VMName or PodVMName
+// is set and that the requested permission is supported.
+func validateCnsFileAccessConfigSpec(spec cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec) error {
+	if spec.PvcName == "" {
+		return fmt.Errorf("pvcName must be set")
+	}
+	if spec.VMName == "" && spec.PodVMName == "" {
+		return fmt.Errorf("either vmName or podVMName must be set")
+	}
+	if spec.VMName != "" && spec.PodVMName != "" {
+		return fmt.Errorf("only one of vmName or podVMName can be set")
+	}
+	switch spec.Permission {
+	case "", cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadOnly,
+		cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadWrite:
+	default:
+		return fmt.Errorf("unsupported permission %q. Supported values are %q and %q", spec.Permission,
+			cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadOnly,
+			cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadWrite)
+	}
+	return nil
+}
+
 // cnsFileAccessConfigAlreadyExists lists all CnsFileAccessConfig CRs in the given namespace
-// and verifies if any of them has the same VM name and PVC name.
+// and verifies if any of them has the same VM (or PodVM) name and PVC name.
 // It returns the name of the CR with the same VM and PVC.
 func cnsFileAccessConfigAlreadyExists(ctx context.Context, clientConfig *rest.Config, namespace string,
-	vm string, pvc string) (string, error) {
+	vm string, isPodVM bool, pvc string) (string, error) {
 	log := logger.GetLogger(ctx)
 
 	cnsOperatorClient, err := k8s.NewClientForGroup(ctx, clientConfig, cnsoperatorv1alpha1.GroupName)
@@ -109,7 +148,7 @@ func cnsFileAccessConfigAlreadyExists(ctx context.Context, clientConfig *rest.Co
 	}
 
 	// Obtain VM's UID.
-	vmUID, err := getVmUID(ctx, vm, namespace)
+	vmUID, err := getClientVmUID(ctx, vm, isPodVM, namespace)
 	if err != nil {
 		log.Errorf("failed to get VM UID for VM %s. Err: %s", vm, err)
 		return "", err
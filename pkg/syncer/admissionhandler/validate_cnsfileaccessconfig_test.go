@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionhandler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cnsfileaccessconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsfileaccessconfig/v1alpha1"
+)
+
+func TestValidateCnsFileAccessConfigSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec
+		wantErr bool
+	}{
+		{
+			name: "VM with default permission",
+			spec: cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{PvcName: "pvc-1", VMName: "vm-1"},
+		},
+		{
+			name: "PodVM with read-only permission and root squash",
+			spec: cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{
+				PvcName:    "pvc-1",
+				PodVMName:  "pod-1",
+				Permission: cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadOnly,
+				RootSquash: true,
+			},
+		},
+		{
+			name:    "Neither VM nor PodVM",
+			spec:    cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{PvcName: "pvc-1"},
+			wantErr: true,
+		},
+		{
+			name: "Both VM and PodVM",
+			spec: cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{
+				PvcName:   "pvc-1",
+				VMName:    "vm-1",
+				PodVMName: "pod-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported permission",
+			spec: cnsfileaccessconfigv1alpha1.CnsFileAccessConfigSpec{
+				PvcName:    "pvc-1",
+				VMName:     "vm-1",
+				Permission: "NO_ACCESS",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCnsFileAccessConfigSpec(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
 	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
@@ -346,6 +347,12 @@ func validateGuestPVCOperation(ctx context.Context, req *admissionv1.AdmissionRe
 		}
 	}
 
+	// A linked clone can also be created from a PVC, in which case pvCSI creates it
+	// from a VolumeSnapshot of the source volume taken in the supervisor cluster.
+	if dataSource.APIVersion == "" && dataSource.Kind == "PersistentVolumeClaim" {
+		return validateGuestLinkedCloneFromPVC(ctx, &pvc, dataSource)
+	}
+
 	// Validate the APIGroup. It should be "snapshot.storage.k8s.io"
 	if dataSource.APIVersion != "snapshot.storage.k8s.io" {
 		return &admissionv1.AdmissionResponse{
@@ -463,24 +470,15 @@ func validateGuestPVCOperation(ctx context.Context, req *admissionv1.AdmissionRe
 				},
 			}
 		}
-		// The svStorageClass parameter in the storageclass associated with the LinkedClone PVC
-		// should be the same as the svStorageClass in the storageclass of the source PVC
-		sourcePVCStorageClassName := sourcePVC.Spec.StorageClassName
-		linkedClonePVCStorageClassName := pvc.Spec.StorageClassName
-
-		// Validate source PVC StorageClass
-		if sourcePVCStorageClassName == nil {
-			errMsg := "source PVC does not have a StorageClass specified, " +
-				"please specify a StorageClass for linked clone creation"
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+		resp := validateLinkedCloneSourcePVC(ctx, k8sClient, &pvc, sourcePVC)
+		if !resp.Allowed {
+			return resp
 		}
-		if *sourcePVCStorageClassName == "" {
-			errMsg := "source PVC has an empty StorageClass name and cannot be validated for linked clone creation"
+		// should not be a second level LinkedClone
+		if metav1.HasAnnotation(sourcePVC.ObjectMeta, common.AnnKeyLinkedClone) {
+			errMsg := fmt.Sprintf("cannot create a LinkedClone "+
+				"from a VolumeSnapshot %s/%s that is created from another LinkedClone %s/%s",
+				volumeSnapshot.Namespace, volumeSnapshot.Name, sourcePVC.Namespace, sourcePVC.Name)
 			return &admissionv1.AdmissionResponse{
 				Allowed: false,
 				Result: &metav1.Status{
@@ -488,163 +486,248 @@ func validateGuestPVCOperation(ctx context.Context, req *admissionv1.AdmissionRe
 				},
 			}
 		}
+	}
+	// return AdmissionResponse result
+	return &admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+}
 
-		// Validate LinkedClone PVC StorageClass
-		if linkedClonePVCStorageClassName == nil {
-			errMsg := "LinkedClone PVC does not have a StorageClass specified," +
-				"please specify a StorageClass for linked clone creation"
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+// validateLinkedCloneSourcePVC validates that the linked clone PVC can be created
+// from the given source PVC, i.e. both use StorageClasses with the same
+// svStorageClass parameter and request the same size.
+func validateLinkedCloneSourcePVC(ctx context.Context, k8sClient clientset.Interface,
+	pvc *corev1.PersistentVolumeClaim, sourcePVC *corev1.PersistentVolumeClaim) *admissionv1.AdmissionResponse {
+	// The svStorageClass parameter in the storageclass associated with the LinkedClone PVC
+	// should be the same as the svStorageClass in the storageclass of the source PVC
+	sourcePVCStorageClassName := sourcePVC.Spec.StorageClassName
+	linkedClonePVCStorageClassName := pvc.Spec.StorageClassName
+
+	// Validate source PVC StorageClass
+	if sourcePVCStorageClassName == nil {
+		errMsg := "source PVC does not have a StorageClass specified, " +
+			"please specify a StorageClass for linked clone creation"
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
-		if *linkedClonePVCStorageClassName == "" {
-			errMsg := "LinkedClone PVC has an empty StorageClass name and cannot be validated for linked clone creation"
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	}
+	if *sourcePVCStorageClassName == "" {
+		errMsg := "source PVC has an empty StorageClass name and cannot be validated for linked clone creation"
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		// Retrieve the StorageClass objects
-		sourceStorageClass, err := k8sClient.StorageV1().StorageClasses().Get(ctx, *sourcePVCStorageClassName,
-			metav1.GetOptions{})
-		if err != nil {
-			errMsg := fmt.Sprintf("error getting source PVC StorageClass %s from api server: %v",
-				*sourcePVCStorageClassName, err)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	// Validate LinkedClone PVC StorageClass
+	if linkedClonePVCStorageClassName == nil {
+		errMsg := "LinkedClone PVC does not have a StorageClass specified," +
+			"please specify a StorageClass for linked clone creation"
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
+		}
+	}
+	if *linkedClonePVCStorageClassName == "" {
+		errMsg := "LinkedClone PVC has an empty StorageClass name and cannot be validated for linked clone creation"
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		linkedCloneStorageClass, err := k8sClient.StorageV1().StorageClasses().Get(ctx, *linkedClonePVCStorageClassName,
-			metav1.GetOptions{})
-		if err != nil {
-			errMsg := fmt.Sprintf("error getting LinkedClone PVC StorageClass %s from api server: %v",
-				*linkedClonePVCStorageClassName, err)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	// Retrieve the StorageClass objects
+	sourceStorageClass, err := k8sClient.StorageV1().StorageClasses().Get(ctx, *sourcePVCStorageClassName,
+		metav1.GetOptions{})
+	if err != nil {
+		errMsg := fmt.Sprintf("error getting source PVC StorageClass %s from api server: %v",
+			*sourcePVCStorageClassName, err)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		// Extract svStorageClass from StorageClass parameters (case-insensitive lookup)
-		var sourceSvStorageClass string
-		var sourceHasSvStorageClass bool
-		for param, value := range sourceStorageClass.Parameters {
-			if strings.ToLower(param) == common.AttributeSupervisorStorageClass {
-				sourceSvStorageClass = value
-				sourceHasSvStorageClass = true
-				break
-			}
+	linkedCloneStorageClass, err := k8sClient.StorageV1().StorageClasses().Get(ctx, *linkedClonePVCStorageClassName,
+		metav1.GetOptions{})
+	if err != nil {
+		errMsg := fmt.Sprintf("error getting LinkedClone PVC StorageClass %s from api server: %v",
+			*linkedClonePVCStorageClassName, err)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		var linkedCloneSvStorageClass string
-		var linkedCloneHasSvStorageClass bool
-		for param, value := range linkedCloneStorageClass.Parameters {
-			if strings.ToLower(param) == common.AttributeSupervisorStorageClass {
-				linkedCloneSvStorageClass = value
-				linkedCloneHasSvStorageClass = true
-				break
-			}
+	// Extract svStorageClass from StorageClass parameters (case-insensitive lookup)
+	var sourceSvStorageClass string
+	var sourceHasSvStorageClass bool
+	for param, value := range sourceStorageClass.Parameters {
+		if strings.ToLower(param) == common.AttributeSupervisorStorageClass {
+			sourceSvStorageClass = value
+			sourceHasSvStorageClass = true
+			break
 		}
+	}
 
-		// Both storage classes must have svStorageClass parameter
-		if !sourceHasSvStorageClass {
-			errMsg := fmt.Sprintf("source PVC StorageClass %s does not have %s parameter",
-				*sourcePVCStorageClassName, common.AttributeSupervisorStorageClass)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	var linkedCloneSvStorageClass string
+	var linkedCloneHasSvStorageClass bool
+	for param, value := range linkedCloneStorageClass.Parameters {
+		if strings.ToLower(param) == common.AttributeSupervisorStorageClass {
+			linkedCloneSvStorageClass = value
+			linkedCloneHasSvStorageClass = true
+			break
 		}
+	}
 
-		if !linkedCloneHasSvStorageClass {
-			errMsg := fmt.Sprintf("LinkedClone PVC StorageClass %s does not have %s parameter",
-				*linkedClonePVCStorageClassName, common.AttributeSupervisorStorageClass)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	// Both storage classes must have svStorageClass parameter
+	if !sourceHasSvStorageClass {
+		errMsg := fmt.Sprintf("source PVC StorageClass %s does not have %s parameter",
+			*sourcePVCStorageClassName, common.AttributeSupervisorStorageClass)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		// Compare svStorageClass values
-		if sourceSvStorageClass != linkedCloneSvStorageClass {
-			errMsg := fmt.Sprintf("StorageClass svStorageClass mismatch, Namespace: %s, "+
-				"LinkedClone StorageClass: %s (svStorageClass: %s), "+
-				"source PVC StorageClass: %s (svStorageClass: %s)",
-				sourcePVC.Namespace, *linkedClonePVCStorageClassName, linkedCloneSvStorageClass,
-				*sourcePVCStorageClassName, sourceSvStorageClass)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	if !linkedCloneHasSvStorageClass {
+		errMsg := fmt.Sprintf("LinkedClone PVC StorageClass %s does not have %s parameter",
+			*linkedClonePVCStorageClassName, common.AttributeSupervisorStorageClass)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
 
-		// the size should be same
-		sourcePVCSize, ok1 := sourcePVC.Spec.Resources.Requests[corev1.ResourceStorage]
-		linkedClonePVCSize, ok2 := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
-		if !ok1 {
-			errMsg := fmt.Sprintf("source PVC %s/%s does not have a storage request defined",
-				sourcePVC.Namespace, sourcePVC.Name)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	// Compare svStorageClass values
+	if sourceSvStorageClass != linkedCloneSvStorageClass {
+		errMsg := fmt.Sprintf("StorageClass svStorageClass mismatch, Namespace: %s, "+
+			"LinkedClone StorageClass: %s (svStorageClass: %s), "+
+			"source PVC StorageClass: %s (svStorageClass: %s)",
+			sourcePVC.Namespace, *linkedClonePVCStorageClassName, linkedCloneSvStorageClass,
+			*sourcePVCStorageClassName, sourceSvStorageClass)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
-		if !ok2 {
-			errMsg := fmt.Sprintf("linkedClone PVC %s/%s does not have a storage request defined",
-				pvc.Namespace, pvc.Name)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	}
+
+	// the size should be same
+	sourcePVCSize, ok1 := sourcePVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	linkedClonePVCSize, ok2 := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok1 {
+		errMsg := fmt.Sprintf("source PVC %s/%s does not have a storage request defined",
+			sourcePVC.Namespace, sourcePVC.Name)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
-		szResult := sourcePVCSize.Cmp(linkedClonePVCSize)
-		if szResult != 0 {
-			errMsg := fmt.Sprintf("size mismatch, Source PVC: %s LinkedClone PVC: %s",
-				sourcePVCSize.String(), linkedClonePVCSize.String())
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+	}
+	if !ok2 {
+		errMsg := fmt.Sprintf("linkedClone PVC %s/%s does not have a storage request defined",
+			pvc.Namespace, pvc.Name)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
 		}
+	}
+	szResult := sourcePVCSize.Cmp(linkedClonePVCSize)
+	if szResult != 0 {
+		errMsg := fmt.Sprintf("size mismatch, Source PVC: %s LinkedClone PVC: %s",
+			sourcePVCSize.String(), linkedClonePVCSize.String())
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: errMsg,
+			},
+		}
+	}
+	return &admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+}
 
-		// should not be a second level LinkedClone
-		if metav1.HasAnnotation(sourcePVC.ObjectMeta, common.AnnKeyLinkedClone) {
-			errMsg := fmt.Sprintf("cannot create a LinkedClone "+
-				"from a VolumeSnapshot %s/%s that is created from another LinkedClone %s/%s",
-				volumeSnapshot.Namespace, volumeSnapshot.Name, sourcePVC.Namespace, sourcePVC.Name)
-			return &admissionv1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Message: errMsg,
-				},
-			}
+// validateGuestLinkedCloneFromPVC validates a linked clone PVC request whose
+// datasource is another PVC. The source PVC needs to be bound, not under
+// deletion and not a LinkedClone itself.
+func validateGuestLinkedCloneFromPVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim,
+	dataSource *corev1.ObjectReference) *admissionv1.AdmissionResponse {
+	k8sClient, err := k8s.NewClient(ctx)
+	if err != nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("failed to get k8s client when validating linkedclone request"+
+					" with error: %v", err),
+			},
+		}
+	}
+	sourcePVC, err := k8sClient.CoreV1().PersistentVolumeClaims(dataSource.Namespace).Get(ctx, dataSource.Name,
+		metav1.GetOptions{})
+	if err != nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("error getting source PVC %s/%s from api server when validating linked clone "+
+					"request, error: %v", dataSource.Namespace, dataSource.Name, err),
+			},
+		}
+	}
+	if sourcePVC.DeletionTimestamp != nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("LinkedClone %s/%s source PVC %s/%s is marked for deletion, "+
+					"failing linkedclone request", pvc.Namespace, pvc.Name, sourcePVC.Namespace, sourcePVC.Name),
+			},
+		}
+	}
+	if sourcePVC.Status.Phase != corev1.ClaimBound {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("source PVC %s/%s is not bound, failing linkedclone request",
+					sourcePVC.Namespace, sourcePVC.Name),
+			},
+		}
+	}
+	resp := validateLinkedCloneSourcePVC(ctx, k8sClient, pvc, sourcePVC)
+	if !resp.Allowed {
+		return resp
+	}
+	// should not be a second level LinkedClone
+	if metav1.HasAnnotation(sourcePVC.ObjectMeta, common.AnnKeyLinkedClone) {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("cannot create a LinkedClone from PVC %s/%s that is another LinkedClone",
+					sourcePVC.Namespace, sourcePVC.Name),
+			},
 		}
 	}
-	// return AdmissionResponse result
 	return &admissionv1.AdmissionResponse{
 		Allowed: true,
 	}
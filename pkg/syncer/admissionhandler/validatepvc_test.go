@@ -1091,6 +1091,126 @@ func TestValidateGuestPVCOperation_LinkedClone_StorageClass(t *testing.T) {
 			expectedAllowed:        false,
 			expectedMessageContain: "does not have svstorageclass parameter",
 		},
+		{
+			name: "LinkedClone from a bound PVC should succeed",
+			kubeObjs: []runtime.Object{
+				&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testStorageClassA,
+					},
+					Provisioner: "csi.vsphere.vmware.com",
+					Parameters: map[string]string{
+						common.AttributeSupervisorStorageClass: testSvStorageClass1,
+					},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testSourcePVCName,
+						Namespace: testLinkedCloneNamespace,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: stringPtr(testStorageClassA),
+						AccessModes: []corev1.PersistentVolumeAccessMode{
+							corev1.ReadWriteOnce,
+						},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("5Gi"),
+							},
+						},
+					},
+					Status: corev1.PersistentVolumeClaimStatus{
+						Phase: corev1.ClaimBound,
+					},
+				},
+			},
+			pvc: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testLinkedClonePVCName,
+					Namespace: testLinkedCloneNamespace,
+					Annotations: map[string]string{
+						common.AnnKeyLinkedClone: "true",
+					},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: stringPtr(testStorageClassA),
+					AccessModes: []corev1.PersistentVolumeAccessMode{
+						corev1.ReadWriteOnce,
+					},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("5Gi"),
+						},
+					},
+					DataSource: &corev1.TypedLocalObjectReference{
+						Kind: "PersistentVolumeClaim",
+						Name: testSourcePVCName,
+					},
+				},
+			},
+			expectedAllowed:        true,
+			expectedMessageContain: "",
+		},
+		{
+			name: "LinkedClone from an unbound PVC should fail",
+			kubeObjs: []runtime.Object{
+				&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testStorageClassA,
+					},
+					Provisioner: "csi.vsphere.vmware.com",
+					Parameters: map[string]string{
+						common.AttributeSupervisorStorageClass: testSvStorageClass1,
+					},
+				},
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testSourcePVCName,
+						Namespace: testLinkedCloneNamespace,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: stringPtr(testStorageClassA),
+						AccessModes: []corev1.PersistentVolumeAccessMode{
+							corev1.ReadWriteOnce,
+						},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("5Gi"),
+							},
+						},
+					},
+					Status: corev1.PersistentVolumeClaimStatus{
+						Phase: corev1.ClaimPending,
+					},
+				},
+			},
+			pvc: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testLinkedClonePVCName,
+					Namespace: testLinkedCloneNamespace,
+					Annotations: map[string]string{
+						common.AnnKeyLinkedClone: "true",
+					},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: stringPtr(testStorageClassA),
+					AccessModes: []corev1.PersistentVolumeAccessMode{
+						corev1.ReadWriteOnce,
+					},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("5Gi"),
+						},
+					},
+					DataSource: &corev1.TypedLocalObjectReference{
+						Kind: "PersistentVolumeClaim",
+						Name: testSourcePVCName,
+					},
+				},
+			},
+			expectedAllowed:        false,
+			expectedMessageContain: "is not bound",
+		},
 	}
 
 	for _, test := range tests {
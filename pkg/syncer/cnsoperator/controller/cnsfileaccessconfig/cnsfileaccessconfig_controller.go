@@ -63,6 +63,7 @@ const (
 	defaultMaxWorkerThreads = 10
 	capvVmLabelKey          = "capv.vmware.com"
 	devopsUserLabelKey      = "cns.vmware.com/user-created"
+	podVMKind               = "Pod"
 )
 
 // backOffDuration is a map of cnsfileaccessconfig name's to the time after
@@ -153,6 +154,18 @@ func Add(mgr manager.Manager, clusterFlavor cnstypes.CnsClusterFlavor,
 		log.Error(msg)
 		return err
 	}
+	// Client VMs used to be recorded in CnsFileVolumeClient instances
+	// without the prefix of their kind.
+	cnsFileVolumeClientInstance, err := cnsfilevolumeclient.GetFileVolumeClientInstance(ctx)
+	if err != nil {
+		log.Errorf("Failed to get CNSFileVolumeClient instance. Err: %+v", err)
+		return err
+	}
+	err = cnsFileVolumeClientInstance.MigrateClientVMNames(ctx)
+	if err != nil {
+		log.Errorf("Failed to migrate the client VM names of CNSFileVolumeClient instances. Err: %+v", err)
+		return err
+	}
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: cnsoperatorapis.GroupName})
 	return add(mgr, newReconciler(mgr, configInfo, volumeManager, vmOperatorClient, dynamicClient, recorder))
 }
@@ -242,18 +255,28 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 	timeout = backOffDuration[request.NamespacedName]
 	backOffDurationMapMutex.Unlock()
 
-	// Get the virtualmachine instance
-	vm, apiVersion, err := getVirtualMachine(ctx, r.vmOperatorClient, instance.Spec.VMName, instance.Namespace)
+	// Get the virtualmachine or the PodVM instance.
+	clientName := getClientName(instance)
+	var (
+		vm         *vmoperatortypes.VirtualMachine
+		podVM      *v1.Pod
+		apiVersion string
+	)
+	if instance.Spec.PodVMName != "" {
+		podVM, err = getPodVM(ctx, r.client, instance.Spec.PodVMName, instance.Namespace)
+	} else {
+		vm, apiVersion, err = getVirtualMachine(ctx, r.vmOperatorClient, instance.Spec.VMName, instance.Namespace)
+	}
 	if err != nil {
 		msg := fmt.Sprintf("Failed to get virtualmachine instance for the VM with name: %q. Error: %+v",
-			instance.Spec.VMName, err)
+			clientName, err)
 		log.Error(msg)
 		// If virtualmachine instance is NotFound and if deletion timestamp is set on CnsFileAccessConfig instance,
 		// then proceed with the deletion of CnsFileAccessConfig instance.
 		if apierrors.IsNotFound(err) && instance.DeletionTimestamp != nil {
 			log.Infof("CnsFileAccessConfig instance %q has deletion timestamp set, but VM instance with "+
 				"name %q is not found. Processing the deletion of CnsFileAccessConfig instance.",
-				instance.Name, instance.Spec.VMName)
+				instance.Name, clientName)
 			// Fetch the PVC and PV instance and get volume ID
 			skipConfigureVolumeACL := false
 			volumeID, err := util.GetVolumeID(ctx, r.client, instance.Spec.PvcName, instance.Namespace)
@@ -306,12 +329,16 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 		setInstanceError(ctx, r, instance, msg)
 		return reconcile.Result{RequeueAfter: timeout}, nil
 	}
-	log.Debugf("Found virtualMachine instance for VM: %q/%q: %+v", instance.Namespace, instance.Spec.VMName, vm)
+	if vm != nil {
+		log.Debugf("Found virtualMachine instance for VM: %q/%q: %+v", instance.Namespace, clientName, vm)
+	} else {
+		log.Debugf("Found PodVM instance for PodVM: %q/%q", instance.Namespace, clientName)
+	}
 
 	ifFileVolumesWithVmserviceVmsSupported := commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
 		common.FileVolumesWithVmService)
 
-	if ifFileVolumesWithVmserviceVmsSupported {
+	if ifFileVolumesWithVmserviceVmsSupported && vm != nil {
 		err = validateVmAndPvc(ctx, instance.Labels, instance.Name, instance.Spec.PvcName,
 			instance.Namespace, r.client, vm)
 		if err != nil {
@@ -343,7 +370,7 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 			}
 		}
 		if volumeExists {
-			err = r.configureNetPermissionsForFileVolume(ctx, volumeID, vm, instance, true)
+			err = r.configureNetPermissionsForFileVolume(ctx, volumeID, vm, podVM, instance, true)
 			if err != nil {
 				msg := fmt.Sprintf("Failed to configure CnsFileAccessConfig instance with error: %+v", err)
 				log.Error(msg)
@@ -375,9 +402,10 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 		return reconcile.Result{}, nil
 	}
 
-	// If the CnsFileAccessConfig instance is already successful,
-	// and not deleted by the user, remove the instance from the queue.
-	if instance.Status.Done {
+	// If the CnsFileAccessConfig instance is already successful, not deleted
+	// by the user and its requested permissions are configured on the file
+	// volume, remove the instance from the queue.
+	if instance.Status.Done && !isNetPermissionChanged(instance) {
 		// Cleanup instance entry from backOffDuration map.
 		log.Infof("CnsFileAccessConfig instance: %q on namespace: %q has status marked as done. Skipping reconcile.",
 			instance.Name, instance.Namespace)
@@ -396,19 +424,28 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 		return reconcile.Result{RequeueAfter: timeout}, nil
 	}
 
+	ownerKind := reflect.TypeOf(vmoperatortypes.VirtualMachine{}).Name()
+	var ownerUID types.UID
+	if podVM != nil {
+		ownerKind = podVMKind
+		ownerUID = podVM.UID
+		apiVersion = v1.SchemeGroupVersion.String()
+	} else {
+		ownerUID = vm.UID
+	}
 	vmOwnerRefExists := false
 	if len(instance.OwnerReferences) != 0 {
 		for _, ownerRef := range instance.OwnerReferences {
-			if ownerRef.Kind == reflect.TypeOf(vmoperatortypes.VirtualMachine{}).Name() &&
-				ownerRef.Name == instance.Spec.VMName && ownerRef.UID == vm.UID {
+			if ownerRef.Kind == ownerKind &&
+				ownerRef.Name == clientName && ownerRef.UID == ownerUID {
 				vmOwnerRefExists = true
 				break
 			}
 		}
 	}
 	if !vmOwnerRefExists {
-		// Set ownerRef on CnsFileAccessConfig instance (in-memory) to VM instance.
-		setInstanceOwnerRef(instance, instance.Spec.VMName, vm.UID, apiVersion)
+		// Set ownerRef on CnsFileAccessConfig instance (in-memory) to VM or PodVM instance.
+		setInstanceOwnerRef(instance, ownerKind, clientName, ownerUID, apiVersion)
 		err = updateCnsFileAccessConfig(ctx, r.client, instance)
 		if err != nil {
 			msg := fmt.Sprintf("failed to update CnsFileAccessConfig instance: %q on namespace: %q. Error: %+v",
@@ -429,7 +466,7 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 
 	log.Infof("Reconciling CnsFileAccessConfig with instance: %q from namespace: %q. timeout %q seconds",
 		instance.Name, instance.Namespace, timeout)
-	if !instance.Status.Done {
+	if !instance.Status.Done || isNetPermissionChanged(instance) {
 		volumeID, err := util.GetVolumeID(ctx, r.client, instance.Spec.PvcName, instance.Namespace)
 		if err != nil {
 			msg := fmt.Sprintf("Failed to get volumeID from pvcName: %q. Error: %+v", instance.Spec.PvcName, err)
@@ -478,7 +515,7 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 			setInstanceError(ctx, r, instance, msg)
 			return reconcile.Result{RequeueAfter: timeout}, nil
 		}
-		err = r.configureNetPermissionsForFileVolume(ctx, volumeID, vm, instance, false)
+		err = r.configureNetPermissionsForFileVolume(ctx, volumeID, vm, podVM, instance, false)
 		if err != nil {
 			msg := fmt.Sprintf("Failed to configure CnsFileAccessConfig instance with error: %+v", err)
 			log.Error(msg)
@@ -487,7 +524,7 @@ func (r *ReconcileCnsFileAccessConfig) Reconcile(ctx context.Context,
 		}
 		// Update the instance to indicate the volume registration is successful.
 		msg := fmt.Sprintf("Successfully configured access points of VM: %q on the volume: %q",
-			instance.Spec.VMName, instance.Spec.PvcName)
+			clientName, instance.Spec.PvcName)
 		instance.Status.AccessPoints = accessPoints
 		err = setInstanceSuccess(ctx, r, instance, msg)
 		if err != nil {
@@ -633,8 +670,10 @@ func (r *ReconcileCnsFileAccessConfig) removePermissionsForFileVolume(ctx contex
 		return logger.LogNewErrorf(log, "Failed to get CNSFileVolumeClient instance. Error: %+v", err)
 	}
 
+	clientName := getClientName(instance)
+	clientVMName := getClientVMName(instance)
 	vmIP, vmsAssociatedWithIP, err := cnsFileVolumeClientInstance.GetVMIPFromVMName(ctx,
-		instance.Namespace+"/"+instance.Spec.PvcName, instance.Spec.VMName)
+		instance.Namespace+"/"+instance.Spec.PvcName, clientVMName)
 	if err != nil {
 		return logger.LogNewErrorf(log, "Failed to get VM IP from VM name in CNSFileVolumeClient instance. "+
 			"Error: %+v", err)
@@ -645,17 +684,17 @@ func (r *ReconcileCnsFileAccessConfig) removePermissionsForFileVolume(ctx contex
 		// In case of VDS setup, we will always have 1 VM associated with IP. But, in case of
 		// SNAT setup, we may have multiple VMs associated with same IP. We will remove ACL
 		// permission only when it is the last VM associated with IP.
-		err = r.configureVolumeACLs(ctx, volumeID, vmIP, true)
+		err = r.configureVolumeACLs(ctx, volumeID, vmIP, instance, true)
 		if err != nil {
 			return logger.LogNewErrorf(log, "Failed to remove net permissions for file volume %q. Error: %+v",
 				volumeID, err)
 		}
 	}
 	err = cnsFileVolumeClientInstance.RemoveClientVMFromIPList(ctx,
-		instance.Namespace+"/"+instance.Spec.PvcName, instance.Spec.VMName, vmIP)
+		instance.Namespace+"/"+instance.Spec.PvcName, clientVMName, vmIP)
 	if err != nil {
 		return logger.LogNewErrorf(log, "Failed to remove VM %q with IP %q from IPList. Error: %+v",
-			instance.Spec.VMName, vmIP, err)
+			clientName, vmIP, err)
 	}
 	log.Infof("Successfully removed VM IP %q from IPList for CnsFileAccessConfig request with name: %q on "+
 		"namespace: %q", vmIP, instance.Name, instance.Namespace)
@@ -663,21 +702,33 @@ func (r *ReconcileCnsFileAccessConfig) removePermissionsForFileVolume(ctx contex
 }
 
 // configureNetPermissionsForFileVolume helps to add or remove net permissions
-// for a given file volume. The callers of this method can remove or add net
-// permissions by setting the parameter removePermission to true or false
-// respectively. Returns error if any operation fails.
+// for a given file volume. Exactly one of vm or podVM is expected to be set,
+// depending on the client requested in the instance. The callers of this
+// method can remove or add net permissions by setting the parameter
+// removePermission to true or false respectively. Returns error if any
+// operation fails.
 func (r *ReconcileCnsFileAccessConfig) configureNetPermissionsForFileVolume(ctx context.Context,
-	volumeID string, vm *vmoperatortypes.VirtualMachine, instance *v1a1.CnsFileAccessConfig,
+	volumeID string, vm *vmoperatortypes.VirtualMachine, podVM *v1.Pod, instance *v1a1.CnsFileAccessConfig,
 	removePermission bool) error {
 	log := logger.GetLogger(ctx)
 	volumePermissionLock, _ := volumePermissionLockMap.LoadOrStore(volumeID, &sync.Mutex{})
 	instanceLock, _ := volumePermissionLock.(*sync.Mutex)
 	instanceLock.Lock()
 	defer instanceLock.Unlock()
-	tkgVMIP, err := r.getVMExternalIP(ctx, vm)
+	clientName := getClientName(instance)
+	clientVMName := getClientVMName(instance)
+	var (
+		tkgVMIP string
+		err     error
+	)
+	if podVM != nil {
+		tkgVMIP, err = getPodVMIP(ctx, podVM)
+	} else {
+		tkgVMIP, err = r.getVMExternalIP(ctx, vm)
+	}
 	if err != nil {
 		return logger.LogNewErrorf(log, "Failed to get external facing IP address for VM: %s/%s instance. Error: %+v",
-			vm.Namespace, vm.Name, err)
+			instance.Namespace, clientName, err)
 	}
 	cnsFileVolumeClientInstance, err := cnsfilevolumeclient.GetFileVolumeClientInstance(ctx)
 	if err != nil {
@@ -691,35 +742,66 @@ func (r *ReconcileCnsFileAccessConfig) configureNetPermissionsForFileVolume(ctx
 	log.Infof("CNSFileVolumeClient for PVC %s/%s has the following ClientVMs registered: %v for IP: %q",
 		instance.Namespace, instance.Spec.PvcName, clientVms, tkgVMIP)
 	if !removePermission {
-		if len(clientVms) == 0 {
-			err = r.configureVolumeACLs(ctx, volumeID, tkgVMIP, false)
+		// The ACL of the file volume is shared by all the client VMs exposed
+		// by the same IP. Configure it for the first client VM, and again if
+		// the permission requested by this instance differs from the one last
+		// configured for the IP. A permission which differs from the one
+		// configured for other client VMs sharing the IP is rejected, as it
+		// would change their access too.
+		netPermission := getNetPermission(instance)
+		configuredNetPermission, err := cnsFileVolumeClientInstance.GetNetPermissionForIP(ctx,
+			instance.Namespace+"/"+instance.Spec.PvcName, tkgVMIP)
+		if err != nil {
+			return logger.LogNewErrorf(log, "Failed to get the net permission configured for IP %q. Error: %+v",
+				tkgVMIP, err)
+		}
+		if otherClientVms := getOtherClientVms(clientVms, clientVMName); len(otherClientVms) != 0 &&
+			configuredNetPermission != nil && *configuredNetPermission != netPermission {
+			return logger.LogNewErrorf(log, "Net permission %+v requested by VM %q conflicts with net permission "+
+				"%+v configured on file volume %q for VMs %v sharing the IP %q", netPermission, clientName,
+				*configuredNetPermission, volumeID, otherClientVms, tkgVMIP)
+		}
+		if len(clientVms) == 0 || configuredNetPermission == nil || *configuredNetPermission != netPermission {
+			if len(clientVms) != 0 {
+				log.Infof("Net permission %+v configured on file volume %q for IP %q differs from %+v "+
+					"requested by VM %q. Updating the ACL.", configuredNetPermission, volumeID, tkgVMIP,
+					netPermission, clientName)
+			}
+			err = r.configureVolumeACLs(ctx, volumeID, tkgVMIP, instance, false)
 			if err != nil {
 				return logger.LogNewErrorf(log, "Failed to add net permissions for file volume %q. Error: %+v",
 					volumeID, err)
 			}
 		}
 		err = cnsFileVolumeClientInstance.AddClientVMToIPList(ctx,
-			instance.Namespace+"/"+instance.Spec.PvcName, instance.Spec.VMName, tkgVMIP)
+			instance.Namespace+"/"+instance.Spec.PvcName, clientVMName, tkgVMIP)
 		if err != nil {
 			return logger.LogNewErrorf(log, "Failed to add VM %q with IP %q to IPList. Error: %+v",
-				vm.Name, tkgVMIP, err)
+				clientName, tkgVMIP, err)
+		}
+		err = cnsFileVolumeClientInstance.SetNetPermissionForIP(ctx,
+			instance.Namespace+"/"+instance.Spec.PvcName, tkgVMIP, netPermission)
+		if err != nil {
+			return logger.LogNewErrorf(log, "Failed to record net permission for IP %q. Error: %+v",
+				tkgVMIP, err)
 		}
 		log.Infof("Successfully added VM IP %q to IPList for CnsFileAccessConfig request with name: %q on namespace: %q",
 			tkgVMIP, instance.Name, instance.Namespace)
 		return nil
 	}
 	// RemovePermission is set to true.
-	if len(clientVms) == 1 && clientVms[0] == vm.Name {
-		err = r.configureVolumeACLs(ctx, volumeID, tkgVMIP, true)
+	if len(clientVms) == 1 && clientVms[0] == clientVMName {
+		err = r.configureVolumeACLs(ctx, volumeID, tkgVMIP, instance, true)
 		if err != nil {
 			return logger.LogNewErrorf(log, "Failed to remove net permissions for file volume %q. Error: %+v",
 				volumeID, err)
 		}
 	}
 	err = cnsFileVolumeClientInstance.RemoveClientVMFromIPList(ctx,
-		instance.Namespace+"/"+instance.Spec.PvcName, instance.Spec.VMName, tkgVMIP)
+		instance.Namespace+"/"+instance.Spec.PvcName, clientVMName, tkgVMIP)
 	if err != nil {
-		return logger.LogNewErrorf(log, "Failed to remove VM %q with IP %q to IPList. Error: %+v", vm.Name, tkgVMIP, err)
+		return logger.LogNewErrorf(log, "Failed to remove VM %q with IP %q to IPList. Error: %+v",
+			clientName, tkgVMIP, err)
 	}
 	log.Infof("Successfully removed VM IP %q from IPList for CnsFileAccessConfig request with name: %q on namespace: %q",
 		tkgVMIP, instance.Name, instance.Namespace)
@@ -728,18 +810,19 @@ func (r *ReconcileCnsFileAccessConfig) configureNetPermissionsForFileVolume(ctx
 
 // configureVolumeACLs helps to prepare the CnsVolumeACLConfigureSpec
 // for a given TKG VM IP address and volumeID and invoke CNS API.
+// The access permission and root squash settings are taken from the
+// CnsFileAccessConfig instance.
 func (r *ReconcileCnsFileAccessConfig) configureVolumeACLs(ctx context.Context,
-	volumeID string, tkgVMIP string, delete bool) error {
+	volumeID string, tkgVMIP string, instance *v1a1.CnsFileAccessConfig, delete bool) error {
 	log := logger.GetLogger(ctx)
 	cnsVolumeID := cnstypes.CnsVolumeId{
 		Id: volumeID,
 	}
 	vSanFileShareNetPermissions := make([]vsanfstypes.VsanFileShareNetPermission, 0)
-	vsanFileShareAccessType := vsanfstypes.VsanFileShareAccessTypeREAD_WRITE
 	vSanFileShareNetPermissions = append(vSanFileShareNetPermissions, vsanfstypes.VsanFileShareNetPermission{
 		Ips:         tkgVMIP,
-		Permissions: vsanFileShareAccessType,
-		AllowRoot:   true,
+		Permissions: getVsanFileShareAccessType(instance.Spec.Permission),
+		AllowRoot:   !instance.Spec.RootSquash,
 	})
 
 	cnsNFSAccessControlSpecList := make([]cnstypes.CnsNFSAccessControlSpec, 0)
@@ -850,6 +933,8 @@ func setInstanceSuccess(ctx context.Context, r *ReconcileCnsFileAccessConfig,
 	instance *v1a1.CnsFileAccessConfig, msg string) error {
 	instance.Status.Done = true
 	instance.Status.Error = ""
+	instance.Status.Permission = instance.Spec.Permission
+	instance.Status.RootSquash = instance.Spec.RootSquash
 	err := k8s.UpdateStatus(ctx, r.client, instance)
 	if err != nil {
 		return err
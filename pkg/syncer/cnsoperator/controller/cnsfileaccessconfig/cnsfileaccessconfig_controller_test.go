@@ -6,7 +6,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	vmoperatortypes "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	vsanfstypes "github.com/vmware/govmomi/vsan/vsanfs/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1a1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsfileaccessconfig/v1alpha1"
 )
 
 func TestValidateVmAndPvc_NoLabels(t *testing.T) {
@@ -66,3 +68,59 @@ func TestValidateVmAndPvc_DevOpsUser_InvalidVM(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid combination")
 	assert.Contains(t, err.Error(), "my-vm")
 }
+
+func TestGetVsanFileShareAccessType(t *testing.T) {
+	assert.Equal(t, vsanfstypes.VsanFileShareAccessTypeREAD_WRITE, getVsanFileShareAccessType(""))
+	assert.Equal(t, vsanfstypes.VsanFileShareAccessTypeREAD_WRITE,
+		getVsanFileShareAccessType(v1a1.CnsFileAccessPermissionReadWrite))
+	assert.Equal(t, vsanfstypes.VsanFileShareAccessTypeREAD_ONLY,
+		getVsanFileShareAccessType(v1a1.CnsFileAccessPermissionReadOnly))
+}
+
+func TestGetClientName(t *testing.T) {
+	instance := &v1a1.CnsFileAccessConfig{Spec: v1a1.CnsFileAccessConfigSpec{VMName: "vm-1"}}
+	assert.Equal(t, "vm-1", getClientName(instance))
+	assert.Equal(t, "GUEST_VM:vm-1", getClientVMName(instance))
+
+	instance = &v1a1.CnsFileAccessConfig{Spec: v1a1.CnsFileAccessConfigSpec{PodVMName: "pod-1"}}
+	assert.Equal(t, "pod-1", getClientName(instance))
+	assert.Equal(t, "POD_VM:pod-1", getClientVMName(instance))
+}
+
+func TestGetNetPermission(t *testing.T) {
+	instance := &v1a1.CnsFileAccessConfig{Spec: v1a1.CnsFileAccessConfigSpec{VMName: "vm-1"}}
+	netPermission := getNetPermission(instance)
+	assert.Equal(t, string(vsanfstypes.VsanFileShareAccessTypeREAD_WRITE), netPermission.Permissions)
+	assert.True(t, netPermission.AllowRoot)
+
+	instance.Spec.Permission = v1a1.CnsFileAccessPermissionReadOnly
+	instance.Spec.RootSquash = true
+	netPermission = getNetPermission(instance)
+	assert.Equal(t, string(vsanfstypes.VsanFileShareAccessTypeREAD_ONLY), netPermission.Permissions)
+	assert.False(t, netPermission.AllowRoot)
+}
+
+func TestIsNetPermissionChanged(t *testing.T) {
+	// Instances configured before the permission was recorded in the status
+	// default to READ_WRITE without root squash.
+	instance := &v1a1.CnsFileAccessConfig{
+		Spec:   v1a1.CnsFileAccessConfigSpec{VMName: "vm-1", Permission: v1a1.CnsFileAccessPermissionReadWrite},
+		Status: v1a1.CnsFileAccessConfigStatus{Done: true},
+	}
+	assert.False(t, isNetPermissionChanged(instance))
+
+	instance.Spec.Permission = v1a1.CnsFileAccessPermissionReadOnly
+	assert.True(t, isNetPermissionChanged(instance))
+
+	instance.Status.Permission = v1a1.CnsFileAccessPermissionReadOnly
+	assert.False(t, isNetPermissionChanged(instance))
+
+	instance.Spec.RootSquash = true
+	assert.True(t, isNetPermissionChanged(instance))
+}
+
+func TestGetOtherClientVms(t *testing.T) {
+	assert.Empty(t, getOtherClientVms(nil, "vm-1"))
+	assert.Empty(t, getOtherClientVms([]string{"vm-1"}, "vm-1"))
+	assert.Equal(t, []string{"vm-2"}, getOtherClientVms([]string{"vm-1", "vm-2"}, "vm-1"))
+}
@@ -19,9 +19,10 @@ package cnsfileaccessconfig
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	vmoperatortypes "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	vsanfstypes "github.com/vmware/govmomi/vsan/vsanfs/types"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 
@@ -29,6 +30,8 @@ import (
 	cnsfileaccessconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsfileaccessconfig/v1alpha1"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/utils"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/cnsfilevolumeclient"
+	cnsfilevolumeclientv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/cnsfilevolumeclient/v1alpha1"
 )
 
 // getVirtualMachine gets the virtual machine instance with a name on a SV
@@ -50,13 +53,99 @@ func getVirtualMachine(ctx context.Context, vmOperatorClient client.Client,
 	return virtualMachine, apiVersion, nil
 }
 
-// setInstanceOwnerRef sets ownerRef on CnsFileAccessConfig instance to VM
-// instance.
-func setInstanceOwnerRef(instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig, vmName string,
+// getPodVM gets the PodVM (vSphere Pod) instance with a name on a SV
+// namespace.
+func getPodVM(ctx context.Context, k8sClient client.Client,
+	podVMName string, namespace string) (*v1.Pod, error) {
+	log := logger.GetLogger(ctx)
+	pod := &v1.Pod{}
+	err := k8sClient.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: podVMName}, pod)
+	if err != nil {
+		log.Errorf("Failed to get PodVM instance with name: %q. Error: %+v", podVMName, err)
+		return nil, err
+	}
+	return pod, nil
+}
+
+// getPodVMIP returns the IP address of the given PodVM which is used
+// to configure net permissions on the file volume.
+func getPodVMIP(ctx context.Context, pod *v1.Pod) (string, error) {
+	log := logger.GetLogger(ctx)
+	if pod.Status.PodIP == "" {
+		return "", logger.LogNewErrorf(log, "IP address is not yet assigned to PodVM %s/%s",
+			pod.Namespace, pod.Name)
+	}
+	log.Infof("Found IP %q for PodVM %q in namespace %q", pod.Status.PodIP, pod.Name, pod.Namespace)
+	return pod.Status.PodIP, nil
+}
+
+// getClientName returns the name of the VM or PodVM for which file volume
+// access is requested in the CnsFileAccessConfig instance.
+func getClientName(instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig) string {
+	if instance.Spec.PodVMName != "" {
+		return instance.Spec.PodVMName
+	}
+	return instance.Spec.VMName
+}
+
+// getClientVMName returns the name of the VM or PodVM for which file volume
+// access is requested in the CnsFileAccessConfig instance, prefixed with its
+// kind as recorded in CnsFileVolumeClient instances.
+func getClientVMName(instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig) string {
+	if instance.Spec.PodVMName != "" {
+		return cnsfilevolumeclient.PodVMClientPrefix + instance.Spec.PodVMName
+	}
+	return cnsfilevolumeclient.GuestVMClientPrefix + instance.Spec.VMName
+}
+
+// getVsanFileShareAccessType maps the permission requested in the
+// CnsFileAccessConfig instance to vSAN file share access type.
+// READ_WRITE is used when permission is not set.
+func getVsanFileShareAccessType(
+	permission cnsfileaccessconfigv1alpha1.CnsFileAccessPermission) vsanfstypes.VsanFileShareAccessType {
+	if permission == cnsfileaccessconfigv1alpha1.CnsFileAccessPermissionReadOnly {
+		return vsanfstypes.VsanFileShareAccessTypeREAD_ONLY
+	}
+	return vsanfstypes.VsanFileShareAccessTypeREAD_WRITE
+}
+
+// getNetPermission returns the net permission requested on the file volume
+// by the CnsFileAccessConfig instance.
+func getNetPermission(
+	instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig) cnsfilevolumeclientv1alpha1.CnsFileVolumeNetPermission {
+	return cnsfilevolumeclientv1alpha1.CnsFileVolumeNetPermission{
+		Permissions: string(getVsanFileShareAccessType(instance.Spec.Permission)),
+		AllowRoot:   !instance.Spec.RootSquash,
+	}
+}
+
+// isNetPermissionChanged returns true if the permission or root squash
+// requested in the spec of the CnsFileAccessConfig instance differ from the
+// ones last configured on the file volume, as recorded in its status.
+func isNetPermissionChanged(instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig) bool {
+	return getVsanFileShareAccessType(instance.Spec.Permission) !=
+		getVsanFileShareAccessType(instance.Status.Permission) ||
+		instance.Spec.RootSquash != instance.Status.RootSquash
+}
+
+// getOtherClientVms returns the client VMs registered for an IP other than
+// the given client VM.
+func getOtherClientVms(clientVms []string, clientName string) []string {
+	var otherClientVms []string
+	for _, clientVM := range clientVms {
+		if clientVM != clientName {
+			otherClientVms = append(otherClientVms, clientVM)
+		}
+	}
+	return otherClientVms
+}
+
+// setInstanceOwnerRef sets ownerRef on CnsFileAccessConfig instance to the
+// VM or PodVM instance.
+func setInstanceOwnerRef(instance *cnsfileaccessconfigv1alpha1.CnsFileAccessConfig, kind string, vmName string,
 	vmUID apitypes.UID, apiVersion string) {
 	bController := true
 	bOwnerDeletion := true
-	kind := reflect.TypeOf(vmoperatortypes.VirtualMachine{}).Name()
 	instance.OwnerReferences = []metav1.OwnerReference{
 		{
 			APIVersion:         apiVersion,
@@ -0,0 +1,550 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsimportvolume
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apis "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator"
+	cnsimportvolumev1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsimportvolume/v1alpha1"
+	volumes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	commonconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
+	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/util"
+)
+
+const (
+	workerThreadsEnvVar     = "WORKER_THREADS_IMPORT_VOLUME"
+	defaultMaxWorkerThreads = 10
+	staticPvNamePrefix      = "static-pv-"
+)
+
+// backOffDuration is a map of cnsimportvolume name's to the time after which
+// a request for this instance will be requeued. Initialized to 1 second for
+// new instances and for instances whose latest reconcile operation succeeded.
+// If the reconcile fails, backoff is incremented exponentially.
+var (
+	backOffDuration         map[apitypes.NamespacedName]time.Duration
+	backOffDurationMapMutex = sync.Mutex{}
+)
+
+// Add creates a new CnsImportVolume Controller and adds it to the Manager,
+// ConfigurationInfo and VirtualCenterTypes. The Manager will set fields on
+// the Controller and start it when the Manager is Started.
+func Add(mgr manager.Manager, clusterFlavor cnstypes.CnsClusterFlavor,
+	configInfo *commonconfig.ConfigurationInfo, volumeManager volumes.Manager) error {
+	ctx, log := logger.GetNewContextWithLogger()
+	if clusterFlavor != cnstypes.CnsClusterFlavorVanilla {
+		log.Debug("Not initializing the CnsImportVolume Controller as its a non-Vanilla CSI deployment")
+		return nil
+	}
+	if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.StaticVolumeImport) {
+		log.Infof("Not initializing the CnsImportVolume Controller as %q feature is disabled on the cluster",
+			common.StaticVolumeImport)
+		return nil
+	}
+	if len(configInfo.Cfg.VirtualCenter) > 1 {
+		log.Infof("Not initializing the CnsImportVolume Controller as it is a multi VC deployment.")
+		return nil
+	}
+
+	// Initializes kubernetes client.
+	k8sclient, err := k8s.NewClient(ctx)
+	if err != nil {
+		log.Errorf("Creating Kubernetes client failed. Err: %v", err)
+		return err
+	}
+
+	// eventBroadcaster broadcasts events on cnsimportvolume instances to the
+	// event sink.
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(
+		&typedcorev1.EventSinkImpl{
+			Interface: k8sclient.CoreV1().Events(""),
+		},
+	)
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: apis.GroupName})
+	return add(mgr, newReconciler(mgr, configInfo, volumeManager, recorder, k8sclient))
+}
+
+// newReconciler returns a new reconcile.Reconciler.
+func newReconciler(mgr manager.Manager, configInfo *commonconfig.ConfigurationInfo,
+	volumeManager volumes.Manager, recorder record.EventRecorder,
+	k8sclient clientset.Interface) reconcile.Reconciler {
+	return &ReconcileCnsImportVolume{client: mgr.GetClient(), scheme: mgr.GetScheme(),
+		configInfo: configInfo, volumeManager: volumeManager, recorder: recorder, k8sclient: k8sclient}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler.
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	ctx, log := logger.GetNewContextWithLogger()
+
+	maxWorkerThreads := util.GetMaxWorkerThreads(ctx,
+		workerThreadsEnvVar, defaultMaxWorkerThreads)
+	// Create a new controller.
+	err := ctrl.NewControllerManagedBy(mgr).Named("cnsimportvolume-controller").
+		For(&cnsimportvolumev1alpha1.CnsImportVolume{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxWorkerThreads}).
+		Complete(r)
+	if err != nil {
+		log.Errorf("Failed to build application controller. Err: %v", err)
+		return err
+	}
+
+	backOffDuration = make(map[apitypes.NamespacedName]time.Duration)
+	return nil
+}
+
+// blank assignment to verify that ReconcileCnsImportVolume implements
+// reconcile.Reconciler.
+var _ reconcile.Reconciler = &ReconcileCnsImportVolume{}
+
+// ReconcileCnsImportVolume reconciles a CnsImportVolume object.
+type ReconcileCnsImportVolume struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver.
+	client        client.Client
+	scheme        *runtime.Scheme
+	configInfo    *commonconfig.ConfigurationInfo
+	volumeManager volumes.Manager
+	recorder      record.EventRecorder
+	k8sclient     clientset.Interface
+}
+
+// Reconcile reads that state of the cluster for a CnsImportVolume object
+// and makes changes based on the state read and what is in the
+// CnsImportVolume.Spec.
+// Note:
+// The Controller will requeue the Request to be processed again if the
+// returned error is non-nil or Result.Requeue is true. Otherwise, upon
+// completion it will remove the work from the queue.
+func (r *ReconcileCnsImportVolume) Reconcile(ctx context.Context,
+	request reconcile.Request) (reconcile.Result, error) {
+	ctx = logger.NewContextWithLogger(ctx)
+	log := logger.GetLogger(ctx)
+	// Fetch the CnsImportVolume instance.
+	instance := &cnsimportvolumev1alpha1.CnsImportVolume{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Infof("CnsImportVolume resource with name: %q on namespace: %q not found. "+
+				"Ignoring since object must be deleted.", request.Name, request.Namespace)
+			backOffDurationMapMutex.Lock()
+			delete(backOffDuration, request.NamespacedName)
+			backOffDurationMapMutex.Unlock()
+			return reconcile.Result{}, nil
+		}
+		log.Errorf("Error reading the CnsImportVolume with name: %q on namespace: %q. Err: %+v",
+			request.Name, request.Namespace, err)
+		// Error reading the object - return with err.
+		return reconcile.Result{}, err
+	}
+
+	// If all the disks of the CnsImportVolume instance are already imported,
+	// remove the instance from the queue.
+	if instance.Status.Completed {
+		backOffDurationMapMutex.Lock()
+		delete(backOffDuration, request.NamespacedName)
+		backOffDurationMapMutex.Unlock()
+		return reconcile.Result{}, nil
+	}
+
+	// Initialize backOffDuration for the instance, if required.
+	backOffDurationMapMutex.Lock()
+	var timeout time.Duration
+	if _, exists := backOffDuration[request.NamespacedName]; !exists {
+		backOffDuration[request.NamespacedName] = time.Second
+	}
+	timeout = backOffDuration[request.NamespacedName]
+	backOffDurationMapMutex.Unlock()
+	log.Infof("Reconciling CnsImportVolume with instance: %q from namespace: %q. timeout %q seconds",
+		instance.Name, request.Namespace, timeout)
+
+	// Validate CnsImportVolume spec to check for valid entries. An invalid
+	// spec is not requeued, the instance is reconciled again once the spec
+	// is updated.
+	err = validateCnsImportVolumeSpec(ctx, instance)
+	if err != nil {
+		log.Error(err)
+		setInstanceError(ctx, r, instance, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	sc, err := r.k8sclient.StorageV1().StorageClasses().Get(ctx, instance.Spec.StorageClassName,
+		metav1.GetOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to fetch StorageClass: %q with error: %+v", instance.Spec.StorageClassName, err)
+		log.Error(msg)
+		setInstanceError(ctx, r, instance, msg)
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+	if sc.Provisioner != cnsoperatortypes.VSphereCSIDriverName {
+		msg := fmt.Sprintf("StorageClass: %q uses provisioner %q, expected %q", sc.Name, sc.Provisioner,
+			cnsoperatortypes.VSphereCSIDriverName)
+		log.Error(msg)
+		setInstanceError(ctx, r, instance, msg)
+		return reconcile.Result{}, nil
+	}
+
+	vc, err := cnsvsphere.GetVirtualCenterInstance(ctx, r.configInfo, false)
+	if err != nil {
+		log.Errorf("Failed to get virtual center instance with error: %+v", err)
+		setInstanceError(ctx, r, instance, "Unable to connect to VC for volume import")
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+
+	disks := append([]cnsimportvolumev1alpha1.CnsImportVolumeDisk{}, instance.Spec.Disks...)
+	if instance.Spec.DatastoreFolder != nil {
+		folderDisks, err := r.getDisksInDatastoreFolder(ctx, vc, instance.Spec.DatastoreFolder)
+		if err != nil {
+			log.Error(err)
+			setInstanceError(ctx, r, instance, err.Error())
+			return reconcile.Result{RequeueAfter: timeout}, nil
+		}
+		disks = append(disks, folderDisks...)
+		if err = validateImportDisks(disks); err != nil {
+			log.Error(err)
+			setInstanceError(ctx, r, instance, err.Error())
+			return reconcile.Result{}, nil
+		}
+	}
+
+	// Import the disks which are not imported yet. Disks imported in a
+	// previous reconcile retain their status. The status is looked up by the
+	// source of the disk, as the PVC names of the disks found in the
+	// datastore folder are derived from their file names.
+	importedDisks := make(map[string]cnsimportvolumev1alpha1.CnsImportVolumeDiskStatus)
+	for _, diskStatus := range instance.Status.Disks {
+		if diskStatus.Imported {
+			importedDisks[diskStatus.Source] = diskStatus
+		}
+	}
+	var (
+		diskStatuses []cnsimportvolumev1alpha1.CnsImportVolumeDiskStatus
+		failedDisks  int
+	)
+	for _, disk := range disks {
+		diskStatus, imported := importedDisks[getDiskSource(disk)]
+		if !imported {
+			diskStatus = r.importDisk(ctx, vc, instance, sc, disk)
+			if !diskStatus.Imported {
+				failedDisks++
+			}
+		}
+		diskStatuses = append(diskStatuses, diskStatus)
+	}
+	instance.Status.Disks = diskStatuses
+	if failedDisks > 0 {
+		msg := fmt.Sprintf("Failed to import %d of %d disks", failedDisks, len(disks))
+		log.Errorf("%s for CnsImportVolume: %q on namespace: %q", msg, instance.Name, instance.Namespace)
+		setInstanceError(ctx, r, instance, msg)
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+	msg := fmt.Sprintf("Successfully imported %d disks", len(disks))
+	log.Infof("%s for CnsImportVolume: %q on namespace: %q", msg, instance.Name, instance.Namespace)
+	setInstanceSuccess(ctx, r, instance, msg)
+	backOffDurationMapMutex.Lock()
+	delete(backOffDuration, request.NamespacedName)
+	backOffDurationMapMutex.Unlock()
+	return reconcile.Result{}, nil
+}
+
+// importDisk registers the given disk with CNS and creates a PV/PVC pair
+// for it. The returned status carries the error, if any.
+func (r *ReconcileCnsImportVolume) importDisk(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume, sc *storagev1.StorageClass,
+	disk cnsimportvolumev1alpha1.CnsImportVolumeDisk) cnsimportvolumev1alpha1.CnsImportVolumeDiskStatus {
+	log := logger.GetLogger(ctx)
+	diskStatus := cnsimportvolumev1alpha1.CnsImportVolumeDiskStatus{
+		PVCName: disk.PVCName,
+		Source:  getDiskSource(disk),
+	}
+	volumeID, err := r.registerDisk(ctx, vc, disk)
+	if err != nil {
+		log.Errorf("Failed to register disk %q for PVC %q on namespace: %q. Err: %v",
+			diskStatus.Source, disk.PVCName, instance.Namespace, err)
+		diskStatus.Error = err.Error()
+		return diskStatus
+	}
+	diskStatus.VolumeID = volumeID
+
+	pvName, err := r.createPVAndPVC(ctx, vc, instance, sc, disk.PVCName, volumeID)
+	if err != nil {
+		log.Errorf("Failed to create PV/PVC for volume %q and PVC %q on namespace: %q. Err: %v",
+			volumeID, disk.PVCName, instance.Namespace, err)
+		diskStatus.Error = err.Error()
+		return diskStatus
+	}
+	diskStatus.PVName = pvName
+	diskStatus.Imported = true
+	log.Infof("Imported disk %q as volume %q with PV %q and PVC %q on namespace: %q",
+		diskStatus.Source, volumeID, pvName, disk.PVCName, instance.Namespace)
+	return diskStatus
+}
+
+// registerDisk registers the given disk as a CNS block volume and returns
+// the volume ID. Disks specified by URL path are registered as FCD first
+// when the vCenter supports vslm APIs.
+func (r *ReconcileCnsImportVolume) registerDisk(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	disk cnsimportvolumev1alpha1.CnsImportVolumeDisk) (string, error) {
+	log := logger.GetLogger(ctx)
+	backingDetails := &cnstypes.CnsBlockBackingDetails{
+		BackingDiskId: disk.VolumeID,
+	}
+	if disk.DiskURLPath != "" {
+		useVslmAPIs, err := common.UseVslmAPIs(ctx, vc.Client.ServiceContent.About)
+		if err != nil {
+			return "", err
+		}
+		if useVslmAPIs {
+			backingDiskID, err := r.volumeManager.RegisterDisk(ctx, disk.DiskURLPath, disk.PVCName)
+			if err != nil {
+				return "", fmt.Errorf("failed to register disk %q as FCD. Error: %v", disk.DiskURLPath, err)
+			}
+			log.Infof("Registered disk %q as FCD %q", disk.DiskURLPath, backingDiskID)
+			backingDetails.BackingDiskId = backingDiskID
+		} else {
+			backingDetails.BackingDiskUrlPath = disk.DiskURLPath
+		}
+	}
+
+	var volumeName string
+	if backingDetails.BackingDiskId != "" {
+		volumeName = staticPvNamePrefix + backingDetails.BackingDiskId
+	} else {
+		id, _ := uuid.NewUUID()
+		volumeName = staticPvNamePrefix + id.String()
+	}
+	createSpec := &cnstypes.CnsVolumeCreateSpec{
+		Name:       volumeName,
+		VolumeType: common.BlockVolumeType,
+		Metadata: cnstypes.CnsVolumeMetadata{
+			ContainerCluster: cnsvsphere.GetContainerCluster(r.configInfo.Cfg.Global.ClusterID,
+				r.configInfo.Cfg.VirtualCenter[vc.Config.Host].User, cnstypes.CnsClusterFlavorVanilla,
+				r.configInfo.Cfg.Global.ClusterDistribution),
+		},
+		BackingObjectDetails: backingDetails,
+	}
+	volInfo, _, err := r.volumeManager.CreateVolume(ctx, createSpec, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create CNS volume. Error: %v", err)
+	}
+	return volInfo.VolumeID.Id, nil
+}
+
+// createPVAndPVC creates a PV for the given volume and a PVC pre-bound to it
+// in the namespace of the instance. The PV takes its reclaim policy and
+// fsType from the StorageClass. Existing PV and PVC are reused if they refer
+// to the same volume.
+func (r *ReconcileCnsImportVolume) createPVAndPVC(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume, sc *storagev1.StorageClass, pvcName string,
+	volumeID string) (string, error) {
+	log := logger.GetLogger(ctx)
+	pvName := staticPvNamePrefix + volumeID
+	existingPVName, found := commonco.ContainerOrchestratorUtility.GetPVNameFromCSIVolumeID(volumeID)
+	if found && existingPVName != pvName {
+		return "", fmt.Errorf("PV %q with the volume ID %q is already present", existingPVName, volumeID)
+	}
+
+	querySelection := cnstypes.CnsQuerySelection{
+		Names: []string{
+			string(cnstypes.QuerySelectionNameTypeDataStoreUrl),
+			string(cnstypes.QuerySelectionNameTypeBackingObjectDetails),
+		},
+	}
+	volume, err := common.QueryVolumeByID(ctx, r.volumeManager, volumeID, &querySelection)
+	if err != nil {
+		return "", fmt.Errorf("failed to query CNS volume %q. Error: %v", volumeID, err)
+	}
+	if volume.BackingObjectDetails == nil {
+		return "", fmt.Errorf("CNS volume %q has no backing object details", volumeID)
+	}
+	capacityInMb := volume.BackingObjectDetails.GetCnsBackingObjectDetails().CapacityInMb
+
+	var nodeAffinity *v1.VolumeNodeAffinity
+	if util.IsTopologyAwareCluster(r.configInfo.Cfg) {
+		segments, err := util.GetDatastoreAccessibleTopology(ctx, r.client, vc, volume.DatastoreUrl)
+		if err != nil {
+			return "", err
+		}
+		nodeAffinity = util.GetPVNodeAffinity(segments)
+	}
+
+	pv, err := r.k8sclient.CoreV1().PersistentVolumes().Get(ctx, pvName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get PV %q. Error: %v", pvName, err)
+		}
+		claimRef := &v1.ObjectReference{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+			Namespace:  instance.Namespace,
+			Name:       pvcName,
+		}
+		pvSpec := getPersistentVolumeSpec(pvName, volumeID, capacityInMb, instance.Spec.VolumeMode, sc,
+			claimRef, nodeAffinity)
+		pv, err = r.k8sclient.CoreV1().PersistentVolumes().Create(ctx, pvSpec, metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to create PV %q. Error: %v", pvName, err)
+		}
+		log.Infof("Created PV %q for volume %q", pvName, volumeID)
+	} else if pv.Spec.CSI == nil || pv.Spec.CSI.VolumeHandle != volumeID {
+		return "", fmt.Errorf("PV %q already exists and does not refer to volume %q", pvName, volumeID)
+	}
+
+	pvc, err := r.k8sclient.CoreV1().PersistentVolumeClaims(instance.Namespace).Get(ctx, pvcName,
+		metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get PVC %q. Error: %v", pvcName, err)
+		}
+		pvcSpec := getPersistentVolumeClaimSpec(pvcName, instance.Namespace, capacityInMb,
+			*pv.Spec.VolumeMode, instance.Spec.StorageClassName, pvName)
+		_, err = r.k8sclient.CoreV1().PersistentVolumeClaims(instance.Namespace).Create(ctx, pvcSpec,
+			metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to create PVC %q. Error: %v", pvcName, err)
+		}
+		log.Infof("Created PVC %q on namespace %q for PV %q", pvcName, instance.Namespace, pvName)
+	} else if pvc.Spec.VolumeName != pvName {
+		return "", fmt.Errorf("PVC %q already exists on namespace %q and is not bound to PV %q",
+			pvcName, instance.Namespace, pvName)
+	}
+	return pvName, nil
+}
+
+// getDisksInDatastoreFolder returns the disks to be imported for the virtual
+// disks found in the given datastore folder.
+func (r *ReconcileCnsImportVolume) getDisksInDatastoreFolder(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	folder *cnsimportvolumev1alpha1.CnsImportVolumeDatastoreFolder) (
+	[]cnsimportvolumev1alpha1.CnsImportVolumeDisk, error) {
+	diskURLPaths, err := listVirtualDisksInFolder(ctx, vc, folder.FolderURLPath)
+	if err != nil {
+		return nil, err
+	}
+	var disks []cnsimportvolumev1alpha1.CnsImportVolumeDisk
+	for _, diskURLPath := range diskURLPaths {
+		pvcName, err := getPVCNameForDisk(folder.PVCNamePrefix, diskURLPath)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, cnsimportvolumev1alpha1.CnsImportVolumeDisk{
+			PVCName:     pvcName,
+			DiskURLPath: diskURLPath,
+		})
+	}
+	return disks, nil
+}
+
+// setInstanceError sets error and records an event on the CnsImportVolume
+// instance.
+func setInstanceError(ctx context.Context, r *ReconcileCnsImportVolume,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume, errMsg string) {
+	log := logger.GetLogger(ctx)
+	instance.Status.Completed = false
+	instance.Status.Error = errMsg
+	err := updateCnsImportVolumeStatus(ctx, r.client, instance)
+	if err != nil {
+		log.Errorf("updateCnsImportVolumeStatus failed. err: %v", err)
+		return
+	}
+	recordEvent(ctx, r, instance, v1.EventTypeWarning, errMsg)
+}
+
+// setInstanceSuccess sets instance to success and records an event on the
+// CnsImportVolume instance.
+func setInstanceSuccess(ctx context.Context, r *ReconcileCnsImportVolume,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume, msg string) {
+	log := logger.GetLogger(ctx)
+	instance.Status.Completed = true
+	instance.Status.Error = ""
+	err := updateCnsImportVolumeStatus(ctx, r.client, instance)
+	if err != nil {
+		log.Errorf("updateCnsImportVolumeStatus failed. err: %v", err)
+		return
+	}
+	recordEvent(ctx, r, instance, v1.EventTypeNormal, msg)
+}
+
+// recordEvent records the event, sets the backOffDuration for the instance
+// appropriately and logs the message.
+// backOffDuration is reset to 1 second on success and doubled on failure.
+func recordEvent(ctx context.Context, r *ReconcileCnsImportVolume,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume, eventtype string, msg string) {
+	log := logger.GetLogger(ctx)
+	log.Debugf("Event type is %s", eventtype)
+	namespacedName := apitypes.NamespacedName{
+		Name:      instance.Name,
+		Namespace: instance.Namespace,
+	}
+	switch eventtype {
+	case v1.EventTypeWarning:
+		// Double backOff duration.
+		backOffDurationMapMutex.Lock()
+		backOffDuration[namespacedName] = min(backOffDuration[namespacedName]*2,
+			cnsoperatortypes.MaxBackOffDurationForReconciler)
+		r.recorder.Event(instance, v1.EventTypeWarning, "CnsImportVolumeFailed", msg)
+		backOffDurationMapMutex.Unlock()
+	case v1.EventTypeNormal:
+		// Reset backOff duration to one second.
+		backOffDurationMapMutex.Lock()
+		backOffDuration[namespacedName] = time.Second
+		r.recorder.Event(instance, v1.EventTypeNormal, "CnsImportVolumeSucceeded", msg)
+		backOffDurationMapMutex.Unlock()
+	}
+}
+
+// updateCnsImportVolumeStatus updates the status of CnsImportVolume instance
+// in K8S.
+func updateCnsImportVolumeStatus(ctx context.Context, client client.Client,
+	instance *cnsimportvolumev1alpha1.CnsImportVolume) error {
+	log := logger.GetLogger(ctx)
+	err := client.Status().Update(ctx, instance)
+	if err != nil {
+		log.Errorf("Failed to update status of CnsImportVolume instance: %q on namespace: %q. Error: %+v",
+			instance.Name, instance.Namespace, err)
+	}
+	return err
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsimportvolume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	cnsimportvolumev1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsimportvolume/v1alpha1"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+)
+
+const (
+	// defaultFSType is the fsType set on PVs with Filesystem volume mode when
+	// the StorageClass does not specify one.
+	defaultFSType = "ext4"
+	// fsTypeParam is the StorageClass parameter carrying the fsType.
+	fsTypeParam = "csi.storage.k8s.io/fstype"
+	// diskFileExtension is the extension of virtual disk descriptor files.
+	diskFileExtension = ".vmdk"
+)
+
+// invalidPVCNameChars matches characters not allowed in a PVC name.
+var invalidPVCNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// datastoreFolder holds the components of a datastore folder URL path.
+type datastoreFolder struct {
+	host       string
+	path       string
+	datacenter string
+	datastore  string
+}
+
+// diskURLPath returns the URL path of the given disk file in the folder.
+func (f *datastoreFolder) diskURLPath(fileName string) string {
+	diskPath := fileName
+	if f.path != "" {
+		diskPath = f.path + "/" + fileName
+	}
+	return "https://" + f.host + "/folder/" + diskPath + "?dcPath=" + url.PathEscape(f.datacenter) +
+		"&dsName=" + url.PathEscape(f.datastore)
+}
+
+// validateCnsImportVolumeSpec validates the input params of CnsImportVolume
+// instance.
+func validateCnsImportVolumeSpec(ctx context.Context, instance *cnsimportvolumev1alpha1.CnsImportVolume) error {
+	log := logger.GetLogger(ctx)
+	spec := instance.Spec
+	if spec.StorageClassName == "" {
+		return errors.New("StorageClassName must be specified")
+	}
+	if spec.VolumeMode != "" && spec.VolumeMode != v1.PersistentVolumeBlock &&
+		spec.VolumeMode != v1.PersistentVolumeFilesystem {
+		return fmt.Errorf("unsupported VolumeMode: %q", spec.VolumeMode)
+	}
+	if len(spec.Disks) == 0 && spec.DatastoreFolder == nil {
+		return errors.New("either Disks or DatastoreFolder must be specified")
+	}
+	for _, disk := range spec.Disks {
+		if errs := validation.IsDNS1123Subdomain(disk.PVCName); len(errs) != 0 {
+			return fmt.Errorf("invalid PVCName: %q. %s", disk.PVCName, strings.Join(errs, ", "))
+		}
+		if (disk.VolumeID == "") == (disk.DiskURLPath == "") {
+			return fmt.Errorf("exactly one of VolumeID and DiskURLPath must be specified for PVC: %q",
+				disk.PVCName)
+		}
+	}
+	if err := validateImportDisks(spec.Disks); err != nil {
+		return err
+	}
+	if spec.DatastoreFolder != nil {
+		if spec.DatastoreFolder.PVCNamePrefix == "" {
+			return errors.New("PVCNamePrefix must be specified for DatastoreFolder")
+		}
+		if _, err := parseDatastoreFolderURLPath(spec.DatastoreFolder.FolderURLPath); err != nil {
+			return err
+		}
+	}
+	log.Debugf("Validated spec of CnsImportVolume: %q on namespace: %q", instance.Name, instance.Namespace)
+	return nil
+}
+
+// validateImportDisks checks that no two of the given disks have the same
+// PVC name or source. It is called for the disks of the spec, and again once
+// the PVC names of the disks found in the datastore folder are derived from
+// their sanitized file names, which may collide.
+func validateImportDisks(disks []cnsimportvolumev1alpha1.CnsImportVolumeDisk) error {
+	pvcNames := make(map[string]string)
+	sources := make(map[string]struct{})
+	for _, disk := range disks {
+		source := getDiskSource(disk)
+		if otherSource, exists := pvcNames[disk.PVCName]; exists {
+			return fmt.Errorf("PVCName: %q is used for more than one disk: %q and %q", disk.PVCName,
+				otherSource, source)
+		}
+		pvcNames[disk.PVCName] = source
+		if _, exists := sources[source]; exists {
+			return fmt.Errorf("disk %q is specified more than once", source)
+		}
+		sources[source] = struct{}{}
+	}
+	return nil
+}
+
+// getDiskSource returns the VolumeID or DiskURLPath the given disk is
+// imported from.
+func getDiskSource(disk cnsimportvolumev1alpha1.CnsImportVolumeDisk) string {
+	if disk.DiskURLPath != "" {
+		return disk.DiskURLPath
+	}
+	return disk.VolumeID
+}
+
+// parseDatastoreFolderURLPath parses a datastore folder URL path of the form
+// https://<vc_ip>/folder/<folder_path>?dcPath=<datacenterName>&dsName=<datastoreName>
+func parseDatastoreFolderURLPath(folderURLPath string) (*datastoreFolder, error) {
+	u, err := url.Parse(folderURLPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FolderURLPath: %q. Error: %v", folderURLPath, err)
+	}
+	if u.Scheme != "https" || u.Host == "" || (u.Path != "/folder" && !strings.HasPrefix(u.Path, "/folder/")) {
+		return nil, fmt.Errorf("invalid FolderURLPath: %q. Expected format: "+
+			"https://<vc_ip>/folder/<folder_path>?dcPath=<datacenterName>&dsName=<datastoreName>", folderURLPath)
+	}
+	folder := &datastoreFolder{
+		host:       u.Host,
+		path:       strings.Trim(strings.TrimPrefix(u.Path, "/folder"), "/"),
+		datacenter: u.Query().Get("dcPath"),
+		datastore:  u.Query().Get("dsName"),
+	}
+	if folder.datacenter == "" || folder.datastore == "" {
+		return nil, fmt.Errorf("invalid FolderURLPath: %q. dcPath and dsName must be specified", folderURLPath)
+	}
+	return folder, nil
+}
+
+// getPVCNameForDisk returns the name of the PVC to be created for a disk
+// found in a datastore folder.
+func getPVCNameForDisk(prefix string, diskURLPath string) (string, error) {
+	u, err := url.Parse(diskURLPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid disk URL path: %q. Error: %v", diskURLPath, err)
+	}
+	diskName := strings.TrimSuffix(path.Base(u.Path), diskFileExtension)
+	pvcName := invalidPVCNameChars.ReplaceAllString(strings.ToLower(prefix+"-"+diskName), "-")
+	pvcName = strings.Trim(pvcName, "-.")
+	if errs := validation.IsDNS1123Subdomain(pvcName); len(errs) != 0 {
+		return "", fmt.Errorf("failed to derive a valid PVC name for disk %q. %s", diskURLPath,
+			strings.Join(errs, ", "))
+	}
+	return pvcName, nil
+}
+
+// listVirtualDisksInFolder returns the URL paths of the virtual disks in the
+// given datastore folder. Subfolders are not searched.
+func listVirtualDisksInFolder(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	folderURLPath string) ([]string, error) {
+	log := logger.GetLogger(ctx)
+	folder, err := parseDatastoreFolderURLPath(folderURLPath)
+	if err != nil {
+		return nil, err
+	}
+	finder := find.NewFinder(vc.Client.Client, false)
+	dc, err := finder.Datacenter(ctx, folder.datacenter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find datacenter %q. Error: %v", folder.datacenter, err)
+	}
+	finder.SetDatacenter(dc)
+	ds, err := finder.Datastore(ctx, folder.datastore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find datastore %q. Error: %v", folder.datastore, err)
+	}
+	browser, err := ds.Browser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get browser for datastore %q. Error: %v", folder.datastore, err)
+	}
+	// VmDiskFileQuery only matches disk descriptor files, so the extent
+	// files of the disks are not returned.
+	searchSpec := vim25types.HostDatastoreBrowserSearchSpec{
+		Query:        []vim25types.BaseFileQuery{&vim25types.VmDiskFileQuery{}},
+		MatchPattern: []string{"*" + diskFileExtension},
+	}
+	task, err := browser.SearchDatastore(ctx, ds.Path(folder.path), &searchSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search folder %q. Error: %v", folderURLPath, err)
+	}
+	taskInfo, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search folder %q. Error: %v", folderURLPath, err)
+	}
+	results, ok := taskInfo.Result.(vim25types.HostDatastoreBrowserSearchResults)
+	if !ok {
+		return nil, fmt.Errorf("unexpected search result %T for folder %q", taskInfo.Result, folderURLPath)
+	}
+	var diskURLPaths []string
+	for _, file := range results.File {
+		diskURLPaths = append(diskURLPaths, folder.diskURLPath(file.GetFileInfo().Path))
+	}
+	slices.Sort(diskURLPaths)
+	log.Infof("Found %d virtual disks in folder %q", len(diskURLPaths), folderURLPath)
+	return diskURLPaths, nil
+}
+
+// getPersistentVolumeSpec creates PV spec for the imported volume using the
+// reclaim policy and fsType of the given StorageClass.
+func getPersistentVolumeSpec(pvName string, volumeID string, capacityInMb int64,
+	volumeMode v1.PersistentVolumeMode, sc *storagev1.StorageClass, claimRef *v1.ObjectReference,
+	nodeAffinity *v1.VolumeNodeAffinity) *v1.PersistentVolume {
+	if volumeMode == "" {
+		volumeMode = v1.PersistentVolumeFilesystem
+	}
+	reclaimPolicy := v1.PersistentVolumeReclaimDelete
+	if sc.ReclaimPolicy != nil {
+		reclaimPolicy = *sc.ReclaimPolicy
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: pvName,
+			Annotations: map[string]string{
+				"pv.kubernetes.io/provisioned-by": cnsoperatortypes.VSphereCSIDriverName,
+			},
+		},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: reclaimPolicy,
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse(strconv.FormatInt(capacityInMb, 10) + "Mi"),
+			},
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       cnsoperatortypes.VSphereCSIDriverName,
+					VolumeHandle: volumeID,
+				},
+			},
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			ClaimRef:         claimRef,
+			StorageClassName: sc.Name,
+			VolumeMode:       &volumeMode,
+			NodeAffinity:     nodeAffinity,
+		},
+	}
+	// FStype should be set only when volumeMode for PV is Filesystem.
+	if volumeMode == v1.PersistentVolumeFilesystem {
+		pv.Spec.CSI.FSType = defaultFSType
+		if fsType, ok := sc.Parameters[fsTypeParam]; ok && fsType != "" {
+			pv.Spec.CSI.FSType = fsType
+		}
+	}
+	return pv
+}
+
+// getPersistentVolumeClaimSpec creates PVC spec pre-bound to the given PV.
+func getPersistentVolumeClaimSpec(pvcName string, namespace string, capacityInMb int64,
+	volumeMode v1.PersistentVolumeMode, storageClassName string, pvName string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: resource.MustParse(strconv.FormatInt(capacityInMb, 10) + "Mi"),
+				},
+			},
+			StorageClassName: &storageClassName,
+			VolumeName:       pvName,
+			VolumeMode:       &volumeMode,
+		},
+	}
+}
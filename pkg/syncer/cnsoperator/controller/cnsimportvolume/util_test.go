@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cnsimportvolume
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cnsimportvolumev1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator/cnsimportvolume/v1alpha1"
+)
+
+const testFolderURLPath = "https://10.192.255.221/folder/legacy/disks?dcPath=Datacenter-1&dsName=vsanDatastore"
+
+func TestValidateCnsImportVolumeSpec(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		spec    cnsimportvolumev1alpha1.CnsImportVolumeSpec
+		wantErr bool
+	}{
+		{
+			name: "volume ID and disk URL path",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				Disks: []cnsimportvolumev1alpha1.CnsImportVolumeDisk{
+					{PVCName: "pvc-1", VolumeID: "fcd-1"},
+					{PVCName: "pvc-2", DiskURLPath: "https://vc/folder/vm/vm.vmdk?dcPath=dc&dsName=ds"},
+				},
+			},
+		},
+		{
+			name: "datastore folder",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				DatastoreFolder: &cnsimportvolumev1alpha1.CnsImportVolumeDatastoreFolder{
+					FolderURLPath: testFolderURLPath,
+					PVCNamePrefix: "legacy",
+				},
+			},
+		},
+		{
+			name: "missing storage class",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				Disks: []cnsimportvolumev1alpha1.CnsImportVolumeDisk{{PVCName: "pvc-1", VolumeID: "fcd-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no disks",
+			spec:    cnsimportvolumev1alpha1.CnsImportVolumeSpec{StorageClassName: "sc"},
+			wantErr: true,
+		},
+		{
+			name: "both volume ID and disk URL path",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				Disks: []cnsimportvolumev1alpha1.CnsImportVolumeDisk{
+					{PVCName: "pvc-1", VolumeID: "fcd-1", DiskURLPath: "https://vc/folder/a.vmdk?dcPath=dc&dsName=ds"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate PVC name",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				Disks: []cnsimportvolumev1alpha1.CnsImportVolumeDisk{
+					{PVCName: "pvc-1", VolumeID: "fcd-1"},
+					{PVCName: "pvc-1", VolumeID: "fcd-2"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate disk",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				Disks: []cnsimportvolumev1alpha1.CnsImportVolumeDisk{
+					{PVCName: "pvc-1", VolumeID: "fcd-1"},
+					{PVCName: "pvc-2", VolumeID: "fcd-1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid PVC name",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				Disks:            []cnsimportvolumev1alpha1.CnsImportVolumeDisk{{PVCName: "PVC_1", VolumeID: "fcd-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid volume mode",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				VolumeMode:       "Raw",
+				Disks:            []cnsimportvolumev1alpha1.CnsImportVolumeDisk{{PVCName: "pvc-1", VolumeID: "fcd-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "folder without datastore",
+			spec: cnsimportvolumev1alpha1.CnsImportVolumeSpec{
+				StorageClassName: "sc",
+				DatastoreFolder: &cnsimportvolumev1alpha1.CnsImportVolumeDatastoreFolder{
+					FolderURLPath: "https://vc/folder/legacy?dcPath=dc",
+					PVCNamePrefix: "legacy",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &cnsimportvolumev1alpha1.CnsImportVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			err := validateCnsImportVolumeSpec(ctx, instance)
+			assert.Equal(t, tt.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestDatastoreFolderDiskURLPath(t *testing.T) {
+	folder, err := parseDatastoreFolderURLPath(testFolderURLPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &datastoreFolder{host: "10.192.255.221", path: "legacy/disks",
+		datacenter: "Datacenter-1", datastore: "vsanDatastore"}, folder)
+	assert.Equal(t, "https://10.192.255.221/folder/legacy/disks/vm_1.vmdk?dcPath=Datacenter-1&dsName=vsanDatastore",
+		folder.diskURLPath("vm_1.vmdk"))
+
+	rootFolder, err := parseDatastoreFolderURLPath("https://vc/folder?dcPath=dc&dsName=ds")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://vc/folder/a.vmdk?dcPath=dc&dsName=ds", rootFolder.diskURLPath("a.vmdk"))
+
+	_, err = parseDatastoreFolderURLPath("https://vc/legacy?dcPath=dc&dsName=ds")
+	assert.Error(t, err)
+}
+
+func TestGetPVCNameForDisk(t *testing.T) {
+	pvcName, err := getPVCNameForDisk("legacy",
+		"https://vc/folder/disks/App_Data%201.vmdk?dcPath=dc&dsName=ds")
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy-app-data-1", pvcName)
+}
+
+func TestValidateImportDisks(t *testing.T) {
+	diskURLPaths := []string{
+		"https://vc/folder/disks/App_Data.vmdk?dcPath=dc&dsName=ds",
+		"https://vc/folder/disks/app-data.vmdk?dcPath=dc&dsName=ds",
+	}
+	var disks []cnsimportvolumev1alpha1.CnsImportVolumeDisk
+	for _, diskURLPath := range diskURLPaths {
+		pvcName, err := getPVCNameForDisk("legacy", diskURLPath)
+		assert.NoError(t, err)
+		disks = append(disks, cnsimportvolumev1alpha1.CnsImportVolumeDisk{PVCName: pvcName, DiskURLPath: diskURLPath})
+	}
+	assert.NoError(t, validateImportDisks(disks[:1]))
+	assert.ErrorContains(t, validateImportDisks(disks), `"legacy-app-data" is used for more than one disk`)
+}
+
+func TestGetPersistentVolumeSpec(t *testing.T) {
+	retain := v1.PersistentVolumeReclaimRetain
+	sc := &storagev1.StorageClass{
+		ObjectMeta:    metav1.ObjectMeta{Name: "sc"},
+		ReclaimPolicy: &retain,
+		Parameters:    map[string]string{fsTypeParam: "xfs"},
+	}
+	claimRef := &v1.ObjectReference{Namespace: "default", Name: "pvc-1"}
+	pv := getPersistentVolumeSpec("static-pv-fcd-1", "fcd-1", 1024, "", sc, claimRef, nil)
+	assert.Equal(t, v1.PersistentVolumeReclaimRetain, pv.Spec.PersistentVolumeReclaimPolicy)
+	assert.Equal(t, "xfs", pv.Spec.CSI.FSType)
+	assert.Equal(t, v1.PersistentVolumeFilesystem, *pv.Spec.VolumeMode)
+	assert.Equal(t, "sc", pv.Spec.StorageClassName)
+	assert.Equal(t, claimRef, pv.Spec.ClaimRef)
+
+	blockPV := getPersistentVolumeSpec("static-pv-fcd-1", "fcd-1", 1024, v1.PersistentVolumeBlock,
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "sc"}}, claimRef, nil)
+	assert.Equal(t, v1.PersistentVolumeReclaimDelete, blockPV.Spec.PersistentVolumeReclaimPolicy)
+	assert.Empty(t, blockPV.Spec.CSI.FSType)
+
+	pvc := getPersistentVolumeClaimSpec("pvc-1", "default", 1024, v1.PersistentVolumeBlock, "sc", pv.Name)
+	assert.Equal(t, pv.Name, pvc.Spec.VolumeName)
+	assert.Equal(t, "1Gi", pvc.Spec.Resources.Requests.Storage().String())
+}
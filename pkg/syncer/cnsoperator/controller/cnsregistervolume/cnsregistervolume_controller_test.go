@@ -237,6 +237,10 @@ func (m *mockVolumeManager) ReRegisterVolume(ctx context.Context, volumeID strin
 
 type mockCOCommon struct{}
 
+func (m *mockCOCommon) RecordFaultEvent(ctx context.Context, object *corev1.ObjectReference,
+	event commoncotypes.FaultEvent) {
+}
+
 func (m *mockCOCommon) ListPVCs(ctx context.Context, namespace string) []*corev1.PersistentVolumeClaim {
 	//TODO implement me
 	panic("implement me")
@@ -30,6 +30,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -128,26 +129,52 @@ func Add(mgr manager.Manager, clusterFlavor cnstypes.CnsClusterFlavor,
 	)
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme,
 		corev1.EventSource{Component: csinodetopologyv1alpha1.GroupName})
-	return add(mgr, newReconciler(mgr, configInfo, recorder,
-		enableTKGsHAinGuest, vmOperatorClient, supervisorNamespace))
+	enableNodeAttachLimits := clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		coCommonInterface.IsFSSEnabled(ctx, common.NodeAttachLimits)
+	enableTopologyDriftDetection := clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		coCommonInterface.IsFSSEnabled(ctx, common.TopologyDriftDetection)
+	enableHostLocalVolumes := clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		coCommonInterface.IsFSSEnabled(ctx, common.HostLocalVolumes)
+	var hostChangeEvents chan event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]
+	if enableTopologyDriftDetection {
+		log.Infof("The %s FSS is enabled. Topology labels of the NodeVMs will be checked for drift every %d "+
+			"minutes and when a NodeVM moves to another host.", common.TopologyDriftDetection,
+			configInfo.Cfg.Global.TopologyDriftCheckIntervalInMin)
+		hostChangeEvents = make(chan event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology],
+			nodeVMHostChangeEventsBufferSize)
+		for vcHost := range configInfo.Cfg.VirtualCenter {
+			go watchNodeVMHostChanges(ctx, vcHost, mgr.GetClient(), hostChangeEvents)
+		}
+	}
+	return add(mgr, newReconciler(mgr, configInfo, recorder, k8sclient, enableTKGsHAinGuest,
+		enableNodeAttachLimits, enableTopologyDriftDetection, enableHostLocalVolumes, vmOperatorClient,
+		supervisorNamespace), hostChangeEvents)
 }
 
 // newReconciler returns a new `reconcile.Reconciler`.
 func newReconciler(mgr manager.Manager, configInfo *cnsconfig.ConfigurationInfo, recorder record.EventRecorder,
-	enableTKGsHAinGuest bool, vmOperatorClient client.Client,
+	k8sClient clientset.Interface, enableTKGsHAinGuest bool, enableNodeAttachLimits bool,
+	enableTopologyDriftDetection bool, enableHostLocalVolumes bool, vmOperatorClient client.Client,
 	supervisorNamespace string) reconcile.Reconciler {
 	return &ReconcileCSINodeTopology{
-		client:              mgr.GetClient(),
-		scheme:              mgr.GetScheme(),
-		configInfo:          configInfo,
-		recorder:            recorder,
-		enableTKGsHAinGuest: enableTKGsHAinGuest,
-		vmOperatorClient:    vmOperatorClient,
-		supervisorNamespace: supervisorNamespace}
+		client:                       mgr.GetClient(),
+		scheme:                       mgr.GetScheme(),
+		configInfo:                   configInfo,
+		recorder:                     recorder,
+		k8sClient:                    k8sClient,
+		enableTKGsHAinGuest:          enableTKGsHAinGuest,
+		enableNodeAttachLimits:       enableNodeAttachLimits,
+		enableTopologyDriftDetection: enableTopologyDriftDetection,
+		enableHostLocalVolumes:       enableHostLocalVolumes,
+		vmOperatorClient:             vmOperatorClient,
+		supervisorNamespace:          supervisorNamespace}
 }
 
 // add adds a new Controller to mgr with r as the `reconcile.Reconciler`.
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+// If hostChangeEvents is not nil, the CSINodeTopology instances sent on it
+// are reconciled as well.
+func add(mgr manager.Manager, r reconcile.Reconciler,
+	hostChangeEvents <-chan event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]) error {
 	log := logger.GetLoggerWithNoContext()
 
 	// Create a new controller.
@@ -191,6 +218,16 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 	log.Info("Started watching on CSINodeTopology resources")
+
+	if hostChangeEvents != nil {
+		// Watch for NodeVMs moving to another host.
+		err = c.Watch(source.Channel(hostChangeEvents,
+			&handler.TypedEnqueueRequestForObject[*csinodetopologyv1alpha1.CSINodeTopology]{}))
+		if err != nil {
+			log.Errorf("Failed to watch for host changes of the NodeVMs with error: %+v", err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -202,13 +239,24 @@ var _ reconcile.Reconciler = &ReconcileCSINodeTopology{}
 type ReconcileCSINodeTopology struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver.
-	client              client.Client
-	scheme              *runtime.Scheme
-	configInfo          *cnsconfig.ConfigurationInfo
-	recorder            record.EventRecorder
+	client     client.Client
+	scheme     *runtime.Scheme
+	configInfo *cnsconfig.ConfigurationInfo
+	recorder   record.EventRecorder
+	// k8sClient is used to cordon nodes on topology drift.
+	k8sClient           clientset.Interface
 	enableTKGsHAinGuest bool
-	vmOperatorClient    client.Client
-	supervisorNamespace string
+	// enableNodeAttachLimits is set when the block volume attach limit of
+	// the NodeVM is to be computed along with its topology labels.
+	enableNodeAttachLimits bool
+	// enableTopologyDriftDetection is set when the topology labels of the
+	// NodeVM are to be re-evaluated after the instance is at Success.
+	enableTopologyDriftDetection bool
+	// enableHostLocalVolumes is set when the ESXi host of the NodeVM is to
+	// be reported along with its topology labels.
+	enableHostLocalVolumes bool
+	vmOperatorClient       client.Client
+	supervisorNamespace    string
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -242,8 +290,13 @@ func (r *ReconcileCSINodeTopology) reconcileForVanilla(ctx context.Context, requ
 		// Error reading the object - return with err.
 		return reconcile.Result{}, err
 	}
-	// If the CR status is already at Success, do not reconcile further.
+	// If the CR status is already at Success, do not reconcile further
+	// unless the topology labels of the NodeVM are to be checked for drift.
 	if instance.Status.Status == csinodetopologyv1alpha1.CSINodeTopologySuccess {
+		if r.enableTopologyDriftDetection && (r.isTopologyEnabled() || r.enableHostLocalVolumes) &&
+			instance.Spec.NodeUUID != "" {
+			return r.reconcileTopologyDrift(ctx, instance)
+		}
 		log.Infof("CSINodeTopology instance with name %q is already at %q state. No need to "+
 			"reconcile further.", instance.Name, instance.Status.Status)
 		return reconcile.Result{}, err
@@ -292,6 +345,36 @@ func (r *ReconcileCSINodeTopology) reconcileForVanilla(ctx context.Context, requ
 		return reconcile.Result{RequeueAfter: timeout}, nil
 	}
 
+	if r.enableNodeAttachLimits {
+		// The attach limit is computed every time the node re-registers, so
+		// that it reflects the current controllers of the NodeVM. It is best
+		// effort: on failure the node falls back to the default attach limit
+		// and its topology is registered anyway.
+		maxBlockVolumes, err := nodeVM.GetBlockVolumeAttachLimit(ctx)
+		if err != nil {
+			msg := fmt.Sprintf("failed to compute block volume attach limit for the nodeVM %q, "+
+				"the default attach limit will be used. Error: %v", instance.Name, err)
+			log.Warn(msg)
+			r.recorder.Event(instance, corev1.EventTypeWarning, "AttachLimitRetrievalFailed", msg)
+			maxBlockVolumes = 0
+		} else {
+			log.Infof("Block volume attach limit of nodeVM %q is %d", instance.Name, maxBlockVolumes)
+		}
+		instance.Status.MaxBlockVolumes = maxBlockVolumes
+	}
+
+	if r.enableHostLocalVolumes {
+		host, err := getNodeHostTopologyValue(ctx, nodeVM)
+		if err != nil {
+			msg := fmt.Sprintf("failed to get the ESXi host of the nodeVM %q. Error: %v", instance.Name, err)
+			log.Error(msg)
+			_ = updateCRStatus(ctx, r, instance, csinodetopologyv1alpha1.CSINodeTopologyError, msg)
+			return reconcile.Result{RequeueAfter: timeout}, nil
+		}
+		log.Infof("NodeVM %q is running on ESXi host %q", instance.Name, host)
+		instance.Status.Host = host
+	}
+
 	if !r.isTopologyEnabled() {
 		// Not a topology aware setup.
 		// Set the Status to Success and return.
@@ -485,6 +568,16 @@ func updateCRStatus(ctx context.Context, r *ReconcileCSINodeTopology, instance *
 	return nil
 }
 
+// getNodeHostTopologyValue returns the value identifying the ESXi host of the
+// NodeVM in the host topology segment.
+func getNodeHostTopologyValue(ctx context.Context, nodeVM *cnsvsphere.VirtualMachine) (string, error) {
+	host, err := nodeVM.GetHostSystem(ctx)
+	if err != nil {
+		return "", err
+	}
+	return (&cnsvsphere.HostSystem{HostSystem: host}).GetTopologyValue(ctx)
+}
+
 func getNodeTopologyInfo(ctx context.Context, nodeVM *cnsvsphere.VirtualMachine,
 	cfg *cnsconfig.Config) ([]csinodetopologyv1alpha1.TopologyLabel, error) {
 	log := logger.GetLogger(ctx)
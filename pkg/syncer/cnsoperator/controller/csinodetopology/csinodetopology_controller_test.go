@@ -25,15 +25,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	vmoperatortypes "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cnsconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
 	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
 )
 
@@ -190,3 +193,152 @@ func TestCSINodeTopologyControllerForTKGSHA(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTopologyDrift(t *testing.T) {
+	registered := []csinodetopologyv1alpha1.TopologyLabel{
+		{Key: corev1.LabelTopologyZone, Value: "zone-a"},
+		{Key: corev1.LabelTopologyRegion, Value: "region-1"},
+	}
+	tests := []struct {
+		name            string
+		current         []csinodetopologyv1alpha1.TopologyLabel
+		expectedDrifted []csinodetopologyv1alpha1.TopologyLabel
+	}{
+		{
+			name: "NoDrift",
+			current: []csinodetopologyv1alpha1.TopologyLabel{
+				{Key: corev1.LabelTopologyRegion, Value: "region-1"},
+				{Key: corev1.LabelTopologyZone, Value: "zone-a"},
+			},
+			expectedDrifted: nil,
+		},
+		{
+			name: "ZoneChanged",
+			current: []csinodetopologyv1alpha1.TopologyLabel{
+				{Key: corev1.LabelTopologyZone, Value: "zone-b"},
+				{Key: corev1.LabelTopologyRegion, Value: "region-1"},
+			},
+			expectedDrifted: []csinodetopologyv1alpha1.TopologyLabel{
+				{Key: corev1.LabelTopologyZone, Value: "zone-b"},
+			},
+		},
+		{
+			name: "ZoneRemovedAndRegionChanged",
+			current: []csinodetopologyv1alpha1.TopologyLabel{
+				{Key: corev1.LabelTopologyRegion, Value: "region-2"},
+			},
+			expectedDrifted: []csinodetopologyv1alpha1.TopologyLabel{
+				{Key: corev1.LabelTopologyRegion, Value: "region-2"},
+				{Key: corev1.LabelTopologyZone, Value: ""},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drifted := getTopologyDrift(registered, test.current)
+			assert.Equal(t, test.expectedDrifted, drifted)
+			assert.True(t, topologyLabelsEqual(test.expectedDrifted, drifted))
+		})
+	}
+}
+
+func TestHasNodeAffinityOnKeys(t *testing.T) {
+	newPV := func(keys ...string) *corev1.PersistentVolume {
+		pv := &corev1.PersistentVolume{}
+		if len(keys) == 0 {
+			return pv
+		}
+		var exprs []corev1.NodeSelectorRequirement
+		for _, key := range keys {
+			exprs = append(exprs, corev1.NodeSelectorRequirement{
+				Key:      key,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"value"},
+			})
+		}
+		pv.Spec.NodeAffinity = &corev1.VolumeNodeAffinity{
+			Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: exprs}},
+			},
+		}
+		return pv
+	}
+	driftedKeys := map[string]struct{}{corev1.LabelTopologyZone: {}}
+
+	assert.False(t, hasNodeAffinityOnKeys(newPV(), driftedKeys))
+	assert.False(t, hasNodeAffinityOnKeys(newPV(corev1.LabelTopologyRegion), driftedKeys))
+	assert.True(t, hasNodeAffinityOnKeys(newPV(corev1.LabelTopologyRegion, corev1.LabelTopologyZone), driftedKeys))
+}
+
+func TestCordonAndUncordonNodeOnTopologyDrift(t *testing.T) {
+	ctx := context.Background()
+	const (
+		nodeName = "test-node"
+		pvName   = "test-pv"
+	)
+	pvSource := pvName
+	newObjects := func(unschedulable bool) []runtime.Object {
+		return []runtime.Object{
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+				Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: pvName},
+				Spec: corev1.PersistentVolumeSpec{
+					NodeAffinity: &corev1.VolumeNodeAffinity{
+						Required: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{
+									Key:      corev1.LabelTopologyZone,
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"zone-1"},
+								}},
+							}},
+						},
+					},
+				},
+			},
+			&storagev1.VolumeAttachment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-va"},
+				Spec: storagev1.VolumeAttachmentSpec{
+					Attacher: csitypes.Name,
+					NodeName: nodeName,
+					Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvSource},
+				},
+				Status: storagev1.VolumeAttachmentStatus{Attached: true},
+			},
+		}
+	}
+	instance := &csinodetopologyv1alpha1.CSINodeTopology{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	driftedLabels := []csinodetopologyv1alpha1.TopologyLabel{{Key: corev1.LabelTopologyZone, Value: "zone-2"}}
+
+	t.Run("NodeCordonedByDriverIsUncordoned", func(t *testing.T) {
+		k8sClient := k8sfake.NewSimpleClientset(newObjects(false)...)
+		r := &ReconcileCSINodeTopology{k8sClient: k8sClient, recorder: record.NewFakeRecorder(10)}
+
+		assert.NoError(t, r.cordonNodeOnTopologyDrift(ctx, instance, driftedLabels))
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.True(t, node.Spec.Unschedulable)
+		assert.Contains(t, node.Annotations, nodeCordonedOnTopologyDriftAnnotationKey)
+
+		r.uncordonNodeOnTopologyDriftResolved(ctx, instance)
+		node, err = k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.False(t, node.Spec.Unschedulable)
+		assert.NotContains(t, node.Annotations, nodeCordonedOnTopologyDriftAnnotationKey)
+	})
+
+	t.Run("NodeCordonedByOthersIsLeftCordoned", func(t *testing.T) {
+		k8sClient := k8sfake.NewSimpleClientset(newObjects(true)...)
+		r := &ReconcileCSINodeTopology{k8sClient: k8sClient, recorder: record.NewFakeRecorder(10)}
+
+		assert.NoError(t, r.cordonNodeOnTopologyDrift(ctx, instance, driftedLabels))
+		r.uncordonNodeOnTopologyDriftResolved(ctx, instance)
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.True(t, node.Spec.Unschedulable)
+		assert.NotContains(t, node.Annotations, nodeCordonedOnTopologyDriftAnnotationKey)
+	})
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csinodetopology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	vim25types "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/node"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
+)
+
+const (
+	// nodeVMHostWatchRestartInterval is the time after which the watch on
+	// the hosts of the NodeVMs is restarted if it fails.
+	nodeVMHostWatchRestartInterval = time.Minute
+	// nodeVMHostChangeEventsBufferSize is the size of the channel on which
+	// CSINodeTopology instances of moved NodeVMs are queued for reconcile.
+	nodeVMHostChangeEventsBufferSize = 100
+	// nodeCordonedOnTopologyDriftAnnotationKey is set on a node cordoned by
+	// the driver on topology drift, so that only these nodes are uncordoned
+	// when the drift is resolved.
+	nodeCordonedOnTopologyDriftAnnotationKey = "csi.vsphere.cordoned-on-topology-drift"
+)
+
+// reconcileTopologyDrift recomputes the topology labels of the NodeVM of a
+// CSINodeTopology instance at Success and reports the labels which differ
+// from the registered TopologyLabels in DriftedTopologyLabels. The instance
+// is updated only when the drift changes, so that the update does not trigger
+// another check. A node cordoned by the driver on topology drift is uncordoned
+// once there is no drift. The instance is requeued for the next periodic check.
+func (r *ReconcileCSINodeTopology) reconcileTopologyDrift(ctx context.Context,
+	instance *csinodetopologyv1alpha1.CSINodeTopology) (reconcile.Result, error) {
+	log := logger.GetLogger(ctx)
+	result := reconcile.Result{
+		RequeueAfter: time.Duration(r.configInfo.Cfg.Global.TopologyDriftCheckIntervalInMin) * time.Minute,
+	}
+
+	nodeVM, err := node.GetManager(ctx).GetNodeVMAndUpdateCache(ctx, instance.Spec.NodeUUID, nil)
+	if err != nil {
+		log.Errorf("failed to retrieve nodeVM %q to check for topology drift. Error: %+v",
+			instance.Spec.NodeUUID, err)
+		return result, nil
+	}
+	registeredLabels := instance.Status.TopologyLabels
+	var currentLabels []csinodetopologyv1alpha1.TopologyLabel
+	if r.isTopologyEnabled() {
+		currentLabels, err = getNodeTopologyInfo(ctx, nodeVM, r.configInfo.Cfg)
+		if err != nil {
+			log.Errorf("failed to fetch topology information for the nodeVM %q to check for topology drift. "+
+				"Error: %v", instance.Name, err)
+			return result, nil
+		}
+	}
+	if r.enableHostLocalVolumes && instance.Status.Host != "" {
+		// The ESXi host reported by the node drifts when the NodeVM moves
+		// to another host.
+		host, err := getNodeHostTopologyValue(ctx, nodeVM)
+		if err != nil {
+			log.Errorf("failed to get the ESXi host of the nodeVM %q to check for topology drift. Error: %v",
+				instance.Name, err)
+			return result, nil
+		}
+		registeredLabels = append(registeredLabels[:len(registeredLabels):len(registeredLabels)],
+			csinodetopologyv1alpha1.TopologyLabel{Key: common.TopologyLabelESXiHost, Value: instance.Status.Host})
+		currentLabels = append(currentLabels,
+			csinodetopologyv1alpha1.TopologyLabel{Key: common.TopologyLabelESXiHost, Value: host})
+	}
+
+	driftedLabels := getTopologyDrift(registeredLabels, currentLabels)
+	if topologyLabelsEqual(driftedLabels, instance.Status.DriftedTopologyLabels) {
+		log.Debugf("No change in topology drift of nodeVM %q. Drifted topology labels: %+v",
+			instance.Name, driftedLabels)
+		if len(driftedLabels) == 0 {
+			// Uncordoning the node may have failed when the drift was
+			// resolved, or the drift may have been cleared by re-registering
+			// the node.
+			r.uncordonNodeOnTopologyDriftResolved(ctx, instance)
+		}
+		return result, nil
+	}
+
+	if len(driftedLabels) == 0 {
+		instance.Status.DriftedTopologyLabels = nil
+		instance.Status.TopologyDriftDetectedTime = nil
+		if err := r.client.Update(ctx, instance); err != nil {
+			log.Errorf("failed to update the CSINodeTopology instance with name %q. Error: %+v",
+				instance.Name, err)
+			return reconcile.Result{}, err
+		}
+		msg := fmt.Sprintf("Topology labels of nodeVM %q match the registered topology labels %s again",
+			instance.Name, formatTopologyLabels(registeredLabels))
+		log.Info(msg)
+		r.recorder.Event(instance, corev1.EventTypeNormal, "TopologyDriftResolved", msg)
+		r.uncordonNodeOnTopologyDriftResolved(ctx, instance)
+		return result, nil
+	}
+
+	detectedTime := metav1.Now()
+	instance.Status.DriftedTopologyLabels = driftedLabels
+	instance.Status.TopologyDriftDetectedTime = &detectedTime
+	if err := r.client.Update(ctx, instance); err != nil {
+		log.Errorf("failed to update the CSINodeTopology instance with name %q. Error: %+v",
+			instance.Name, err)
+		return reconcile.Result{}, err
+	}
+	msg := fmt.Sprintf("Topology labels of nodeVM %q drifted from the registered topology labels %s to %s. "+
+		"The node must be re-registered for the drifted topology labels to be applied.", instance.Name,
+		formatTopologyLabels(registeredLabels), formatTopologyLabels(driftedLabels))
+	log.Warn(msg)
+	r.recorder.Event(instance, corev1.EventTypeWarning, "TopologyDriftDetected", msg)
+
+	if r.configInfo.Cfg.Global.CordonNodesOnTopologyDrift {
+		if err := r.cordonNodeOnTopologyDrift(ctx, instance, driftedLabels); err != nil {
+			msg := fmt.Sprintf("failed to cordon node %q on topology drift. Error: %v", instance.Name, err)
+			log.Error(msg)
+			r.recorder.Event(instance, corev1.EventTypeWarning, "NodeCordonFailed", msg)
+		}
+	}
+	return result, nil
+}
+
+// cordonNodeOnTopologyDrift cordons the node of a CSINodeTopology instance if
+// it has vSphere CSI volumes attached whose node affinity depends on any of
+// the drifted topology labels. New pods using these volumes would otherwise be
+// scheduled on the node and fail to attach the volumes.
+func (r *ReconcileCSINodeTopology) cordonNodeOnTopologyDrift(ctx context.Context,
+	instance *csinodetopologyv1alpha1.CSINodeTopology, driftedLabels []csinodetopologyv1alpha1.TopologyLabel) error {
+	log := logger.GetLogger(ctx)
+	nodeName := instance.Name
+	driftedKeys := make(map[string]struct{})
+	for _, label := range driftedLabels {
+		driftedKeys[label.Key] = struct{}{}
+	}
+
+	volumeAttachments, err := r.k8sClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list VolumeAttachments. Error: %v", err)
+	}
+	var topologyBoundPVs []string
+	for _, va := range volumeAttachments.Items {
+		if va.Spec.NodeName != nodeName || va.Spec.Attacher != csitypes.Name || !va.Status.Attached ||
+			va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		pv, err := r.k8sClient.CoreV1().PersistentVolumes().Get(ctx, *va.Spec.Source.PersistentVolumeName,
+			metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get PV %q attached to node %q. Error: %v",
+				*va.Spec.Source.PersistentVolumeName, nodeName, err)
+		}
+		if hasNodeAffinityOnKeys(pv, driftedKeys) {
+			topologyBoundPVs = append(topologyBoundPVs, pv.Name)
+		}
+	}
+	if len(topologyBoundPVs) == 0 {
+		log.Infof("Node %q has no attached volumes bound to the drifted topology labels. Not cordoning the node.",
+			nodeName)
+		return nil
+	}
+
+	k8sNode, err := r.k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if k8sNode.Spec.Unschedulable {
+		// A node cordoned by someone else is left to them to uncordon.
+		log.Infof("Node %q is already cordoned. Not cordoning the node on topology drift.", nodeName)
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}},"spec":{"unschedulable":true}}`,
+		nodeCordonedOnTopologyDriftAnnotationKey)
+	_, err = r.k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType,
+		[]byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Cordoned node %q as its topology labels drifted while volumes %v bound to the "+
+		"drifted topology labels are attached to it", nodeName, topologyBoundPVs)
+	log.Warn(msg)
+	r.recorder.Event(instance, corev1.EventTypeWarning, "NodeCordonedOnTopologyDrift", msg)
+	return nil
+}
+
+// uncordonNodeOnTopologyDriftResolved uncordons the node of a CSINodeTopology
+// instance if it was cordoned by cordonNodeOnTopologyDrift. A node which was
+// uncordoned in the meantime only has the annotation removed.
+func (r *ReconcileCSINodeTopology) uncordonNodeOnTopologyDriftResolved(ctx context.Context,
+	instance *csinodetopologyv1alpha1.CSINodeTopology) {
+	log := logger.GetLogger(ctx)
+	nodeName := instance.Name
+	k8sNode, err := r.k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Errorf("failed to get node %q to uncordon it on topology drift resolution. Error: %v",
+				nodeName, err)
+		}
+		return
+	}
+	if _, exists := k8sNode.Annotations[nodeCordonedOnTopologyDriftAnnotationKey]; !exists {
+		return
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, nodeCordonedOnTopologyDriftAnnotationKey)
+	if k8sNode.Spec.Unschedulable {
+		patch = fmt.Sprintf(`{"metadata":{"annotations":{%q:null}},"spec":{"unschedulable":false}}`,
+			nodeCordonedOnTopologyDriftAnnotationKey)
+	}
+	_, err = r.k8sClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType,
+		[]byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("failed to uncordon node %q on topology drift resolution. Error: %v", nodeName, err)
+		log.Error(msg)
+		r.recorder.Event(instance, corev1.EventTypeWarning, "NodeUncordonFailed", msg)
+		return
+	}
+	if !k8sNode.Spec.Unschedulable {
+		log.Infof("Node %q cordoned on topology drift was already uncordoned", nodeName)
+		return
+	}
+	msg := fmt.Sprintf("Uncordoned node %q as its topology labels match the registered topology labels again",
+		nodeName)
+	log.Info(msg)
+	r.recorder.Event(instance, corev1.EventTypeNormal, "NodeUncordonedOnTopologyDriftResolved", msg)
+}
+
+// getTopologyDrift returns the current topology labels which differ from the
+// registered topology labels, sorted by key. A registered label which is no
+// longer present is returned with an empty value.
+func getTopologyDrift(registered,
+	current []csinodetopologyv1alpha1.TopologyLabel) []csinodetopologyv1alpha1.TopologyLabel {
+	registeredValues := make(map[string]string)
+	for _, label := range registered {
+		registeredValues[label.Key] = label.Value
+	}
+	currentValues := make(map[string]string)
+	for _, label := range current {
+		currentValues[label.Key] = label.Value
+	}
+	var drifted []csinodetopologyv1alpha1.TopologyLabel
+	for key, value := range currentValues {
+		if registeredValue, exists := registeredValues[key]; !exists || registeredValue != value {
+			drifted = append(drifted, csinodetopologyv1alpha1.TopologyLabel{Key: key, Value: value})
+		}
+	}
+	for key := range registeredValues {
+		if _, exists := currentValues[key]; !exists {
+			drifted = append(drifted, csinodetopologyv1alpha1.TopologyLabel{Key: key})
+		}
+	}
+	sort.Slice(drifted, func(i, j int) bool {
+		return drifted[i].Key < drifted[j].Key
+	})
+	return drifted
+}
+
+// topologyLabelsEqual checks if two lists of topology labels sorted by key
+// are equal.
+func topologyLabelsEqual(a, b []csinodetopologyv1alpha1.TopologyLabel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasNodeAffinityOnKeys checks if the required node affinity of the PV has a
+// match expression on any of the given label keys.
+func hasNodeAffinityOnKeys(pv *corev1.PersistentVolume, keys map[string]struct{}) bool {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if _, exists := keys[expr.Key]; exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatTopologyLabels(labels []csinodetopologyv1alpha1.TopologyLabel) string {
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, label.Key+"="+label.Value)
+	}
+	return "[" + strings.Join(pairs, ", ") + "]"
+}
+
+// watchNodeVMHostChanges watches the host of every VM in the given vCenter
+// and queues the CSINodeTopology instance of a NodeVM for reconcile when the
+// NodeVM moves to another host, so that topology drift caused by vMotion is
+// detected without waiting for the periodic check. The watch is restarted if
+// it fails.
+func watchNodeVMHostChanges(ctx context.Context, vcHost string, k8sClient client.Client,
+	events chan<- event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]) {
+	log := logger.GetLogger(ctx)
+	for {
+		err := waitForNodeVMHostChanges(ctx, vcHost, k8sClient, events)
+		log.Infof("Watch on the hosts of the NodeVMs in vCenter %q exited with err: %v. Restarting it in %v",
+			vcHost, err, nodeVMHostWatchRestartInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(nodeVMHostWatchRestartInterval):
+		}
+	}
+}
+
+func waitForNodeVMHostChanges(ctx context.Context, vcHost string, k8sClient client.Client,
+	events chan<- event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]) error {
+	log := logger.GetLogger(ctx)
+	vc, err := cnsvsphere.GetVirtualCenterInstanceForVCenterHost(ctx, vcHost, true)
+	if err != nil {
+		return err
+	}
+	containerView, err := view.NewManager(vc.Client.Client).CreateContainerView(ctx,
+		vc.Client.ServiceContent.RootFolder, []string{"VirtualMachine"}, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = containerView.Destroy(context.Background())
+	}()
+	pc, err := property.DefaultCollector(vc.Client.Client).Create(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = pc.Destroy(context.Background())
+	}()
+
+	filter := new(property.WaitFilter)
+	filter.Add(containerView.Reference(), "VirtualMachine", []string{"config.uuid", "runtime.host"},
+		containerView.TraversalSpec())
+	filter.Spec.ObjectSet[0].Skip = vim25types.NewBool(true)
+
+	// vmUUIDs is a map of VM moref to its UUID, populated from the initial
+	// values of the properties returned by the property collector.
+	vmUUIDs := make(map[vim25types.ManagedObjectReference]string)
+	log.Infof("Starting watch on the hosts of the NodeVMs in vCenter %q", vcHost)
+	return property.WaitForUpdatesEx(ctx, pc, filter, func(updates []vim25types.ObjectUpdate) bool {
+		var movedVMUUIDs []string
+		for _, update := range updates {
+			if update.Kind == vim25types.ObjectUpdateKindLeave {
+				delete(vmUUIDs, update.Obj)
+				continue
+			}
+			for _, change := range update.ChangeSet {
+				switch change.Name {
+				case "config.uuid":
+					if uuid, ok := change.Val.(string); ok {
+						vmUUIDs[update.Obj] = uuid
+					}
+				case "runtime.host":
+					// Host of a VM is reported when the VM enters the view.
+					// Only a modification is a move to another host.
+					if update.Kind != vim25types.ObjectUpdateKindModify {
+						continue
+					}
+					if uuid, exists := vmUUIDs[update.Obj]; exists {
+						movedVMUUIDs = append(movedVMUUIDs, uuid)
+					}
+				}
+			}
+		}
+		if len(movedVMUUIDs) > 0 {
+			enqueueCSINodeTopologiesForVMs(ctx, k8sClient, movedVMUUIDs, events)
+		}
+		return false
+	})
+}
+
+// enqueueCSINodeTopologiesForVMs queues the CSINodeTopology instances of the
+// NodeVMs with the given UUIDs for reconcile.
+func enqueueCSINodeTopologiesForVMs(ctx context.Context, k8sClient client.Client, vmUUIDs []string,
+	events chan<- event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]) {
+	log := logger.GetLogger(ctx)
+	instances := &csinodetopologyv1alpha1.CSINodeTopologyList{}
+	if err := k8sClient.List(ctx, instances); err != nil {
+		log.Errorf("failed to list CSINodeTopology instances. Error: %+v", err)
+		return
+	}
+	for i := range instances.Items {
+		instance := &instances.Items[i]
+		for _, uuid := range vmUUIDs {
+			if strings.EqualFold(instance.Spec.NodeUUID, uuid) {
+				log.Infof("NodeVM %q moved to another host. Checking for topology drift.", instance.Name)
+				events <- event.TypedGenericEvent[*csinodetopologyv1alpha1.CSINodeTopology]{Object: instance}
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumemigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	volumemigrationv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/volumemigration/v1alpha1"
+	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+)
+
+const defaultMaxConcurrentMigrations = 4
+
+// errPVNodeAffinityImmutable is returned when the node affinity of a PV
+// cannot be updated, as it is immutable unless the MutablePVNodeAffinity
+// feature gate is enabled in the cluster.
+var errPVNodeAffinityImmutable = errors.New("node affinity of PVs is immutable in the cluster")
+
+// targetDatastore is the datastore the volumes of a batch are relocated to.
+type targetDatastore struct {
+	ref vimtypes.ManagedObjectReference
+	url string
+	// topologySegments are the topology segments of the nodes and
+	// accessibleTopologySegments the ones out of them from which the
+	// datastore is accessible. They are set in topology aware clusters only.
+	topologySegments           []map[string]string
+	accessibleTopologySegments []map[string]string
+}
+
+// validateVolumeMigrationSpec validates the fields of the VolumeMigration
+// spec.
+func validateVolumeMigrationSpec(ctx context.Context, instance *volumemigrationv1alpha1.VolumeMigration) error {
+	log := logger.GetLogger(ctx)
+	spec := instance.Spec
+	if spec.TargetDatastoreURL == "" && spec.TargetDatastoreCluster == "" {
+		return logger.LogNewErrorf(log, "either TargetDatastoreURL or TargetDatastoreCluster "+
+			"must be specified for VolumeMigration: %q", instance.Name)
+	}
+	if spec.TargetDatastoreURL != "" && spec.TargetDatastoreCluster != "" {
+		return logger.LogNewErrorf(log, "TargetDatastoreURL and TargetDatastoreCluster cannot be "+
+			"specified together for VolumeMigration: %q", instance.Name)
+	}
+	if spec.SourceDatastoreURL != "" && spec.SourceDatastoreURL == spec.TargetDatastoreURL {
+		return logger.LogNewErrorf(log, "SourceDatastoreURL and TargetDatastoreURL cannot be the same "+
+			"for VolumeMigration: %q", instance.Name)
+	}
+	if len(spec.PVCs) == 0 && spec.PVCSelector == nil && !spec.AllVolumes {
+		return logger.LogNewErrorf(log, "either PVCs, PVCSelector or AllVolumes must be specified "+
+			"for VolumeMigration: %q", instance.Name)
+	}
+	if spec.AllVolumes && (len(spec.PVCs) != 0 || spec.PVCSelector != nil) {
+		return logger.LogNewErrorf(log, "AllVolumes cannot be specified together with PVCs or PVCSelector "+
+			"for VolumeMigration: %q", instance.Name)
+	}
+	if spec.MaxConcurrentMigrations < 0 {
+		return logger.LogNewErrorf(log, "MaxConcurrentMigrations cannot be negative for VolumeMigration: %q",
+			instance.Name)
+	}
+	for _, pvc := range spec.PVCs {
+		if pvc.Namespace == "" || pvc.Name == "" {
+			return logger.LogNewErrorf(log, "namespace and name must be specified for every PVC "+
+				"in VolumeMigration: %q", instance.Name)
+		}
+	}
+	if spec.PVCSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.PVCSelector); err != nil {
+			return logger.LogNewErrorf(log, "invalid PVCSelector for VolumeMigration: %q. Error: %v",
+				instance.Name, err)
+		}
+	}
+	return nil
+}
+
+// getMaxConcurrentMigrations returns the number of volumes relocated at a
+// time for the given spec.
+func getMaxConcurrentMigrations(spec volumemigrationv1alpha1.VolumeMigrationSpec) int {
+	if spec.MaxConcurrentMigrations == 0 {
+		return defaultMaxConcurrentMigrations
+	}
+	return int(spec.MaxConcurrentMigrations)
+}
+
+// selectVolumes returns the vSphere CSI block volumes to be migrated out of
+// the given PVs. If selectAll is false, only the volumes bound to the PVCs
+// in pvcs or referred to by pvcRefs are selected. An error is returned if a
+// PVC in pvcRefs is not bound to a vSphere CSI block volume.
+func selectVolumes(ctx context.Context, pvs []v1.PersistentVolume,
+	pvcRefs []volumemigrationv1alpha1.VolumeMigrationPVCReference, pvcs []v1.PersistentVolumeClaim,
+	selectAll bool) ([]volumemigrationv1alpha1.VolumeMigrationVolumeStatus, error) {
+	selectedPVCs := make(map[apitypes.NamespacedName]bool)
+	for _, pvc := range pvcs {
+		selectedPVCs[apitypes.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}] = true
+	}
+	unmatchedPVCRefs := make(map[apitypes.NamespacedName]bool)
+	for _, ref := range pvcRefs {
+		key := apitypes.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		selectedPVCs[key] = true
+		unmatchedPVCRefs[key] = true
+	}
+
+	var volumes []volumemigrationv1alpha1.VolumeMigrationVolumeStatus
+	for _, pv := range pvs {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != cnsoperatortypes.VSphereCSIDriverName ||
+			pv.Spec.ClaimRef == nil || pv.Status.Phase != v1.VolumeBound {
+			continue
+		}
+		volumeID := pv.Spec.CSI.VolumeHandle
+		if common.GetCnsVolumeType(ctx, volumeID) != common.BlockVolumeType {
+			continue
+		}
+		key := apitypes.NamespacedName{Namespace: pv.Spec.ClaimRef.Namespace, Name: pv.Spec.ClaimRef.Name}
+		if !selectAll && !selectedPVCs[key] {
+			continue
+		}
+		delete(unmatchedPVCRefs, key)
+		volumes = append(volumes, volumemigrationv1alpha1.VolumeMigrationVolumeStatus{
+			PVName:       pv.Name,
+			PVCNamespace: key.Namespace,
+			PVCName:      key.Name,
+			VolumeID:     volumeID,
+			State:        volumemigrationv1alpha1.VolumeMigrationPending,
+		})
+	}
+	if len(unmatchedPVCRefs) > 0 {
+		var names []string
+		for key := range unmatchedPVCRefs {
+			names = append(names, key.String())
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("PVCs %v are not bound to vSphere CSI block volumes", names)
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].PVName < volumes[j].PVName })
+	return volumes, nil
+}
+
+// filterVolumesBySourceDatastore sets the source datastore of the given
+// volumes from datastoreURLs, a map of volume ID to datastore URL. If
+// sourceDatastoreURL is set, only the volumes placed on it are returned.
+// Otherwise, volumes not found in CNS are returned as failed.
+func filterVolumesBySourceDatastore(volumes []volumemigrationv1alpha1.VolumeMigrationVolumeStatus,
+	datastoreURLs map[string]string,
+	sourceDatastoreURL string) []volumemigrationv1alpha1.VolumeMigrationVolumeStatus {
+	var filtered []volumemigrationv1alpha1.VolumeMigrationVolumeStatus
+	for _, volume := range volumes {
+		datastoreURL, found := datastoreURLs[volume.VolumeID]
+		if sourceDatastoreURL != "" && datastoreURL != sourceDatastoreURL {
+			continue
+		}
+		if !found {
+			volume.State = volumemigrationv1alpha1.VolumeMigrationFailed
+			volume.Error = fmt.Sprintf("volume %q not found in CNS", volume.VolumeID)
+		}
+		volume.SourceDatastoreURL = datastoreURL
+		filtered = append(filtered, volume)
+	}
+	return filtered
+}
+
+// getNextBatch returns the indices of up to batchSize volumes to be
+// relocated next. Volumes left in progress by a previous reconcile are
+// picked before pending volumes.
+func getNextBatch(volumes []volumemigrationv1alpha1.VolumeMigrationVolumeStatus, batchSize int) []int {
+	var inProgress, pending []int
+	for i, volume := range volumes {
+		switch volume.State {
+		case volumemigrationv1alpha1.VolumeMigrationInProgress:
+			inProgress = append(inProgress, i)
+		case volumemigrationv1alpha1.VolumeMigrationPending:
+			pending = append(pending, i)
+		}
+	}
+	batch := append(inProgress, pending...)
+	if len(batch) > batchSize {
+		batch = batch[:batchSize]
+	}
+	return batch
+}
+
+// getDatastoreByURL returns the datastore with the given URL in any of the
+// datacenters of the vCenter.
+func getDatastoreByURL(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	datastoreURL string) (*targetDatastore, error) {
+	log := logger.GetLogger(ctx)
+	datacenters, err := vc.GetDatacenters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, dc := range datacenters {
+		dsInfo, err := dc.GetDatastoreInfoByURL(ctx, datastoreURL)
+		if err != nil {
+			log.Debugf("datastore %q not found in datacenter %q. Err: %v", datastoreURL, dc.InventoryPath, err)
+			continue
+		}
+		return &targetDatastore{ref: dsInfo.Reference(), url: datastoreURL}, nil
+	}
+	return nil, fmt.Errorf("datastore corresponding to URL %q not found in vCenter %q",
+		datastoreURL, vc.Config.Host)
+}
+
+// getDatastoreClusterMembers returns the summary of the datastores in the
+// datastore cluster with the given name.
+func getDatastoreClusterMembers(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	datastoreCluster string) ([]mo.Datastore, error) {
+	dsc, err := vc.GetDatastoreClusterByName(ctx, datastoreCluster)
+	if err != nil {
+		if errors.Is(err, cnsvsphere.ErrDatastoreClusterNotFound) {
+			return nil, fmt.Errorf("datastore cluster %q not found in vCenter %q", datastoreCluster, vc.Config.Host)
+		}
+		return nil, fmt.Errorf("failed to find datastore cluster %q. Error: %v", datastoreCluster, err)
+	}
+	return dsc.GetMemberDatastores(ctx)
+}
+
+// pickDatastoreWithMostFreeSpace returns the accessible datastore with the
+// most free space out of the given datastores, skipping datastores which are
+// in or entering maintenance mode.
+func pickDatastoreWithMostFreeSpace(datastores []mo.Datastore) (*targetDatastore, error) {
+	picked := -1
+	for i, ds := range datastores {
+		if !ds.Summary.Accessible {
+			continue
+		}
+		if cnsvsphere.IsDatastoreInMaintenanceMode(ds.Summary) {
+			continue
+		}
+		if picked == -1 || ds.Summary.FreeSpace > datastores[picked].Summary.FreeSpace {
+			picked = i
+		}
+	}
+	if picked == -1 {
+		return nil, errors.New("no accessible datastore found which is not in maintenance mode")
+	}
+	return &targetDatastore{ref: datastores[picked].Reference(), url: datastores[picked].Summary.Url}, nil
+}
+
+// isPVNodeAffinityImmutableError checks if the given error of a PV update is
+// the rejection of the node affinity change by the API server.
+func isPVNodeAffinityImmutableError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return false
+	}
+	for _, cause := range statusErr.Status().Details.Causes {
+		if strings.HasPrefix(cause.Field, "spec.nodeAffinity") {
+			return true
+		}
+	}
+	return false
+}
+
+// getInaccessiblePVTopology returns the topology segments out of segments
+// which the node affinity of the given PV allows and which are not in
+// accessibleSegments. Pods using the PV can be scheduled on the nodes of
+// these segments, so the volume must not be relocated to a datastore which
+// is not accessible from them.
+func getInaccessiblePVTopology(pv *v1.PersistentVolume, segments,
+	accessibleSegments []map[string]string) []map[string]string {
+	var inaccessible []map[string]string
+	for _, segment := range segments {
+		if !pvNodeAffinityAllowsSegment(pv, segment) {
+			continue
+		}
+		if !slices.ContainsFunc(accessibleSegments, func(accessible map[string]string) bool {
+			return maps.Equal(accessible, segment)
+		}) {
+			inaccessible = append(inaccessible, segment)
+		}
+	}
+	return inaccessible
+}
+
+// pvNodeAffinityAllowsSegment checks if the node affinity of the given PV
+// allows the nodes of the given topology segment. Requirements on labels
+// which are not part of the segment are assumed to be met.
+func pvNodeAffinityAllowsSegment(pv *v1.PersistentVolume, segment map[string]string) bool {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return true
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		matches := true
+		for _, expr := range term.MatchExpressions {
+			value, exists := segment[expr.Key]
+			if !exists {
+				continue
+			}
+			switch expr.Operator {
+			case v1.NodeSelectorOpIn:
+				matches = slices.Contains(expr.Values, value)
+			case v1.NodeSelectorOpNotIn:
+				matches = !slices.Contains(expr.Values, value)
+			case v1.NodeSelectorOpDoesNotExist:
+				matches = false
+			}
+			if !matches {
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumemigration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	volumemigrationv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/volumemigration/v1alpha1"
+	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+)
+
+func TestValidateVolumeMigrationSpec(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		spec    volumemigrationv1alpha1.VolumeMigrationSpec
+		wantErr bool
+	}{
+		{
+			name: "all volumes on source datastore to target datastore",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				AllVolumes:         true,
+				SourceDatastoreURL: "ds:///vmfs/volumes/ds-1/",
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-2/",
+			},
+		},
+		{
+			name: "no volume selection",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				SourceDatastoreURL: "ds:///vmfs/volumes/ds-1/",
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-2/",
+			},
+			wantErr: true,
+		},
+		{
+			name: "all volumes and PVCs",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				AllVolumes:         true,
+				PVCs:               []volumemigrationv1alpha1.VolumeMigrationPVCReference{{Namespace: "ns", Name: "pvc"}},
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-2/",
+			},
+			wantErr: true,
+		},
+		{
+			name: "PVCs and target datastore cluster",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				PVCs:                   []volumemigrationv1alpha1.VolumeMigrationPVCReference{{Namespace: "ns", Name: "pvc"}},
+				TargetDatastoreCluster: "pod-1",
+			},
+		},
+		{
+			name:    "no target",
+			spec:    volumemigrationv1alpha1.VolumeMigrationSpec{SourceDatastoreURL: "ds:///vmfs/volumes/ds-1/"},
+			wantErr: true,
+		},
+		{
+			name: "both targets",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				TargetDatastoreURL:     "ds:///vmfs/volumes/ds-2/",
+				TargetDatastoreCluster: "pod-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "same source and target",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				SourceDatastoreURL: "ds:///vmfs/volumes/ds-1/",
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-1/",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max concurrent migrations",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				TargetDatastoreURL:      "ds:///vmfs/volumes/ds-2/",
+				MaxConcurrentMigrations: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "PVC without namespace",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				PVCs:               []volumemigrationv1alpha1.VolumeMigrationPVCReference{{Name: "pvc"}},
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-2/",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid PVC selector",
+			spec: volumemigrationv1alpha1.VolumeMigrationSpec{
+				PVCSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Bogus"}},
+				},
+				TargetDatastoreURL: "ds:///vmfs/volumes/ds-2/",
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &volumemigrationv1alpha1.VolumeMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "migration"},
+				Spec:       test.spec,
+			}
+			err := validateVolumeMigrationSpec(ctx, instance)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func newTestPV(name, driver, volumeID, pvcNamespace, pvcName string) v1.PersistentVolume {
+	pv := v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: volumeID},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+	}
+	if pvcName != "" {
+		pv.Spec.ClaimRef = &v1.ObjectReference{Namespace: pvcNamespace, Name: pvcName}
+		pv.Status.Phase = v1.VolumeBound
+	}
+	return pv
+}
+
+func TestSelectVolumes(t *testing.T) {
+	ctx := context.Background()
+	pvs := []v1.PersistentVolume{
+		newTestPV("pv-b", cnsoperatortypes.VSphereCSIDriverName, "vol-b", "ns-1", "pvc-b"),
+		newTestPV("pv-a", cnsoperatortypes.VSphereCSIDriverName, "vol-a", "ns-1", "pvc-a"),
+		newTestPV("pv-file", cnsoperatortypes.VSphereCSIDriverName, "file:vol-f", "ns-1", "pvc-f"),
+		newTestPV("pv-other", "other.csi.driver", "vol-o", "ns-2", "pvc-o"),
+		newTestPV("pv-unbound", cnsoperatortypes.VSphereCSIDriverName, "vol-u", "", ""),
+	}
+
+	volumes, err := selectVolumes(ctx, pvs, nil, nil, true)
+	assert.NoError(t, err)
+	if assert.Len(t, volumes, 2) {
+		assert.Equal(t, "pv-a", volumes[0].PVName)
+		assert.Equal(t, "vol-a", volumes[0].VolumeID)
+		assert.Equal(t, "ns-1", volumes[0].PVCNamespace)
+		assert.Equal(t, "pvc-a", volumes[0].PVCName)
+		assert.Equal(t, volumemigrationv1alpha1.VolumeMigrationPending, volumes[0].State)
+		assert.Equal(t, "pv-b", volumes[1].PVName)
+	}
+
+	selectedPVCs := []v1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "pvc-b"}},
+	}
+	volumes, err = selectVolumes(ctx, pvs, nil, selectedPVCs, false)
+	assert.NoError(t, err)
+	if assert.Len(t, volumes, 1) {
+		assert.Equal(t, "pv-b", volumes[0].PVName)
+	}
+
+	pvcRefs := []volumemigrationv1alpha1.VolumeMigrationPVCReference{{Namespace: "ns-1", Name: "pvc-a"}}
+	volumes, err = selectVolumes(ctx, pvs, pvcRefs, selectedPVCs, false)
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 2)
+
+	pvcRefs = []volumemigrationv1alpha1.VolumeMigrationPVCReference{{Namespace: "ns-1", Name: "pvc-f"}}
+	_, err = selectVolumes(ctx, pvs, pvcRefs, nil, false)
+	assert.Error(t, err)
+}
+
+func TestFilterVolumesBySourceDatastore(t *testing.T) {
+	volumes := []volumemigrationv1alpha1.VolumeMigrationVolumeStatus{
+		{PVName: "pv-1", VolumeID: "vol-1", State: volumemigrationv1alpha1.VolumeMigrationPending},
+		{PVName: "pv-2", VolumeID: "vol-2", State: volumemigrationv1alpha1.VolumeMigrationPending},
+		{PVName: "pv-3", VolumeID: "vol-3", State: volumemigrationv1alpha1.VolumeMigrationPending},
+	}
+	datastoreURLs := map[string]string{
+		"vol-1": "ds:///vmfs/volumes/ds-1/",
+		"vol-2": "ds:///vmfs/volumes/ds-2/",
+	}
+
+	filtered := filterVolumesBySourceDatastore(volumes, datastoreURLs, "ds:///vmfs/volumes/ds-1/")
+	if assert.Len(t, filtered, 1) {
+		assert.Equal(t, "pv-1", filtered[0].PVName)
+		assert.Equal(t, "ds:///vmfs/volumes/ds-1/", filtered[0].SourceDatastoreURL)
+	}
+
+	filtered = filterVolumesBySourceDatastore(volumes, datastoreURLs, "")
+	if assert.Len(t, filtered, 3) {
+		assert.Equal(t, "ds:///vmfs/volumes/ds-2/", filtered[1].SourceDatastoreURL)
+		assert.Equal(t, volumemigrationv1alpha1.VolumeMigrationPending, filtered[1].State)
+		assert.Equal(t, volumemigrationv1alpha1.VolumeMigrationFailed, filtered[2].State)
+		assert.NotEmpty(t, filtered[2].Error)
+	}
+}
+
+func TestGetNextBatch(t *testing.T) {
+	volumes := []volumemigrationv1alpha1.VolumeMigrationVolumeStatus{
+		{State: volumemigrationv1alpha1.VolumeMigrationSucceeded},
+		{State: volumemigrationv1alpha1.VolumeMigrationPending},
+		{State: volumemigrationv1alpha1.VolumeMigrationFailed},
+		{State: volumemigrationv1alpha1.VolumeMigrationPending},
+		{State: volumemigrationv1alpha1.VolumeMigrationInProgress},
+		{State: volumemigrationv1alpha1.VolumeMigrationPending},
+	}
+	assert.Equal(t, []int{4, 1}, getNextBatch(volumes, 2))
+	assert.Equal(t, []int{4, 1, 3, 5}, getNextBatch(volumes, 10))
+	assert.Empty(t, getNextBatch(volumes[:1], 2))
+}
+
+func TestGetMaxConcurrentMigrations(t *testing.T) {
+	assert.Equal(t, defaultMaxConcurrentMigrations,
+		getMaxConcurrentMigrations(volumemigrationv1alpha1.VolumeMigrationSpec{}))
+	assert.Equal(t, 8,
+		getMaxConcurrentMigrations(volumemigrationv1alpha1.VolumeMigrationSpec{MaxConcurrentMigrations: 8}))
+}
+
+func newTestDatastore(name string, freeSpace int64, accessible bool, maintenanceMode string) mo.Datastore {
+	ds := mo.Datastore{
+		Summary: vimtypes.DatastoreSummary{
+			Url:             "ds:///vmfs/volumes/" + name + "/",
+			FreeSpace:       freeSpace,
+			Accessible:      accessible,
+			MaintenanceMode: maintenanceMode,
+		},
+	}
+	ds.Self = vimtypes.ManagedObjectReference{Type: "Datastore", Value: name}
+	return ds
+}
+
+func TestPickDatastoreWithMostFreeSpace(t *testing.T) {
+	datastores := []mo.Datastore{
+		newTestDatastore("ds-1", 100, true, "normal"),
+		newTestDatastore("ds-2", 500, true, "inMaintenance"),
+		newTestDatastore("ds-3", 400, false, "normal"),
+		newTestDatastore("ds-4", 300, true, ""),
+	}
+	target, err := pickDatastoreWithMostFreeSpace(datastores)
+	assert.NoError(t, err)
+	assert.Equal(t, "ds:///vmfs/volumes/ds-4/", target.url)
+	assert.Equal(t, "ds-4", target.ref.Value)
+
+	_, err = pickDatastoreWithMostFreeSpace(datastores[1:3])
+	assert.Error(t, err)
+}
+
+func TestIsPVNodeAffinityImmutableError(t *testing.T) {
+	pvGroupKind := schema.GroupKind{Kind: "PersistentVolume"}
+	nodeAffinityErr := apierrors.NewInvalid(pvGroupKind, "pv-1", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "nodeAffinity"), nil, "field is immutable"),
+	})
+	otherFieldErr := apierrors.NewInvalid(pvGroupKind, "pv-1", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "capacity"), nil, "field is immutable"),
+	})
+
+	assert.True(t, isPVNodeAffinityImmutableError(nodeAffinityErr))
+	assert.False(t, isPVNodeAffinityImmutableError(otherFieldErr))
+	assert.False(t, isPVNodeAffinityImmutableError(apierrors.NewConflict(
+		schema.GroupResource{Resource: "persistentvolumes"}, "pv-1", errors.New("conflict"))))
+	assert.False(t, isPVNodeAffinityImmutableError(errors.New("connection refused")))
+}
+
+func TestGetInaccessiblePVTopology(t *testing.T) {
+	zoneA := map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+	zoneB := map[string]string{"topology.kubernetes.io/zone": "zone-b"}
+	segments := []map[string]string{zoneA, zoneB}
+	pv := newTestPV("pv-1", "csi.vsphere.vmware.com", "vol-1", "ns", "pvc-1")
+	assert.Equal(t, []map[string]string{zoneB}, getInaccessiblePVTopology(&pv, segments,
+		[]map[string]string{zoneA}))
+
+	pv.Spec.NodeAffinity = &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{
+					Key:      "topology.kubernetes.io/zone",
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{"zone-a"},
+				}},
+			}},
+		},
+	}
+	assert.Empty(t, getInaccessiblePVTopology(&pv, segments, []map[string]string{zoneA}))
+	assert.Equal(t, []map[string]string{zoneA}, getInaccessiblePVTopology(&pv, segments,
+		[]map[string]string{zoneB}))
+
+	pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Operator = v1.NodeSelectorOpNotIn
+	assert.Empty(t, getInaccessiblePVTopology(&pv, segments, []map[string]string{zoneB}))
+}
@@ -0,0 +1,650 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumemigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apis "sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/cnsoperator"
+	volumes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	commonconfig "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/utils"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	volumemigrationv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/volumemigration/v1alpha1"
+	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
+	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/util"
+)
+
+const (
+	workerThreadsEnvVar     = "WORKER_THREADS_VOLUME_MIGRATION"
+	defaultMaxWorkerThreads = 4
+	// queryVolumeBatchSize is the number of volumes queried from CNS at a
+	// time while resolving the volumes to be migrated.
+	queryVolumeBatchSize = 100
+)
+
+// backOffDuration is a map of volumemigration name's to the time after which
+// a request for this instance will be requeued. Initialized to 1 second for
+// new instances and for instances whose latest reconcile operation succeeded.
+// If the reconcile fails, backoff is incremented exponentially.
+var (
+	backOffDuration         map[apitypes.NamespacedName]time.Duration
+	backOffDurationMapMutex = sync.Mutex{}
+)
+
+// Add creates a new VolumeMigration Controller and adds it to the Manager,
+// ConfigurationInfo and VirtualCenterTypes. The Manager will set fields on
+// the Controller and start it when the Manager is Started.
+func Add(mgr manager.Manager, clusterFlavor cnstypes.CnsClusterFlavor,
+	configInfo *commonconfig.ConfigurationInfo, volumeManager volumes.Manager) error {
+	ctx, log := logger.GetNewContextWithLogger()
+	if clusterFlavor != cnstypes.CnsClusterFlavorVanilla {
+		log.Debug("Not initializing the VolumeMigration Controller as its a non-Vanilla CSI deployment")
+		return nil
+	}
+	if !commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.DatastoreVolumeMigration) {
+		log.Infof("Not initializing the VolumeMigration Controller as %q feature is disabled on the cluster",
+			common.DatastoreVolumeMigration)
+		return nil
+	}
+	if len(configInfo.Cfg.VirtualCenter) > 1 {
+		log.Infof("Not initializing the VolumeMigration Controller as it is a multi VC deployment.")
+		return nil
+	}
+
+	// Initializes kubernetes client.
+	k8sclient, err := k8s.NewClient(ctx)
+	if err != nil {
+		log.Errorf("Creating Kubernetes client failed. Err: %v", err)
+		return err
+	}
+
+	// eventBroadcaster broadcasts events on volumemigration instances to the
+	// event sink.
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(
+		&typedcorev1.EventSinkImpl{
+			Interface: k8sclient.CoreV1().Events(""),
+		},
+	)
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: apis.GroupName})
+	return add(mgr, newReconciler(mgr, configInfo, volumeManager, recorder, k8sclient))
+}
+
+// newReconciler returns a new reconcile.Reconciler.
+func newReconciler(mgr manager.Manager, configInfo *commonconfig.ConfigurationInfo,
+	volumeManager volumes.Manager, recorder record.EventRecorder,
+	k8sclient clientset.Interface) reconcile.Reconciler {
+	return &ReconcileVolumeMigration{client: mgr.GetClient(), scheme: mgr.GetScheme(),
+		configInfo: configInfo, volumeManager: volumeManager, recorder: recorder, k8sclient: k8sclient}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler.
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	ctx, log := logger.GetNewContextWithLogger()
+
+	maxWorkerThreads := util.GetMaxWorkerThreads(ctx,
+		workerThreadsEnvVar, defaultMaxWorkerThreads)
+	// Create a new controller.
+	err := ctrl.NewControllerManagedBy(mgr).Named("volumemigration-controller").
+		For(&volumemigrationv1alpha1.VolumeMigration{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxWorkerThreads}).
+		Complete(r)
+	if err != nil {
+		log.Errorf("Failed to build application controller. Err: %v", err)
+		return err
+	}
+
+	backOffDuration = make(map[apitypes.NamespacedName]time.Duration)
+	return nil
+}
+
+// blank assignment to verify that ReconcileVolumeMigration implements
+// reconcile.Reconciler.
+var _ reconcile.Reconciler = &ReconcileVolumeMigration{}
+
+// ReconcileVolumeMigration reconciles a VolumeMigration object.
+type ReconcileVolumeMigration struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver.
+	client        client.Client
+	scheme        *runtime.Scheme
+	configInfo    *commonconfig.ConfigurationInfo
+	volumeManager volumes.Manager
+	recorder      record.EventRecorder
+	k8sclient     clientset.Interface
+	// pvNodeAffinityImmutable is set once the API server rejects an update
+	// of the node affinity of a PV, so that the update is not attempted for
+	// the other PVs until the syncer restarts.
+	pvNodeAffinityImmutable atomic.Bool
+}
+
+// Reconcile reads that state of the cluster for a VolumeMigration object
+// and makes changes based on the state read and what is in the
+// VolumeMigration.Spec. The volumes to be migrated are resolved on the first
+// reconcile and recorded in the status. Every subsequent reconcile relocates
+// the next batch of volumes and requeues the instance until all the volumes
+// are processed.
+// Note:
+// The Controller will requeue the Request to be processed again if the
+// returned error is non-nil or Result.Requeue is true. Otherwise, upon
+// completion it will remove the work from the queue.
+func (r *ReconcileVolumeMigration) Reconcile(ctx context.Context,
+	request reconcile.Request) (reconcile.Result, error) {
+	ctx = logger.NewContextWithLogger(ctx)
+	log := logger.GetLogger(ctx)
+	// Fetch the VolumeMigration instance.
+	instance := &volumemigrationv1alpha1.VolumeMigration{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Infof("VolumeMigration resource with name: %q not found. "+
+				"Ignoring since object must be deleted.", request.Name)
+			backOffDurationMapMutex.Lock()
+			delete(backOffDuration, request.NamespacedName)
+			backOffDurationMapMutex.Unlock()
+			return reconcile.Result{}, nil
+		}
+		log.Errorf("Error reading the VolumeMigration with name: %q. Err: %+v", request.Name, err)
+		// Error reading the object - return with err.
+		return reconcile.Result{}, err
+	}
+
+	// If all the volumes of the VolumeMigration instance are processed,
+	// remove the instance from the queue.
+	if instance.Status.Completed {
+		backOffDurationMapMutex.Lock()
+		delete(backOffDuration, request.NamespacedName)
+		backOffDurationMapMutex.Unlock()
+		return reconcile.Result{}, nil
+	}
+
+	// Initialize backOffDuration for the instance, if required.
+	backOffDurationMapMutex.Lock()
+	var timeout time.Duration
+	if _, exists := backOffDuration[request.NamespacedName]; !exists {
+		backOffDuration[request.NamespacedName] = time.Second
+	}
+	timeout = backOffDuration[request.NamespacedName]
+	backOffDurationMapMutex.Unlock()
+	log.Infof("Reconciling VolumeMigration with instance: %q. timeout %q seconds", instance.Name, timeout)
+
+	// Validate VolumeMigration spec to check for valid entries. An invalid
+	// spec is not requeued, the instance is reconciled again once the spec
+	// is updated.
+	err = validateVolumeMigrationSpec(ctx, instance)
+	if err != nil {
+		log.Error(err)
+		setInstanceError(ctx, r, instance, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	vc, err := cnsvsphere.GetVirtualCenterInstance(ctx, r.configInfo, false)
+	if err != nil {
+		log.Errorf("Failed to get virtual center instance with error: %+v", err)
+		setInstanceError(ctx, r, instance, "Unable to connect to VC for volume migration")
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+
+	if instance.Status.Volumes == nil {
+		volumesToMigrate, err := r.getVolumesToMigrate(ctx, instance)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to resolve the volumes to be migrated. Error: %v", err)
+			log.Error(msg)
+			setInstanceError(ctx, r, instance, msg)
+			return reconcile.Result{RequeueAfter: timeout}, nil
+		}
+		if len(volumesToMigrate) == 0 {
+			setInstanceSuccess(ctx, r, instance, "No volumes selected for migration")
+			return reconcile.Result{}, nil
+		}
+		log.Infof("Selected %d volumes for migration by VolumeMigration: %q", len(volumesToMigrate),
+			instance.Name)
+		instance.Status.Volumes = volumesToMigrate
+		err = updateVolumeMigrationStatus(ctx, r.client, instance)
+		if err != nil {
+			return reconcile.Result{RequeueAfter: timeout}, nil
+		}
+	}
+
+	batch := getNextBatch(instance.Status.Volumes, getMaxConcurrentMigrations(instance.Spec))
+	if len(batch) == 0 {
+		var failedVolumes int
+		for _, volume := range instance.Status.Volumes {
+			if volume.State == volumemigrationv1alpha1.VolumeMigrationFailed {
+				failedVolumes++
+			}
+		}
+		backOffDurationMapMutex.Lock()
+		delete(backOffDuration, request.NamespacedName)
+		backOffDurationMapMutex.Unlock()
+		if failedVolumes > 0 {
+			msg := fmt.Sprintf("Failed to migrate %d of %d volumes", failedVolumes,
+				len(instance.Status.Volumes))
+			log.Errorf("%s for VolumeMigration: %q", msg, instance.Name)
+			instance.Status.Completed = true
+			instance.Status.Error = msg
+			if err := updateVolumeMigrationStatus(ctx, r.client, instance); err == nil {
+				r.recorder.Event(instance, v1.EventTypeWarning, "VolumeMigrationFailed", msg)
+			}
+			return reconcile.Result{}, nil
+		}
+		msg := fmt.Sprintf("Successfully migrated %d volumes", len(instance.Status.Volumes))
+		log.Infof("%s for VolumeMigration: %q", msg, instance.Name)
+		setInstanceSuccess(ctx, r, instance, msg)
+		return reconcile.Result{}, nil
+	}
+
+	target, err := r.getTargetDatastore(ctx, vc, instance.Spec)
+	if err != nil {
+		log.Error(err)
+		setInstanceError(ctx, r, instance, err.Error())
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+	if util.IsTopologyAwareCluster(r.configInfo.Cfg) {
+		target.topologySegments, target.accessibleTopologySegments, err = util.GetDatastoreTopology(ctx,
+			r.client, vc, target.url)
+		if err != nil {
+			msg := fmt.Sprintf("failed to get the topology of datastore %q. Error: %v", target.url, err)
+			log.Error(msg)
+			setInstanceError(ctx, r, instance, msg)
+			return reconcile.Result{RequeueAfter: timeout}, nil
+		}
+	}
+
+	// Mark the volumes of the batch in progress before relocating them, so
+	// that the progress of the migration is visible on the instance.
+	now := metav1.Now()
+	for _, i := range batch {
+		volume := &instance.Status.Volumes[i]
+		volume.State = volumemigrationv1alpha1.VolumeMigrationInProgress
+		volume.TargetDatastoreURL = target.url
+		volume.StartTime = &now
+		volume.Error = ""
+	}
+	err = updateVolumeMigrationStatus(ctx, r.client, instance)
+	if err != nil {
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+
+	var wg sync.WaitGroup
+	for _, i := range batch {
+		wg.Add(1)
+		go func(volume *volumemigrationv1alpha1.VolumeMigrationVolumeStatus) {
+			defer wg.Done()
+			r.migrateVolume(ctx, vc, volume, target)
+		}(&instance.Status.Volumes[i])
+	}
+	wg.Wait()
+
+	instance.Status.Error = ""
+	err = updateVolumeMigrationStatus(ctx, r.client, instance)
+	if err != nil {
+		return reconcile.Result{RequeueAfter: timeout}, nil
+	}
+	// Requeue right away to pick the next batch of volumes.
+	return reconcile.Result{RequeueAfter: time.Second}, nil
+}
+
+// migrateVolume relocates the given volume to the target datastore and
+// updates the node affinity of its PV. The volume is not relocated if the
+// target datastore is not accessible from the topology its PV allows or is
+// not compatible with its storage policy. The outcome is recorded in the
+// given volume status.
+func (r *ReconcileVolumeMigration) migrateVolume(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	volume *volumemigrationv1alpha1.VolumeMigrationVolumeStatus, target *targetDatastore) {
+	log := logger.GetLogger(ctx)
+	if volume.SourceDatastoreURL != target.url {
+		err := r.validateTargetDatastore(ctx, vc, volume, target)
+		if err != nil {
+			log.Errorf("Not relocating volume %q of PV %q to datastore %q. Err: %v",
+				volume.VolumeID, volume.PVName, target.url, err)
+			completionTime := metav1.Now()
+			volume.State = volumemigrationv1alpha1.VolumeMigrationFailed
+			volume.CompletionTime = &completionTime
+			volume.Error = err.Error()
+			return
+		}
+		err = r.relocateVolume(ctx, volume.VolumeID, target)
+		if err != nil {
+			log.Errorf("Failed to relocate volume %q of PV %q to datastore %q. Err: %v",
+				volume.VolumeID, volume.PVName, target.url, err)
+			completionTime := metav1.Now()
+			volume.State = volumemigrationv1alpha1.VolumeMigrationFailed
+			volume.CompletionTime = &completionTime
+			volume.Error = err.Error()
+			return
+		}
+		log.Infof("Relocated volume %q of PV %q from datastore %q to datastore %q",
+			volume.VolumeID, volume.PVName, volume.SourceDatastoreURL, target.url)
+	}
+	completionTime := metav1.Now()
+	volume.State = volumemigrationv1alpha1.VolumeMigrationSucceeded
+	volume.CompletionTime = &completionTime
+
+	if util.IsTopologyAwareCluster(r.configInfo.Cfg) {
+		err := r.updatePVNodeAffinity(ctx, volume.PVName, target)
+		if errors.Is(err, errPVNodeAffinityImmutable) {
+			// The target datastore is accessible from the topology the
+			// current node affinity allows, so the PV stays usable.
+			msg := fmt.Sprintf("Volume %q is relocated to datastore %q, but node affinity of PV %q is not "+
+				"updated to the topology of the datastore as it is immutable unless the MutablePVNodeAffinity "+
+				"feature gate is enabled. The existing node affinity only allows nodes which can access the "+
+				"datastore.", volume.VolumeID, target.url, volume.PVName)
+			log.Info(msg)
+			r.recorder.Event(&v1.ObjectReference{Kind: "PersistentVolume", APIVersion: "v1", Name: volume.PVName},
+				v1.EventTypeNormal, "PVNodeAffinityNotUpdated", msg)
+		} else if err != nil {
+			log.Errorf("Failed to update node affinity of PV %q. Err: %v", volume.PVName, err)
+			volume.Error = fmt.Sprintf("volume is relocated, but node affinity of PV %q is not updated. "+
+				"Error: %v", volume.PVName, err)
+		}
+	}
+}
+
+// validateTargetDatastore checks that the target datastore is accessible
+// from all the topology segments the node affinity of the PV of the given
+// volume allows, and that it is compatible with the storage policy of the
+// volume.
+func (r *ReconcileVolumeMigration) validateTargetDatastore(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	volume *volumemigrationv1alpha1.VolumeMigrationVolumeStatus, target *targetDatastore) error {
+	if util.IsTopologyAwareCluster(r.configInfo.Cfg) {
+		pv, err := r.k8sclient.CoreV1().PersistentVolumes().Get(ctx, volume.PVName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get PV %q. Error: %v", volume.PVName, err)
+		}
+		inaccessible := getInaccessiblePVTopology(pv, target.topologySegments, target.accessibleTopologySegments)
+		if len(inaccessible) > 0 {
+			return fmt.Errorf("datastore %q is not accessible from topology %v allowed by the node affinity "+
+				"of PV %q", target.url, inaccessible, volume.PVName)
+		}
+	}
+
+	querySelection := cnstypes.CnsQuerySelection{
+		Names: []string{string(cnstypes.QuerySelectionNameTypePolicyId)},
+	}
+	queryFilter := cnstypes.CnsQueryFilter{VolumeIds: []cnstypes.CnsVolumeId{{Id: volume.VolumeID}}}
+	queryResult, err := utils.QueryVolumeUtil(ctx, r.volumeManager, queryFilter, &querySelection)
+	if err != nil {
+		return fmt.Errorf("failed to query volume %q from CNS. Error: %v", volume.VolumeID, err)
+	}
+	if len(queryResult.Volumes) == 0 || queryResult.Volumes[0].StoragePolicyId == "" {
+		return nil
+	}
+	storagePolicyID := queryResult.Volumes[0].StoragePolicyId
+	compat, err := vc.PbmCheckCompatibility(ctx, []vimtypes.ManagedObjectReference{target.ref}, storagePolicyID)
+	if err != nil {
+		return fmt.Errorf("failed to check the compatibility of datastore %q with storage policy %q. Error: %v",
+			target.url, storagePolicyID, err)
+	}
+	for _, hub := range compat.CompatibleDatastores() {
+		if hub.HubId == target.ref.Value {
+			return nil
+		}
+	}
+	return fmt.Errorf("datastore %q is not compatible with storage policy %q of volume %q",
+		target.url, storagePolicyID, volume.VolumeID)
+}
+
+// relocateVolume relocates the given volume to the target datastore. A
+// volume already placed on the target datastore is considered relocated.
+func (r *ReconcileVolumeMigration) relocateVolume(ctx context.Context, volumeID string,
+	target *targetDatastore) error {
+	log := logger.GetLogger(ctx)
+	relocateSpec := cnstypes.NewCnsBlockVolumeRelocateSpec(volumeID, target.ref)
+	task, err := r.volumeManager.RelocateVolume(ctx, relocateSpec)
+	if err != nil {
+		// Handle case when target DS is same as source DS, i.e. volume has
+		// already relocated.
+		if soap.IsSoapFault(err) {
+			if _, isAlreadyExistErr := soap.ToSoapFault(err).VimFault().(vimtypes.AlreadyExists); isAlreadyExistErr {
+				log.Infof("Volume %q is already placed on datastore %q", volumeID, target.url)
+				return nil
+			}
+		}
+		return err
+	}
+	taskInfo, err := task.WaitForResultEx(ctx)
+	if err != nil {
+		return err
+	}
+	results := taskInfo.Result.(cnstypes.CnsVolumeOperationBatchResult)
+	for _, result := range results.VolumeResults {
+		fault := result.GetCnsVolumeOperationResult().Fault
+		if fault != nil {
+			log.Errorf("Fault: %+v encountered while relocating volume %v", fault, volumeID)
+			return fmt.Errorf("fault: %+v", fault.LocalizedMessage)
+		}
+	}
+	return nil
+}
+
+// updatePVNodeAffinity sets the node affinity of the given PV to the
+// topology of the datastore the volume is relocated to. The API server
+// rejects the update unless node affinity of PVs is mutable in the cluster,
+// in which case errPVNodeAffinityImmutable is returned.
+func (r *ReconcileVolumeMigration) updatePVNodeAffinity(ctx context.Context, pvName string,
+	target *targetDatastore) error {
+	log := logger.GetLogger(ctx)
+	datastoreURL := target.url
+	nodeAffinity := util.GetPVNodeAffinity(target.accessibleTopologySegments)
+	pv, err := r.k8sclient.CoreV1().PersistentVolumes().Get(ctx, pvName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(pv.Spec.NodeAffinity, nodeAffinity) {
+		return nil
+	}
+	if r.pvNodeAffinityImmutable.Load() {
+		return errPVNodeAffinityImmutable
+	}
+	pv.Spec.NodeAffinity = nodeAffinity
+	_, err = r.k8sclient.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{})
+	if err != nil {
+		if isPVNodeAffinityImmutableError(err) {
+			log.Infof("Node affinity of PV %q is immutable in the cluster. Err: %v", pvName, err)
+			r.pvNodeAffinityImmutable.Store(true)
+			return errPVNodeAffinityImmutable
+		}
+		return err
+	}
+	log.Infof("Updated node affinity of PV %q to the topology of datastore %q", pvName, datastoreURL)
+	return nil
+}
+
+// getVolumesToMigrate returns the volumes selected for migration by the
+// given instance.
+func (r *ReconcileVolumeMigration) getVolumesToMigrate(ctx context.Context,
+	instance *volumemigrationv1alpha1.VolumeMigration) ([]volumemigrationv1alpha1.VolumeMigrationVolumeStatus,
+	error) {
+	var pvcs []v1.PersistentVolumeClaim
+	if instance.Spec.PVCSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(instance.Spec.PVCSelector)
+		if err != nil {
+			return nil, err
+		}
+		pvcList, err := r.k8sclient.CoreV1().PersistentVolumeClaims("").List(ctx,
+			metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PVCs. Error: %v", err)
+		}
+		pvcs = pvcList.Items
+	}
+	pvList, err := r.k8sclient.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PVs. Error: %v", err)
+	}
+	volumesToMigrate, err := selectVolumes(ctx, pvList.Items, instance.Spec.PVCs, pvcs, instance.Spec.AllVolumes)
+	if err != nil {
+		return nil, err
+	}
+	if len(volumesToMigrate) == 0 {
+		return nil, nil
+	}
+	var volumeIDs []string
+	for _, volume := range volumesToMigrate {
+		volumeIDs = append(volumeIDs, volume.VolumeID)
+	}
+	datastoreURLs, err := r.getVolumeDatastoreURLs(ctx, volumeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return filterVolumesBySourceDatastore(volumesToMigrate, datastoreURLs, instance.Spec.SourceDatastoreURL), nil
+}
+
+// getVolumeDatastoreURLs returns a map of volume ID to the URL of the
+// datastore the volume is placed on, for the given volumes found in CNS.
+func (r *ReconcileVolumeMigration) getVolumeDatastoreURLs(ctx context.Context,
+	volumeIDs []string) (map[string]string, error) {
+	querySelection := cnstypes.CnsQuerySelection{
+		Names: []string{string(cnstypes.QuerySelectionNameTypeDataStoreUrl)},
+	}
+	datastoreURLs := make(map[string]string)
+	for start := 0; start < len(volumeIDs); start += queryVolumeBatchSize {
+		end := min(start+queryVolumeBatchSize, len(volumeIDs))
+		var queryFilter cnstypes.CnsQueryFilter
+		for _, volumeID := range volumeIDs[start:end] {
+			queryFilter.VolumeIds = append(queryFilter.VolumeIds, cnstypes.CnsVolumeId{Id: volumeID})
+		}
+		queryResult, err := utils.QueryVolumeUtil(ctx, r.volumeManager, queryFilter, &querySelection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query volumes from CNS. Error: %v", err)
+		}
+		for _, volume := range queryResult.Volumes {
+			datastoreURLs[volume.VolumeId.Id] = volume.DatastoreUrl
+		}
+	}
+	return datastoreURLs, nil
+}
+
+// getTargetDatastore returns the datastore the next batch of volumes is
+// relocated to.
+func (r *ReconcileVolumeMigration) getTargetDatastore(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	spec volumemigrationv1alpha1.VolumeMigrationSpec) (*targetDatastore, error) {
+	if spec.TargetDatastoreURL != "" {
+		return getDatastoreByURL(ctx, vc, spec.TargetDatastoreURL)
+	}
+	datastores, err := getDatastoreClusterMembers(ctx, vc, spec.TargetDatastoreCluster)
+	if err != nil {
+		return nil, err
+	}
+	target, err := pickDatastoreWithMostFreeSpace(datastores)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pick a datastore in datastore cluster %q. Error: %v",
+			spec.TargetDatastoreCluster, err)
+	}
+	return target, nil
+}
+
+// setInstanceError sets error and records an event on the VolumeMigration
+// instance.
+func setInstanceError(ctx context.Context, r *ReconcileVolumeMigration,
+	instance *volumemigrationv1alpha1.VolumeMigration, errMsg string) {
+	log := logger.GetLogger(ctx)
+	instance.Status.Completed = false
+	instance.Status.Error = errMsg
+	err := updateVolumeMigrationStatus(ctx, r.client, instance)
+	if err != nil {
+		log.Errorf("updateVolumeMigrationStatus failed. err: %v", err)
+		return
+	}
+	recordEvent(ctx, r, instance, v1.EventTypeWarning, errMsg)
+}
+
+// setInstanceSuccess sets instance to success and records an event on the
+// VolumeMigration instance.
+func setInstanceSuccess(ctx context.Context, r *ReconcileVolumeMigration,
+	instance *volumemigrationv1alpha1.VolumeMigration, msg string) {
+	log := logger.GetLogger(ctx)
+	instance.Status.Completed = true
+	instance.Status.Error = ""
+	err := updateVolumeMigrationStatus(ctx, r.client, instance)
+	if err != nil {
+		log.Errorf("updateVolumeMigrationStatus failed. err: %v", err)
+		return
+	}
+	recordEvent(ctx, r, instance, v1.EventTypeNormal, msg)
+}
+
+// recordEvent records the event, sets the backOffDuration for the instance
+// appropriately and logs the message.
+// backOffDuration is reset to 1 second on success and doubled on failure.
+func recordEvent(ctx context.Context, r *ReconcileVolumeMigration,
+	instance *volumemigrationv1alpha1.VolumeMigration, eventtype string, msg string) {
+	log := logger.GetLogger(ctx)
+	log.Debugf("Event type is %s", eventtype)
+	namespacedName := apitypes.NamespacedName{
+		Name: instance.Name,
+	}
+	switch eventtype {
+	case v1.EventTypeWarning:
+		// Double backOff duration.
+		backOffDurationMapMutex.Lock()
+		backOffDuration[namespacedName] = min(backOffDuration[namespacedName]*2,
+			cnsoperatortypes.MaxBackOffDurationForReconciler)
+		r.recorder.Event(instance, v1.EventTypeWarning, "VolumeMigrationFailed", msg)
+		backOffDurationMapMutex.Unlock()
+	case v1.EventTypeNormal:
+		// Reset backOff duration to one second.
+		backOffDurationMapMutex.Lock()
+		backOffDuration[namespacedName] = time.Second
+		r.recorder.Event(instance, v1.EventTypeNormal, "VolumeMigrationSucceeded", msg)
+		backOffDurationMapMutex.Unlock()
+	}
+}
+
+// updateVolumeMigrationStatus updates the status of VolumeMigration instance
+// in K8S.
+func updateVolumeMigrationStatus(ctx context.Context, client client.Client,
+	instance *volumemigrationv1alpha1.VolumeMigration) error {
+	log := logger.GetLogger(ctx)
+	err := client.Status().Update(ctx, instance)
+	if err != nil {
+		log.Errorf("Failed to update status of VolumeMigration instance: %q. Error: %+v", instance.Name, err)
+	}
+	return err
+}
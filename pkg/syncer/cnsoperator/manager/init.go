@@ -287,6 +287,26 @@ func InitCnsOperator(ctx context.Context, clusterFlavor cnstypes.CnsClusterFlavo
 			log.Errorf("Failed to create %q CRD. Error: %+v", csinodetopology.CRDSingular, err)
 			return err
 		}
+		if cnsOperator.coCommonInterface.IsFSSEnabled(ctx, common.StaticVolumeImport) {
+			// Create CnsImportVolume CRD from manifest.
+			err = k8s.CreateCustomResourceDefinitionFromManifest(ctx,
+				cnsoperatorconfig.EmbedCnsImportVolumeCRFile,
+				cnsoperatorconfig.EmbedCnsImportVolumeCRFileName)
+			if err != nil {
+				log.Errorf("Failed to create %q CRD. Err: %+v", cnsoperatorv1alpha1.CnsImportVolumePlural, err)
+				return err
+			}
+		}
+		if cnsOperator.coCommonInterface.IsFSSEnabled(ctx, common.DatastoreVolumeMigration) {
+			// Create VolumeMigration CRD from manifest.
+			err = k8s.CreateCustomResourceDefinitionFromManifest(ctx,
+				internalapiscnsoperatorconfig.EmbedVolumeMigrationFile,
+				internalapiscnsoperatorconfig.EmbedVolumeMigrationFileName)
+			if err != nil {
+				log.Errorf("Failed to create %q CRD. Err: %+v", internalapis.VolumeMigrationPlural, err)
+				return err
+			}
+		}
 	} else if clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		if cnsOperator.coCommonInterface.IsFSSEnabled(ctx, common.TKGsHA) {
 			// Create CSINodeTopology CRD.
@@ -372,6 +392,16 @@ func InitCommonModules(ctx context.Context, clusterFlavor cnstypes.CnsClusterFla
 		return err
 	}
 
+	if clusterFlavor != cnstypes.CnsClusterFlavorGuest &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.FullSyncSchedule) {
+		err := k8s.CreateCustomResourceDefinitionFromManifest(ctx, internalapiscnsoperatorconfig.EmbedCsiFullSyncConfig,
+			internalapiscnsoperatorconfig.EmbedCsiFullSyncConfigName)
+		if err != nil {
+			log.Errorf("Failed to create %q CRD. Err: %+v", internalapis.CsiFullSyncConfigPlural, err)
+			return err
+		}
+	}
+
 	if commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.TriggerCsiFullSync) {
 		log.Infof("Triggerfullsync feature enabled")
 		err := k8s.CreateCustomResourceDefinitionFromManifest(ctx, internalapiscnsoperatorconfig.EmbedTriggerCsiFullSync,
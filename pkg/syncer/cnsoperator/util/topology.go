@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	vim25types "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/node"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/config"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
+)
+
+// IsTopologyAwareCluster returns true if topology labels are configured in
+// the vSphere config of a vanilla cluster.
+func IsTopologyAwareCluster(cfg *config.Config) bool {
+	return cfg.Labels.TopologyCategories != "" || (cfg.Labels.Zone != "" && cfg.Labels.Region != "")
+}
+
+// GetDatastoreAccessibleTopology returns the topology segments from which
+// the given datastore is accessible by all nodes, using the CSINodeTopology
+// instances of a vanilla cluster.
+func GetDatastoreAccessibleTopology(ctx context.Context, c client.Client,
+	vc *cnsvsphere.VirtualCenter, datastoreURL string) ([]map[string]string, error) {
+	_, accessibleSegments, err := GetDatastoreTopology(ctx, c, vc, datastoreURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(accessibleSegments) == 0 {
+		return nil, fmt.Errorf("datastore %q is not accessible from all nodes of any topology domain",
+			datastoreURL)
+	}
+	return accessibleSegments, nil
+}
+
+// GetDatastoreTopology returns the topology segments of the nodes of a
+// vanilla cluster, using their CSINodeTopology instances, and the segments
+// out of them from which the given datastore is accessible by all nodes.
+func GetDatastoreTopology(ctx context.Context, c client.Client, vc *cnsvsphere.VirtualCenter,
+	datastoreURL string) (segments []map[string]string, accessibleSegments []map[string]string, err error) {
+	nodeTopologyList := &csinodetopologyv1alpha1.CSINodeTopologyList{}
+	err = c.List(ctx, nodeTopologyList)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list CSINodeTopology instances. Error: %v", err)
+	}
+	nodeManager := node.GetManager(ctx)
+	var nodeVMs []*cnsvsphere.VirtualMachine
+	nodeNames := make(map[vim25types.ManagedObjectReference]string)
+	allNodes := make(map[string]struct{})
+	for _, nodeTopology := range nodeTopologyList.Items {
+		if nodeTopology.Status.Status != csinodetopologyv1alpha1.CSINodeTopologySuccess {
+			continue
+		}
+		nodeVM, err := nodeManager.GetNodeVMAndUpdateCache(ctx, nodeTopology.Spec.NodeUUID, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get VM for node %q. Error: %v", nodeTopology.Name, err)
+		}
+		nodeVMs = append(nodeVMs, nodeVM)
+		nodeNames[nodeVM.Reference()] = nodeTopology.Name
+		allNodes[nodeTopology.Name] = struct{}{}
+	}
+	accessibleNodeVMs, err := common.GetNodeVMsWithAccessToDatastore(ctx, vc, datastoreURL, nodeVMs)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessibleNodes := make(map[string]struct{})
+	for _, nodeVM := range accessibleNodeVMs {
+		accessibleNodes[nodeNames[nodeVM.Reference()]] = struct{}{}
+	}
+	return getAccessibleTopologySegments(nodeTopologyList.Items, allNodes),
+		getAccessibleTopologySegments(nodeTopologyList.Items, accessibleNodes), nil
+}
+
+// getAccessibleTopologySegments returns the topology segments of the given
+// nodes in which every node is present in accessibleNodes.
+func getAccessibleTopologySegments(nodeTopologies []csinodetopologyv1alpha1.CSINodeTopology,
+	accessibleNodes map[string]struct{}) []map[string]string {
+	var (
+		segmentKeys []string
+		segments    = make(map[string]map[string]string)
+		accessible  = make(map[string]bool)
+	)
+	for _, nodeTopology := range nodeTopologies {
+		if nodeTopology.Status.Status != csinodetopologyv1alpha1.CSINodeTopologySuccess ||
+			len(nodeTopology.Status.TopologyLabels) == 0 {
+			continue
+		}
+		segment := make(map[string]string)
+		var labels []string
+		for _, label := range nodeTopology.Status.TopologyLabels {
+			segment[label.Key] = label.Value
+			labels = append(labels, label.Key+"="+label.Value)
+		}
+		slices.Sort(labels)
+		segmentKey := strings.Join(labels, ",")
+		if _, exists := segments[segmentKey]; !exists {
+			segmentKeys = append(segmentKeys, segmentKey)
+			segments[segmentKey] = segment
+			accessible[segmentKey] = true
+		}
+		if _, ok := accessibleNodes[nodeTopology.Name]; !ok {
+			accessible[segmentKey] = false
+		}
+	}
+	var accessibleSegments []map[string]string
+	for _, segmentKey := range segmentKeys {
+		if accessible[segmentKey] {
+			accessibleSegments = append(accessibleSegments, segments[segmentKey])
+		}
+	}
+	return accessibleSegments
+}
+
+// GetPVNodeAffinity returns the node affinity for a PV accessible from the
+// given topology segments.
+func GetPVNodeAffinity(segments []map[string]string) *v1.VolumeNodeAffinity {
+	var terms []v1.NodeSelectorTerm
+	for _, segment := range segments {
+		var keys []string
+		for key := range segment {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		var expressions []v1.NodeSelectorRequirement
+		for _, key := range keys {
+			expressions = append(expressions, v1.NodeSelectorRequirement{
+				Key:      key,
+				Operator: v1.NodeSelectorOpIn,
+				Values:   []string{segment[key]},
+			})
+		}
+		terms = append(terms, v1.NodeSelectorTerm{MatchExpressions: expressions})
+	}
+	return &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: terms,
+		},
+	}
+}
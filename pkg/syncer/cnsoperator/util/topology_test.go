@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
+)
+
+func TestGetAccessibleTopologySegments(t *testing.T) {
+	newNodeTopology := func(name, zone string) csinodetopologyv1alpha1.CSINodeTopology {
+		return csinodetopologyv1alpha1.CSINodeTopology{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: csinodetopologyv1alpha1.CSINodeTopologyStatus{
+				Status: csinodetopologyv1alpha1.CSINodeTopologySuccess,
+				TopologyLabels: []csinodetopologyv1alpha1.TopologyLabel{
+					{Key: "topology.csi.vmware.com/k8s-zone", Value: zone},
+				},
+			},
+		}
+	}
+	nodeTopologies := []csinodetopologyv1alpha1.CSINodeTopology{
+		newNodeTopology("node-a1", "zone-a"),
+		newNodeTopology("node-a2", "zone-a"),
+		newNodeTopology("node-b1", "zone-b"),
+		newNodeTopology("node-c1", "zone-c"),
+	}
+	accessibleNodes := map[string]struct{}{"node-a1": {}, "node-a2": {}, "node-b1": {}}
+	segments := getAccessibleTopologySegments(nodeTopologies, accessibleNodes)
+	assert.Equal(t, []map[string]string{
+		{"topology.csi.vmware.com/k8s-zone": "zone-a"},
+		{"topology.csi.vmware.com/k8s-zone": "zone-b"},
+	}, segments)
+
+	// zone-a is dropped as node-a2 has no access to the datastore.
+	delete(accessibleNodes, "node-a2")
+	segments = getAccessibleTopologySegments(nodeTopologies, accessibleNodes)
+	assert.Equal(t, []map[string]string{{"topology.csi.vmware.com/k8s-zone": "zone-b"}}, segments)
+
+	nodeAffinity := GetPVNodeAffinity(segments)
+	assert.Equal(t, []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{{
+		Key: "topology.csi.vmware.com/k8s-zone", Operator: v1.NodeSelectorOpIn, Values: []string{"zone-b"},
+	}}}}, nodeAffinity.Required.NodeSelectorTerms)
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	"github.com/vmware/govmomi/object"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+
+	volumes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/utils"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// Reasons of the events recorded on the PVs of evacuated volumes.
+	volumeEvacuatedReason        = "VolumeEvacuated"
+	volumeEvacuationFailedReason = "VolumeEvacuationFailed"
+)
+
+// evacuationCandidate is a volume placed on a datastore under maintenance.
+type evacuationCandidate struct {
+	pv              *v1.PersistentVolume
+	volumeID        string
+	capacityMB      int64
+	storagePolicyID string
+	source          *cnsvsphere.DatastoreInfo
+}
+
+// csiEvacuateDatastores relocates the block volumes placed on the datastores
+// in or entering maintenance mode, or tagged for drain, in each vCenter to
+// other datastores. At most maxRelocations volumes are relocated per vCenter
+// in a call, so the datastores are drained over several intervals.
+func csiEvacuateDatastores(ctx context.Context, metadataSyncer *metadataSyncInformer,
+	recorder record.EventRecorder, maxRelocations int) {
+	log := logger.GetLogger(ctx)
+	vcconfigs, err := cnsvsphere.GetVirtualCenterConfigs(ctx, metadataSyncer.configInfo.Cfg)
+	if err != nil {
+		log.Errorf("DatastoreEvacuation: failed to get virtual center configs. Error: %v", err)
+		return
+	}
+	pvs, err := metadataSyncer.pvLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("DatastoreEvacuation: failed to list PVs. Error: %v", err)
+		return
+	}
+	pvByVolumeID := make(map[string]*v1.PersistentVolume)
+	for _, pv := range pvs {
+		if pv.Spec.CSI != nil && !IsFileVolume(pv) {
+			pvByVolumeID[pv.Spec.CSI.VolumeHandle] = pv
+		}
+	}
+	for _, vcconfig := range vcconfigs {
+		err := evacuateDatastoresInVC(ctx, metadataSyncer, vcconfig.Host, pvByVolumeID, recorder, maxRelocations)
+		if err != nil {
+			log.Errorf("DatastoreEvacuation: failed to evacuate datastores in vCenter %q. Error: %v",
+				vcconfig.Host, err)
+		}
+	}
+}
+
+// evacuateDatastoresInVC relocates up to maxRelocations block volumes off the
+// datastores under maintenance in the given vCenter and reports the volumes
+// left to relocate on each of them.
+func evacuateDatastoresInVC(ctx context.Context, metadataSyncer *metadataSyncInformer, vcHost string,
+	pvByVolumeID map[string]*v1.PersistentVolume, recorder record.EventRecorder, maxRelocations int) error {
+	log := logger.GetLogger(ctx)
+	vc, err := cnsvsphere.GetVirtualCenterInstanceForVCenterHost(ctx, vcHost, true)
+	if err != nil {
+		return err
+	}
+	volManager, err := getVolManagerForVcHost(ctx, vcHost, metadataSyncer)
+	if err != nil {
+		return err
+	}
+	datacenters, err := vc.GetDatacenters(ctx)
+	if err != nil {
+		return err
+	}
+	dsByURL := make(map[string]*cnsvsphere.DatastoreInfo)
+	for _, dc := range datacenters {
+		dcDatastores, err := dc.GetAllDatastores(ctx)
+		if err != nil {
+			return err
+		}
+		for url, ds := range dcDatastores {
+			dsByURL[url] = ds
+		}
+	}
+	querySelection := cnstypes.CnsQuerySelection{
+		Names: []string{
+			string(cnstypes.QuerySelectionNameTypeVolumeType),
+			string(cnstypes.QuerySelectionNameTypeDataStoreUrl),
+			string(cnstypes.QuerySelectionNameTypeBackingObjectDetails),
+			string(cnstypes.QuerySelectionNameTypePolicyId),
+		},
+	}
+	queryResult, err := utils.QueryAllVolumesForCluster(ctx, volManager,
+		metadataSyncer.configInfo.Cfg.Global.ClusterID, querySelection)
+	if err != nil {
+		return err
+	}
+	volumesByDatastore := make(map[string][]cnstypes.CnsVolume)
+	var dsRefs []vimtypes.ManagedObjectReference
+	for _, volume := range queryResult.Volumes {
+		ds, ok := dsByURL[volume.DatastoreUrl]
+		if !ok || volume.VolumeType != common.BlockVolumeType {
+			continue
+		}
+		if _, ok := pvByVolumeID[volume.VolumeId.Id]; !ok {
+			continue
+		}
+		if _, ok := volumesByDatastore[volume.DatastoreUrl]; !ok {
+			dsRefs = append(dsRefs, ds.Reference())
+		}
+		volumesByDatastore[volume.DatastoreUrl] = append(volumesByDatastore[volume.DatastoreUrl], volume)
+	}
+	underMaintenance, err := common.GetDatastoresUnderMaintenance(ctx, vc, dsRefs)
+	if err != nil {
+		return err
+	}
+
+	prometheus.DatastoreEvacuationPendingVolumesGaugeVec.DeletePartialMatch(map[string]string{"vcenter": vcHost})
+	var candidates []evacuationCandidate
+	for url, dsVolumes := range volumesByDatastore {
+		source := dsByURL[url]
+		if _, ok := underMaintenance[source.Reference().Value]; !ok {
+			continue
+		}
+		log.Infof("DatastoreEvacuation: %d volumes are placed on datastore %q under maintenance in vCenter %q",
+			len(dsVolumes), source.Info.Name, vcHost)
+		prometheus.DatastoreEvacuationPendingVolumesGaugeVec.WithLabelValues(vcHost,
+			source.Info.Name).Set(float64(len(dsVolumes)))
+		for _, volume := range dsVolumes {
+			candidate := evacuationCandidate{
+				pv:              pvByVolumeID[volume.VolumeId.Id],
+				volumeID:        volume.VolumeId.Id,
+				storagePolicyID: volume.StoragePolicyId,
+				source:          source,
+			}
+			if volume.BackingObjectDetails != nil {
+				candidate.capacityMB = volume.BackingObjectDetails.GetCnsBackingObjectDetails().CapacityInMb
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) > maxRelocations {
+		candidates = candidates[:maxRelocations]
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Pick the targets upfront so that the free space taken by the volumes
+	// relocated in this call is accounted for. The targets compatible with a
+	// storage policy share the DatastoreInfo of the targets of their source.
+	targetsBySource := make(map[string][]*cnsvsphere.DatastoreInfo)
+	compatibleTargetsBySourceAndPolicy := make(map[string][]*cnsvsphere.DatastoreInfo)
+	var wg sync.WaitGroup
+	for _, candidate := range candidates {
+		sourceMoID := candidate.source.Reference().Value
+		targets, ok := targetsBySource[sourceMoID]
+		if !ok {
+			targets, err = getEvacuationTargets(ctx, vc, candidate.source)
+			if err != nil {
+				log.Errorf("DatastoreEvacuation: failed to get target datastores for datastore %q. Error: %v",
+					candidate.source.Info.Name, err)
+			}
+			targetsBySource[sourceMoID] = targets
+		}
+		if candidate.storagePolicyID != "" && len(targets) != 0 {
+			key := sourceMoID + "/" + candidate.storagePolicyID
+			compatibleTargets, ok := compatibleTargetsBySourceAndPolicy[key]
+			if !ok {
+				compatibleTargets, err = getCompatibleEvacuationTargets(ctx, vc, targets, candidate.storagePolicyID)
+				if err != nil {
+					log.Errorf("DatastoreEvacuation: failed to check the compatibility of the target datastores "+
+						"of datastore %q with storage policy %q. Error: %v",
+						candidate.source.Info.Name, candidate.storagePolicyID, err)
+				}
+				compatibleTargetsBySourceAndPolicy[key] = compatibleTargets
+			}
+			targets = compatibleTargets
+		}
+		target := pickEvacuationTarget(targets, candidate.capacityMB)
+		if target == nil {
+			recorder.Eventf(candidate.pv, v1.EventTypeWarning, volumeEvacuationFailedReason,
+				"No compatible datastore found to relocate volume %q off datastore %q under maintenance",
+				candidate.volumeID, candidate.source.Info.Name)
+			prometheus.DatastoreEvacuationRelocationsCounterVec.WithLabelValues(prometheus.PrometheusFailStatus).Inc()
+			continue
+		}
+		target.Info.FreeSpace -= candidate.capacityMB * common.MbInBytes
+		wg.Add(1)
+		go func(candidate evacuationCandidate, target *cnsvsphere.DatastoreInfo) {
+			defer wg.Done()
+			evacuateVolume(ctx, volManager, recorder, candidate, target)
+		}(candidate, target)
+	}
+	wg.Wait()
+	return nil
+}
+
+// evacuateVolume relocates the volume of the candidate to the target
+// datastore and records the outcome on its PV.
+func evacuateVolume(ctx context.Context, volManager volumes.Manager, recorder record.EventRecorder,
+	candidate evacuationCandidate, target *cnsvsphere.DatastoreInfo) {
+	log := logger.GetLogger(ctx)
+	log.Infof("DatastoreEvacuation: relocating volume %q of PV %q from datastore %q to datastore %q",
+		candidate.volumeID, candidate.pv.Name, candidate.source.Info.Name, target.Info.Name)
+	err := relocateVolumeToDatastore(ctx, volManager, candidate.volumeID, target.Reference())
+	if err != nil {
+		log.Errorf("DatastoreEvacuation: failed to relocate volume %q of PV %q to datastore %q. Error: %v",
+			candidate.volumeID, candidate.pv.Name, target.Info.Name, err)
+		recorder.Eventf(candidate.pv, v1.EventTypeWarning, volumeEvacuationFailedReason,
+			"Failed to relocate volume %q off datastore %q under maintenance to datastore %q: %v",
+			candidate.volumeID, candidate.source.Info.Name, target.Info.Name, err)
+		prometheus.DatastoreEvacuationRelocationsCounterVec.WithLabelValues(prometheus.PrometheusFailStatus).Inc()
+		return
+	}
+	log.Infof("DatastoreEvacuation: relocated volume %q of PV %q to datastore %q",
+		candidate.volumeID, candidate.pv.Name, target.Info.Name)
+	recorder.Eventf(candidate.pv, v1.EventTypeNormal, volumeEvacuatedReason,
+		"Relocated volume %q off datastore %q under maintenance to datastore %q",
+		candidate.volumeID, candidate.source.Info.Name, target.Info.Name)
+	prometheus.DatastoreEvacuationRelocationsCounterVec.WithLabelValues(prometheus.PrometheusPassStatus).Inc()
+}
+
+// getEvacuationTargets returns the datastores which are accessible from all
+// the hosts mounting the source datastore and are not under maintenance.
+// Relocating a volume to one of them keeps it accessible from the same nodes.
+func getEvacuationTargets(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	source *cnsvsphere.DatastoreInfo) ([]*cnsvsphere.DatastoreInfo, error) {
+	var sourceMo mo.Datastore
+	pc := property.DefaultCollector(vc.Client.Client)
+	err := pc.RetrieveOne(ctx, source.Reference(), []string{"host"}, &sourceMo)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []*cnsvsphere.HostSystem
+	for _, mount := range sourceMo.Host {
+		hosts = append(hosts, &cnsvsphere.HostSystem{HostSystem: object.NewHostSystem(vc.Client.Client, mount.Key)})
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("datastore %q is not mounted on any host", source.Info.Name)
+	}
+	shared, err := cnsvsphere.GetSharedDatastoresForHosts(ctx, hosts)
+	if err != nil {
+		return nil, err
+	}
+	var dsRefs []vimtypes.ManagedObjectReference
+	for _, ds := range shared {
+		dsRefs = append(dsRefs, ds.Reference())
+	}
+	underMaintenance, err := common.GetDatastoresUnderMaintenance(ctx, vc, dsRefs)
+	if err != nil {
+		return nil, err
+	}
+	underMaintenance[source.Reference().Value] = struct{}{}
+	var targets []*cnsvsphere.DatastoreInfo
+	for _, ds := range shared {
+		if _, ok := underMaintenance[ds.Reference().Value]; !ok {
+			targets = append(targets, ds)
+		}
+	}
+	return targets, nil
+}
+
+// getCompatibleEvacuationTargets returns the targets which are compatible
+// with the given storage policy, so that relocated volumes stay compliant.
+func getCompatibleEvacuationTargets(ctx context.Context, vc *cnsvsphere.VirtualCenter,
+	targets []*cnsvsphere.DatastoreInfo, storagePolicyID string) ([]*cnsvsphere.DatastoreInfo, error) {
+	var dsRefs []vimtypes.ManagedObjectReference
+	for _, ds := range targets {
+		dsRefs = append(dsRefs, ds.Reference())
+	}
+	compat, err := vc.PbmCheckCompatibility(ctx, dsRefs, storagePolicyID)
+	if err != nil {
+		return nil, err
+	}
+	return filterCompatibleEvacuationTargets(targets, compat.CompatibleDatastores()), nil
+}
+
+// filterCompatibleEvacuationTargets returns the targets which are among the
+// given compatible placement hubs.
+func filterCompatibleEvacuationTargets(targets []*cnsvsphere.DatastoreInfo,
+	compatibleHubs []pbmtypes.PbmPlacementHub) []*cnsvsphere.DatastoreInfo {
+	compatible := make(map[string]struct{})
+	for _, hub := range compatibleHubs {
+		compatible[hub.HubId] = struct{}{}
+	}
+	var filtered []*cnsvsphere.DatastoreInfo
+	for _, ds := range targets {
+		if _, ok := compatible[ds.Reference().Value]; ok {
+			filtered = append(filtered, ds)
+		}
+	}
+	return filtered
+}
+
+// pickEvacuationTarget returns the datastore with the most free space among
+// the given targets which can fit a volume of the given capacity, or nil if
+// none of them can.
+func pickEvacuationTarget(targets []*cnsvsphere.DatastoreInfo, capacityMB int64) *cnsvsphere.DatastoreInfo {
+	var picked *cnsvsphere.DatastoreInfo
+	for _, ds := range targets {
+		if ds.Info.FreeSpace < capacityMB*common.MbInBytes {
+			continue
+		}
+		if picked == nil || ds.Info.FreeSpace > picked.Info.FreeSpace {
+			picked = ds
+		}
+	}
+	return picked
+}
+
+// relocateVolumeToDatastore relocates the given volume to the target
+// datastore. A volume already placed on the target datastore is considered
+// relocated.
+func relocateVolumeToDatastore(ctx context.Context, volManager volumes.Manager, volumeID string,
+	target vimtypes.ManagedObjectReference) error {
+	relocateSpec := cnstypes.NewCnsBlockVolumeRelocateSpec(volumeID, target)
+	task, err := volManager.RelocateVolume(ctx, relocateSpec)
+	if err != nil {
+		if soap.IsSoapFault(err) {
+			if _, ok := soap.ToSoapFault(err).VimFault().(vimtypes.AlreadyExists); ok {
+				return nil
+			}
+		}
+		return err
+	}
+	taskInfo, err := task.WaitForResultEx(ctx)
+	if err != nil {
+		return err
+	}
+	results := taskInfo.Result.(cnstypes.CnsVolumeOperationBatchResult)
+	for _, result := range results.VolumeResults {
+		fault := result.GetCnsVolumeOperationResult().Fault
+		if fault != nil {
+			return fmt.Errorf("fault: %+v", fault.LocalizedMessage)
+		}
+	}
+	return nil
+}
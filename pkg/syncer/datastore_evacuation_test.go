@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/object"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+)
+
+func TestPickEvacuationTarget(t *testing.T) {
+	newDatastore := func(name string, freeSpaceMB int64) *cnsvsphere.DatastoreInfo {
+		return &cnsvsphere.DatastoreInfo{
+			Info: &vimtypes.DatastoreInfo{Name: name, FreeSpace: freeSpaceMB * common.MbInBytes},
+		}
+	}
+	targets := []*cnsvsphere.DatastoreInfo{
+		newDatastore("ds-1", 100),
+		newDatastore("ds-2", 500),
+		newDatastore("ds-3", 300),
+	}
+	assert.Equal(t, "ds-2", pickEvacuationTarget(targets, 200).Info.Name)
+	assert.Equal(t, "ds-2", pickEvacuationTarget(targets, 500).Info.Name)
+	assert.Nil(t, pickEvacuationTarget(targets, 600))
+	assert.Nil(t, pickEvacuationTarget(nil, 1))
+}
+
+func TestFilterCompatibleEvacuationTargets(t *testing.T) {
+	newDatastore := func(moID string) *cnsvsphere.DatastoreInfo {
+		return &cnsvsphere.DatastoreInfo{
+			Datastore: &cnsvsphere.Datastore{Datastore: object.NewDatastore(nil,
+				vimtypes.ManagedObjectReference{Type: "Datastore", Value: moID})},
+			Info: &vimtypes.DatastoreInfo{Name: moID},
+		}
+	}
+	targets := []*cnsvsphere.DatastoreInfo{newDatastore("datastore-1"), newDatastore("datastore-2"),
+		newDatastore("datastore-3")}
+	compatibleHubs := []pbmtypes.PbmPlacementHub{
+		{HubType: "Datastore", HubId: "datastore-3"},
+		{HubType: "Datastore", HubId: "datastore-1"},
+		{HubType: "Datastore", HubId: "datastore-4"},
+	}
+	assert.Equal(t, []*cnsvsphere.DatastoreInfo{targets[0], targets[2]},
+		filterCompatibleEvacuationTargets(targets, compatibleHubs))
+	assert.Empty(t, filterCompatibleEvacuationTargets(targets, nil))
+}
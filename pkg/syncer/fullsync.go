@@ -70,13 +70,16 @@ const (
 func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc string) error {
 	log := logger.GetLogger(ctx)
 	log.Infof("FullSync for VC %s: start", vc)
+	if !Sharder.OwnsAny(vc) {
+		log.Infof("FullSync for VC %s: no shard of the VC is owned by this syncer replica. Skipping.", vc)
+		return nil
+	}
 	fullSyncStartTime := time.Now()
 	var migrationFeatureStateForFullSync bool
 	var err error
 	// Fetch CSI migration feature state, before performing full sync operations.
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla {
-		if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.CSIMigration) &&
-			len(metadataSyncer.configInfo.Cfg.VirtualCenter) == 1 {
+		if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.CSIMigration) {
 			migrationFeatureStateForFullSync = true
 		}
 	}
@@ -88,7 +91,7 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 	}
 	// Sync VolumeInfo CRs for the below conditions:
 	// Either it is a Vanilla k8s deployment with Multi-VC configuration or, it's a StretchSupervisor cluster
-	if len(metadataSyncer.configInfo.Cfg.VirtualCenter) > 1 ||
+	if (len(metadataSyncer.configInfo.Cfg.VirtualCenter) > 1 && Sharder.OwnsVCenter(vc)) ||
 		(metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorWorkload && IsPodVMOnStretchSupervisorFSSEnabled) {
 		volumeInfoCRFullSync(ctx, metadataSyncer, vc)
 		cleanUpVolumeInfoCrDeletionMap(ctx, metadataSyncer, vc)
@@ -120,6 +123,10 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 		}
 		prometheus.FullSyncOpsHistVec.WithLabelValues(fullSyncStatus).Observe(
 			(time.Since(fullSyncStartTime)).Seconds())
+		if Sharder != nil {
+			prometheus.SyncerShardFullSyncHistVec.WithLabelValues(vc, fullSyncStatus).Observe(
+				(time.Since(fullSyncStartTime)).Seconds())
+		}
 	}()
 
 	// Get K8s PVs in State "Bound", "Available" or "Released" for the given VC.
@@ -128,9 +135,6 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 		log.Errorf("FullSync for VC %s: Failed to get PVs from kubernetes. Err: %v", vc, err)
 		return err
 	}
-
-	// k8sPVMap is useful for clean and quicker look up.
-	k8sPVMap := make(map[string]string)
 	// Instantiate volumeMigrationService when migration feature state is True.
 	if migrationFeatureStateForFullSync {
 		// Instantiate volumeMigrationService when migration feature state is True.
@@ -139,6 +143,11 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 			return err
 		}
 	}
+	if Sharder != nil {
+		k8sPVs = getOwnedPVs(ctx, k8sPVs, vc, migrationFeatureStateForFullSync)
+	}
+	// k8sPVMap is useful for clean and quicker look up.
+	k8sPVMap := make(map[string]string)
 
 	// Iterate through all the k8sPVs and use volume id as the key for k8sPVMap
 	// items. For migrated volumes, invoke GetVolumeID from migration service.
@@ -148,8 +157,8 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 			k8sPVMap[pv.Spec.CSI.VolumeHandle] = ""
 		} else if migrationFeatureStateForFullSync && pv.Spec.VsphereVolume != nil {
 			// For vSphere volumes, migration service will register volumes in CNS.
-			// Note that we can never reach here in case of a multi VC setup
-			// as we have already filtered out in-tree volumes.
+			// On a multi VC setup, k8sPVs only has the in-tree volumes registered
+			// on this VC.
 			migrationVolumeSpec := &migration.VolumeSpec{
 				VolumePath:        pv.Spec.VsphereVolume.VolumePath,
 				StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
@@ -163,6 +172,12 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 			k8sPVMap[volumeHandle] = ""
 		}
 	}
+	// The volumes of the PVs out of the namespace scope of the full sync
+	// schedule of the VC are neither created nor updated in CNS. k8sPVMap
+	// holds all the PVs, so that the volumes of the PVs out of scope are not
+	// deleted either.
+	pvsInScope, outOfScopeVolumes := getPVsInFullSyncScope(ctx, k8sPVs, fullSyncSchedules.getSchedule(vc),
+		migrationFeatureStateForFullSync)
 	// pvToPVCMap maps pv name to corresponding PVC.
 	// pvcToPodMap maps pvc to the mounted Pod.
 	pvToPVCMap, pvcToPodMap, err := buildPVCMapPodMap(ctx, k8sPVs, metadataSyncer, vc)
@@ -252,6 +267,9 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 	var queryAllResult *cnstypes.CnsQueryResult
 	if metadataSyncer.configInfo.Cfg.Global.ClusterID != "" {
 		// Cluster ID is removed from vSphere Config Secret post 9.0 release in Supervisor
+		if err = fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+			return err
+		}
 		queryAllResult, err = utils.QueryAllVolumesForCluster(ctx, volManager,
 			metadataSyncer.configInfo.Cfg.Global.ClusterID, cnstypes.CnsQuerySelection{})
 		if err != nil {
@@ -278,7 +296,7 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 				volumeIDsWithOldClusterID = append(volumeIDsWithOldClusterID, volume.VolumeId)
 			}
 			queryAllResult, err := fullSyncGetQueryResults(ctx, volumeIDsWithOldClusterID,
-				metadataSyncer.configInfo.Cfg.Global.ClusterID, volManager, metadataSyncer)
+				metadataSyncer.configInfo.Cfg.Global.ClusterID, volManager, metadataSyncer, vc)
 			if err != nil {
 				log.Errorf("FullSync for VC %s: fullSyncGetQueryResults failed to query volume metadata from vc. Err: %v", vc, err)
 				return err
@@ -351,6 +369,9 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 				for _, updateSpec := range updateMetadataSpecArray {
 					log.Debugf("Calling UpdateVolumeMetadata for volume %s with updateSpec: %+v",
 						updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
+					if err := fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+						return err
+					}
 					if err := volManager.UpdateVolumeMetadata(ctx, &updateSpec); err != nil {
 						log.Warnf("FullSync for VC %s: UpdateVolumeMetadata failed while replacing clusterID "+
 							"with supervisorID. Error: %+v", vc, err)
@@ -365,6 +386,9 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 			},
 		}
 		// get queryAllResult using new Supervisor ID for rest of full sync operations
+		if err = fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+			return err
+		}
 		queryAllResult, err = utils.QueryAllVolumesForCluster(ctx, volManager,
 			metadataSyncer.configInfo.Cfg.Global.SupervisorID, querySelection)
 		if err != nil {
@@ -385,14 +409,32 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 			len(cnsBlockVolumeMap))
 		validateAndCorrectVolumeInfoSnapshotDetails(ctx, cnsBlockVolumeMap)
 	}
+	if Sharder != nil {
+		queryAllResult.Volumes = getOwnedCnsVolumes(queryAllResult.Volumes, vc)
+	}
+	cnsVolumesInScope := queryAllResult.Volumes
+	if len(outOfScopeVolumes) > 0 {
+		cnsVolumesInScope = getCnsVolumesInFullSyncScope(queryAllResult.Volumes, outOfScopeVolumes)
+	}
 	vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vc]
 	if !vcHostObjFound {
 		log.Errorf("FullSync for VC %s: Failed to get VC host object.", vc)
 		return errors.New("failed to get VC host object")
 	}
 
+	// With incremental full sync, only the metadata of the volumes whose K8s
+	// objects changed since the previous full sync, and of the volumes of the
+	// audit window of this cycle, is queried from CNS and updated.
+	pvsToSync := pvsInScope
+	var incrementalCycle *fullSyncCycle
+	if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.IncrementalFullSync) {
+		incrementalCycle = incrementalFullSyncStates.startCycle(ctx, vc)
+		pvsToSync = incrementalFullSyncStates.getPVsToSync(ctx, incrementalCycle, pvsInScope, cnsVolumesInScope,
+			pvToPVCMap, pvcToPodMap, migrationFeatureStateForFullSync)
+	}
+
 	volumeToCnsEntityMetadataMap, volumeToK8sEntityMetadataMap, volumeClusterDistributionMap, err :=
-		fullSyncConstructVolumeMaps(ctx, k8sPVs, queryAllResult.Volumes, pvToPVCMap,
+		fullSyncConstructVolumeMaps(ctx, pvsToSync, cnsVolumesInScope, pvToPVCMap,
 			pvcToPodMap, metadataSyncer, migrationFeatureStateForFullSync, volManager, vc)
 	if err != nil {
 		log.Errorf("FullSync for VC %s: fullSyncGetEntityMetadata failed with err %+v", vc, err)
@@ -405,7 +447,7 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 	containerCluster := cnsvsphere.GetContainerCluster(clusterIDforVolumeMetadata,
 		vcHostObj.User, metadataSyncer.clusterFlavor,
 		metadataSyncer.configInfo.Cfg.Global.ClusterDistribution)
-	createSpecArray, updateSpecArray := fullSyncGetVolumeSpecs(ctx, vcenter.Client.Version, k8sPVs,
+	createSpecArray, updateSpecArray := fullSyncGetVolumeSpecs(ctx, vcenter.Client.Version, pvsToSync,
 		volumeToCnsEntityMetadataMap, volumeToK8sEntityMetadataMap, volumeClusterDistributionMap,
 		containerCluster, migrationFeatureStateForFullSync, vc)
 	volToBeDeleted, err := getVolumesToBeDeleted(ctx, queryAllResult.Volumes, k8sPVMap, metadataSyncer,
@@ -422,6 +464,9 @@ func CsiFullSync(ctx context.Context, metadataSyncer *metadataSyncInformer, vc s
 	go fullSyncUpdateVolumes(ctx, updateSpecArray, metadataSyncer, &wg, volManager, vc)
 	go fullSyncDeleteVolumes(ctx, volToBeDeleted, metadataSyncer, &wg, migrationFeatureStateForFullSync, volManager, vc)
 	wg.Wait()
+	if incrementalCycle != nil {
+		incrementalFullSyncStates.endCycle(incrementalCycle, updateSpecArray)
+	}
 
 	cleanupCnsMaps(k8sPVMap, vc)
 	log.Debugf("FullSync for VC %s: cnsDeletionMap at end of cycle: %v", vc, cnsDeletionMap)
@@ -731,6 +776,15 @@ func cleanUpVolumeInfoCrDeletionMap(ctx context.Context, metadataSyncer *metadat
 	for _, pv := range currentK8sPV {
 		if pv.Spec.CSI != nil {
 			currentK8sPVMap[pv.Spec.CSI.VolumeHandle] = true
+		} else if pv.Spec.VsphereVolume != nil && volumeMigrationService != nil {
+			volumeHandle, err := volumeMigrationService.GetVolumeID(ctx,
+				&migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath}, false)
+			if err != nil {
+				log.Errorf("FullSync for VC %s: failed to find volumeHandle for in-tree volume %s. Err: %v",
+					vc, pv.Name, err)
+				continue
+			}
+			currentK8sPVMap[volumeHandle] = true
 		} else {
 			log.Errorf("FullSync for VC %s: failed to find volumeHandle for volume %s", vc, pv.Name)
 		}
@@ -765,6 +819,15 @@ func volumeInfoCRFullSync(ctx context.Context, metadataSyncer *metadataSyncInfor
 	for _, pv := range currentK8sPV {
 		if pv.Spec.CSI != nil {
 			currentK8sPVMap[pv.Spec.CSI.VolumeHandle] = true
+		} else if pv.Spec.VsphereVolume != nil && volumeMigrationService != nil {
+			volumeHandle, err := volumeMigrationService.GetVolumeID(ctx,
+				&migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath}, false)
+			if err != nil {
+				log.Errorf("FullSync for VC %s: failed to find volumeHandle for in-tree volume %s. Err: %v",
+					vc, pv.Name, err)
+				continue
+			}
+			currentK8sPVMap[volumeHandle] = true
 		} else {
 			log.Errorf("FullSync for VC %s: failed to find volumeHandle for volume %s", vc, pv.Name)
 		}
@@ -1035,6 +1098,9 @@ func fullSyncCreateVolumes(ctx context.Context, createSpecArray []cnstypes.CnsVo
 		if pv, existsInK8s := currentK8sPVMap[volumeID]; existsInK8s {
 			log.Debugf("FullSync for VC %s: Calling CreateVolume for volume id: %q with createSpec %+v",
 				vc, volumeID, spew.Sdump(createSpec))
+			if err := fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+				return
+			}
 			_, _, err := volManager.CreateVolume(ctx, &createSpec, nil)
 			if err != nil {
 				log.Warnf("FullSync for VC %s: Failed to create volume with the spec: %+v. "+
@@ -1117,7 +1183,7 @@ func fullSyncDeleteVolumes(ctx context.Context, volumeIDDeleteArray []cnstypes.C
 			"which is not present in k8s and needs to be checked for volume deletion.", vc)
 		return
 	}
-	allQueryResults, err := fullSyncGetQueryResults(ctx, queryVolumeIds, "", volManager, metadataSyncer)
+	allQueryResults, err := fullSyncGetQueryResults(ctx, queryVolumeIds, "", volManager, metadataSyncer, vc)
 	if err != nil {
 		log.Errorf("FullSync for VC %s: fullSyncGetQueryResults failed to query volume metadata from vc. Err: %v", vc, err)
 		return
@@ -1137,6 +1203,9 @@ func fullSyncDeleteVolumes(ctx context.Context, volumeIDDeleteArray []cnstypes.C
 			if !inUsebyOtherK8SCluster {
 				log.Infof("FullSync for VC %s: fullSyncDeleteVolumes: Calling DeleteVolume for volume %v with delete disk %v",
 					vc, volume.VolumeId.Id, deleteDisk)
+				if err := fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+					return
+				}
 				_, err := volManager.DeleteVolume(ctx, volume.VolumeId.Id, deleteDisk)
 				if err != nil {
 					log.Warnf("FullSync for VC %s: fullSyncDeleteVolumes: Failed to delete volume %s with error %+v",
@@ -1181,6 +1250,9 @@ func fullSyncUpdateVolumes(ctx context.Context, updateSpecArray []cnstypes.CnsVo
 	for _, updateSpec := range updateSpecArray {
 		log.Debugf("FullSync for VC %s: Calling UpdateVolumeMetadata for volume %s with updateSpec: %+v",
 			vc, updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
+		if err := fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+			return
+		}
 		if err := volManager.UpdateVolumeMetadata(ctx, &updateSpec); err != nil {
 			log.Warnf("FullSync for VC %s: UpdateVolumeMetadata failed with err %v", vc, err)
 		}
@@ -1281,11 +1353,11 @@ func fullSyncConstructVolumeMaps(ctx context.Context, pvList []*v1.PersistentVol
 	// queryFilter.VolumeIds should be one which is present in both k8s
 	// and in CNS.
 	if len(queryVolumeIds) == 0 {
-		log.Warn("could not find any volume which is present in both k8s and in CNS")
+		log.Info("could not find any volume to query which is present in both k8s and in CNS")
 		return volumeToCnsEntityMetadataMap, volumeToK8sEntityMetadataMap, volumeClusterDistributionMap, nil
 	}
 	allQueryResults, err := fullSyncGetQueryResults(ctx, queryVolumeIds,
-		clusterIDforVolumeMetadata, volManager, metadataSyncer)
+		clusterIDforVolumeMetadata, volManager, metadataSyncer, vc)
 	if err != nil {
 		log.Errorf("FullSync for VC %s: fullSyncGetQueryResults failed to query volume metadata from vc. Err: %v", vc, err)
 		return nil, nil, nil, err
@@ -1636,6 +1708,52 @@ func isUpdateRequired(ctx context.Context, vCenterVersion string, k8sMetadataLis
 	return false
 }
 
+// getOwnedPVs returns the PVs of the given VC whose volume is owned by this
+// syncer replica, and updates the metrics of the shards of the VC. PVs whose
+// volume ID can't be found are owned by the owner of the VC.
+func getOwnedPVs(ctx context.Context, pvList []*v1.PersistentVolume, vc string,
+	migrationFeatureStateForFullSync bool) []*v1.PersistentVolume {
+	log := logger.GetLogger(ctx)
+	shardVolumes := make(map[string]int)
+	var ownedPVs []*v1.PersistentVolume
+	for _, pv := range pvList {
+		volumeHandle := getFullSyncVolumeHandle(ctx, pv, migrationFeatureStateForFullSync)
+		if volumeHandle == "" {
+			if Sharder.OwnsVCenter(vc) {
+				ownedPVs = append(ownedPVs, pv)
+			}
+			continue
+		}
+		if shard := Sharder.VolumeShard(vc, volumeHandle); Sharder.Owns(shard) {
+			shardVolumes[shard]++
+			ownedPVs = append(ownedPVs, pv)
+		}
+	}
+	for _, shard := range Sharder.VolumeShards(vc) {
+		if Sharder.Owns(shard) {
+			prometheus.SyncerShardOwnedGaugeVec.WithLabelValues(shard).Set(1)
+			prometheus.SyncerShardVolumesGaugeVec.WithLabelValues(shard).Set(float64(shardVolumes[shard]))
+		} else {
+			prometheus.SyncerShardOwnedGaugeVec.WithLabelValues(shard).Set(0)
+			prometheus.SyncerShardVolumesGaugeVec.DeleteLabelValues(shard)
+		}
+	}
+	log.Infof("FullSync for VC %s: syncing %d of %d PVs owned by this syncer replica", vc, len(ownedPVs), len(pvList))
+	return ownedPVs
+}
+
+// getOwnedCnsVolumes returns the CNS volumes of the given VC owned by this
+// syncer replica.
+func getOwnedCnsVolumes(cnsVolumeList []cnstypes.CnsVolume, vc string) []cnstypes.CnsVolume {
+	var ownedVolumes []cnstypes.CnsVolume
+	for _, vol := range cnsVolumeList {
+		if Sharder.OwnsVolume(vc, vol.VolumeId.Id) {
+			ownedVolumes = append(ownedVolumes, vol)
+		}
+	}
+	return ownedVolumes
+}
+
 // cleanupCnsMaps performs cleanup on cnsCreationMap and cnsDeletionMap.
 // Removes volume entries from cnsCreationMap that do not exist in K8s
 // and volume entries from cnsDeletionMap that exist in K8s.
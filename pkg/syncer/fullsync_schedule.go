@@ -0,0 +1,456 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	// Quiet windows may use any IANA time zone, whether the syncer image has
+	// the time zone database or not.
+	_ "time/tzdata"
+
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/common/commonco"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	csifullsyncconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/csifullsyncconfig/v1alpha1"
+	triggercsifullsyncv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/triggercsifullsync/v1alpha1"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/sharding"
+)
+
+// fullSyncVCenterState is the schedule and the state of the full syncs of a VC.
+type fullSyncVCenterState struct {
+	schedule csifullsyncconfigv1alpha1.FullSyncSchedule
+	// cnsCallLimiter limits the CNS calls of full sync to the budget of the
+	// schedule. It is nil if the CNS calls are not limited.
+	cnsCallLimiter flowcontrol.RateLimiter
+	inQuietWindow  bool
+	inProgress     bool
+	lastRunStart   time.Time
+	lastRunEnd     time.Time
+	lastRunErr     error
+}
+
+// fullSyncScheduler runs the full sync of each VC following its schedule in
+// the CsiFullSyncConfig.
+type fullSyncScheduler struct {
+	lock sync.Mutex
+	// vCenters maps each VC to the schedule and the state of its full syncs.
+	vCenters map[string]*fullSyncVCenterState
+}
+
+// fullSyncSchedules holds the schedule of each VC, whose scope and CNS calls
+// budget are honoured by CsiFullSync. It is only populated when the
+// FullSyncSchedule feature is enabled.
+var fullSyncSchedules = &fullSyncScheduler{
+	vCenters: make(map[string]*fullSyncVCenterState),
+}
+
+// getVCenterState returns the state of the given VC, creating it if needed.
+// The lock must be held by the caller.
+func (s *fullSyncScheduler) getVCenterState(vc string) *fullSyncVCenterState {
+	state, ok := s.vCenters[vc]
+	if !ok {
+		state = &fullSyncVCenterState{}
+		s.vCenters[vc] = state
+	}
+	return state
+}
+
+// setSchedule sets the schedule of the given VC. The CNS calls limiter of the
+// VC is replaced when its budget changes.
+func (s *fullSyncScheduler) setSchedule(vc string, schedule csifullsyncconfigv1alpha1.FullSyncSchedule) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.getVCenterState(vc)
+	if schedule.MaxCnsCallsPerMinute != state.schedule.MaxCnsCallsPerMinute {
+		state.cnsCallLimiter = nil
+		if schedule.MaxCnsCallsPerMinute > 0 {
+			state.cnsCallLimiter = flowcontrol.NewTokenBucketRateLimiter(
+				float32(schedule.MaxCnsCallsPerMinute)/60, 1)
+		}
+	}
+	state.schedule = schedule
+}
+
+// getSchedule returns the schedule of the given VC.
+func (s *fullSyncScheduler) getSchedule(vc string) csifullsyncconfigv1alpha1.FullSyncSchedule {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if state, ok := s.vCenters[vc]; ok {
+		return state.schedule
+	}
+	return csifullsyncconfigv1alpha1.FullSyncSchedule{}
+}
+
+// waitForCnsCallBudget blocks until the full sync of the given VC can make a
+// CNS call within the CNS calls budget of the VC.
+func (s *fullSyncScheduler) waitForCnsCallBudget(ctx context.Context, vc string) error {
+	log := logger.GetLogger(ctx)
+	s.lock.Lock()
+	var limiter flowcontrol.RateLimiter
+	if state, ok := s.vCenters[vc]; ok {
+		limiter = state.cnsCallLimiter
+	}
+	s.lock.Unlock()
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return logger.LogNewErrorf(log, "FullSync for VC %s: failed to wait for the CNS calls budget. Err: %v",
+			vc, err)
+	}
+	return nil
+}
+
+// startIfDue returns true and marks the full sync of the given VC as started
+// at the given time, if its interval elapsed since the start of its previous
+// full sync, it is not in progress and the VC is not in a quiet window.
+func (s *fullSyncScheduler) startIfDue(ctx context.Context, vc string, now time.Time,
+	defaultInterval time.Duration) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.getVCenterState(vc)
+	state.inQuietWindow = isInQuietWindow(ctx, state.schedule.QuietWindows, now)
+	if state.inProgress || state.inQuietWindow || now.Before(state.nextRun(defaultInterval)) {
+		return false
+	}
+	state.inProgress = true
+	state.lastRunStart = now
+	return true
+}
+
+// end marks the full sync of the given VC as ended with the given error.
+func (s *fullSyncScheduler) end(vc string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := s.getVCenterState(vc)
+	state.inProgress = false
+	state.lastRunEnd = time.Now()
+	state.lastRunErr = err
+}
+
+// interval returns the interval between two full syncs of the VC.
+func (state *fullSyncVCenterState) interval(defaultInterval time.Duration) time.Duration {
+	if state.schedule.IntervalMinutes > 0 {
+		return time.Duration(state.schedule.IntervalMinutes) * time.Minute
+	}
+	return defaultInterval
+}
+
+// nextRun returns the earliest time the next full sync of the VC is started.
+func (state *fullSyncVCenterState) nextRun(defaultInterval time.Duration) time.Time {
+	if state.lastRunStart.IsZero() {
+		return state.lastRunStart
+	}
+	return state.lastRunStart.Add(state.interval(defaultInterval))
+}
+
+// schedule starts the full syncs of the given VCs which are due, with their
+// schedule from the given CsiFullSyncConfig. If the CsiFullSyncConfig is nil,
+// the VCs use the default schedule.
+func (s *fullSyncScheduler) schedule(ctx context.Context, metadataSyncer *metadataSyncInformer,
+	fullSyncConfig *csifullsyncconfigv1alpha1.CsiFullSyncConfig, vcs []string, defaultInterval time.Duration) {
+	log := logger.GetLogger(ctx)
+	now := time.Now()
+	var dueVCs []string
+	for _, vc := range vcs {
+		var schedule csifullsyncconfigv1alpha1.FullSyncSchedule
+		if fullSyncConfig != nil {
+			schedule = fullSyncConfig.GetSchedule(vc)
+		}
+		s.setSchedule(vc, schedule)
+		if s.startIfDue(ctx, vc, now, defaultInterval) {
+			dueVCs = append(dueVCs, vc)
+		}
+	}
+	if len(dueVCs) == 0 {
+		return
+	}
+	if len(metadataSyncer.configInfo.Cfg.VirtualCenter) > 1 && Sharder.Owns(sharding.ClusterShard) &&
+		commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx, common.TopologyAwareFileVolume) {
+		createMissingFileVolumeInfoCrs(ctx, metadataSyncer)
+	}
+	for _, vc := range dueVCs {
+		log.Infof("fullSync is triggered for VC %s", vc)
+		go func() {
+			err := CsiFullSync(ctx, metadataSyncer, vc)
+			if err != nil {
+				log.Infof("CSI full sync failed with error: %+v for VC %s", err, vc)
+			}
+			s.end(vc, err)
+		}()
+	}
+}
+
+// getStatus returns the full sync schedule and status of the given VCs.
+func (s *fullSyncScheduler) getStatus(vcs []string,
+	defaultInterval time.Duration) []triggercsifullsyncv1alpha1.VCenterFullSyncStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var statuses []triggercsifullsyncv1alpha1.VCenterFullSyncStatus
+	for _, vc := range vcs {
+		state := s.getVCenterState(vc)
+		status := triggercsifullsyncv1alpha1.VCenterFullSyncStatus{
+			Host:                  vc,
+			IntervalMinutes:       int(state.interval(defaultInterval) / time.Minute),
+			IncludeNamespaces:     state.schedule.IncludeNamespaces,
+			ExcludeNamespaces:     state.schedule.ExcludeNamespaces,
+			MaxCnsCallsPerMinute:  state.schedule.MaxCnsCallsPerMinute,
+			InQuietWindow:         state.inQuietWindow,
+			InProgress:            state.inProgress,
+			LastRunStartTimeStamp: getStatusTime(state.lastRunStart),
+			LastRunEndTimeStamp:   getStatusTime(state.lastRunEnd),
+			NextRunTimeStamp:      getStatusTime(state.nextRun(defaultInterval)),
+		}
+		if state.lastRunErr != nil {
+			status.Error = state.lastRunErr.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// getStatusTime returns the given time with the precision of the status, or
+// nil if it is not set.
+func getStatusTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	statusTime := metav1.NewTime(t.Truncate(time.Second))
+	return &statusTime
+}
+
+// updateStatus sets the full sync schedule and status of the given VCs in the
+// TriggerCsiFullSync instance, if they changed.
+func (s *fullSyncScheduler) updateStatus(ctx context.Context, cnsOperatorClient client.Client, vcs []string,
+	defaultInterval time.Duration) {
+	log := logger.GetLogger(ctx)
+	instance := &triggercsifullsyncv1alpha1.TriggerCsiFullSync{}
+	key := k8stypes.NamespacedName{Namespace: "", Name: common.TriggerCsiFullSyncCRName}
+	if err := cnsOperatorClient.Get(ctx, key, instance); err != nil {
+		log.Warnf("Unable to get the trigger full sync instance. Err: %+v", err)
+		return
+	}
+	// The status is updated by the TriggerCsiFullSync controller while an on
+	// demand full sync is in progress, so it is left untouched until it ends.
+	if instance.Status.InProgress {
+		return
+	}
+	statuses := s.getStatus(vcs, defaultInterval)
+	if equality.Semantic.DeepEqual(instance.Status.VCenters, statuses) {
+		return
+	}
+	instance.Status.VCenters = statuses
+	if err := cnsOperatorClient.Update(ctx, instance); err != nil {
+		log.Warnf("Failed to update the full sync schedule status of TriggerCsiFullSync instance %q. Err: %+v",
+			common.TriggerCsiFullSyncCRName, err)
+	}
+}
+
+// runFullSyncSchedule runs the full syncs of the VCs following their schedule
+// in the CsiFullSyncConfig. Every fullSyncScheduleInterval, the full sync of
+// a VC is started if its interval elapsed since the start of its previous full
+// sync and it is not in a quiet window. Full syncs in progress are not stopped
+// when a quiet window starts.
+func runFullSyncSchedule(metadataSyncer *metadataSyncInformer, cnsOperatorClient client.Client) {
+	ctx, log := logger.GetNewContextWithLogger()
+	defaultInterval := time.Duration(getFullSyncIntervalInMin(ctx)) * time.Minute
+	ticker := time.NewTicker(fullSyncScheduleInterval)
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		ctx, log = logger.GetNewContextWithLogger()
+		// If the full sync config can't be read, the VCs use the default
+		// schedule, so that full sync is never stopped by a missing config.
+		fullSyncConfig, err := getCsiFullSyncConfig(ctx, cnsOperatorClient)
+		if err != nil {
+			log.Warnf("Unable to get the full sync config. Using the default full sync schedule. Err: %+v", err)
+		}
+		vcs, err := getFullSyncVCenters(ctx, metadataSyncer)
+		if err != nil {
+			log.Errorf("Failed to get all virtual configs for CSI full sync. Error: %+v", err)
+			continue
+		}
+		fullSyncSchedules.schedule(ctx, metadataSyncer, fullSyncConfig, vcs, defaultInterval)
+		// The status is only updated by the syncer replica owning the cluster
+		// shard, so that the replicas do not overwrite each other.
+		if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.TriggerCsiFullSync) &&
+			Sharder.Owns(sharding.ClusterShard) {
+			fullSyncSchedules.updateStatus(ctx, cnsOperatorClient, vcs, defaultInterval)
+		}
+	}
+}
+
+// getCsiFullSyncConfig returns the CsiFullSyncConfig instance, or nil if it
+// does not exist.
+func getCsiFullSyncConfig(ctx context.Context,
+	cnsOperatorClient client.Client) (*csifullsyncconfigv1alpha1.CsiFullSyncConfig, error) {
+	fullSyncConfig := &csifullsyncconfigv1alpha1.CsiFullSyncConfig{}
+	key := k8stypes.NamespacedName{Namespace: "", Name: csifullsyncconfigv1alpha1.CsiFullSyncConfigCRName}
+	if err := cnsOperatorClient.Get(ctx, key, fullSyncConfig); err != nil {
+		// The CRD is created by the syncer leader, which may not have
+		// created it yet.
+		if apierrors.IsNotFound(err) || apiMeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return fullSyncConfig, nil
+}
+
+// getFullSyncVCenters returns the hosts of the VCs to run full sync for.
+func getFullSyncVCenters(ctx context.Context, metadataSyncer *metadataSyncInformer) ([]string, error) {
+	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorWorkload ||
+		len(metadataSyncer.configInfo.Cfg.VirtualCenter) == 1 {
+		return []string{metadataSyncer.configInfo.Cfg.Global.VCenterIP}, nil
+	}
+	vcconfigs, err := cnsvsphere.GetVirtualCenterConfigs(ctx, metadataSyncer.configInfo.Cfg)
+	if err != nil {
+		return nil, err
+	}
+	vcs := make([]string, 0, len(vcconfigs))
+	for _, vcconfig := range vcconfigs {
+		vcs = append(vcs, vcconfig.Host)
+	}
+	return vcs, nil
+}
+
+// isInQuietWindow returns true if the given time is in one of the given quiet
+// windows. Invalid quiet windows are ignored.
+func isInQuietWindow(ctx context.Context, quietWindows []csifullsyncconfigv1alpha1.QuietWindow,
+	now time.Time) bool {
+	log := logger.GetLogger(ctx)
+	for _, quietWindow := range quietWindows {
+		inQuietWindow, err := quietWindowContains(quietWindow, now)
+		if err != nil {
+			log.Warnf("FullSync: ignoring invalid quiet window %+v. Err: %v", quietWindow, err)
+			continue
+		}
+		if inQuietWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// quietWindowContains returns true if the given time is in the quiet window.
+func quietWindowContains(quietWindow csifullsyncconfigv1alpha1.QuietWindow, now time.Time) (bool, error) {
+	location := time.UTC
+	if quietWindow.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(quietWindow.TimeZone)
+		if err != nil {
+			return false, err
+		}
+	}
+	start, err := time.Parse("15:04", quietWindow.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := time.Parse("15:04", quietWindow.End)
+	if err != nil {
+		return false, err
+	}
+	now = now.In(location)
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	minute := now.Hour()*60 + now.Minute()
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute && quietWindowStartsOn(quietWindow, now.Weekday()), nil
+	}
+	// The window spans midnight, so it started either today or yesterday.
+	if minute >= startMinute {
+		return quietWindowStartsOn(quietWindow, now.Weekday()), nil
+	}
+	return minute < endMinute && quietWindowStartsOn(quietWindow, (now.Weekday()+6)%7), nil
+}
+
+// quietWindowStartsOn returns true if the quiet window starts on the given
+// day of the week.
+func quietWindowStartsOn(quietWindow csifullsyncconfigv1alpha1.QuietWindow, day time.Weekday) bool {
+	if len(quietWindow.Days) == 0 {
+		return true
+	}
+	for _, quietWindowDay := range quietWindow.Days {
+		if strings.EqualFold(quietWindowDay, day.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamespaceInFullSyncScope returns true if the volumes of the PVCs in the
+// given namespace are synced by full sync with the given schedule.
+func isNamespaceInFullSyncScope(schedule csifullsyncconfigv1alpha1.FullSyncSchedule, namespace string) bool {
+	if slices.Contains(schedule.ExcludeNamespaces, namespace) {
+		return false
+	}
+	return len(schedule.IncludeNamespaces) == 0 || slices.Contains(schedule.IncludeNamespaces, namespace)
+}
+
+// getPVsInFullSyncScope returns the PVs whose volumes are created and updated
+// by full sync with the given schedule, and the volume IDs of the other PVs.
+// PVs which are not bound to a PVC are only synced when full sync is not
+// restricted to some namespaces. The scope does not apply to the deletion of
+// volumes, which is checked against all the PVs.
+func getPVsInFullSyncScope(ctx context.Context, pvList []*v1.PersistentVolume,
+	schedule csifullsyncconfigv1alpha1.FullSyncSchedule,
+	migrationFeatureStateForFullSync bool) ([]*v1.PersistentVolume, map[string]bool) {
+	if len(schedule.IncludeNamespaces) == 0 && len(schedule.ExcludeNamespaces) == 0 {
+		return pvList, nil
+	}
+	var pvsInScope []*v1.PersistentVolume
+	outOfScopeVolumes := make(map[string]bool)
+	for _, pv := range pvList {
+		var namespace string
+		if pv.Spec.ClaimRef != nil {
+			namespace = pv.Spec.ClaimRef.Namespace
+		}
+		if isNamespaceInFullSyncScope(schedule, namespace) {
+			pvsInScope = append(pvsInScope, pv)
+		} else if volumeHandle := getFullSyncVolumeHandle(ctx, pv, migrationFeatureStateForFullSync); volumeHandle != "" {
+			outOfScopeVolumes[volumeHandle] = true
+		}
+	}
+	return pvsInScope, outOfScopeVolumes
+}
+
+// getCnsVolumesInFullSyncScope returns the CNS volumes which are not in the
+// given out of scope volumes.
+func getCnsVolumesInFullSyncScope(cnsVolumeList []cnstypes.CnsVolume,
+	outOfScopeVolumes map[string]bool) []cnstypes.CnsVolume {
+	var volumesInScope []cnstypes.CnsVolume
+	for _, vol := range cnsVolumeList {
+		if !outOfScopeVolumes[vol.VolumeId.Id] {
+			volumesInScope = append(volumesInScope, vol)
+		}
+	}
+	return volumesInScope
+}
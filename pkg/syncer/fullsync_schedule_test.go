@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	csifullsyncconfigv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/cnsoperator/csifullsyncconfig/v1alpha1"
+)
+
+func TestQuietWindowContains(t *testing.T) {
+	// 2026-10-19 is a Monday.
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.October, 19, hour, minute, 0, 0, time.UTC)
+	}
+	businessHours := csifullsyncconfigv1alpha1.QuietWindow{
+		Start: "08:00",
+		End:   "18:00",
+		Days:  []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+	}
+	overnight := csifullsyncconfigv1alpha1.QuietWindow{
+		Start: "22:00",
+		End:   "02:00",
+		Days:  []string{"Sunday"},
+	}
+	losAngeles := csifullsyncconfigv1alpha1.QuietWindow{
+		Start:    "09:00",
+		End:      "17:00",
+		TimeZone: "America/Los_Angeles",
+	}
+	tests := []struct {
+		name        string
+		quietWindow csifullsyncconfigv1alpha1.QuietWindow
+		now         time.Time
+		expected    bool
+	}{
+		{"business hours start", businessHours, at(8, 0), true},
+		{"business hours end", businessHours, at(18, 0), false},
+		{"before business hours", businessHours, at(7, 59), false},
+		{"business hours on weekend", businessHours, at(12, 0).AddDate(0, 0, -1), false},
+		{"overnight after midnight", overnight, at(1, 30), true},
+		{"overnight end", overnight, at(2, 0), false},
+		{"overnight before midnight on another day", overnight, at(23, 0), false},
+		{"overnight before midnight", overnight, at(23, 0).AddDate(0, 0, -1), true},
+		{"time zone", losAngeles, at(17, 0), true},
+		{"time zone before start", losAngeles, at(15, 59), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inQuietWindow, err := quietWindowContains(test.quietWindow, test.now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, inQuietWindow)
+		})
+	}
+
+	_, err := quietWindowContains(csifullsyncconfigv1alpha1.QuietWindow{Start: "8h", End: "18:00"}, at(9, 0))
+	assert.Error(t, err)
+	_, err = quietWindowContains(csifullsyncconfigv1alpha1.QuietWindow{Start: "08:00", End: "18:00",
+		TimeZone: "Invalid/Zone"}, at(9, 0))
+	assert.Error(t, err)
+	// Invalid quiet windows are ignored.
+	assert.False(t, isInQuietWindow(context.TODO(), []csifullsyncconfigv1alpha1.QuietWindow{
+		{Start: "8h", End: "18:00"}}, at(9, 0)))
+}
+
+func TestFullSyncScheduler(t *testing.T) {
+	ctx := context.TODO()
+	s := &fullSyncScheduler{vCenters: make(map[string]*fullSyncVCenterState)}
+	fullSyncConfig := &csifullsyncconfigv1alpha1.CsiFullSyncConfig{
+		Spec: csifullsyncconfigv1alpha1.CsiFullSyncConfigSpec{
+			Default: csifullsyncconfigv1alpha1.FullSyncSchedule{IntervalMinutes: 60},
+			VCenters: []csifullsyncconfigv1alpha1.VCenterFullSyncSchedule{{
+				Host: "vc-2",
+				FullSyncSchedule: csifullsyncconfigv1alpha1.FullSyncSchedule{
+					QuietWindows:         []csifullsyncconfigv1alpha1.QuietWindow{{Start: "08:00", End: "18:00"}},
+					MaxCnsCallsPerMinute: 120,
+				},
+			}},
+		},
+	}
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	s.setSchedule("vc-1", fullSyncConfig.GetSchedule("vc-1"))
+	s.setSchedule("vc-2", fullSyncConfig.GetSchedule("vc-2"))
+	assert.Equal(t, 60, s.getSchedule("vc-1").IntervalMinutes)
+	assert.Nil(t, s.vCenters["vc-1"].cnsCallLimiter)
+	assert.NotNil(t, s.vCenters["vc-2"].cnsCallLimiter)
+	assert.Equal(t, float32(2), s.vCenters["vc-2"].cnsCallLimiter.QPS())
+	assert.NoError(t, s.waitForCnsCallBudget(ctx, "vc-2"))
+	assert.NoError(t, s.waitForCnsCallBudget(ctx, "vc-3"))
+
+	// The first full sync is started unless the VC is in a quiet window.
+	assert.True(t, s.startIfDue(ctx, "vc-1", now, 30*time.Minute))
+	assert.False(t, s.startIfDue(ctx, "vc-2", now, 30*time.Minute))
+	assert.True(t, s.vCenters["vc-2"].inQuietWindow)
+	// A full sync in progress is not started again.
+	assert.False(t, s.startIfDue(ctx, "vc-1", now.Add(time.Hour), 30*time.Minute))
+	s.end("vc-1", errors.New("failed"))
+
+	statuses := s.getStatus([]string{"vc-1", "vc-2"}, 30*time.Minute)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, 60, statuses[0].IntervalMinutes)
+	assert.Equal(t, metav1.NewTime(now.Add(time.Hour)), *statuses[0].NextRunTimeStamp)
+	assert.Equal(t, "failed", statuses[0].Error)
+	assert.Equal(t, 30, statuses[1].IntervalMinutes)
+	assert.True(t, statuses[1].InQuietWindow)
+	assert.Nil(t, statuses[1].LastRunStartTimeStamp)
+
+	// The next full sync is started once the interval of the VC elapsed.
+	assert.False(t, s.startIfDue(ctx, "vc-1", now.Add(59*time.Minute), 30*time.Minute))
+	assert.True(t, s.startIfDue(ctx, "vc-1", now.Add(time.Hour), 30*time.Minute))
+	assert.True(t, s.startIfDue(ctx, "vc-2", now.Add(6*time.Hour), 30*time.Minute))
+
+	// Removing the budget removes the limiter.
+	s.setSchedule("vc-2", csifullsyncconfigv1alpha1.FullSyncSchedule{})
+	assert.Nil(t, s.vCenters["vc-2"].cnsCallLimiter)
+}
+
+func TestGetPVsInFullSyncScope(t *testing.T) {
+	ctx := context.TODO()
+	newPV := func(volumeHandle, namespace string) *v1.PersistentVolume {
+		pv := &v1.PersistentVolume{
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeSource: v1.PersistentVolumeSource{
+					CSI: &v1.CSIPersistentVolumeSource{VolumeHandle: volumeHandle},
+				},
+			},
+		}
+		if namespace != "" {
+			pv.Spec.ClaimRef = &v1.ObjectReference{Namespace: namespace}
+		}
+		return pv
+	}
+	pvList := []*v1.PersistentVolume{newPV("vol-1", "ns-1"), newPV("vol-2", "ns-2"), newPV("vol-3", "")}
+
+	pvsInScope, outOfScopeVolumes := getPVsInFullSyncScope(ctx, pvList,
+		csifullsyncconfigv1alpha1.FullSyncSchedule{}, false)
+	assert.Equal(t, pvList, pvsInScope)
+	assert.Empty(t, outOfScopeVolumes)
+
+	pvsInScope, outOfScopeVolumes = getPVsInFullSyncScope(ctx, pvList,
+		csifullsyncconfigv1alpha1.FullSyncSchedule{ExcludeNamespaces: []string{"ns-2"}}, false)
+	assert.Equal(t, []*v1.PersistentVolume{pvList[0], pvList[2]}, pvsInScope)
+	assert.Equal(t, map[string]bool{"vol-2": true}, outOfScopeVolumes)
+
+	pvsInScope, outOfScopeVolumes = getPVsInFullSyncScope(ctx, pvList,
+		csifullsyncconfigv1alpha1.FullSyncSchedule{IncludeNamespaces: []string{"ns-1", "ns-2"},
+			ExcludeNamespaces: []string{"ns-2"}}, false)
+	assert.Equal(t, []*v1.PersistentVolume{pvList[0]}, pvsInScope)
+	assert.Equal(t, map[string]bool{"vol-2": true, "vol-3": true}, outOfScopeVolumes)
+
+	cnsVolumes := []cnstypes.CnsVolume{
+		{VolumeId: cnstypes.CnsVolumeId{Id: "vol-1"}},
+		{VolumeId: cnstypes.CnsVolumeId{Id: "vol-2"}},
+		{VolumeId: cnstypes.CnsVolumeId{Id: "vol-orphan"}},
+	}
+	assert.Equal(t, []cnstypes.CnsVolume{cnsVolumes[0], cnsVolumes[2]},
+		getCnsVolumesInFullSyncScope(cnsVolumes, outOfScopeVolumes))
+}
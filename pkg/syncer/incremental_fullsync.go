@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/apis/migration"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+// fullSyncVolumeState is the state of the K8s objects of a volume found in
+// sync with CNS by a full sync.
+type fullSyncVolumeState struct {
+	pvResourceVersion  string
+	pvcResourceVersion string
+	// metadataHash is the hash of the entity metadata of the PV, PVC and pods
+	// of the volume, as synced to CNS.
+	metadataHash string
+}
+
+// fullSyncCycle is a full sync of the volumes of a VC. In an incremental
+// cycle, the metadata of a volume is only queried from CNS when its K8s
+// objects changed since the last full sync which found it in sync with CNS,
+// or when it falls in the audit window of the cycle.
+type fullSyncCycle struct {
+	vc string
+	// complete is true when the metadata of all the volumes is queried.
+	complete bool
+	// auditWindow is the window of the volumes queried in this cycle whether
+	// their K8s objects changed or not.
+	auditWindow uint32
+	// auditWindows is the number of audit windows the volumes are spread in.
+	auditWindows uint32
+	// queried holds the state of the volumes whose metadata is queried.
+	queried map[string]fullSyncVolumeState
+	// skipped holds the state of the volumes whose metadata is not queried.
+	skipped map[string]fullSyncVolumeState
+}
+
+// fullSyncVolumeStates holds the state of the volumes found in sync with CNS
+// by the previous full syncs of each VC.
+type fullSyncVolumeStates struct {
+	lock sync.Mutex
+	// volumes maps each VC to the state of its volumes by volume ID.
+	volumes map[string]map[string]fullSyncVolumeState
+	// cycles maps each VC to the number of full syncs run for it.
+	cycles map[string]uint32
+}
+
+// incrementalFullSyncStates is used by CsiFullSync when the
+// IncrementalFullSync feature is enabled.
+var incrementalFullSyncStates = &fullSyncVolumeStates{
+	volumes: make(map[string]map[string]fullSyncVolumeState),
+	cycles:  make(map[string]uint32),
+}
+
+// startCycle starts a full sync of the volumes of the given VC. Every
+// completeFullSyncInterval cycles, starting with the first one, is a complete
+// full sync. The volumes are spread in as many audit windows, so that each
+// volume is queried at least once every completeFullSyncInterval cycles.
+func (s *fullSyncVolumeStates) startCycle(ctx context.Context, vc string) *fullSyncCycle {
+	log := logger.GetLogger(ctx)
+	interval := uint32(getCompleteFullSyncInterval(ctx))
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cycle := s.cycles[vc]
+	s.cycles[vc] = cycle + 1
+	fullSyncCycle := &fullSyncCycle{
+		vc:           vc,
+		complete:     cycle%interval == 0 || s.volumes[vc] == nil,
+		auditWindow:  cycle % interval,
+		auditWindows: interval,
+		queried:      make(map[string]fullSyncVolumeState),
+		skipped:      make(map[string]fullSyncVolumeState),
+	}
+	if fullSyncCycle.complete {
+		log.Infof("FullSync for VC %s: querying the metadata of all the volumes", vc)
+	} else {
+		log.Infof("FullSync for VC %s: querying the metadata of the volumes changed since the last full sync "+
+			"and of audit window %d/%d", vc, fullSyncCycle.auditWindow, interval)
+	}
+	return fullSyncCycle
+}
+
+// getPVsToSync returns the PVs whose metadata is queried from CNS in the
+// cycle. PVs of volumes not in CNS are always returned, as they are created
+// by the full sync.
+func (s *fullSyncVolumeStates) getPVsToSync(ctx context.Context, cycle *fullSyncCycle,
+	pvList []*v1.PersistentVolume, cnsVolumeList []cnstypes.CnsVolume, pvToPVCMap pvcMap, pvcToPodMap podMap,
+	migrationFeatureStateForFullSync bool) []*v1.PersistentVolume {
+	log := logger.GetLogger(ctx)
+	cnsVolumeMap := make(map[string]bool, len(cnsVolumeList))
+	for _, vol := range cnsVolumeList {
+		cnsVolumeMap[vol.VolumeId.Id] = true
+	}
+	s.lock.Lock()
+	previousStates := s.volumes[cycle.vc]
+	s.lock.Unlock()
+
+	var pvsToSync []*v1.PersistentVolume
+	for _, pv := range pvList {
+		volumeHandle := getFullSyncVolumeHandle(ctx, pv, migrationFeatureStateForFullSync)
+		if volumeHandle == "" || !cnsVolumeMap[volumeHandle] {
+			pvsToSync = append(pvsToSync, pv)
+			continue
+		}
+		state := fullSyncVolumeState{
+			pvResourceVersion: pv.ResourceVersion,
+			metadataHash: getMetadataHash(buildCnsMetadataList(ctx, pv, pvToPVCMap, pvcToPodMap,
+				clusterIDforVolumeMetadata, cycle.vc)),
+		}
+		if pvc, ok := pvToPVCMap[pv.Name]; ok {
+			state.pvcResourceVersion = pvc.ResourceVersion
+		}
+		previousState, ok := previousStates[volumeHandle]
+		if !cycle.complete && ok && previousState == state &&
+			getAuditWindow(volumeHandle, cycle.auditWindows) != cycle.auditWindow {
+			cycle.skipped[volumeHandle] = state
+			continue
+		}
+		cycle.queried[volumeHandle] = state
+		pvsToSync = append(pvsToSync, pv)
+	}
+	log.Infof("FullSync for VC %s: querying the metadata of %d volumes, skipping %d unchanged volumes",
+		cycle.vc, len(cycle.queried), len(cycle.skipped))
+	return pvsToSync
+}
+
+// endCycle records the state of the volumes found in sync with CNS in the
+// cycle, which are the volumes not queried and the queried volumes which
+// did not need an update. The updated volumes are queried again by the next
+// cycle, to verify the update.
+func (s *fullSyncVolumeStates) endCycle(cycle *fullSyncCycle,
+	updateSpecArray []cnstypes.CnsVolumeMetadataUpdateSpec) {
+	updated := make(map[string]bool, len(updateSpecArray))
+	for _, updateSpec := range updateSpecArray {
+		updated[updateSpec.VolumeId.Id] = true
+	}
+	states := make(map[string]fullSyncVolumeState, len(cycle.queried)+len(cycle.skipped))
+	for volumeHandle, state := range cycle.skipped {
+		states[volumeHandle] = state
+	}
+	for volumeHandle, state := range cycle.queried {
+		if !updated[volumeHandle] {
+			states[volumeHandle] = state
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.volumes[cycle.vc] = states
+}
+
+// getFullSyncVolumeHandle returns the volume ID of the given PV, or an empty
+// string if it can't be found.
+func getFullSyncVolumeHandle(ctx context.Context, pv *v1.PersistentVolume,
+	migrationFeatureStateForFullSync bool) string {
+	log := logger.GetLogger(ctx)
+	if pv.Spec.CSI != nil {
+		return pv.Spec.CSI.VolumeHandle
+	}
+	if migrationFeatureStateForFullSync && pv.Spec.VsphereVolume != nil {
+		migrationVolumeSpec := &migration.VolumeSpec{
+			VolumePath:        pv.Spec.VsphereVolume.VolumePath,
+			StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
+		volumeHandle, err := volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
+		if err != nil {
+			log.Warnf("FullSync: Failed to get VolumeID from volumeMigrationService for spec: %v. Err: %+v",
+				migrationVolumeSpec, err)
+			return ""
+		}
+		return volumeHandle
+	}
+	return ""
+}
+
+// getMetadataHash returns a hash of the given entity metadata, independent
+// of the order of the entities and of their labels.
+func getMetadataHash(metadataList []cnstypes.BaseCnsEntityMetadata) string {
+	entities := make([]string, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		entity, ok := metadata.(*cnstypes.CnsKubernetesEntityMetadata)
+		if !ok {
+			continue
+		}
+		labels := make([]string, 0, len(entity.Labels))
+		for _, label := range entity.Labels {
+			labels = append(labels, label.Key+"="+label.Value)
+		}
+		sort.Strings(labels)
+		references := make([]string, 0, len(entity.ReferredEntity))
+		for _, reference := range entity.ReferredEntity {
+			references = append(references, reference.EntityType+"/"+reference.Namespace+"/"+
+				reference.EntityName+"/"+reference.ClusterID)
+		}
+		sort.Strings(references)
+		entities = append(entities, fmt.Sprintf("%s/%s/%s/%s %q %q", entity.EntityType, entity.Namespace,
+			entity.EntityName, entity.ClusterID, labels, references))
+	}
+	sort.Strings(entities)
+	hash := fnv.New64a()
+	for _, entity := range entities {
+		_, _ = hash.Write([]byte(entity))
+		_, _ = hash.Write([]byte{0})
+	}
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// getAuditWindow returns the audit window of the given volume.
+func getAuditWindow(volumeHandle string, auditWindows uint32) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(volumeHandle))
+	return hash.Sum32() % auditWindows
+}
+
+// getCompleteFullSyncInterval returns the number of full sync cycles between
+// two complete full syncs, when the IncrementalFullSync feature is enabled.
+// If environment variable COMPLETE_FULL_SYNC_INTERVAL_CYCLES is set and
+// valid, return the value read from environment variable. Otherwise, use the
+// default value.
+func getCompleteFullSyncInterval(ctx context.Context) int {
+	log := logger.GetLogger(ctx)
+	interval := defaultCompleteFullSyncIntervalCycles
+	if v := os.Getenv("COMPLETE_FULL_SYNC_INTERVAL_CYCLES"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil && value > 0 {
+			interval = value
+		} else {
+			log.Warnf("FullSync: complete full sync interval set in env variable "+
+				"COMPLETE_FULL_SYNC_INTERVAL_CYCLES %s is invalid, will use the default interval", v)
+		}
+	}
+	return interval
+}
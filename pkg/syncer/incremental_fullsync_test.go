@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cnstypes "github.com/vmware/govmomi/cns/types"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetMetadataHashIgnoresOrder(t *testing.T) {
+	pvMetadata := &cnstypes.CnsKubernetesEntityMetadata{
+		CnsEntityMetadata: cnstypes.CnsEntityMetadata{
+			EntityName: "pv-1",
+			Labels:     []vimtypes.KeyValue{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+		},
+		EntityType: string(cnstypes.CnsKubernetesEntityTypePV),
+	}
+	pvcMetadata := &cnstypes.CnsKubernetesEntityMetadata{
+		CnsEntityMetadata: cnstypes.CnsEntityMetadata{EntityName: "pvc-1"},
+		EntityType:        string(cnstypes.CnsKubernetesEntityTypePVC),
+		Namespace:         "ns-1",
+	}
+	reordered := &cnstypes.CnsKubernetesEntityMetadata{
+		CnsEntityMetadata: cnstypes.CnsEntityMetadata{
+			EntityName: "pv-1",
+			Labels:     []vimtypes.KeyValue{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}},
+		},
+		EntityType: string(cnstypes.CnsKubernetesEntityTypePV),
+	}
+	relabeled := &cnstypes.CnsKubernetesEntityMetadata{
+		CnsEntityMetadata: cnstypes.CnsEntityMetadata{
+			EntityName: "pv-1",
+			Labels:     []vimtypes.KeyValue{{Key: "a", Value: "1"}, {Key: "b", Value: "3"}},
+		},
+		EntityType: string(cnstypes.CnsKubernetesEntityTypePV),
+	}
+
+	hash := getMetadataHash([]cnstypes.BaseCnsEntityMetadata{pvMetadata, pvcMetadata})
+	assert.Equal(t, hash, getMetadataHash([]cnstypes.BaseCnsEntityMetadata{pvcMetadata, reordered}))
+	assert.NotEqual(t, hash, getMetadataHash([]cnstypes.BaseCnsEntityMetadata{pvMetadata}))
+	assert.NotEqual(t, hash, getMetadataHash([]cnstypes.BaseCnsEntityMetadata{relabeled, pvcMetadata}))
+}
+
+func TestIncrementalFullSyncCycles(t *testing.T) {
+	ctx := context.TODO()
+	t.Setenv("COMPLETE_FULL_SYNC_INTERVAL_CYCLES", "3")
+	states := &fullSyncVolumeStates{
+		volumes: make(map[string]map[string]fullSyncVolumeState),
+		cycles:  make(map[string]uint32),
+	}
+	newPV := func(name, volumeHandle string) *v1.PersistentVolume {
+		return &v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: "1"},
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeSource: v1.PersistentVolumeSource{
+					CSI: &v1.CSIPersistentVolumeSource{VolumeHandle: volumeHandle},
+				},
+			},
+		}
+	}
+	// Pick two volumes in the audit window of the complete cycles, so that
+	// they are only queried by the incremental cycles when they change.
+	var volumeHandles []string
+	for i := 0; len(volumeHandles) < 2; i++ {
+		volumeHandle := fmt.Sprintf("vol-%d", i)
+		if getAuditWindow(volumeHandle, 3) == 0 {
+			volumeHandles = append(volumeHandles, volumeHandle)
+		}
+	}
+	pv1 := newPV("pv-1", volumeHandles[0])
+	pv2 := newPV("pv-2", volumeHandles[1])
+	pv3 := newPV("pv-3", "vol-not-in-cns")
+	pvList := []*v1.PersistentVolume{pv1, pv2, pv3}
+	cnsVolumes := []cnstypes.CnsVolume{
+		{VolumeId: cnstypes.CnsVolumeId{Id: volumeHandles[0]}},
+		{VolumeId: cnstypes.CnsVolumeId{Id: volumeHandles[1]}},
+	}
+
+	// The first cycle is complete.
+	cycle := states.startCycle(ctx, "vc-1")
+	assert.True(t, cycle.complete)
+	pvsToSync := states.getPVsToSync(ctx, cycle, pvList, cnsVolumes, pvcMap{}, podMap{}, false)
+	assert.Equal(t, pvList, pvsToSync)
+	// pv-2 is updated, so it is queried again by the next cycle.
+	states.endCycle(cycle, []cnstypes.CnsVolumeMetadataUpdateSpec{{VolumeId: cnsVolumes[1].VolumeId}})
+	assert.Len(t, states.volumes["vc-1"], 1)
+
+	// The next cycle only queries the changed and updated volumes and the
+	// volumes not in CNS.
+	pv1.ResourceVersion = "2"
+	cycle = states.startCycle(ctx, "vc-1")
+	assert.False(t, cycle.complete)
+	pvsToSync = states.getPVsToSync(ctx, cycle, pvList, cnsVolumes, pvcMap{}, podMap{}, false)
+	assert.Equal(t, pvList, pvsToSync)
+	states.endCycle(cycle, nil)
+
+	// Unchanged volumes are skipped.
+	cycle = states.startCycle(ctx, "vc-1")
+	assert.False(t, cycle.complete)
+	pvsToSync = states.getPVsToSync(ctx, cycle, pvList, cnsVolumes, pvcMap{}, podMap{}, false)
+	for _, pv := range pvsToSync {
+		assert.NotEqual(t, volumeHandles[0], pv.Spec.CSI.VolumeHandle)
+	}
+	assert.Contains(t, pvsToSync, pv3)
+	assert.Contains(t, cycle.skipped, volumeHandles[0])
+	states.endCycle(cycle, nil)
+	assert.Len(t, states.volumes["vc-1"], 2)
+
+	// Every third cycle is complete.
+	cycle = states.startCycle(ctx, "vc-1")
+	assert.True(t, cycle.complete)
+	pvsToSync = states.getPVsToSync(ctx, cycle, pvList, cnsVolumes, pvcMap{}, podMap{}, false)
+	assert.Equal(t, pvList, pvsToSync)
+
+	// The cycles of each VC are independent.
+	assert.True(t, states.startCycle(ctx, "vc-2").complete)
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/sharding"
+)
+
+// metadataEventType is the type of the PV, PVC or pod event synced to CNS
+// by the metadata syncer.
+type metadataEventType string
+
+const (
+	pvcUpdatedEvent metadataEventType = "pvc-updated"
+	pvcDeletedEvent metadataEventType = "pvc-deleted"
+	pvUpdatedEvent  metadataEventType = "pv-updated"
+	pvDeletedEvent  metadataEventType = "pv-deleted"
+	podAddedEvent   metadataEventType = "pod-added"
+	podUpdatedEvent metadataEventType = "pod-updated"
+	podDeletedEvent metadataEventType = "pod-deleted"
+)
+
+// metadataEventShardScope is the scope of the shards of the events synced by
+// the metadata syncer, which are split by volume, or pod, and not by vCenter.
+const metadataEventShardScope = "metadata-sync"
+
+// metadataEvent is an event received from the PV, PVC or pod informers,
+// waiting in the metadata syncer work queue. newObj is the object of add and
+// delete events.
+type metadataEvent struct {
+	eventType metadataEventType
+	oldObj    interface{}
+	newObj    interface{}
+	// receivedTime is the time the first of the events coalesced in this
+	// event was received.
+	receivedTime time.Time
+}
+
+// metadataSyncQueue is a rate limited work queue of the events to sync to
+// CNS, keyed by volume ID for PV and PVC events and by pod for pod events.
+// The events of a key are synced in the order they were received by a single
+// worker at a time. Consecutive events of the same type for a key are
+// coalesced, so that a burst of updates results in a single CNS call. The
+// events of a key are retried with an exponential backoff when they fail to
+// sync, and dropped after maxMetadataSyncRetries, leaving them to full sync.
+//
+// The queue is held in memory and is not persisted. The events pending when
+// the syncer restarts or loses leadership, and the events received while it
+// is not the leader, are not replayed. Instead, the pending work is
+// re-derived from the informer caches by resyncMetadataSyncQueue once they
+// are synced at startup, which runs after the syncer becomes the leader.
+type metadataSyncQueue struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+	// lock protects pending.
+	lock sync.Mutex
+	// pending holds the events of each key in the queue, in the order they
+	// were received.
+	pending map[string][]*metadataEvent
+	// sync syncs an event to CNS. It returns an error when the event should
+	// be retried.
+	sync func(event *metadataEvent) error
+}
+
+// newMetadataSyncQueue returns a metadataSyncQueue syncing the events with
+// the given function.
+func newMetadataSyncQueue(sync func(event *metadataEvent) error) *metadataSyncQueue {
+	return &metadataSyncQueue{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](metadataSyncRetryIntervalStart,
+				metadataSyncRetryIntervalMax),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: "metadata-sync",
+			}),
+		pending: make(map[string][]*metadataEvent),
+		sync:    sync,
+	}
+}
+
+// add adds the event to the queue under the given key.
+func (q *metadataSyncQueue) add(key string, event *metadataEvent) {
+	q.lock.Lock()
+	events := q.pending[key]
+	if len(events) > 0 && events[len(events)-1].eventType == event.eventType {
+		// Sync the change from the old object of the first event to the new
+		// object of the last event at once.
+		last := events[len(events)-1]
+		last.newObj = event.newObj
+	} else {
+		q.pending[key] = append(events, event)
+	}
+	q.lock.Unlock()
+	q.queue.Add(key)
+}
+
+// Run starts the given number of workers syncing the events of the queue,
+// until the context is done.
+func (q *metadataSyncQueue) Run(ctx context.Context, workers int) {
+	log := logger.GetLogger(ctx)
+	defer q.queue.ShutDown()
+
+	log.Infof("Starting metadata sync work queue with %d workers", workers)
+	defer log.Infof("Shutting down metadata sync work queue")
+
+	stopCh := ctx.Done()
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for q.processNextKey(ctx) {
+			}
+		}, 0, stopCh)
+	}
+	go wait.Until(q.updateMetrics, metadataSyncQueueMetricsInterval, stopCh)
+	<-stopCh
+}
+
+// processNextKey syncs the pending events of the next key in the queue. It
+// returns false when the queue is shut down.
+func (q *metadataSyncQueue) processNextKey(ctx context.Context) bool {
+	log := logger.GetLogger(ctx)
+	key, quit := q.queue.Get()
+	if quit {
+		return false
+	}
+	defer q.queue.Done(key)
+
+	q.lock.Lock()
+	events := q.pending[key]
+	delete(q.pending, key)
+	q.lock.Unlock()
+
+	for i, event := range events {
+		err := q.sync(event)
+		if err == nil {
+			prometheus.MetadataSyncEventsHistVec.WithLabelValues(string(event.eventType),
+				prometheus.PrometheusPassStatus).Observe(time.Since(event.receivedTime).Seconds())
+			continue
+		}
+		if q.queue.NumRequeues(key) < maxMetadataSyncRetries {
+			log.Infof("Retrying %s event for %q after failure. Err: %v", event.eventType, key, err)
+			prometheus.MetadataSyncEventsHistVec.WithLabelValues(string(event.eventType),
+				prometheus.PrometheusRetryStatus).Observe(time.Since(event.receivedTime).Seconds())
+			// Put the failed and remaining events back ahead of the events
+			// received in the meantime.
+			q.lock.Lock()
+			q.pending[key] = append(events[i:len(events):len(events)], q.pending[key]...)
+			q.lock.Unlock()
+			q.queue.AddRateLimited(key)
+			return true
+		}
+		log.Errorf("Dropping %s event for %q after %d retries. The volume metadata will be synced by "+
+			"the next full sync. Err: %v", event.eventType, key, maxMetadataSyncRetries, err)
+		prometheus.MetadataSyncEventsHistVec.WithLabelValues(string(event.eventType),
+			prometheus.PrometheusFailStatus).Observe(time.Since(event.receivedTime).Seconds())
+	}
+	q.queue.Forget(key)
+	return true
+}
+
+// updateMetrics updates the metrics of the number of events in the queue and
+// of the age of the oldest one.
+func (q *metadataSyncQueue) updateMetrics() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var depth int
+	var oldest time.Time
+	for _, events := range q.pending {
+		depth += len(events)
+		for _, event := range events {
+			if oldest.IsZero() || event.receivedTime.Before(oldest) {
+				oldest = event.receivedTime
+			}
+		}
+	}
+	prometheus.MetadataSyncQueueDepthGauge.Set(float64(depth))
+	if oldest.IsZero() {
+		prometheus.MetadataSyncQueueOldestEventAgeGauge.Set(0)
+	} else {
+		prometheus.MetadataSyncQueueOldestEventAgeGauge.Set(time.Since(oldest).Seconds())
+	}
+}
+
+// handleMetadataEvent syncs the event received from the PV, PVC or pod
+// informers to CNS. The event is added to the metadata sync queue when it is
+// enabled, and synced from the informer callback otherwise.
+func (metadataSyncer *metadataSyncInformer) handleMetadataEvent(eventType metadataEventType,
+	oldObj, newObj interface{}) {
+	event := &metadataEvent{
+		eventType:    eventType,
+		oldObj:       oldObj,
+		newObj:       newObj,
+		receivedTime: time.Now(),
+	}
+	var key string
+	if metadataSyncer.metadataSyncQueue != nil || Sharder != nil {
+		key = metadataSyncer.getMetadataEventKey(newObj)
+	}
+	// With sharding, the events are synced by the replica owning the volume,
+	// or the pod, of the event.
+	if (key == "" && !Sharder.Owns(sharding.ClusterShard)) ||
+		(key != "" && !Sharder.OwnsVolume(metadataEventShardScope, key)) {
+		return
+	}
+	if metadataSyncer.metadataSyncQueue != nil && key != "" {
+		metadataSyncer.metadataSyncQueue.add(key, event)
+		return
+	}
+	// Events of objects the key can't be computed for, such as PVCs whose PV
+	// is not in the informer cache yet, are synced from the informer callback.
+	// Failures are logged by the handlers and left to full sync.
+	_ = metadataSyncer.syncMetadataEvent(event)
+}
+
+// resyncMetadataSyncQueue adds an update of every PV and PVC in the informer
+// caches to the metadata sync queue, so that the metadata of the volumes
+// changed while the syncer was not running is synced to CNS without waiting
+// for the next full sync. The updates are made from an old object with no
+// labels and no phase, so that the handlers sync the current metadata of the
+// volume. Pods need no resync, as the initial list of the pod informer is
+// delivered to its add handler.
+func (metadataSyncer *metadataSyncInformer) resyncMetadataSyncQueue(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	pvs, err := metadataSyncer.pvLister.List(labels.Everything())
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to list PVs from the informer cache. Err: %v", err)
+	}
+	for _, pv := range pvs {
+		oldPv := pv.DeepCopy()
+		oldPv.Labels = nil
+		oldPv.Status.Phase = ""
+		metadataSyncer.handleMetadataEvent(pvUpdatedEvent, oldPv, pv)
+	}
+	pvcs, err := metadataSyncer.pvcLister.List(labels.Everything())
+	if err != nil {
+		return logger.LogNewErrorf(log, "failed to list PVCs from the informer cache. Err: %v", err)
+	}
+	for _, pvc := range pvcs {
+		oldPvc := pvc.DeepCopy()
+		oldPvc.Labels = nil
+		oldPvc.Status.Phase = ""
+		metadataSyncer.handleMetadataEvent(pvcUpdatedEvent, oldPvc, pvc)
+	}
+	log.Infof("Added %d PVs and %d PVCs from the informer caches to the metadata sync queue",
+		len(pvs), len(pvcs))
+	return nil
+}
+
+// syncMetadataEvent syncs the event to CNS with the handler of its type.
+func (metadataSyncer *metadataSyncInformer) syncMetadataEvent(event *metadataEvent) error {
+	switch event.eventType {
+	case pvcUpdatedEvent:
+		return pvcUpdated(event.oldObj, event.newObj, metadataSyncer)
+	case pvcDeletedEvent:
+		return pvcDeleted(event.newObj, metadataSyncer)
+	case pvUpdatedEvent:
+		return pvUpdated(event.oldObj, event.newObj, metadataSyncer)
+	case pvDeletedEvent:
+		return pvDeleted(event.newObj, metadataSyncer)
+	case podAddedEvent:
+		podAdded(event.newObj, metadataSyncer)
+	case podUpdatedEvent:
+		return podUpdated(event.oldObj, event.newObj, metadataSyncer)
+	case podDeletedEvent:
+		return podDeleted(event.newObj, metadataSyncer)
+	}
+	return nil
+}
+
+// getMetadataEventKey returns the key of the metadata sync queue for the
+// object of an event. PV and PVC events are keyed by the ID of the volume,
+// so that the events of a volume are synced in order, and pod events by the
+// namespace and name of the pod. An empty key is returned when the object is
+// not recognized or its volume is unknown.
+func (metadataSyncer *metadataSyncInformer) getMetadataEventKey(obj interface{}) string {
+	switch obj := obj.(type) {
+	case *v1.PersistentVolume:
+		return getVolumeIDFromPV(obj)
+	case *v1.PersistentVolumeClaim:
+		if obj.Spec.VolumeName == "" {
+			return ""
+		}
+		pv, err := metadataSyncer.pvLister.Get(obj.Spec.VolumeName)
+		if err != nil {
+			return ""
+		}
+		return getVolumeIDFromPV(pv)
+	case *v1.Pod:
+		return "pod/" + obj.Namespace + "/" + obj.Name
+	}
+	return ""
+}
+
+// getVolumeIDFromPV returns the ID of the volume of a vSphere CSI PV, or the
+// path of the volume of an in-tree vSphere PV.
+func getVolumeIDFromPV(pv *v1.PersistentVolume) string {
+	if pv.Spec.CSI != nil {
+		return pv.Spec.CSI.VolumeHandle
+	}
+	if pv.Spec.VsphereVolume != nil {
+		return pv.Spec.VsphereVolume.VolumePath
+	}
+	return ""
+}
+
+// getMetadataSyncWorkers returns the number of workers of the metadata sync
+// queue. If environment variable METADATA_SYNC_WORKERS is set and valid,
+// return the value read from environment variable. Otherwise, use the
+// default value.
+func getMetadataSyncWorkers(ctx context.Context) int {
+	log := logger.GetLogger(ctx)
+	workers := defaultMetadataSyncWorkers
+	if v := os.Getenv("METADATA_SYNC_WORKERS"); v != "" {
+		if value, err := strconv.Atoi(v); err == nil && value > 0 {
+			workers = value
+			log.Infof("MetadataSync: number of workers is set to %d", workers)
+		} else {
+			log.Warnf("MetadataSync: number of workers set in env variable METADATA_SYNC_WORKERS %s "+
+				"is invalid, will use the default value %d", v, defaultMetadataSyncWorkers)
+		}
+	}
+	return workers
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestMetadataSyncQueueCoalescesEvents(t *testing.T) {
+	var synced []*metadataEvent
+	q := newMetadataSyncQueue(func(event *metadataEvent) error {
+		synced = append(synced, event)
+		return nil
+	})
+	defer q.queue.ShutDown()
+
+	now := time.Now()
+	q.add("vol-1", &metadataEvent{eventType: pvcUpdatedEvent, oldObj: "v1", newObj: "v2", receivedTime: now})
+	q.add("vol-1", &metadataEvent{eventType: pvcUpdatedEvent, oldObj: "v2", newObj: "v3", receivedTime: now})
+	q.add("vol-1", &metadataEvent{eventType: pvUpdatedEvent, oldObj: "pv1", newObj: "pv2", receivedTime: now})
+	q.add("vol-1", &metadataEvent{eventType: pvcDeletedEvent, newObj: "v3", receivedTime: now})
+	assert.Equal(t, 1, q.queue.Len())
+
+	assert.True(t, q.processNextKey(context.TODO()))
+	if assert.Len(t, synced, 3) {
+		// The two PVC updates are synced at once, from the first old object
+		// to the last new object.
+		assert.Equal(t, pvcUpdatedEvent, synced[0].eventType)
+		assert.Equal(t, "v1", synced[0].oldObj)
+		assert.Equal(t, "v3", synced[0].newObj)
+		assert.Equal(t, pvUpdatedEvent, synced[1].eventType)
+		assert.Equal(t, pvcDeletedEvent, synced[2].eventType)
+	}
+	assert.Empty(t, q.pending)
+	assert.Equal(t, 0, q.queue.Len())
+}
+
+func TestMetadataSyncQueueRetriesFailedEvents(t *testing.T) {
+	var synced []metadataEventType
+	fail := true
+	q := newMetadataSyncQueue(func(event *metadataEvent) error {
+		if event.eventType == pvUpdatedEvent && fail {
+			fail = false
+			return errors.New("vCenter is not reachable")
+		}
+		synced = append(synced, event.eventType)
+		return nil
+	})
+	defer q.queue.ShutDown()
+
+	now := time.Now()
+	q.add("vol-1", &metadataEvent{eventType: pvcUpdatedEvent, receivedTime: now})
+	q.add("vol-1", &metadataEvent{eventType: pvUpdatedEvent, receivedTime: now})
+	q.add("vol-1", &metadataEvent{eventType: pvDeletedEvent, receivedTime: now})
+
+	assert.True(t, q.processNextKey(context.TODO()))
+	assert.Equal(t, []metadataEventType{pvcUpdatedEvent}, synced)
+	// The failed event and the following ones are retried in order.
+	assert.Equal(t, 1, q.queue.NumRequeues("vol-1"))
+	if assert.Len(t, q.pending["vol-1"], 2) {
+		assert.Equal(t, pvUpdatedEvent, q.pending["vol-1"][0].eventType)
+		assert.Equal(t, pvDeletedEvent, q.pending["vol-1"][1].eventType)
+	}
+
+	// The key is added back to the queue after the backoff.
+	assert.True(t, q.processNextKey(context.TODO()))
+	assert.Equal(t, []metadataEventType{pvcUpdatedEvent, pvUpdatedEvent, pvDeletedEvent}, synced)
+	assert.Equal(t, 0, q.queue.NumRequeues("vol-1"))
+	assert.Empty(t, q.pending)
+}
+
+func TestGetMetadataEventKey(t *testing.T) {
+	metadataSyncer := &metadataSyncInformer{}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{VolumeHandle: "vol-1"},
+			},
+		},
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns-1"}}
+	unbound := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "ns-1"}}
+
+	assert.Equal(t, "vol-1", metadataSyncer.getMetadataEventKey(pv))
+	assert.Equal(t, "pod/ns-1/pod-1", metadataSyncer.getMetadataEventKey(pod))
+	assert.Equal(t, "", metadataSyncer.getMetadataEventKey(unbound))
+	assert.Equal(t, "", metadataSyncer.getMetadataEventKey("unknown"))
+}
+
+func TestResyncMetadataSyncQueue(t *testing.T) {
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Labels: map[string]string{"app": "db"}},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{VolumeHandle: "vol-1"},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "ns-1", Labels: map[string]string{"app": "db"}},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pv-1"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+	pvIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, pvIndexer.Add(pv))
+	pvcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, pvcIndexer.Add(pvc))
+	metadataSyncer := &metadataSyncInformer{
+		pvLister:  corelisters.NewPersistentVolumeLister(pvIndexer),
+		pvcLister: corelisters.NewPersistentVolumeClaimLister(pvcIndexer),
+	}
+	metadataSyncer.metadataSyncQueue = newMetadataSyncQueue(func(event *metadataEvent) error { return nil })
+	defer metadataSyncer.metadataSyncQueue.queue.ShutDown()
+
+	assert.NoError(t, metadataSyncer.resyncMetadataSyncQueue(context.TODO()))
+	events := metadataSyncer.metadataSyncQueue.pending["vol-1"]
+	if assert.Len(t, events, 2) {
+		// The updates are made from an old object with no labels and no
+		// phase, so that the current metadata is synced.
+		assert.Equal(t, pvUpdatedEvent, events[0].eventType)
+		assert.Equal(t, pv, events[0].newObj)
+		oldPv := events[0].oldObj.(*v1.PersistentVolume)
+		assert.Empty(t, oldPv.Labels)
+		assert.Empty(t, oldPv.Status.Phase)
+		assert.Equal(t, pvcUpdatedEvent, events[1].eventType)
+		assert.Equal(t, pvc, events[1].newObj)
+		oldPvc := events[1].oldObj.(*v1.PersistentVolumeClaim)
+		assert.Empty(t, oldPvc.Labels)
+		assert.Empty(t, oldPvc.Status.Phase)
+	}
+	// The cached objects are left untouched.
+	assert.Equal(t, "db", pv.Labels["app"])
+	assert.Equal(t, v1.ClaimBound, pvc.Status.Phase)
+}
@@ -77,8 +77,10 @@ import (
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology"
 	csinodetopologyv1alpha1 "sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/csinodetopology/v1alpha1"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/featurestates"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/internalapis/vcentercapability"
 	k8s "sigs.k8s.io/vsphere-csi-driver/v3/pkg/kubernetes"
 	cnsoperatortypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/cnsoperator/types"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/sharding"
 	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/syncer/storagepool"
 )
 
@@ -135,6 +137,11 @@ var (
 
 	// PeriodicSyncIntervalInMin the time interval to run sync for
 	PeriodicSyncIntervalInMin time.Duration
+
+	// Sharder splits the work of the syncer between the syncer replicas when
+	// sharding is enabled. It is nil otherwise, and this replica does all the
+	// work.
+	Sharder *sharding.Sharder
 )
 
 const (
@@ -638,14 +645,21 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 
 	// Set up kubernetes resource listeners for metadata syncer.
 	metadataSyncer.k8sInformerManager = k8s.NewInformer(ctx, k8sClient, true)
+	// Sync the PV, PVC and pod events from a rate limited work queue, so that
+	// a slow vCenter does not block the informers and failures are retried
+	// without waiting for the next full sync.
+	if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.MetadataSyncWorkQueue) {
+		metadataSyncer.metadataSyncQueue = newMetadataSyncQueue(metadataSyncer.syncMetadataEvent)
+		go metadataSyncer.metadataSyncQueue.Run(ctx, getMetadataSyncWorkers(ctx))
+	}
 	err = metadataSyncer.k8sInformerManager.AddPVCListener(
 		ctx,
 		nil, // Add.
 		func(oldObj interface{}, newObj interface{}) { // Update.
-			pvcUpdated(oldObj, newObj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(pvcUpdatedEvent, oldObj, newObj)
 		},
 		func(obj interface{}) { // Delete.
-			pvcDeleted(obj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(pvcDeletedEvent, nil, obj)
 		})
 	if err != nil {
 		return logger.LogNewErrorf(log, "failed to listen on PVCs. Error: %v", err)
@@ -656,10 +670,10 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 			pvAdded(obj, metadataSyncer)
 		}, // Add.
 		func(oldObj interface{}, newObj interface{}) { // Update.
-			pvUpdated(oldObj, newObj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(pvUpdatedEvent, oldObj, newObj)
 		},
 		func(obj interface{}) { // Delete.
-			pvDeleted(obj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(pvDeletedEvent, nil, obj)
 		})
 	if err != nil {
 		return logger.LogNewErrorf(log, "failed to listen on PVs. Error: %v", err)
@@ -667,13 +681,13 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 	err = metadataSyncer.k8sInformerManager.AddPodListener(
 		ctx,
 		func(obj interface{}) { // Add.
-			podAdded(obj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(podAddedEvent, nil, obj)
 		},
 		func(oldObj interface{}, newObj interface{}) { // Update.
-			podUpdated(oldObj, newObj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(podUpdatedEvent, oldObj, newObj)
 		},
 		func(obj interface{}) { // Delete.
-			podDeleted(obj, metadataSyncer)
+			metadataSyncer.handleMetadataEvent(podDeletedEvent, nil, obj)
 		})
 	if err != nil {
 		return logger.LogNewErrorf(log, "failed to listen on pods. Error: %v", err)
@@ -682,10 +696,33 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 	metadataSyncer.pvLister = metadataSyncer.k8sInformerManager.GetPVLister()
 	metadataSyncer.pvcLister = metadataSyncer.k8sInformerManager.GetPVCLister()
 	metadataSyncer.podLister = metadataSyncer.k8sInformerManager.GetPodLister()
+	// Expose the health of the vCenter sessions, CNS ListViews and informers
+	// through the health endpoints.
+	switch metadataSyncer.clusterFlavor {
+	case cnstypes.CnsClusterFlavorVanilla:
+		common.RegisterVCenterHealthChecks(&common.Managers{
+			VcenterManager: cnsvsphere.GetVirtualCenterManager(ctx),
+			VolumeManagers: metadataSyncer.volumeManagers,
+		})
+	case cnstypes.CnsClusterFlavorWorkload:
+		common.RegisterVCenterHealthChecks(&common.Managers{
+			VcenterManager: cnsvsphere.GetVirtualCenterManager(ctx),
+			VolumeManagers: map[string]volumes.Manager{metadataSyncer.host: metadataSyncer.volumeManager},
+		})
+	}
+	common.RegisterInformersHealthCheck()
 	stopCh := metadataSyncer.k8sInformerManager.Listen()
 	if stopCh == nil {
 		return logger.LogNewError(log, "Failed to sync informer caches")
 	}
+	if metadataSyncer.metadataSyncQueue != nil {
+		// The queue is not persisted. Re-derive the work left pending by the
+		// previous leader from the informer caches.
+		if err := metadataSyncer.resyncMetadataSyncQueue(ctx); err != nil {
+			log.Warnf("Failed to resync the metadata sync queue. The volume metadata will be synced "+
+				"by full sync. Err: %v", err)
+		}
+	}
 	log.Infof("Initialized metadata syncer")
 
 	fullSyncTicker := time.NewTicker(time.Duration(getFullSyncIntervalInMin(ctx)) * time.Minute)
@@ -693,7 +730,29 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 	// Trigger full sync.
 	// If TriggerCsiFullSync feature gate is enabled, use TriggerCsiFullSync to
 	// trigger full sync. If not, directly invoke full sync methods.
-	if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.TriggerCsiFullSync) {
+	// With sharding, each replica runs the full sync of the shards it owns, so
+	// full sync is not triggered through the TriggerCsiFullSync API, whose
+	// controller only runs in the leader.
+	// If FullSyncSchedule feature gate is enabled, the full sync of each VC is
+	// run following its schedule in the CsiFullSyncConfig instead.
+	if metadataSyncer.clusterFlavor != cnstypes.CnsClusterFlavorGuest &&
+		metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.FullSyncSchedule) {
+		log.Infof("%q feature flag is enabled. Using CsiFullSyncConfig to schedule full sync",
+			common.FullSyncSchedule)
+		// Get a config to talk to the apiserver.
+		restConfig, err := config.GetConfig()
+		if err != nil {
+			log.Errorf("failed to get Kubernetes config. Err: %+v", err)
+			return err
+		}
+
+		cnsOperatorClient, err := k8s.NewClientForGroup(ctx, restConfig, cnsoperatorv1alpha1.GroupName)
+		if err != nil {
+			log.Errorf("Failed to create CnsOperator client. Err: %+v", err)
+			return err
+		}
+		go runFullSyncSchedule(metadataSyncer, cnsOperatorClient)
+	} else if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.TriggerCsiFullSync) && Sharder == nil {
 		log.Infof("%q feature flag is enabled. Using TriggerCsiFullSync API to trigger full sync",
 			common.TriggerCsiFullSync)
 		// Get a config to talk to the apiserver.
@@ -770,7 +829,7 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 
 						isTopologyAwareFileVolumeEnabled := commonco.ContainerOrchestratorUtility.IsFSSEnabled(ctx,
 							common.TopologyAwareFileVolume)
-						if isTopologyAwareFileVolumeEnabled {
+						if isTopologyAwareFileVolumeEnabled && Sharder.Owns(sharding.ClusterShard) {
 							createMissingFileVolumeInfoCrs(ctx, metadataSyncer)
 						}
 
@@ -836,6 +895,9 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 					}
 					// Update mapping for all VCs.
 					for _, vcconfig := range vcconfigs {
+						if !Sharder.OwnsVCenter(vcconfig.Host) {
+							continue
+						}
 						csiGetPVtoBackingDiskObjectIdMapping(ctx, k8sClient, metadataSyncer, vcconfig.Host)
 					}
 
@@ -844,6 +906,66 @@ func InitMetadataSyncer(ctx context.Context, clusterFlavor cnstypes.CnsClusterFl
 		}
 	}
 
+	// Publish the capabilities discovered on the vCenters. The instances are
+	// only published by the syncer owning the cluster shard, so that the
+	// replicas of the driver do not race on them. The snapshot limits are
+	// configured per namespace in Supervisor clusters.
+	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla ||
+		metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorWorkload {
+		var getConfig func() *cnsconfig.Config
+		if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla {
+			getConfig = func() *cnsconfig.Config {
+				return metadataSyncer.configInfo.Cfg
+			}
+		}
+		err = vcentercapability.StartPublisher(ctx, getConfig, func() bool {
+			return Sharder.Owns(sharding.ClusterShard)
+		})
+		if err != nil {
+			log.Warnf("failed to start publishing the capabilities of the vCenters. Err: %v", err)
+		}
+	}
+
+	// Trigger fencing of failed nodes on vanilla cluster.
+	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.NodeFencing) {
+		nodeFencingTicker := time.NewTicker(time.Duration(getNodeFencingIntervalInMin(ctx)) * time.Minute)
+		defer nodeFencingTicker.Stop()
+		fencingDelay := time.Duration(getNodeFencingDelayInMin(ctx)) * time.Minute
+		recorder := newSyncerEventRecorder(k8sClient)
+		go func() {
+			for ; true; <-nodeFencingTicker.C {
+				if !Sharder.Owns(sharding.ClusterShard) {
+					continue
+				}
+				ctx, log := logger.GetNewContextWithLogger()
+				log.Debug("fencing of failed nodes is triggered")
+				csiFenceFailedNodes(ctx, k8sClient, metadataSyncer, recorder, fencingDelay)
+			}
+		}()
+	}
+
+	// Trigger evacuation of datastores under maintenance on vanilla cluster.
+	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla &&
+		metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.DatastoreMaintenanceAwareness) &&
+		metadataSyncer.configInfo.Cfg.Global.EvacuateDatastoresUnderMaintenance {
+		datastoreEvacuationTicker := time.NewTicker(
+			time.Duration(metadataSyncer.configInfo.Cfg.Global.DatastoreEvacuationIntervalInMin) * time.Minute)
+		defer datastoreEvacuationTicker.Stop()
+		maxRelocations := metadataSyncer.configInfo.Cfg.Global.MaxDatastoreEvacuationsPerInterval
+		recorder := newSyncerEventRecorder(k8sClient)
+		go func() {
+			for ; true; <-datastoreEvacuationTicker.C {
+				if !Sharder.Owns(sharding.ClusterShard) {
+					continue
+				}
+				ctx, log := logger.GetNewContextWithLogger()
+				log.Debug("evacuation of datastores under maintenance is triggered")
+				csiEvacuateDatastores(ctx, metadataSyncer, recorder, maxRelocations)
+			}
+		}()
+	}
+
 	volumeHealthTicker := time.NewTicker(time.Duration(getVolumeHealthIntervalInMin(ctx)) * time.Minute)
 	defer volumeHealthTicker.Stop()
 
@@ -2496,44 +2618,41 @@ func ReloadConfiguration(metadataSyncer *metadataSyncInformer, reconnectToVCFrom
 
 // pvcUpdated updates persistent volume claim metadata on VC when pvc labels
 // on K8S cluster have been updated.
-func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) {
+func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	// Get old and new pvc objects.
 	oldPvc, ok := oldObj.(*v1.PersistentVolumeClaim)
 	if oldPvc == nil || !ok {
-		return
+		return nil
 	}
 	newPvc, ok := newObj.(*v1.PersistentVolumeClaim)
 	if newPvc == nil || !ok {
-		return
+		return nil
 	}
 	log.Debugf("PVCUpdated: PVC Updated from %+v to %+v", oldPvc, newPvc)
 	if newPvc.Status.Phase != v1.ClaimBound {
 		log.Debugf("PVCUpdated: New PVC not in Bound phase")
-		return
+		return nil
 	}
 
 	// Get pv object attached to pvc.
 	pv, err := metadataSyncer.pvLister.Get(newPvc.Spec.VolumeName)
 	if pv == nil || err != nil {
 		if !apierrors.IsNotFound(err) {
-			log.Errorf("PVCUpdated: Error getting Persistent Volume for pvc %s in namespace %s with err: %v",
-				newPvc.Name, newPvc.Namespace, err)
-			return
+			return logger.LogNewErrorf(log, "PVCUpdated: Error getting Persistent Volume for pvc %s in namespace %s "+
+				"with err: %v", newPvc.Name, newPvc.Namespace, err)
 		}
 		log.Infof("PVCUpdated: PV with name %s not found using PV Lister. Querying API server to get PV Info",
 			newPvc.Spec.VolumeName)
 		// Create the kubernetes client from config.
 		k8sClient, err := k8s.NewClient(ctx)
 		if err != nil {
-			log.Errorf("PVCUpdated: Creating Kubernetes client failed. Err: %v", err)
-			return
+			return logger.LogNewErrorf(log, "PVCUpdated: Creating Kubernetes client failed. Err: %v", err)
 		}
 		pv, err = k8sClient.CoreV1().PersistentVolumes().Get(ctx, newPvc.Spec.VolumeName, metav1.GetOptions{})
 		if err != nil {
-			log.Errorf("PVCUpdated: Error getting Persistent Volume %s from API server with err: %v",
-				newPvc.Spec.VolumeName, err)
-			return
+			return logger.LogNewErrorf(log, "PVCUpdated: Error getting Persistent Volume %s from API server "+
+				"with err: %v", newPvc.Spec.VolumeName, err)
 		}
 		log.Debugf("PVCUpdated: Found Persistent Volume %s from API server", newPvc.Spec.VolumeName)
 	}
@@ -2547,14 +2666,14 @@ func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer
 			log.Infof("PVCUpdated: %q is a vSphere volume claim in namespace %q."+
 				"In-tree vSphere volume are not supported in a multi VC setup. Skipping update",
 				newPvc.Name, newPvc.Namespace)
-			return
+			return nil
 		}
 
 		if !isValidvSphereVolumeClaim(ctx, newPvc.ObjectMeta) {
 			if !isValidvSphereVolume(ctx, pv) {
 				log.Debugf("PVCUpdated: %q is not a valid vSphere volume claim in namespace %q. Skipping update",
 					newPvc.Name, newPvc.Namespace)
-				return
+				return nil
 			}
 		}
 		if oldPvc.Status.Phase == v1.ClaimBound &&
@@ -2562,7 +2681,7 @@ func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer
 			reflect.DeepEqual(newPvc.Labels, oldPvc.Labels) {
 			log.Debugf("PVCUpdated: PVC labels and annotations have not changed for %s in namespace %s",
 				newPvc.Name, newPvc.Namespace)
-			return
+			return nil
 		}
 		// Verify if there is an annotation update
 		if !reflect.DeepEqual(newPvc.GetAnnotations(), oldPvc.GetAnnotations()) {
@@ -2573,7 +2692,7 @@ func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer
 					"Ignoring other annotation updates", newPvc.Name, newPvc.Namespace)
 				// Check if there are no label update, then return.
 				if !reflect.DeepEqual(newPvc.Labels, oldPvc.Labels) {
-					return
+					return nil
 				}
 			}
 		}
@@ -2581,47 +2700,47 @@ func pvcUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer
 		if pv.Spec.VsphereVolume != nil {
 			// Volume is in-tree VCP volume.
 			log.Warnf("PVCUpdated: %q feature state is disabled. Skipping the PVC update", common.CSIMigration)
-			return
+			return nil
 		}
 		// Verify if pv is vsphere csi volume.
 		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != csitypes.Name {
 			log.Debugf("PVCUpdated: Not a vSphere CSI Volume")
-			return
+			return nil
 		}
 		// For volumes provisioned by CSI driver, verify if old and new labels are not equal.
 		if oldPvc.Status.Phase == v1.ClaimBound && reflect.DeepEqual(newPvc.Labels, oldPvc.Labels) {
 			log.Debugf("PVCUpdated: Old PVC and New PVC labels equal")
-			return
+			return nil
 		}
 	}
 
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		// Invoke volume updated method for pvCSI.
 		pvcsiVolumeUpdated(ctx, newPvc, pv.Spec.CSI.VolumeHandle, metadataSyncer)
-	} else {
-		csiPVCUpdated(ctx, newPvc, pv, metadataSyncer)
+		return nil
 	}
+	return csiPVCUpdated(ctx, newPvc, pv, metadataSyncer)
 }
 
 // pvcDeleted deletes pvc metadata on VC when pvc has been deleted on K8s
 // cluster.
-func pvcDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) {
+func pvcDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	pvc, ok := obj.(*v1.PersistentVolumeClaim)
 	if pvc == nil || !ok {
 		log.Warnf("PVCDeleted: unrecognized object %+v", obj)
-		return
+		return nil
 	}
 	log.Debugf("PVCDeleted: %+v", pvc)
 	if pvc.Status.Phase != v1.ClaimBound {
-		return
+		return nil
 	}
 	// Get pv object attached to pvc.
 	pv, err := metadataSyncer.pvLister.Get(pvc.Spec.VolumeName)
 	if pv == nil || err != nil {
 		log.Errorf("PVCDeleted: Error getting Persistent Volume for pvc %s in namespace %s with err: %v",
 			pvc.Name, pvc.Namespace, err)
-		return
+		return nil
 	}
 
 	if IsMigrationEnabled && pv.Spec.VsphereVolume != nil {
@@ -2631,34 +2750,34 @@ func pvcDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) {
 			log.Infof("PVCDeleted: %q is a vSphere volume claim in namespace %q."+
 				"In-tree vSphere volume are not supported in a multi VC setup. Skipping delettion of PVC metadata.",
 				pvc.Name, pvc.Namespace)
-			return
+			return nil
 		}
 
 		if !isValidvSphereVolumeClaim(ctx, pvc.ObjectMeta) {
 			if !isValidvSphereVolume(ctx, pv) {
 				log.Debugf("PVCDeleted: %q is not a valid vSphere volume claim in namespace %q. "+
 					"Skipping deletion of PVC metadata.", pvc.Name, pvc.Namespace)
-				return
+				return nil
 			}
 		}
 	} else {
 		if pv.Spec.VsphereVolume != nil {
 			// Volume is in-tree VCP volume.
 			log.Warnf("PVCDeleted: %q feature state is disabled. Skipping the PVC delete", common.CSIMigration)
-			return
+			return nil
 		}
 		// Verify if pv is vSphere csi volume.
 		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != csitypes.Name {
 			log.Debugf("PVCDeleted: Not a vSphere CSI Volume")
-			return
+			return nil
 		}
 	}
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		// Invoke volume deleted method for pvCSI.
 		pvcsiVolumeDeleted(ctx, string(pvc.GetUID()), metadataSyncer, pv)
-	} else {
-		csiPVCDeleted(ctx, pvc, pv, metadataSyncer)
+		return nil
 	}
+	return csiPVCDeleted(ctx, pvc, pv, metadataSyncer)
 }
 
 // pvAdded updates the PV labels with linkedclone's volumesnapshot uuid
@@ -2698,19 +2817,19 @@ func pvAdded(obj interface{}, metadataSyncer *metadataSyncInformer) {
 
 // pvUpdated updates volume metadata on VC when volume labels on K8S cluster
 // have been updated.
-func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) {
+func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	// Get old and new PV objects.
 	oldPv, ok := oldObj.(*v1.PersistentVolume)
 	if oldPv == nil || !ok {
 		log.Warnf("PVUpdated: unrecognized old object %+v", oldObj)
-		return
+		return nil
 	}
 
 	newPv, ok := newObj.(*v1.PersistentVolume)
 	if newPv == nil || !ok {
 		log.Warnf("PVUpdated: unrecognized new object %+v", newObj)
-		return
+		return nil
 	}
 	log.Debugf("PVUpdated: PV Updated from %+v to %+v", oldPv, newPv)
 
@@ -2718,7 +2837,7 @@ func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer)
 	if newPv.Status.Phase == v1.VolumePending || newPv.Status.Phase == v1.VolumeFailed {
 		log.Debugf("PVUpdated: PV %s metadata is not updated since updated PV is in phase %s",
 			newPv.Name, newPv.Status.Phase)
-		return
+		return nil
 	}
 	if IsMigrationEnabled && newPv.Spec.VsphereVolume != nil {
 
@@ -2728,18 +2847,18 @@ func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer)
 			log.Infof("PVUpdated: %q is a vSphere volume claim in namespace %q."+
 				"In-tree vSphere volume are not supported in a multi VC setup."+
 				"Skipping PV update.", newPv.Name, newPv.Namespace)
-			return
+			return nil
 		}
 
 		if !isValidvSphereVolume(ctx, newPv) {
 			log.Debugf("PVUpdated: PV %q is not a valid vSphere volume. Skipping update of PV metadata.", newPv.Name)
-			return
+			return nil
 		}
 		if (oldPv.Status.Phase == v1.VolumeAvailable || oldPv.Status.Phase == v1.VolumeBound) &&
 			reflect.DeepEqual(newPv.GetAnnotations(), oldPv.GetAnnotations()) &&
 			reflect.DeepEqual(newPv.Labels, oldPv.Labels) {
 			log.Debug("PVUpdated: PV labels and annotations have not changed")
-			return
+			return nil
 		}
 		// Verify if migration annotation is getting removed.
 		if !reflect.DeepEqual(newPv.GetAnnotations(), oldPv.GetAnnotations()) {
@@ -2750,7 +2869,7 @@ func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer)
 					newPv.Name)
 				// Check if there are no label update, then return.
 				if !reflect.DeepEqual(newPv.Labels, oldPv.Labels) {
-					return
+					return nil
 				}
 			}
 		}
@@ -2758,45 +2877,45 @@ func pvUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer)
 		if newPv.Spec.VsphereVolume != nil {
 			// Volume is in-tree VCP volume.
 			log.Warnf("PVUpdated: %q feature state is disabled. Skipping the PV update", common.CSIMigration)
-			return
+			return nil
 		}
 		// Verify if pv is a vSphere csi volume.
 		if newPv.Spec.CSI == nil || newPv.Spec.CSI.Driver != csitypes.Name {
 			log.Debugf("PVUpdated: PV is not a vSphere CSI Volume: %+v", newPv)
-			return
+			return nil
 		}
 		// Return if labels are unchanged.
 		if (oldPv.Status.Phase == v1.VolumeAvailable || oldPv.Status.Phase == v1.VolumeBound) &&
 			reflect.DeepEqual(newPv.GetLabels(), oldPv.GetLabels()) {
 			log.Debugf("PVUpdated: PV labels have not changed")
-			return
+			return nil
 		}
 	}
 	if oldPv.Status.Phase == v1.VolumeBound && newPv.Status.Phase == v1.VolumeReleased &&
 		oldPv.Spec.PersistentVolumeReclaimPolicy == v1.PersistentVolumeReclaimDelete {
 		log.Debugf("PVUpdated: Volume will be deleted by controller")
-		return
+		return nil
 	}
 	if newPv.DeletionTimestamp != nil {
 		log.Debugf("PVUpdated: PV already deleted")
-		return
+		return nil
 	}
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		// Invoke volume updated method for pvCSI.
 		pvcsiVolumeUpdated(ctx, newPv, newPv.Spec.CSI.VolumeHandle, metadataSyncer)
-	} else {
-		csiPVUpdated(ctx, newPv, oldPv, metadataSyncer)
+		return nil
 	}
+	return csiPVUpdated(ctx, newPv, oldPv, metadataSyncer)
 }
 
 // pvDeleted deletes volume metadata on VC when volume has been deleted on
 // K8s cluster.
-func pvDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) {
+func pvDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	pv, ok := obj.(*v1.PersistentVolume)
 	if pv == nil || !ok {
 		log.Warnf("PVDeleted: unrecognized object %+v", obj)
-		return
+		return nil
 	}
 	log.Debugf("PVDeleted: PV: %+v", pv)
 
@@ -2808,31 +2927,31 @@ func pvDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) {
 			log.Infof("PVUpdated: %q is a vSphere volume claim in namespace %q."+
 				"In-tree vSphere volume are not supported in a multi VC setup."+
 				"Skipping deletion of PV metadata.", pv.Name, pv.Namespace)
-			return
+			return nil
 		}
 
 		if !isValidvSphereVolume(ctx, pv) {
 			log.Debugf("PVDeleted: PV %q is not a valid vSphereVolume. Skipping deletion of PV metadata.", pv.Name)
-			return
+			return nil
 		}
 	} else {
 		if pv.Spec.VsphereVolume != nil {
 			// Volume is in-tree VCP volume.
 			log.Warnf("PVDeleted: %q feature state is disabled. Skipping the PVC update", common.CSIMigration)
-			return
+			return nil
 		}
 		// Verify if pv is a vSphere csi volume.
 		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != csitypes.Name {
 			log.Debugf("PVDeleted: Not a vSphere CSI Volume. PV: %+v", pv)
-			return
+			return nil
 		}
 	}
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		// Invoke volume deleted method for pvCSI.
 		pvcsiVolumeDeleted(ctx, string(pv.GetUID()), metadataSyncer, pv)
-	} else {
-		csiPVDeleted(ctx, pv, metadataSyncer)
+		return nil
 	}
+	return csiPVDeleted(ctx, pv, metadataSyncer)
 }
 
 // podAdded helps register inline vSphere in-tree volumes.
@@ -2910,58 +3029,58 @@ func podAdded(obj interface{}, metadataSyncer *metadataSyncInformer) {
 
 // podUpdated updates pod metadata on VC when pod labels have been updated on
 // K8s cluster.
-func podUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) {
+func podUpdated(oldObj, newObj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	// Get old and new pod objects.
 	oldPod, ok := oldObj.(*v1.Pod)
 	if oldPod == nil || !ok {
 		log.Warnf("PodUpdated: unrecognized old object %+v", oldObj)
-		return
+		return nil
 	}
 	newPod, ok := newObj.(*v1.Pod)
 	if newPod == nil || !ok {
 		log.Warnf("PodUpdated: unrecognized new object %+v", newObj)
-		return
+		return nil
 	}
 
 	// If old pod is in pending state and new pod is running, update metadata.
 	if oldPod.Status.Phase == v1.PodPending && newPod.Status.Phase == v1.PodRunning {
 		log.Debugf("PodUpdated: Pod %s calling updatePodMetadata", newPod.Name)
 		// Update pod metadata.
-		updatePodMetadata(ctx, newPod, metadataSyncer, false)
+		return updatePodMetadata(ctx, newPod, metadataSyncer, false)
 	}
+	return nil
 }
 
 // podDeleted deletes pod metadata on VC when pod has been deleted on
 // K8s cluster.
-func podDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) {
+func podDeleted(obj interface{}, metadataSyncer *metadataSyncInformer) error {
 	ctx, log := logger.GetNewContextWithLogger()
 	// Get pod object.
 	pod, ok := obj.(*v1.Pod)
 	if pod == nil || !ok {
 		log.Warnf("PodDeleted: unrecognized new object %+v", obj)
-		return
+		return nil
 	}
 
 	log.Debugf("PodDeleted: Pod %s calling updatePodMetadata", pod.Name)
 	// Update pod metadata.
-	updatePodMetadata(ctx, pod, metadataSyncer, true)
+	return updatePodMetadata(ctx, pod, metadataSyncer, true)
 }
 
 // updatePodMetadata updates metadata for volumes attached to the pod.
-func updatePodMetadata(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSyncInformer, deleteFlag bool) {
+func updatePodMetadata(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSyncInformer, deleteFlag bool) error {
 	if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorGuest {
 		pvcsiUpdatePod(ctx, pod, metadataSyncer, deleteFlag)
-	} else {
-		csiUpdatePod(ctx, pod, metadataSyncer, deleteFlag)
+		return nil
 	}
-
+	return csiUpdatePod(ctx, pod, metadataSyncer, deleteFlag)
 }
 
 // csiPVCUpdated updates volume metadata for PVC objects on the VC in Vanilla
 // k8s and supervisor cluster.
 func csiPVCUpdated(ctx context.Context, pvc *v1.PersistentVolumeClaim,
-	pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) {
+	pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) error {
 	log := logger.GetLogger(ctx)
 	var (
 		volumeHandle string
@@ -2973,22 +3092,19 @@ func csiPVCUpdated(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 		// In case if feature state switch is enabled after syncer is deployed,
 		// we need to initialize the volumeMigrationService.
 		if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-			log.Errorf("PVC Updated: Failed to get migration service. Err: %v", err)
-			return
+			return logger.LogNewErrorf(log, "PVC Updated: Failed to get migration service. Err: %v", err)
 		}
 		migrationVolumeSpec := &migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath,
 			StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
 		volumeHandle, err = volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
 		if err != nil {
-			log.Errorf("PVC Updated: Failed to get VolumeID from volumeMigrationService for migration VolumeSpec: %v "+
-				"with error %+v", migrationVolumeSpec, err)
-			return
+			return logger.LogNewErrorf(log, "PVC Updated: Failed to get VolumeID from volumeMigrationService "+
+				"for migration VolumeSpec: %v with error %+v", migrationVolumeSpec, err)
 		}
 		vcHost, cnsVolumeMgr, err = getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 		if err != nil {
-			log.Errorf("PVCUpdated: Failed to get VC host and volume manager for the given volume: %v. "+
+			return logger.LogNewErrorf(log, "PVCUpdated: Failed to get VC host and volume manager for the given volume: %v. "+
 				"Error occoured: %+v", volumeHandle, err)
-			return
 		}
 	} else {
 		volumeFound := false
@@ -2996,9 +3112,8 @@ func csiPVCUpdated(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 
 		vcHost, cnsVolumeMgr, err = getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 		if err != nil {
-			log.Errorf("PVCUpdated: Failed to get VC host and volume manager for the given volume: %v. "+
+			return logger.LogNewErrorf(log, "PVCUpdated: Failed to get VC host and volume manager for the given volume: %v. "+
 				"Error occoured: %+v", volumeHandle, err)
-			return
 		}
 		// Following wait poll is required to avoid race condition between
 		// pvcUpdated and pvUpdated. This helps avoid race condition between
@@ -3023,23 +3138,20 @@ func csiPVCUpdated(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 				return volumeFound, nil
 			})
 		if err != nil {
-			log.Errorf("PVCUpdated: Error occurred while polling to check if volume is marked as container volume. "+
-				"err: %+v", err)
-			return
+			return logger.LogNewErrorf(log, "PVCUpdated: Error occurred while polling to check if volume is "+
+				"marked as container volume. err: %+v", err)
 		}
 
 		if !volumeFound {
 			// volumeFound will be false when wait poll times out.
-			log.Errorf("PVCUpdated: volume: %q is not marked as the container volume. Skipping PVC entity metadata update",
-				volumeHandle)
-			return
+			return logger.LogNewErrorf(log, "PVCUpdated: volume: %q is not marked as the container volume. "+
+				"Skipping PVC entity metadata update", volumeHandle)
 		}
 	}
 
 	vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 	if !vcHostObjFound {
-		log.Errorf("PVCUpdated: failed to find VC host for given volume: %q.", volumeHandle)
-		return
+		return logger.LogNewErrorf(log, "PVCUpdated: failed to find VC host for given volume: %q.", volumeHandle)
 	}
 
 	// Create updateSpec.
@@ -3068,19 +3180,20 @@ func csiPVCUpdated(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 
 	log.Debugf("PVCUpdated: Calling UpdateVolumeMetadata with updateSpec: %+v", spew.Sdump(updateSpec))
 	if err := cnsVolumeMgr.UpdateVolumeMetadata(ctx, updateSpec); err != nil {
-		log.Errorf("PVCUpdated: UpdateVolumeMetadata failed with err %v", err)
+		return logger.LogNewErrorf(log, "PVCUpdated: UpdateVolumeMetadata failed with err %v", err)
 	}
+	return nil
 }
 
 // csiPVCDeleted deletes volume metadata on VC when volume has been deleted
 // on Vanilla k8s and supervisor cluster.
 func csiPVCDeleted(ctx context.Context, pvc *v1.PersistentVolumeClaim,
-	pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) {
+	pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) error {
 	log := logger.GetLogger(ctx)
 	// Volume will be deleted by controller when reclaim policy is delete.
 	if pv.Spec.PersistentVolumeReclaimPolicy == v1.PersistentVolumeReclaimDelete {
 		log.Debugf("PVCDeleted: Reclaim policy is delete")
-		return
+		return nil
 	}
 
 	// If the PV reclaim policy is retain we need to delete PVC labels.
@@ -3096,16 +3209,14 @@ func csiPVCDeleted(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 		// In case if feature state switch is enabled after syncer is deployed,
 		// we need to initialize the volumeMigrationService.
 		if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-			log.Errorf("PVC Deleted: Failed to get migration service. Err: %v", err)
-			return
+			return logger.LogNewErrorf(log, "PVC Deleted: Failed to get migration service. Err: %v", err)
 		}
 		migrationVolumeSpec := &migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath,
 			StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
 		volumeHandle, err = volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
 		if err != nil {
-			log.Errorf("PVC Deleted: Failed to get VolumeID from volumeMigrationService for migration VolumeSpec: %v "+
-				"with error %+v", migrationVolumeSpec, err)
-			return
+			return logger.LogNewErrorf(log, "PVC Deleted: Failed to get VolumeID from volumeMigrationService "+
+				"for migration VolumeSpec: %v with error %+v", migrationVolumeSpec, err)
 		}
 	} else {
 		volumeHandle = pv.Spec.CSI.VolumeHandle
@@ -3113,15 +3224,13 @@ func csiPVCDeleted(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 
 	vcHost, cnsVolumeMgr, err := getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 	if err != nil {
-		log.Errorf("PVC Deleted: Failed to get VC host and volume manager for the given volume: %v. "+
+		return logger.LogNewErrorf(log, "PVC Deleted: Failed to get VC host and volume manager for the given volume: %v. "+
 			"Error occoured: %+v", volumeHandle, err)
-		return
 	}
 
 	vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 	if !vcHostObjFound {
-		log.Errorf("PVCDeleted: failed to find VC host for given volume: %q.", volumeHandle)
-		return
+		return logger.LogNewErrorf(log, "PVCDeleted: failed to find VC host for given volume: %q.", volumeHandle)
 	}
 
 	containerCluster := cnsvsphere.GetContainerCluster(clusterIDforVolumeMetadata,
@@ -3141,14 +3250,15 @@ func csiPVCDeleted(ctx context.Context, pvc *v1.PersistentVolumeClaim,
 		updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
 
 	if err := cnsVolumeMgr.UpdateVolumeMetadata(ctx, updateSpec); err != nil {
-		log.Errorf("PVCDeleted: UpdateVolumeMetadata failed with err %v", err)
+		return logger.LogNewErrorf(log, "PVCDeleted: UpdateVolumeMetadata failed with err %v", err)
 	}
+	return nil
 }
 
 // csiPVUpdated updates volume metadata on VC when volume labels on Vanilla
 // k8s and supervisor cluster have been updated.
 func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.PersistentVolume,
-	metadataSyncer *metadataSyncInformer) {
+	metadataSyncer *metadataSyncInformer) error {
 	log := logger.GetLogger(ctx)
 	var metadataList []cnstypes.BaseCnsEntityMetadata
 	pvMetadata := cnsvsphere.GetCnsKubernetesEntityMetaData(newPv.Name, newPv.GetLabels(), false,
@@ -3166,16 +3276,14 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 		// In case if feature state switch is enabled after syncer is deployed,
 		// we need to initialize the volumeMigrationService.
 		if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-			log.Errorf("PVUpdated: Failed to get migration service. Err: %v", err)
-			return
+			return logger.LogNewErrorf(log, "PVUpdated: Failed to get migration service. Err: %v", err)
 		}
 		volumeHandle, err = volumeMigrationService.GetVolumeID(ctx,
 			&migration.VolumeSpec{VolumePath: newPv.Spec.VsphereVolume.VolumePath,
 				StoragePolicyName: newPv.Spec.VsphereVolume.StoragePolicyName}, true)
 		if err != nil {
-			log.Errorf("PVUpdated: Failed to get VolumeID from volumeMigrationService for volumePath: %s with error %+v",
-				newPv.Spec.VsphereVolume.VolumePath, err)
-			return
+			return logger.LogNewErrorf(log, "PVUpdated: Failed to get VolumeID from volumeMigrationService "+
+				"for volumePath: %s with error %+v", newPv.Spec.VsphereVolume.VolumePath, err)
 		}
 	} else {
 		volumeHandle = newPv.Spec.CSI.VolumeHandle
@@ -3211,7 +3319,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 					log.Infof("PVUpdated: %q is a vSphere volume claim in namespace %q."+
 						"File share volumes are not supported in a multi VC setup."+
 						"Skipping PV update.", newPv.Name, newPv.Namespace)
-					return
+					return nil
 				}
 			}
 			volumeType = common.FileVolumeType
@@ -3233,7 +3341,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 						"Error occurred: %+v", err)
 					generateEventOnPv(ctx, oldPv, v1.EventTypeWarning,
 						staticVolumeProvisioningFailure, "Failed to identify VC for volume.")
-					return
+					return nil
 				}
 			} else {
 				// File Volume in Multi VC
@@ -3241,7 +3349,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 					log.Infof("PVUpdated: %q is a vSphere volume claim in namespace %q."+
 						"File share volumes are not supported in a multi VC setup as TopologyAwareFileVolume FSS is disabled."+
 						"Skipping PV update.", newPv.Name, newPv.Namespace)
-					return
+					return nil
 				}
 
 				// If VolumeID to VC mappping is found, volume is already created.
@@ -3258,12 +3366,12 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 						log.Infof("PVUpdated: Successfully created static file volume %q on VC %s", newPv.Name, vcHost)
 					} else {
 						// Failed to create static PV
-						log.Errorf("PVUpdated: Failed to create static file volume %q. Error: %+v", newPv.Name, err)
 						generateEventOnPv(ctx, oldPv, v1.EventTypeWarning,
 							staticVolumeProvisioningFailure, "Failed to create volume on any of the VCs")
-						return
+						return logger.LogNewErrorf(log, "PVUpdated: Failed to create static file volume %q. Error: %+v",
+							newPv.Name, err)
 					}
-					return
+					return nil
 				}
 				// Volume to VC mapping already exists.
 				// PV is required to be updated.
@@ -3276,7 +3384,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 					"Error occoured: %+v", err)
 				generateEventOnPv(ctx, oldPv, v1.EventTypeWarning,
 					staticVolumeProvisioningFailure, "Failed to identify VC for volume")
-				return
+				return nil
 			}
 		}
 
@@ -3285,8 +3393,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 
 		vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 		if !vcHostObjFound {
-			log.Errorf("PVUpdated: failed to find VC host for given volume: %q.", volumeHandle)
-			return
+			return logger.LogNewErrorf(log, "PVUpdated: failed to find VC host for given volume: %q.", volumeHandle)
 		}
 
 		containerCluster = cnsvsphere.GetContainerCluster(clusterIDforVolumeMetadata,
@@ -3297,8 +3404,8 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 			// QueryAll with no selection will return only the volume ID.
 			queryResult, err := cnsVolumeMgr.QueryAllVolume(ctx, queryFilter, cnstypes.CnsQuerySelection{})
 			if err != nil {
-				log.Errorf("PVUpdated: QueryVolume failed for volume %q with err=%+v", oldPv.Spec.CSI.VolumeHandle, err.Error())
-				return
+				return logger.LogNewErrorf(log, "PVUpdated: QueryVolume failed for volume %q with err=%+v",
+					oldPv.Spec.CSI.VolumeHandle, err.Error())
 			}
 			if len(queryResult.Volumes) == 0 {
 				log.Infof("PVUpdated: Verified volume: %q is not marked as container volume in CNS. "+
@@ -3307,11 +3414,11 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 				err = createCnsVolume(ctx, oldPv, metadataSyncer, cnsVolumeMgr, volumeType, vcHost, metadataList, volumeHandle)
 				if err != nil {
 					errMsg := fmt.Sprintf("Failed to create volume on VC %s", vcHost)
-					log.Errorf(errMsg)
 					generateEventOnPv(ctx, oldPv, v1.EventTypeWarning,
 						staticVolumeProvisioningFailure, errMsg)
+					return logger.LogNewErrorf(log, "PVUpdated: %s. Error: %+v", errMsg, err)
 				}
-				return
+				return nil
 			} else if queryResult.Volumes[0].VolumeId.Id == oldPv.Spec.CSI.VolumeHandle {
 				log.Infof("PVUpdated: Verified volume: %q is already marked as container volume in CNS.",
 					oldPv.Spec.CSI.VolumeHandle)
@@ -3321,7 +3428,7 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 				log.Infof("PVUpdated: Queried volume: %q is other than requested volume: %q.",
 					oldPv.Spec.CSI.VolumeHandle, queryResult.Volumes[0].VolumeId.Id)
 				// unknown Volume is returned from the CNS, so returning from here.
-				return
+				return nil
 			}
 		}
 	} else {
@@ -3329,15 +3436,13 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 		// Look up VC for the given volume from in-memory map.
 		vcHost, cnsVolumeMgr, err = getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 		if err != nil {
-			log.Errorf("PVUpdated: Failed to get VC host and volume manager for single VC setup. "+
+			return logger.LogNewErrorf(log, "PVUpdated: Failed to get VC host and volume manager for single VC setup. "+
 				"Error occoured: %+v", err)
-			return
 		}
 
 		vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 		if !vcHostObjFound {
-			log.Errorf("PVUpdated: failed to find VC host for given volume: %q.", volumeHandle)
-			return
+			return logger.LogNewErrorf(log, "PVUpdated: failed to find VC host for given volume: %q.", volumeHandle)
 		}
 
 		containerCluster = cnsvsphere.GetContainerCluster(clusterIDforVolumeMetadata,
@@ -3359,32 +3464,32 @@ func csiPVUpdated(ctx context.Context, newPv *v1.PersistentVolume, oldPv *v1.Per
 	log.Debugf("PVUpdated: Calling UpdateVolumeMetadata for volume %q with updateSpec: %+v",
 		updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
 	if err := cnsVolumeMgr.UpdateVolumeMetadata(ctx, updateSpec); err != nil {
-		log.Errorf("PVUpdated: UpdateVolumeMetadata failed with err %v", err)
-		return
+		return logger.LogNewErrorf(log, "PVUpdated: UpdateVolumeMetadata failed with err %v", err)
 	}
 	log.Debugf("PVUpdated: UpdateVolumeMetadata succeed for the volume %q with updateSpec: %+v",
 		updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
+	return nil
 }
 
 // csiPVDeleted deletes volume metadata on VC when volume has been deleted on
 // Vanills k8s and supervisor cluster.
-func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) {
+func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *metadataSyncInformer) error {
 	log := logger.GetLogger(ctx)
 	if IsPodVMOnStretchSupervisorFSSEnabled {
 		volumeInfo, err := volumeInfoService.GetVolumeInfoForVolumeID(ctx, pv.Spec.CSI.VolumeHandle)
 		if err != nil {
 			log.Errorf("failed to fetch CnsVolumeInfo CR. Error: %+v", err)
-			return
+			return nil
 		}
 		restConfig, err := config.GetConfig()
 		if err != nil {
 			log.Errorf("failed to fetch kubernetes config. Error: %+v", err)
-			return
+			return nil
 		}
 		cnsOperatorClient, err := k8s.NewClientForGroup(ctx, restConfig, cnsoperatorv1alpha1.GroupName)
 		if err != nil {
 			log.Errorf("failed to create CNSOperator client. Error: %+v", err)
-			return
+			return nil
 		}
 
 		// Fetch StoragePolicyUsage instance for storageClass associated with the volume.
@@ -3399,7 +3504,7 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 			log.Errorf("failed to fetch %s instance with name %q from supervisor namespace %q. Error: %+v",
 				storagepolicyv1alpha2.CRDSingular, storagePolicyUsageInstanceName,
 				volumeInfo.Spec.Namespace, err)
-			return
+			return nil
 		}
 
 		// Decrease the used capacity in StoragePolicyUsage instance as we are deleting the volume.
@@ -3417,7 +3522,7 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 				patchedStoragePolicyUsageCR)
 			if err != nil {
 				log.Errorf("updateStoragePolicyUsage failed. err: %v", err)
-				return
+				return nil
 			}
 			log.Infof("Successfully decreased the used capacity by %v Mb for StoragePolicyUsage: %q in namespace: %q",
 				volumeInfo.Spec.Capacity.ScaledValue(resource.Mega), storagePolicyUsageCR.Name, storagePolicyUsageCR.Namespace)
@@ -3428,13 +3533,13 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 		err := volumeInfoService.DeleteVolumeInfo(ctx, pv.Spec.CSI.VolumeHandle)
 		if err != nil {
 			log.Errorf("failed to delete cnsVolumeInfo CR for volume: %q. Error: %+v", pv.Spec.CSI.VolumeHandle, err)
-			return
+			return nil
 		}
 	}
 	if pv.Spec.ClaimRef != nil && pv.Status.Phase == v1.VolumeReleased &&
 		pv.Spec.PersistentVolumeReclaimPolicy == v1.PersistentVolumeReclaimDelete {
 		log.Debugf("PVDeleted: Volume deletion will be handled by Controller")
-		return
+		return nil
 	}
 
 	if IsFileVolume(pv) {
@@ -3447,14 +3552,13 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 			log.Debugf("PVDeleted: %q is a vSphere volume claim in namespace %q."+
 				"File share volumes are not supported in a multi VC setup."+
 				"Skipping deletion of PV metadata.", pv.Name, pv.Namespace)
-			return
+			return nil
 		}
 
 		vcHost, cnsVolumeMgr, err := getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, pv.Spec.CSI.VolumeHandle)
 		if err != nil {
-			log.Errorf("PVDeleted: Failed to get VC host and volume manager for single VC setup. "+
+			return logger.LogNewErrorf(log, "PVDeleted: Failed to get VC host and volume manager for single VC setup. "+
 				"Error occoured: %+v", err)
-			return
 		}
 
 		volumeOperationsLock[vcHost].Lock()
@@ -3462,8 +3566,7 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 
 		vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 		if !vcHostObjFound {
-			log.Errorf("PVDeleted: failed to find VC host for given file volume: %q.", pv.Name)
-			return
+			return logger.LogNewErrorf(log, "PVDeleted: failed to find VC host for given file volume: %q.", pv.Name)
 		}
 
 		log.Debugf("PVDeleted: vSphere CSI Driver is calling UpdateVolumeMetadata to "+
@@ -3489,8 +3592,7 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 		log.Debugf("PVDeleted: Calling UpdateVolumeMetadata for volume %s with updateSpec: %+v",
 			updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
 		if err := cnsVolumeMgr.UpdateVolumeMetadata(ctx, updateSpec); err != nil {
-			log.Errorf("PVDeleted: UpdateVolumeMetadata failed with err %v", err)
-			return
+			return logger.LogNewErrorf(log, "PVDeleted: UpdateVolumeMetadata failed with err %v", err)
 		}
 		queryFilter := cnstypes.CnsQueryFilter{
 			VolumeIds: []cnstypes.CnsVolumeId{
@@ -3502,7 +3604,7 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 		queryResult, err := utils.QueryVolumeUtil(ctx, cnsVolumeMgr, queryFilter, nil)
 		if err != nil {
 			log.Error("PVDeleted: QueryVolumeUtil failed with err=%+v", err.Error())
-			return
+			return nil
 		}
 		if queryResult != nil && len(queryResult.Volumes) == 1 &&
 			len(queryResult.Volumes[0].Metadata.EntityMetadata) == 0 {
@@ -3510,8 +3612,8 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 				pv.Spec.CSI.VolumeHandle)
 			_, err := cnsVolumeMgr.DeleteVolume(ctx, pv.Spec.CSI.VolumeHandle, false)
 			if err != nil {
-				log.Errorf("PVDeleted: Failed to delete volume %q with error %+v", pv.Spec.CSI.VolumeHandle, err)
-				return
+				return logger.LogNewErrorf(log, "PVDeleted: Failed to delete volume %q with error %+v",
+					pv.Spec.CSI.VolumeHandle, err)
 			}
 		}
 
@@ -3528,16 +3630,14 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 			// In case if feature state switch is enabled after syncer is deployed,
 			// we need to initialize the volumeMigrationService.
 			if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-				log.Errorf("PVDeleted: Failed to get migration service. Err: %v", err)
-				return
+				return logger.LogNewErrorf(log, "PVDeleted: Failed to get migration service. Err: %v", err)
 			}
 			migrationVolumeSpec := &migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath,
 				StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
 			volumeHandle, err = volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
 			if err != nil {
-				log.Errorf("PVDeleted: Failed to get VolumeID from volumeMigrationService for migration VolumeSpec: %v "+
-					"with error %+v", migrationVolumeSpec, err)
-				return
+				return logger.LogNewErrorf(log, "PVDeleted: Failed to get VolumeID from volumeMigrationService "+
+					"for migration VolumeSpec: %v with error %+v", migrationVolumeSpec, err)
 			}
 		} else {
 			volumeHandle = pv.Spec.CSI.VolumeHandle
@@ -3545,9 +3645,8 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 
 		vcHost, cnsVolumeMgr, err = getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 		if err != nil {
-			log.Errorf("PVDeleted: Failed to get VC host and volume manager for single VC setup. "+
+			return logger.LogNewErrorf(log, "PVDeleted: Failed to get VC host and volume manager for single VC setup. "+
 				"Error occoured: %+v", err)
-			return
 		}
 
 		volumeOperationsLock[vcHost].Lock()
@@ -3562,8 +3661,8 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 			// Delete the cnsvspherevolumemigration crd instance when PV is deleted.
 			err = volumeMigrationService.DeleteVolumeInfo(ctx, volumeHandle)
 			if err != nil {
-				log.Errorf("PVDeleted: failed to delete volumeInfo CR for volume: %q. Error: %+v", volumeHandle, err)
-				return
+				return logger.LogNewErrorf(log, "PVDeleted: failed to delete volumeInfo CR for volume: %q. Error: %+v",
+					volumeHandle, err)
 			}
 		}
 		if metadataSyncer.clusterFlavor == cnstypes.CnsClusterFlavorVanilla {
@@ -3585,12 +3684,14 @@ func csiPVDeleted(ctx context.Context, pv *v1.PersistentVolume, metadataSyncer *
 			}
 		}
 	}
+	return nil
 }
 
 // csiUpdatePod update/deletes pod CnsVolumeMetadata when pod has been
 // created/deleted on Vanilla k8s and supervisor cluster have been updated.
-func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSyncInformer, deleteFlag bool) {
+func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSyncInformer, deleteFlag bool) error {
 	log := logger.GetLogger(ctx)
+	var updateErr error
 	// Iterate through volumes attached to pod.
 	for _, volume := range pod.Spec.Volumes {
 		var (
@@ -3623,16 +3724,14 @@ func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSync
 					// In case if feature state switch is enabled after syncer is
 					// deployed, we need to initialize the volumeMigrationService.
 					if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-						log.Errorf("PodUpdated: Failed to get migration service. Err: %v", err)
-						return
+						return logger.LogNewErrorf(log, "PodUpdated: Failed to get migration service. Err: %v", err)
 					}
 					migrationVolumeSpec := &migration.VolumeSpec{VolumePath: pv.Spec.VsphereVolume.VolumePath,
 						StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName}
 					volumeHandle, err = volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
 					if err != nil {
-						log.Errorf("Failed to get VolumeID from volumeMigrationService for migration VolumeSpec: %v "+
-							"with error %+v", migrationVolumeSpec, err)
-						return
+						return logger.LogNewErrorf(log, "Failed to get VolumeID from volumeMigrationService "+
+							"for migration VolumeSpec: %v with error %+v", migrationVolumeSpec, err)
 					}
 				} else {
 					volumeHandle = pv.Spec.CSI.VolumeHandle
@@ -3644,7 +3743,7 @@ func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSync
 			} else {
 				log.Debugf("Volume %q is not a valid vSphere volume for the pod %q",
 					volume.PersistentVolumeClaim.ClaimName, pod.Name)
-				return
+				return nil
 			}
 		} else {
 			// Inline migrated volumes with no PVC.
@@ -3659,15 +3758,14 @@ func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSync
 					// In case if feature state switch is enabled after syncer is
 					// deployed, we need to initialize the volumeMigrationService.
 					if err = initVolumeMigrationService(ctx, metadataSyncer); err != nil {
-						log.Errorf("PodUpdated: Failed to get migration service. Err: %v", err)
-						return
+						return logger.LogNewErrorf(log, "PodUpdated: Failed to get migration service. Err: %v", err)
 					}
 					migrationVolumeSpec := &migration.VolumeSpec{VolumePath: volume.VsphereVolume.VolumePath}
 					volumeHandle, err = volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, true)
 					if err != nil {
 						log.Warnf("Failed to get VolumeID from volumeMigrationService for migration VolumeSpec: %v "+
 							"with error %+v", migrationVolumeSpec, err)
-						return
+						return nil
 					}
 				} else {
 					log.Debugf("Volume %q is not an inline migrated vSphere volume", volume.Name)
@@ -3690,14 +3788,12 @@ func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSync
 		// Fetch vCenterHost & volumeManager for given volume, based on VC configuration
 		vcHost, cnsVolumeMgr, err := getVcHostAndVolumeManagerForVolumeID(ctx, metadataSyncer, volumeHandle)
 		if err != nil {
-			log.Errorf("csiUpdatePod: Failed to get VC host and volume manager for the given volume: %v. "+
+			return logger.LogNewErrorf(log, "csiUpdatePod: Failed to get VC host and volume manager for the given volume: %v. "+
 				"Error occoured: %+v", volumeHandle, err)
-			return
 		}
 		vcHostObj, vcHostObjFound := metadataSyncer.configInfo.Cfg.VirtualCenter[vcHost]
 		if !vcHostObjFound {
-			log.Errorf("csiUpdatePod: failed to find VC host for given volume: %q.", volumeHandle)
-			return
+			return logger.LogNewErrorf(log, "csiUpdatePod: failed to find VC host for given volume: %q.", volumeHandle)
 		}
 
 		containerCluster := cnsvsphere.GetContainerCluster(clusterIDforVolumeMetadata,
@@ -3717,10 +3813,14 @@ func csiUpdatePod(ctx context.Context, pod *v1.Pod, metadataSyncer *metadataSync
 		log.Debugf("Calling UpdateVolumeMetadata for volume %s with updateSpec: %+v",
 			updateSpec.VolumeId.Id, spew.Sdump(updateSpec))
 		if err := cnsVolumeMgr.UpdateVolumeMetadata(ctx, updateSpec); err != nil {
-			log.Errorf("UpdateVolumeMetadata failed for volume %s with err: %v", volume.Name, err)
+			// Continue with the remaining volumes of the pod, the update
+			// is retried for all of them.
+			updateErr = logger.LogNewErrorf(log, "UpdateVolumeMetadata failed for volume %s with err: %v",
+				volume.Name, err)
 		}
 
 	}
+	return updateErr
 }
 
 func initVolumeHealthReconciler(ctx context.Context, tkgKubeClient clientset.Interface,
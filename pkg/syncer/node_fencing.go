@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	volumes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+)
+
+const (
+	// default interval for checking for failed nodes to fence
+	defaultNodeFencingIntervalInMin = 1
+	// default time a node must stay failed before its volumes are force-detached
+	defaultNodeFencingDelayInMin = 5
+
+	// Reasons of the events recorded on the fenced nodes.
+	nodeFailureDetectedReason     = "NodeFailureDetected"
+	volumeForceDetachedReason     = "VolumeForceDetached"
+	volumeForceDetachFailedReason = "VolumeForceDetachFailed"
+)
+
+// nodeFailureDetectedTime maps the name of a failed node to the time the
+// failure was first detected. It is only accessed by the node fencing
+// goroutine.
+var nodeFailureDetectedTime = make(map[string]time.Time)
+
+// getNodeFencingIntervalInMin returns the interval between two checks for
+// failed nodes.
+func getNodeFencingIntervalInMin(ctx context.Context) int {
+	return getMinutesFromEnv(ctx, "NODE_FENCING_INTERVAL_MINUTES", defaultNodeFencingIntervalInMin)
+}
+
+// getNodeFencingDelayInMin returns the time a node must stay failed before
+// its volumes are force-detached.
+func getNodeFencingDelayInMin(ctx context.Context) int {
+	return getMinutesFromEnv(ctx, "NODE_FENCING_DELAY_MINUTES", defaultNodeFencingDelayInMin)
+}
+
+// getMinutesFromEnv returns the positive number of minutes set in the
+// given env variable, or defaultValue if it is not set or invalid.
+func getMinutesFromEnv(ctx context.Context, envName string, defaultValue int) int {
+	log := logger.GetLogger(ctx)
+	v := os.Getenv(envName)
+	if v == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(v)
+	if err != nil || value <= 0 {
+		log.Warnf("value %q set in env variable %s is invalid, will use the default value %d",
+			v, envName, defaultValue)
+		return defaultValue
+	}
+	log.Infof("%s is set to %d minutes", envName, value)
+	return value
+}
+
+// newSyncerEventRecorder returns a recorder for the events recorded by the
+// syncer on nodes and PVs.
+func newSyncerEventRecorder(k8sClient clientset.Interface) record.EventRecorder {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: syncerComponent})
+}
+
+// csiFenceFailedNodes force-detaches the block volumes attached to the nodes
+// which are tainted out-of-service or not ready, and whose NodeVM is powered
+// off. The VolumeAttachments of nodes tainted out-of-service whose NodeVM is
+// not found are deleted too. The volumes of a node are detached only once
+// the NodeVM is verified to be inactive or not found and the node has stayed
+// failed for fencingDelay.
+// The VolumeAttachments of the detached volumes are deleted so that the
+// volumes can be attached to other nodes.
+func csiFenceFailedNodes(ctx context.Context, k8sClient clientset.Interface,
+	metadataSyncer *metadataSyncInformer, recorder record.EventRecorder, fencingDelay time.Duration) {
+	log := logger.GetLogger(ctx)
+	nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("NodeFencing: failed to list nodes. Error: %v", err)
+		return
+	}
+	vaList, err := k8sClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("NodeFencing: failed to list VolumeAttachments. Error: %v", err)
+		return
+	}
+	nodeVolumeAttachments := make(map[string][]storagev1.VolumeAttachment)
+	for _, va := range vaList.Items {
+		if va.Spec.Attacher != csitypes.Name || va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		nodeVolumeAttachments[va.Spec.NodeName] = append(nodeVolumeAttachments[va.Spec.NodeName], va)
+	}
+
+	failedNodes := make(map[string]struct{})
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		outOfService := hasOutOfServiceTaint(node)
+		if !outOfService && isNodeReady(node) {
+			continue
+		}
+		if len(nodeVolumeAttachments[node.Name]) == 0 {
+			continue
+		}
+		// ErrVMNotFound is also returned when a vCenter is unreachable or its
+		// session is stale, so a NodeVM which is not found is only considered
+		// deleted once an admin tainted the node out-of-service.
+		vmNotFound := false
+		nodeVM, err := nodeMgr.GetNodeVMByNameAndUpdateCache(ctx, node.Name)
+		if err != nil {
+			if !errors.Is(err, cnsvsphere.ErrVMNotFound) {
+				log.Errorf("NodeFencing: failed to get NodeVM of node %q. Error: %v", node.Name, err)
+				continue
+			}
+			if !outOfService {
+				log.Warnf("NodeFencing: NodeVM of node %q not found and the node is not tainted %q. "+
+					"Skipping it.", node.Name, v1.TaintNodeOutOfService)
+				continue
+			}
+			log.Warnf("NodeFencing: NodeVM of node %q not found", node.Name)
+			vmNotFound = true
+		} else {
+			active, err := nodeVM.IsActive(ctx)
+			if err != nil {
+				log.Errorf("NodeFencing: failed to check if NodeVM of node %q is active. Error: %v",
+					node.Name, err)
+				continue
+			}
+			if active {
+				if outOfService {
+					log.Warnf("NodeFencing: node %q is tainted %q but its NodeVM is powered on. Skipping it.",
+						node.Name, v1.TaintNodeOutOfService)
+				}
+				continue
+			}
+		}
+		failedNodes[node.Name] = struct{}{}
+		detectedTime, found := nodeFailureDetectedTime[node.Name]
+		if !found {
+			nodeFailureDetectedTime[node.Name] = time.Now()
+			failure := "NodeVM is not powered on"
+			if vmNotFound {
+				failure = "NodeVM is not found"
+			}
+			recorder.Eventf(node, v1.EventTypeWarning, nodeFailureDetectedReason,
+				"%s. Volumes attached to the node will be force-detached in %v if it does not recover",
+				failure, fencingDelay)
+			continue
+		}
+		if time.Since(detectedTime) < fencingDelay {
+			continue
+		}
+		log.Infof("NodeFencing: node %q failed at %v. Force-detaching its volumes.", node.Name, detectedTime)
+		var volManager volumes.Manager
+		if !vmNotFound {
+			volManager, err = getVolManagerForVcHost(ctx, nodeVM.VirtualCenterHost, metadataSyncer)
+			if err != nil {
+				log.Errorf("NodeFencing: failed to get volume manager for vCenter %q. Error: %v",
+					nodeVM.VirtualCenterHost, err)
+				continue
+			}
+		}
+		for _, va := range nodeVolumeAttachments[node.Name] {
+			pv, err := metadataSyncer.pvLister.Get(*va.Spec.Source.PersistentVolumeName)
+			if err != nil {
+				log.Errorf("NodeFencing: failed to get PV %q. Error: %v", *va.Spec.Source.PersistentVolumeName, err)
+				continue
+			}
+			if pv.Spec.CSI == nil || IsFileVolume(pv) {
+				continue
+			}
+			volumeID := pv.Spec.CSI.VolumeHandle
+			// Volumes of a NodeVM which is not found are already detached
+			// from it, only the VolumeAttachment needs to be deleted.
+			if va.Status.Attached && !vmNotFound {
+				faultType, err := volManager.DetachVolume(ctx, nodeVM, volumeID)
+				if err != nil {
+					log.Errorf("NodeFencing: failed to detach volume %q from node %q. fault: %q, Error: %v",
+						volumeID, node.Name, faultType, err)
+					recorder.Eventf(node, v1.EventTypeWarning, volumeForceDetachFailedReason,
+						"Failed to force-detach volume %q of PV %q: %v", volumeID, pv.Name, err)
+					continue
+				}
+			}
+			err = k8sClient.StorageV1().VolumeAttachments().Delete(ctx, va.Name, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				log.Errorf("NodeFencing: failed to delete VolumeAttachment %q. Error: %v", va.Name, err)
+				recorder.Eventf(node, v1.EventTypeWarning, volumeForceDetachFailedReason,
+					"Failed to delete VolumeAttachment %q of PV %q: %v", va.Name, pv.Name, err)
+				continue
+			}
+			log.Infof("NodeFencing: force-detached volume %q of PV %q from node %q", volumeID, pv.Name, node.Name)
+			recorder.Event(node, v1.EventTypeNormal, volumeForceDetachedReason,
+				fmt.Sprintf("Force-detached volume %q of PV %q", volumeID, pv.Name))
+		}
+	}
+	// Forget the nodes which recovered or no longer have volumes attached.
+	for nodeName := range nodeFailureDetectedTime {
+		if _, ok := failedNodes[nodeName]; !ok {
+			delete(nodeFailureDetectedTime, nodeName)
+		}
+	}
+}
+
+// hasOutOfServiceTaint returns true if the node is tainted with
+// node.kubernetes.io/out-of-service.
+func hasOutOfServiceTaint(node *v1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == v1.TaintNodeOutOfService {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeReady returns true if the Ready condition of the node is true.
+func isNodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/node"
+	cnsvolumes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/volume"
+	cnsvsphere "sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/cns-lib/vsphere"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/unittestcommon"
+	csitypes "sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/types"
+)
+
+// fakeFencingNodeManager returns the NodeVMs of the nodes from a fixed map
+// and ErrVMNotFound for the other nodes.
+type fakeFencingNodeManager struct {
+	node.Manager
+	nodeVMs map[string]*cnsvsphere.VirtualMachine
+}
+
+func (m *fakeFencingNodeManager) GetNodeVMByNameAndUpdateCache(ctx context.Context,
+	nodeName string) (*cnsvsphere.VirtualMachine, error) {
+	if nodeVM, ok := m.nodeVMs[nodeName]; ok {
+		return nodeVM, nil
+	}
+	return nil, cnsvsphere.ErrVMNotFound
+}
+
+func TestNodeFailureConditions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		node                 *v1.Node
+		expectedOutOfService bool
+		expectedReady        bool
+	}{
+		{
+			name: "ready node",
+			node: &v1.Node{
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				},
+			},
+			expectedReady: true,
+		},
+		{
+			name: "not ready node tainted out-of-service",
+			node: &v1.Node{
+				Spec: v1.NodeSpec{
+					Taints: []v1.Taint{
+						{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute},
+						{Key: v1.TaintNodeOutOfService, Value: "nodeshutdown", Effect: v1.TaintEffectNoExecute},
+					},
+				},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown}},
+				},
+			},
+			expectedOutOfService: true,
+		},
+		{
+			name:          "node without conditions",
+			node:          &v1.Node{},
+			expectedReady: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedOutOfService, hasOutOfServiceTaint(test.node))
+			assert.Equal(t, test.expectedReady, isNodeReady(test.node))
+		})
+	}
+}
+
+func TestGetMinutesFromEnv(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, defaultNodeFencingDelayInMin, getNodeFencingDelayInMin(ctx))
+	t.Setenv("NODE_FENCING_DELAY_MINUTES", "10")
+	assert.Equal(t, 10, getNodeFencingDelayInMin(ctx))
+	t.Setenv("NODE_FENCING_DELAY_MINUTES", "0")
+	assert.Equal(t, defaultNodeFencingDelayInMin, getNodeFencingDelayInMin(ctx))
+	t.Setenv("NODE_FENCING_DELAY_MINUTES", "soon")
+	assert.Equal(t, defaultNodeFencingDelayInMin, getNodeFencingDelayInMin(ctx))
+}
+
+func TestCsiFenceFailedNodes(t *testing.T) {
+	ctx := context.Background()
+	model := simulator.VPX()
+	defer model.Remove()
+	if err := model.Create(); err != nil {
+		t.Fatalf("failed to create simulator model. err: %v", err)
+	}
+	s := model.Service.NewServer()
+	defer s.Close()
+	client, err := govmomi.NewClient(ctx, s.URL, true)
+	if err != nil {
+		t.Fatalf("failed to create govmomi client. err: %v", err)
+	}
+	finder := find.NewFinder(client.Client)
+	datacenter, err := finder.DefaultDatacenter(ctx)
+	if err != nil {
+		t.Fatalf("failed to get datacenter. err: %v", err)
+	}
+	finder.SetDatacenter(datacenter)
+	vms, err := finder.VirtualMachineList(ctx, "*")
+	if err != nil || len(vms) < 2 {
+		t.Fatalf("failed to get VMs. err: %v", err)
+	}
+	task, err := vms[0].PowerOff(ctx)
+	if err != nil {
+		t.Fatalf("failed to power off VM. err: %v", err)
+	}
+	if err = task.Wait(ctx); err != nil {
+		t.Fatalf("failed to power off VM. err: %v", err)
+	}
+	newNodeVM := func(vm *object.VirtualMachine) *cnsvsphere.VirtualMachine {
+		return &cnsvsphere.VirtualMachine{
+			VirtualCenterHost: "vc-1",
+			VirtualMachine:    vm,
+			Datacenter:        &cnsvsphere.Datacenter{Datacenter: datacenter, VirtualCenterHost: "vc-1"},
+		}
+	}
+
+	originalNodeMgr := nodeMgr
+	defer func() {
+		nodeMgr = originalNodeMgr
+		nodeFailureDetectedTime = make(map[string]time.Time)
+	}()
+	nodeMgr = &fakeFencingNodeManager{nodeVMs: map[string]*cnsvsphere.VirtualMachine{
+		"powered-off-node": newNodeVM(vms[0]),
+		"not-ready-node":   newNodeVM(vms[1]),
+	}}
+	nodeFailureDetectedTime = make(map[string]time.Time)
+
+	// The node with a powered on NodeVM is not fenced although it is not ready.
+	k8sClient := testclient.NewClientset()
+	pvIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	// The node with a NodeVM which is not found is only fenced if it is
+	// tainted out-of-service.
+	for _, nodeName := range []string{"powered-off-node", "missing-node", "missing-tainted-node",
+		"not-ready-node"} {
+		var taints []v1.Taint
+		if nodeName == "missing-tainted-node" {
+			taints = []v1.Taint{{Key: v1.TaintNodeOutOfService, Effect: v1.TaintEffectNoExecute}}
+		}
+		_, err = k8sClient.CoreV1().Nodes().Create(ctx, &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec:       v1.NodeSpec{Taints: taints},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown}},
+			},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		pvName := "pv-" + nodeName
+		err = pvIndexer.Add(&v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: pvName},
+			Spec: v1.PersistentVolumeSpec{
+				PersistentVolumeSource: v1.PersistentVolumeSource{
+					CSI: &v1.CSIPersistentVolumeSource{Driver: csitypes.Name, VolumeHandle: "volume-" + nodeName},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		_, err = k8sClient.StorageV1().VolumeAttachments().Create(ctx, &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "va-" + nodeName},
+			Spec: storagev1.VolumeAttachmentSpec{
+				Attacher: csitypes.Name,
+				NodeName: nodeName,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+			},
+			Status: storagev1.VolumeAttachmentStatus{Attached: true},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	metadataSyncer := &metadataSyncInformer{
+		volumeManagers: map[string]cnsvolumes.Manager{"vc-1": &unittestcommon.MockVolumeManager{}},
+		pvLister:       corelisters.NewPersistentVolumeLister(pvIndexer),
+	}
+	recorder := record.NewFakeRecorder(10)
+	getVolumeAttachmentNames := func() []string {
+		vaList, err := k8sClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		var names []string
+		for _, va := range vaList.Items {
+			names = append(names, va.Name)
+		}
+		return names
+	}
+
+	// The failure of the nodes is only recorded on the first check.
+	csiFenceFailedNodes(ctx, k8sClient, metadataSyncer, recorder, time.Hour)
+	assert.Len(t, nodeFailureDetectedTime, 2)
+	assert.ElementsMatch(t, []string{"va-powered-off-node", "va-missing-node", "va-missing-tainted-node",
+		"va-not-ready-node"}, getVolumeAttachmentNames())
+	events := []string{<-recorder.Events, <-recorder.Events}
+	for _, expected := range []string{"NodeVM is not powered on", "NodeVM is not found"} {
+		assert.True(t, strings.Contains(strings.Join(events, "\n"), expected),
+			"expected an event with %q in %v", expected, events)
+	}
+
+	// The volumes are not detached before the fencing delay elapsed.
+	csiFenceFailedNodes(ctx, k8sClient, metadataSyncer, recorder, time.Hour)
+	assert.Len(t, getVolumeAttachmentNames(), 4)
+
+	// The volumes of the powered off NodeVM and of the missing NodeVM of the
+	// tainted node are detached once the fencing delay elapsed.
+	csiFenceFailedNodes(ctx, k8sClient, metadataSyncer, recorder, 0)
+	assert.ElementsMatch(t, []string{"va-missing-node", "va-not-ready-node"}, getVolumeAttachmentNames())
+	assert.Len(t, recorder.Events, 2)
+
+	// Nodes which no longer have volumes attached are forgotten.
+	csiFenceFailedNodes(ctx, k8sClient, metadataSyncer, recorder, 0)
+	assert.Empty(t, nodeFailureDetectedTime)
+}
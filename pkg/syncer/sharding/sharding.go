@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits the work of the syncer between its replicas.
+//
+// Each replica holds a Lease in the namespace of the syncer, which it renews
+// periodically. The replicas whose Lease is not expired are the members of
+// the syncer. The work is split in shards, identified by keys such as a
+// vCenter or a range of volume IDs of a vCenter, and each shard is owned by a
+// single member, chosen by rendezvous hashing of the members and the key.
+// When a replica is lost, its Lease expires and its shards are rebalanced to
+// the remaining members, without moving the shards of the other members.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/common/prometheus"
+	"sigs.k8s.io/vsphere-csi-driver/v3/pkg/csi/service/logger"
+)
+
+const (
+	// ClusterShard is the key of the shard of the work of the syncer which is
+	// not split by vCenter.
+	ClusterShard = "cluster"
+	// memberLabel is the label of the Leases of the members of the syncer.
+	memberLabel = "cns.vmware.com/syncer-shard-member"
+	// leaseNamePrefix is the prefix of the names of the Leases of the members
+	// of the syncer.
+	leaseNamePrefix = "vsphere-syncer-shard-"
+)
+
+// Config is the configuration of a Sharder.
+type Config struct {
+	// Namespace is the namespace of the Leases of the members.
+	Namespace string
+	// Identity is the identity of this replica.
+	Identity string
+	// LeaseDuration is the duration after which the Lease of a member which
+	// stopped renewing it is expired.
+	LeaseDuration time.Duration
+	// RenewPeriod is the period of the renewal of the Lease of this replica
+	// and of the refresh of the members.
+	RenewPeriod time.Duration
+	// VolumeRanges is the number of ranges the volume IDs of a vCenter are
+	// split in.
+	VolumeRanges int
+}
+
+// Sharder tracks the members of the syncer and tells which shards are owned
+// by this replica. A nil Sharder owns all the shards, so that callers can use
+// it whether sharding is enabled or not.
+type Sharder struct {
+	client kubernetes.Interface
+	config Config
+	// lock protects members.
+	lock sync.RWMutex
+	// members holds the sorted identities of the members of the syncer.
+	members []string
+}
+
+// New returns a Sharder with the given configuration.
+func New(client kubernetes.Interface, config Config) *Sharder {
+	if config.VolumeRanges < 1 {
+		config.VolumeRanges = 1
+	}
+	return &Sharder{
+		client:  client,
+		config:  config,
+		members: []string{config.Identity},
+	}
+}
+
+// Start joins the members of the syncer and keeps the membership up to date
+// until the context is done, when this replica leaves the members.
+func (s *Sharder) Start(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	if err := s.renew(ctx); err != nil {
+		return logger.LogNewErrorf(log, "failed to join the syncer shard members. Err: %v", err)
+	}
+	if err := s.refresh(ctx); err != nil {
+		return logger.LogNewErrorf(log, "failed to list the syncer shard members. Err: %v", err)
+	}
+	go func() {
+		wait.Until(func() {
+			if err := s.renew(ctx); err != nil {
+				log.Errorf("Failed to renew the syncer shard Lease of %q. Err: %v", s.config.Identity, err)
+			}
+			if err := s.refresh(ctx); err != nil {
+				log.Errorf("Failed to list the syncer shard members. Err: %v", err)
+			}
+		}, s.config.RenewPeriod, ctx.Done())
+		s.leave()
+	}()
+	return nil
+}
+
+// renew creates or renews the Lease of this replica.
+func (s *Sharder) renew(ctx context.Context) error {
+	leases := s.client.CoordinationV1().Leases(s.config.Namespace)
+	name := leaseNamePrefix + s.config.Identity
+	identity := s.config.Identity
+	leaseDurationSeconds := int32(s.config.LeaseDuration.Seconds())
+	now := metav1.NewMicroTime(time.Now())
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.config.Namespace,
+				Labels:    map[string]string{memberLabel: "true"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// refresh updates the members from the Leases which are not expired.
+func (s *Sharder) refresh(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	leaseList, err := s.client.CoordinationV1().Leases(s.config.Namespace).List(ctx,
+		metav1.ListOptions{LabelSelector: memberLabel + "=true"})
+	if err != nil {
+		return err
+	}
+	members := []string{s.config.Identity}
+	now := time.Now()
+	for _, lease := range leaseList.Items {
+		if isExpired(&lease, now) || *lease.Spec.HolderIdentity == s.config.Identity {
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	slices.Sort(members)
+
+	s.lock.Lock()
+	previous := s.members
+	s.members = members
+	s.lock.Unlock()
+	if !slices.Equal(previous, members) {
+		log.Infof("Syncer shard members changed from %v to %v. Rebalancing the shards.", previous, members)
+	}
+	prometheus.SyncerShardMembersGauge.Set(float64(len(members)))
+	return nil
+}
+
+// leave deletes the Lease of this replica, so that its shards are rebalanced
+// without waiting for the Lease to expire.
+func (s *Sharder) leave() {
+	ctx, log := logger.GetNewContextWithLogger()
+	err := s.client.CoordinationV1().Leases(s.config.Namespace).Delete(ctx,
+		leaseNamePrefix+s.config.Identity, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Warnf("Failed to delete the syncer shard Lease of %q. Err: %v", s.config.Identity, err)
+	}
+}
+
+// isExpired returns true if the Lease is not held or was not renewed within
+// its duration.
+func isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry)
+}
+
+// Members returns the identities of the members of the syncer.
+func (s *Sharder) Members() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return slices.Clone(s.members)
+}
+
+// Owns returns true if the shard with the given key is owned by this replica.
+func (s *Sharder) Owns(key string) bool {
+	if s == nil {
+		return true
+	}
+	return s.Owner(key) == s.config.Identity
+}
+
+// Owner returns the identity of the member owning the shard with the given
+// key. It is the member with the highest hash of its identity and the key.
+func (s *Sharder) Owner(key string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var owner string
+	var ownerHash uint64
+	for _, member := range s.members {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(member))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write([]byte(key))
+		if owner == "" || hash.Sum64() > ownerHash {
+			owner, ownerHash = member, hash.Sum64()
+		}
+	}
+	return owner
+}
+
+// OwnsVCenter returns true if the work of the given vCenter which is not
+// split by volumes is owned by this replica.
+func (s *Sharder) OwnsVCenter(vc string) bool {
+	return s.Owns(VCenterShard(vc))
+}
+
+// OwnsVolume returns true if the volume with the given ID in the given scope,
+// such as a vCenter, is owned by this replica.
+func (s *Sharder) OwnsVolume(scope, volumeID string) bool {
+	if s == nil {
+		return true
+	}
+	return s.Owns(s.VolumeShard(scope, volumeID))
+}
+
+// OwnsAny returns true if this replica owns the given vCenter or any range of
+// its volumes.
+func (s *Sharder) OwnsAny(vc string) bool {
+	if s == nil || s.OwnsVCenter(vc) {
+		return true
+	}
+	for volumeRange := 0; volumeRange < s.config.VolumeRanges; volumeRange++ {
+		if s.Owns(volumeRangeShard(vc, volumeRange)) {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumeShards returns the keys of the shards of the volumes in the given
+// scope.
+func (s *Sharder) VolumeShards(scope string) []string {
+	if s == nil {
+		return nil
+	}
+	shards := make([]string, 0, s.config.VolumeRanges)
+	for volumeRange := 0; volumeRange < s.config.VolumeRanges; volumeRange++ {
+		shards = append(shards, volumeRangeShard(scope, volumeRange))
+	}
+	return shards
+}
+
+// VolumeShard returns the key of the shard of the volume with the given ID in
+// the given scope.
+func (s *Sharder) VolumeShard(scope, volumeID string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(volumeID))
+	return volumeRangeShard(scope, int(hash.Sum32()%uint32(s.config.VolumeRanges)))
+}
+
+// VCenterShard returns the key of the shard of the work of the given vCenter
+// which is not split by volumes.
+func VCenterShard(vc string) string {
+	return fmt.Sprintf("vcenter/%s", vc)
+}
+
+// volumeRangeShard returns the key of the shard of the given range of volume
+// IDs in the given scope.
+func volumeRangeShard(scope string, volumeRange int) string {
+	return "volumes/" + scope + "/" + strconv.Itoa(volumeRange)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSharder(client *fake.Clientset, identity string) *Sharder {
+	return New(client, Config{
+		Namespace:     "vmware-system-csi",
+		Identity:      identity,
+		LeaseDuration: 15 * time.Second,
+		RenewPeriod:   5 * time.Second,
+		VolumeRanges:  4,
+	})
+}
+
+func TestShardsAreOwnedByOneMember(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	sharders := []*Sharder{
+		newTestSharder(client, "syncer-a"),
+		newTestSharder(client, "syncer-b"),
+		newTestSharder(client, "syncer-c"),
+	}
+	for _, s := range sharders {
+		assert.NoError(t, s.renew(ctx))
+	}
+	for _, s := range sharders {
+		assert.NoError(t, s.refresh(ctx))
+		assert.Equal(t, []string{"syncer-a", "syncer-b", "syncer-c"}, s.Members())
+	}
+
+	owners := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		volumeID := fmt.Sprintf("volume-%d", i)
+		var owner string
+		for _, s := range sharders {
+			if s.OwnsVolume("vc1", volumeID) {
+				assert.Empty(t, owner, "volume %s is owned by several members", volumeID)
+				owner = s.config.Identity
+			}
+		}
+		assert.NotEmpty(t, owner, "volume %s is not owned", volumeID)
+		owners[volumeID] = owner
+	}
+	for _, s := range sharders {
+		for _, shard := range s.VolumeShards("vc1") {
+			assert.Equal(t, sharders[0].Owner(shard), s.Owner(shard))
+		}
+	}
+
+	// When a member leaves, only its shards are rebalanced.
+	sharders[2].leave()
+	assert.NoError(t, sharders[0].refresh(ctx))
+	assert.Equal(t, []string{"syncer-a", "syncer-b"}, sharders[0].Members())
+	for volumeID, owner := range owners {
+		if owner != "syncer-c" {
+			assert.Equal(t, owner == "syncer-a", sharders[0].OwnsVolume("vc1", volumeID))
+		}
+	}
+}
+
+func TestExpiredMembersAreIgnored(t *testing.T) {
+	ctx := context.TODO()
+	identity := "syncer-b"
+	leaseDurationSeconds := int32(15)
+	renewTime := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	client := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaseNamePrefix + identity,
+			Namespace: "vmware-system-csi",
+			Labels:    map[string]string{memberLabel: "true"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &identity,
+			LeaseDurationSeconds: &leaseDurationSeconds,
+			RenewTime:            &renewTime,
+		},
+	})
+	s := newTestSharder(client, "syncer-a")
+	assert.NoError(t, s.renew(ctx))
+	assert.NoError(t, s.refresh(ctx))
+	assert.Equal(t, []string{"syncer-a"}, s.Members())
+	assert.True(t, s.OwnsAny("vc1"))
+	assert.True(t, s.OwnsVCenter("vc1"))
+
+	// A nil Sharder owns all the shards.
+	var nilSharder *Sharder
+	assert.True(t, nilSharder.OwnsVolume("vc1", "volume-1"))
+	assert.True(t, nilSharder.OwnsVCenter("vc1"))
+	assert.True(t, nilSharder.OwnsAny("vc1"))
+}
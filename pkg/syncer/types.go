@@ -125,6 +125,9 @@ type metadataSyncInformer struct {
 	// The vCenter IP/FQDN under each tag are maintained as a map of string with nil values to improve
 	// retrieval and deletion performance.
 	topologyVCMap map[string]map[string]struct{}
+	// metadataSyncQueue syncs the PV, PVC and pod events to CNS. It is nil
+	// when the events are synced from the informer callbacks.
+	metadataSyncQueue *metadataSyncQueue
 }
 
 const (
@@ -146,3 +149,25 @@ const (
 	// storagePolicyQuotaWorkers represents the number of running worker threads
 	storagePolicyQuotaWorkers = 10
 )
+
+const (
+	// defaultMetadataSyncWorkers represents the default number of workers of
+	// the metadata sync queue
+	defaultMetadataSyncWorkers = 4
+	// metadataSyncRetryIntervalStart represents the start retry interval of the metadata sync queue
+	metadataSyncRetryIntervalStart = time.Second
+	// metadataSyncRetryIntervalMax represents the max retry interval of the metadata sync queue
+	metadataSyncRetryIntervalMax = 5 * time.Minute
+	// maxMetadataSyncRetries represents the number of retries of an event
+	// before it is left to full sync
+	maxMetadataSyncRetries = 10
+	// metadataSyncQueueMetricsInterval represents the interval between two
+	// updates of the metadata sync queue metrics
+	metadataSyncQueueMetricsInterval = 15 * time.Second
+	// defaultCompleteFullSyncIntervalCycles represents the default number of
+	// full sync cycles between two complete full syncs
+	defaultCompleteFullSyncIntervalCycles = 6
+	// fullSyncScheduleInterval represents the interval between two checks of
+	// the full sync schedule of the VCs
+	fullSyncScheduleInterval = time.Minute
+)
@@ -183,9 +183,10 @@ func IsValidVolume(ctx context.Context, volume v1.Volume, pod *v1.Pod,
 // fullSyncGetQueryResults returns list of CnsQueryResult retrieved using
 // queryFilter with offset and limit to query volumes using pagination
 // if volumeIds is empty, then all volumes from CNS will be retrieved by
-// pagination.
+// pagination. The queries are made within the CNS calls budget of the VC.
 func fullSyncGetQueryResults(ctx context.Context, volumeIds []cnstypes.CnsVolumeId, clusterID string,
-	volumeManager volumes.Manager, metadataSyncer *metadataSyncInformer) ([]*cnstypes.CnsQueryResult, error) {
+	volumeManager volumes.Manager, metadataSyncer *metadataSyncInformer,
+	vc string) ([]*cnstypes.CnsQueryResult, error) {
 	log := logger.GetLogger(ctx)
 	log.Debugf("FullSync: fullSyncGetQueryResults is called with volumeIds %v for clusterID %s",
 		volumeIds, clusterID)
@@ -206,6 +207,9 @@ func fullSyncGetQueryResults(ctx context.Context, volumeIds []cnstypes.CnsVolume
 		if clusterID != "" {
 			queryFilter.ContainerClusterIds = []string{clusterID}
 		}
+		if err := fullSyncSchedules.waitForCnsCallBudget(ctx, vc); err != nil {
+			return nil, err
+		}
 		queryResult, err := utils.QueryVolumeUtil(ctx, volumeManager, queryFilter, nil)
 		if err != nil {
 			return nil, logger.LogNewErrorCodef(log, codes.Internal,
@@ -341,25 +345,8 @@ func initVolumeMigrationService(ctx context.Context, metadataSyncer *metadataSyn
 		return nil
 	}
 	var err error
-	var volManager volumes.Manager
-
-	if len(metadataSyncer.configInfo.Cfg.VirtualCenter) > 1 {
-		// Migration feature switch is enabled and multi vCenter feature is enabled, and
-		// Kubernetes Cluster is spread on multiple vCenter Servers.
-		return logger.LogNewErrorf(log,
-			"volume-migration feature is not supported on Multi-vCenter deployment")
-	}
-
-	// It is a single VC setup with Multi VC FSS enabled, we need to pick up the one and only volume manager in inventory.
-	vCenter := metadataSyncer.configInfo.Cfg.Global.VCenterIP
-	cnsVolumeMgr, volMgrFound := metadataSyncer.volumeManagers[vCenter]
-	if !volMgrFound {
-		return logger.LogNewErrorf(log, "could not get volume manager for the vCenter: %q", vCenter)
-	}
-	volManager = cnsVolumeMgr
-
 	volumeMigrationService, err = migration.GetVolumeMigrationService(ctx,
-		&volManager, metadataSyncer.configInfo.Cfg, true)
+		metadataSyncer.volumeManagers, metadataSyncer.configInfo.Cfg, true)
 	if err != nil {
 		log.Errorf("failed to get migration service. Err: %v", err)
 		return err
@@ -453,6 +440,14 @@ func getVcHostAndVolumeManagerForVolumeID(ctx context.Context,
 
 	if volumeInfoService != nil {
 		vCenter, err := volumeInfoService.GetvCenterForVolumeID(ctx, volumeID)
+		if err != nil && volumeMigrationService != nil {
+			// Migrated in-tree volumes may not have a CnsVolumeInfo CR yet.
+			// Look up their vCenter from the volume migration service.
+			if migratedVolumeVCenter, migrationErr := volumeMigrationService.GetVCenterForVolumeID(ctx,
+				volumeID); migrationErr == nil {
+				vCenter, err = migratedVolumeVCenter, nil
+			}
+		}
 		if err != nil {
 			log.Errorf("failed to get vCenter for the volumeID: %q with err=%+v", volumeID, err)
 			return "", nil, logger.LogNewErrorf(log,
@@ -613,9 +608,21 @@ func getPVsInBoundAvailableOrReleasedForVc(ctx context.Context, metadataSyncer *
 		if pv.Spec.CSI == nil {
 			if metadataSyncer.coCommonInterface.IsFSSEnabled(ctx, common.CSIMigration) &&
 				pv.Spec.VsphereVolume != nil {
-				return nil, logger.LogNewErrorf(log,
-					"In-tree volumes are not supported on a multi VC set up."+
-						"Found in-tree volume %q.", pv.Name)
+				// Migrated in-tree volumes are registered with CNS on the vCenter
+				// of their datastore.
+				vCenter, err := getVcHostForMigratedVolume(ctx, metadataSyncer, pv)
+				if err == migration.ErrVolumeIDNotFound {
+					log.Infof("in-tree volume %q is not registered with CNS yet", pv.Name)
+					continue
+				}
+				if err != nil {
+					log.Errorf("failed to get vCenter for the in-tree volume %q with err=%+v", pv.Name, err)
+					continue
+				}
+				if vCenter == vc {
+					k8svolumes = append(k8svolumes, pv)
+				}
+				continue
 			}
 			return nil, logger.LogNewErrorf(log,
 				"Invalid PV %s with empty volume handle.", pv.Name)
@@ -672,13 +679,37 @@ func getPVsInBoundAvailableOrReleasedForVc(ctx context.Context, metadataSyncer *
 
 	k8svolumeIDs := make([]string, 0)
 	for _, volume := range k8svolumes {
-		k8svolumeIDs = append(k8svolumeIDs, volume.Spec.CSI.VolumeHandle)
+		if volume.Spec.CSI != nil {
+			k8svolumeIDs = append(k8svolumeIDs, volume.Spec.CSI.VolumeHandle)
+		} else {
+			k8svolumeIDs = append(k8svolumeIDs, volume.Spec.VsphereVolume.VolumePath)
+		}
 	}
 	log.Debugf("List of K8s volumes for VC %s: %+v", vc, k8svolumeIDs)
 
 	return k8svolumes, nil
 }
 
+// getVcHostForMigratedVolume returns the vCenter on which the given in-tree
+// vSphere volume is registered with CNS. Only the volume mappings cached by the
+// volume migration service are used. Volumes which are not registered yet are
+// left to the volume migration service to register.
+func getVcHostForMigratedVolume(ctx context.Context, metadataSyncer *metadataSyncInformer,
+	pv *v1.PersistentVolume) (string, error) {
+	if err := initVolumeMigrationService(ctx, metadataSyncer); err != nil {
+		return "", err
+	}
+	migrationVolumeSpec := &migration.VolumeSpec{
+		VolumePath:        pv.Spec.VsphereVolume.VolumePath,
+		StoragePolicyName: pv.Spec.VsphereVolume.StoragePolicyName,
+	}
+	volumeHandle, err := volumeMigrationService.GetVolumeID(ctx, migrationVolumeSpec, false)
+	if err != nil {
+		return "", err
+	}
+	return volumeMigrationService.GetVCenterForVolumeID(ctx, volumeHandle)
+}
+
 // createVolumeOnMultiVc attempts to create a static volume on each VC until it gets SUCCESS.
 // If while creating the volume, CNS returns CnsAlreadyRegisteredFault,
 // it means that the volume does not need to be re-created.